@@ -33,6 +33,7 @@ import (
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin/buildinfo"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -56,6 +57,8 @@ func init() {
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = cartov1alpha1.AddToScheme(scheme)
 	_ = knativeservingv1.AddToScheme(scheme)
+	// the Workload CRD is read for --validate-schema
+	_ = apiextensionsv1.AddToScheme(scheme)
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -140,6 +143,6 @@ func main() {
 				c.Eprintf("%s %s\n", printer.Serrorf("Error:"), err)
 			}
 		}
-		os.Exit(1)
+		os.Exit(cli.ExitCode(err))
 	}
 }
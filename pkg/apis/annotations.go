@@ -18,3 +18,5 @@ package apis
 
 const ServiceClaimAnnotationName = "serviceclaims.supplychain.apps.x-tanzu.vmware.com/extensions"
 const LocalSourceProxyAnnotationName = "local-source-proxy.apps.tanzu.vmware.com"
+const LastAppliedConfigurationAnnotationName = "apps.tanzu.vmware.com/last-applied-configuration"
+const ChangeLogAnnotationName = "apps.tanzu.vmware.com/change-log"
@@ -73,6 +73,9 @@ type WorkloadServiceClaim struct {
 	Ref  *WorkloadServiceClaimReference `json:"ref,omitempty"`
 }
 
+// WorkloadServiceClaimReference references a resource in the workload namespace. There is no
+// namespace field, references to other namespaces are kept in the deprecated service claims
+// annotation.
 type WorkloadServiceClaimReference struct {
 	APIVersion string `json:"apiVersion"`
 	Kind       string `json:"kind"`
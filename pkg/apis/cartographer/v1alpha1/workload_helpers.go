@@ -22,10 +22,13 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -41,6 +44,9 @@ const (
 	WorkloadConditionReady  = "Ready"
 	WorkloadAnnotationParam = "annotations"
 	WorkloadMavenParam      = "maven"
+	// WorkloadGitSecretParam is the name of the secret, in the namespace of the workload, with the
+	// credentials the supply chain uses to fetch the git source, over ssh or https
+	WorkloadGitSecretParam = "gitops_ssh_secret"
 )
 
 type MavenSource struct {
@@ -122,6 +128,172 @@ func (w *Workload) Merge(updates *Workload) {
 	w.Spec.Merge(&updates.Spec)
 }
 
+// GetLastAppliedConfiguration returns the workload stored in the last applied configuration
+// annotation, or nil if the workload has no such annotation
+func (w *Workload) GetLastAppliedConfiguration() (*Workload, error) {
+	config := w.GetAnnotations()[apis.LastAppliedConfigurationAnnotationName]
+	if config == "" {
+		return nil, nil
+	}
+	lastApplied := &Workload{}
+	if err := json.Unmarshal([]byte(config), lastApplied); err != nil {
+		return nil, err
+	}
+	return lastApplied, nil
+}
+
+// AddChangeLogEntry adds the entry, one line, at the end of the change log annotation. Only the
+// last max entries are kept, the oldest ones are dropped first.
+func (w *Workload) AddChangeLogEntry(entry string, max int) {
+	entries := []string{}
+	if log := w.GetAnnotations()[apis.ChangeLogAnnotationName]; log != "" {
+		entries = strings.Split(log, "\n")
+	}
+	entries = append(entries, strings.Join(strings.Fields(entry), " "))
+	if max > 0 && len(entries) > max {
+		entries = entries[len(entries)-max:]
+	}
+	w.MergeAnnotations(apis.ChangeLogAnnotationName, strings.Join(entries, "\n"))
+}
+
+// SetLastAppliedConfiguration stores the given workload in the last applied configuration
+// annotation so following applies are able to detect the fields removed from it
+func (w *Workload) SetLastAppliedConfiguration(config *Workload) error {
+	lastApplied := &Workload{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: SchemeGroupVersion.Identifier(),
+			Kind:       "Workload",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      config.Name,
+			Namespace: config.Namespace,
+			Labels:    config.Labels,
+		},
+		Spec: *config.Spec.DeepCopy(),
+	}
+	for k, v := range config.Annotations {
+		if k != apis.LastAppliedConfigurationAnnotationName {
+			lastApplied.MergeAnnotations(k, v)
+		}
+	}
+
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(lastApplied)
+	if err != nil {
+		return err
+	}
+	unstructured.RemoveNestedField(u, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(u, "status")
+	b, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+	w.MergeAnnotations(apis.LastAppliedConfigurationAnnotationName, string(b))
+	return nil
+}
+
+// PruneLastApplied removes from the workload the fields that were present in the last applied
+// configuration but are no longer present in the updates
+func (w *Workload) PruneLastApplied(lastApplied, updates *Workload) {
+	for k := range lastApplied.Labels {
+		if !updates.IsLabelExists(k) {
+			delete(w.Labels, k)
+		}
+	}
+	for k := range lastApplied.Annotations {
+		if k != apis.LastAppliedConfigurationAnnotationName && !updates.IsAnnotationExists(k) {
+			w.RemoveAnnotations(k)
+		}
+	}
+	w.Spec.PruneLastApplied(&lastApplied.Spec, &updates.Spec)
+}
+
+func (w *WorkloadSpec) PruneLastApplied(lastApplied, updates *WorkloadSpec) {
+	for _, p := range lastApplied.Params {
+		if !hasParam(updates.Params, p.Name) {
+			w.RemoveParam(p.Name)
+		}
+	}
+	for _, e := range lastApplied.Env {
+		if !hasEnv(updates.Env, e.Name) {
+			w.RemoveEnv(e.Name)
+		}
+	}
+	if lastApplied.Build != nil {
+		var buildEnv []corev1.EnvVar
+		if updates.Build != nil {
+			buildEnv = updates.Build.Env
+		}
+		for _, e := range lastApplied.Build.Env {
+			if !hasEnv(buildEnv, e.Name) {
+				w.RemoveBuildEnv(e.Name)
+			}
+		}
+	}
+	for _, sc := range lastApplied.ServiceClaims {
+		if !hasServiceClaim(updates.ServiceClaims, sc.Name) {
+			w.DeleteServiceClaim(sc.Name)
+		}
+	}
+	if lastApplied.Resources != nil && w.Resources != nil {
+		updatesResources := updates.Resources
+		if updatesResources == nil {
+			updatesResources = &corev1.ResourceRequirements{}
+		}
+		for k := range lastApplied.Resources.Limits {
+			if _, ok := updatesResources.Limits[k]; !ok {
+				delete(w.Resources.Limits, k)
+			}
+		}
+		for k := range lastApplied.Resources.Requests {
+			if _, ok := updatesResources.Requests[k]; !ok {
+				delete(w.Resources.Requests, k)
+			}
+		}
+		if len(w.Resources.Limits) == 0 {
+			w.Resources.Limits = nil
+		}
+		if len(w.Resources.Requests) == 0 {
+			w.Resources.Requests = nil
+		}
+		if w.Resources.Limits == nil && w.Resources.Requests == nil {
+			w.Resources = nil
+		}
+	}
+	if lastApplied.ServiceAccountName != nil && updates.ServiceAccountName == nil {
+		w.ServiceAccountName = nil
+	}
+	if lastApplied.Source != nil && lastApplied.Source.Subpath != "" && (updates.Source == nil || updates.Source.Subpath == "") && w.Source != nil {
+		w.Source.Subpath = ""
+	}
+}
+
+func hasParam(params []Param, name string) bool {
+	for _, p := range params {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasEnv(env []corev1.EnvVar, name string) bool {
+	for _, e := range env {
+		if e.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasServiceClaim(serviceClaims []WorkloadServiceClaim, name string) bool {
+	for _, sc := range serviceClaims {
+		if sc.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (w *WorkloadSpec) GetMavenSource() *MavenSource {
 	var currentMaven *MavenSource
 	w.GetParam("maven", &currentMaven)
@@ -133,6 +305,7 @@ func (w *Workload) Validate() validation.FieldErrors {
 
 	errs = errs.Also(validation.K8sName(w.Name, cli.NameArgumentName))
 	errs = errs.Also(validation.K8sName(w.Namespace, flags.NamespaceFlagName))
+	errs = errs.Also(validation.K8sLabelMap(w.Labels, "metadata.labels"))
 	errs = errs.Also(w.Spec.Validate())
 
 	return errs
@@ -168,6 +341,39 @@ func (w *WorkloadSpec) Validate() validation.FieldErrors {
 	return errs
 }
 
+// ValidateSourceChange reports the missing git fields when the workload source is switched
+// to git from another kind of source, since none of the previous source can be reused
+func (w *WorkloadSpec) ValidateSourceChange(previous *WorkloadSpec) validation.FieldErrors {
+	errs := validation.FieldErrors{}
+	if previous == nil || w.Source == nil || w.Source.Git == nil {
+		return errs
+	}
+
+	var previousKind string
+	switch {
+	case previous.Source != nil && previous.Source.Git != nil:
+		return errs
+	case previous.Image != "":
+		previousKind = "image"
+	case previous.Source != nil && previous.Source.Image != "":
+		previousKind = "source image"
+	case previous.GetMavenSource() != nil:
+		previousKind = "maven artifact"
+	default:
+		return errs
+	}
+
+	detail := fmt.Sprintf("required to switch the workload source from %s to git", previousKind)
+	if w.Source.Git.URL == "" {
+		errs = errs.Also(validation.ErrMissingFieldWithDetail(flags.GitRepoFlagName, detail))
+	}
+	if w.Source.Git.Ref.Branch == "" && w.Source.Git.Ref.Tag == "" && w.Source.Git.Ref.Commit == "" {
+		errs = errs.Also(validation.ErrMissingOneOfWithDetail(fmt.Sprintf("expected exactly one, got neither: %s", detail), flags.GitBranchFlagName, flags.GitTagFlagName, flags.GitCommitFlagName))
+	}
+
+	return errs
+}
+
 func (w *WorkloadSpec) ValidateMavenSource() validation.FieldErrors {
 	errs := validation.FieldErrors{}
 
@@ -215,6 +421,9 @@ func (w *GitSource) Validate() validation.FieldErrors {
 	return errs
 }
 
+// Merge layers the updates on top of the spec. Params, env, build env and service claims are
+// matched by name, existing entries keep their position and new entries are appended in the
+// order of the updates, so merging the same updates again leaves the spec unchanged
 func (w *WorkloadSpec) Merge(updates *WorkloadSpec) {
 	for _, p := range updates.Params {
 		w.MergeParams(p.Name, p.Value)
@@ -272,6 +481,17 @@ func (w *WorkloadSpec) RemoveParam(name string) {
 	w.Params = params
 }
 
+// ClearParams removes every param but the maven one, which holds the maven source of the workload
+func (w *WorkloadSpec) ClearParams() {
+	var params []Param
+	for i := range w.Params {
+		if w.Params[i].Name == WorkloadMavenParam {
+			params = append(params, w.Params[i])
+		}
+	}
+	w.Params = params
+}
+
 func (w *WorkloadSpec) GetParam(key string, value interface{}) {
 	for _, p := range w.Params {
 		if p.Name == key {
@@ -393,6 +613,10 @@ func (w *WorkloadSpec) RemoveEnv(name string) {
 	w.Env = env
 }
 
+func (w *WorkloadSpec) ClearEnv() {
+	w.Env = nil
+}
+
 func (w *WorkloadSpec) MergeResources(r *corev1.ResourceRequirements) {
 	if r == nil {
 		return
@@ -499,6 +723,59 @@ func (w *WorkloadSpec) MergeServiceClaim(sc WorkloadServiceClaim) {
 	w.ServiceClaims = append(w.ServiceClaims, sc)
 }
 
+// KeepServiceClaimsOrder orders the service claims like in the current spec, as they are a set
+// keyed by name. The claims also in the current spec keep their position and the other ones follow
+// in their order, so reordering the same claims changes nothing.
+func (w *WorkloadSpec) KeepServiceClaimsOrder(current *WorkloadSpec) {
+	if current == nil {
+		return
+	}
+	w.ServiceClaims = keepOrderByName(w.ServiceClaims, current.ServiceClaims, func(sc WorkloadServiceClaim) string { return sc.Name })
+}
+
+// KeepEnvOrder orders the env and build env vars like in the current spec, the same way as
+// KeepServiceClaimsOrder, for the spec to be compared by name with the current one
+func (w *WorkloadSpec) KeepEnvOrder(current *WorkloadSpec) {
+	if current == nil {
+		return
+	}
+	envName := func(e corev1.EnvVar) string { return e.Name }
+	w.Env = keepOrderByName(w.Env, current.Env, envName)
+	if w.Build != nil && current.Build != nil {
+		w.Build.Env = keepOrderByName(w.Build.Env, current.Build.Env, envName)
+	}
+}
+
+// keepOrderByName returns the items in the order of the current items with the same name, followed
+// by the other items in their order. Items with duplicated names are returned as they are, as they
+// are not a set.
+func keepOrderByName[T any](items, current []T, name func(T) string) []T {
+	if len(items) == 0 {
+		return items
+	}
+	byName := make(map[string]T, len(items))
+	for _, item := range items {
+		if _, ok := byName[name(item)]; ok {
+			return items
+		}
+		byName[name(item)] = item
+	}
+	ordered := make([]T, 0, len(items))
+	for _, c := range current {
+		if item, ok := byName[name(c)]; ok {
+			ordered = append(ordered, item)
+			delete(byName, name(c))
+		}
+	}
+	for _, item := range items {
+		if _, ok := byName[name(item)]; ok {
+			ordered = append(ordered, item)
+			delete(byName, name(item))
+		}
+	}
+	return ordered
+}
+
 func NewServiceClaim(name string, serviceRef corev1.ObjectReference) WorkloadServiceClaim {
 	return WorkloadServiceClaim{
 		Name: name,
@@ -526,6 +803,10 @@ func (w *WorkloadSpec) RemoveBuildEnv(name string) {
 	}
 }
 
+func (w *WorkloadSpec) ClearBuildEnv() {
+	w.Build = nil
+}
+
 func (w *WorkloadSpec) MergeBuildEnv(env corev1.EnvVar) {
 	if w.Build == nil {
 		w.Build = &WorkloadBuild{}
@@ -560,12 +841,50 @@ func WorkloadReadyConditionFunc(target client.Object) (bool, error) {
 	return false, nil
 }
 
-func (w *Workload) DeprecationWarnings() []string {
-	warnings := []string{}
+// WorkloadReadyConditionFuncFailingOnUnknown is like WorkloadReadyConditionFunc, but also fails
+// when the Ready condition is Unknown with one of the reasons, which are not expected to resolve
+// without a change to the workload or the cluster. Unknown conditions with other reasons are
+// transient and keep waiting.
+func WorkloadReadyConditionFuncFailingOnUnknown(reasons []string) func(client.Object) (bool, error) {
+	return func(target client.Object) (bool, error) {
+		if done, err := WorkloadReadyConditionFunc(target); done || err != nil {
+			return done, err
+		}
+		obj, ok := target.(*Workload)
+		if !ok || obj.Generation != obj.Status.ObservedGeneration {
+			return false, nil
+		}
+		for _, cond := range obj.Status.Conditions {
+			if cond.Type != WorkloadConditionReady || cond.Status != metav1.ConditionUnknown {
+				continue
+			}
+			for _, reason := range reasons {
+				if cond.Reason == reason {
+					return true, fmt.Errorf("Failed to become ready: %s: %s", cond.Reason, cond.Message)
+				}
+			}
+		}
+		return false, nil
+	}
+}
+
+// CrossNamespaceServiceClaimsWarningID identifies the deprecation warning for service claims
+// that reference a resource in another namespace
+const CrossNamespaceServiceClaimsWarningID = "cross-namespace-service-claims"
+
+// WorkloadWarning is a warning message about the workload. The ID is stable across releases so
+// users can choose to suppress the warning.
+type WorkloadWarning struct {
+	ID      string
+	Message string
+}
+
+func (w *Workload) DeprecationWarnings() []WorkloadWarning {
+	warnings := []WorkloadWarning{}
 	var serviceClaimDeprecationWarningMsg = "Cross namespace service claims are deprecated. Please use `tanzu service claim create` instead."
 
 	if sc := w.GetAnnotations()[apis.ServiceClaimAnnotationName]; sc != "" {
-		warnings = append(warnings, serviceClaimDeprecationWarningMsg)
+		warnings = append(warnings, WorkloadWarning{ID: CrossNamespaceServiceClaimsWarningID, Message: serviceClaimDeprecationWarningMsg})
 	}
 	return warnings
 }
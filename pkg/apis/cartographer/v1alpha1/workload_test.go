@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/apis"
 	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
@@ -285,6 +287,55 @@ func TestWorkload_DeleteAnnotation(t *testing.T) {
 	}
 }
 
+func TestWorkload_AddChangeLogEntry(t *testing.T) {
+	tests := []struct {
+		name     string
+		seed     *Workload
+		entry    string
+		max      int
+		expected string
+	}{{
+		name:     "first entry",
+		seed:     &Workload{},
+		entry:    "2021-09-10T15:00:00Z user: first deployment",
+		max:      2,
+		expected: "2021-09-10T15:00:00Z user: first deployment",
+	}, {
+		name: "multi-line entry",
+		seed: &Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					apis.ChangeLogAnnotationName: "2021-09-01T10:00:00Z user: first deployment",
+				},
+			},
+		},
+		entry:    "2021-09-10T15:00:00Z user: bump\n  the base   image\n",
+		max:      2,
+		expected: "2021-09-01T10:00:00Z user: first deployment\n2021-09-10T15:00:00Z user: bump the base image",
+	}, {
+		name: "oldest entries dropped",
+		seed: &Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					apis.ChangeLogAnnotationName: "1\n2\n3",
+				},
+			},
+		},
+		entry:    "4",
+		max:      2,
+		expected: "3\n4",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.seed.AddChangeLogEntry(test.entry, test.max)
+			if diff := cmp.Diff(test.expected, test.seed.Annotations[apis.ChangeLogAnnotationName]); diff != "" {
+				t.Errorf("AddChangeLogEntry() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
 func TestWorkload_MergeServiceAccountName(t *testing.T) {
 	serviceAccount := "test-service-account"
 	updatedServiceAccount := "updated-service-account"
@@ -607,6 +658,21 @@ func TestWorkload_Validate(t *testing.T) {
 			validation.ErrInvalidValue("", cli.NameArgumentName),
 			validation.ErrInvalidValue("", flags.NamespaceFlagName),
 		),
+	}, {
+		name: "invalid label",
+		workload: Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-workload",
+				Namespace: "default",
+				Labels: map[string]string{
+					"Invalid Key": "x",
+				},
+			},
+			Spec: WorkloadSpec{
+				Image: "ubuntu:bionic",
+			},
+		},
+		want: validation.ErrInvalidValueWithDetail("Invalid Key", "metadata.labels", strings.Join(k8svalidation.IsQualifiedName("Invalid Key"), "; ")),
 	}, {
 		name: "valid git using --git-branch",
 		workload: Workload{
@@ -883,6 +949,90 @@ func TestWorkload_Validate(t *testing.T) {
 	}
 }
 
+func TestWorkloadSpec_ValidateSourceChange(t *testing.T) {
+	gitRefErr := func(from string) validation.FieldErrors {
+		return validation.ErrMissingOneOfWithDetail(fmt.Sprintf("expected exactly one, got neither: required to switch the workload source from %s to git", from), flags.GitBranchFlagName, flags.GitTagFlagName, flags.GitCommitFlagName)
+	}
+	tests := []struct {
+		name     string
+		seed     *WorkloadSpec
+		previous *WorkloadSpec
+		want     validation.FieldErrors
+	}{{
+		name: "new workload",
+		seed: &WorkloadSpec{
+			Source: &Source{Git: &GitSource{URL: "https://example.com/repo.git"}},
+		},
+		want: validation.FieldErrors{},
+	}, {
+		name: "git to git",
+		seed: &WorkloadSpec{
+			Source: &Source{Git: &GitSource{URL: "https://example.com/repo.git"}},
+		},
+		previous: &WorkloadSpec{
+			Source: &Source{Git: &GitSource{URL: "https://example.com/other.git", Ref: GitRef{Branch: "main"}}},
+		},
+		want: validation.FieldErrors{},
+	}, {
+		name: "git to image",
+		seed: &WorkloadSpec{
+			Image: "ubuntu:bionic",
+		},
+		previous: &WorkloadSpec{
+			Source: &Source{Git: &GitSource{URL: "https://example.com/repo.git", Ref: GitRef{Branch: "main"}}},
+		},
+		want: validation.FieldErrors{},
+	}, {
+		name: "image to complete git",
+		seed: &WorkloadSpec{
+			Source: &Source{Git: &GitSource{URL: "https://example.com/repo.git", Ref: GitRef{Tag: "v1.0.0"}}},
+		},
+		previous: &WorkloadSpec{
+			Image: "ubuntu:bionic",
+		},
+		want: validation.FieldErrors{},
+	}, {
+		name: "image to git without ref",
+		seed: &WorkloadSpec{
+			Source: &Source{Git: &GitSource{URL: "https://example.com/repo.git"}},
+		},
+		previous: &WorkloadSpec{
+			Image: "ubuntu:bionic",
+		},
+		want: gitRefErr("image"),
+	}, {
+		name: "source image to git without url",
+		seed: &WorkloadSpec{
+			Source: &Source{Git: &GitSource{Ref: GitRef{Branch: "main"}}},
+		},
+		previous: &WorkloadSpec{
+			Source: &Source{Image: "my-registry/source:latest"},
+		},
+		want: validation.ErrMissingFieldWithDetail(flags.GitRepoFlagName, "required to switch the workload source from source image to git"),
+	}, {
+		name: "maven to git without ref",
+		seed: &WorkloadSpec{
+			Source: &Source{Git: &GitSource{URL: "https://example.com/repo.git"}},
+		},
+		previous: &WorkloadSpec{
+			Params: []Param{{
+				Name:  WorkloadMavenParam,
+				Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"hello-world","groupId":"carto.run","version":"1.0.0"}`)},
+			}},
+		},
+		want: gitRefErr("maven artifact"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.seed.ValidateSourceChange(test.previous)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("ValidateSourceChange() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
 func TestWorkload_Merge(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -1197,6 +1347,298 @@ func TestWorkload_Merge(t *testing.T) {
 	}
 }
 
+func TestWorkloadSpec_MergeOrdering(t *testing.T) {
+	seed := &WorkloadSpec{
+		Params: []Param{
+			{Name: "b", Value: apiextensionsv1.JSON{Raw: []byte(`"b"`)}},
+			{Name: "a", Value: apiextensionsv1.JSON{Raw: []byte(`"a"`)}},
+		},
+		Env: []corev1.EnvVar{
+			{Name: "B", Value: "b"},
+			{Name: "A", Value: "a"},
+		},
+		ServiceClaims: []WorkloadServiceClaim{
+			NewServiceClaim("b", corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Name: "b"}),
+		},
+	}
+	updates := &WorkloadSpec{
+		Params: []Param{
+			{Name: "a", Value: apiextensionsv1.JSON{Raw: []byte(`"a2"`)}},
+			{Name: "d", Value: apiextensionsv1.JSON{Raw: []byte(`"d"`)}},
+			{Name: "c", Value: apiextensionsv1.JSON{Raw: []byte(`"c"`)}},
+		},
+		Env: []corev1.EnvVar{
+			{Name: "C", Value: "c"},
+			{Name: "A", Value: "a2"},
+		},
+		ServiceClaims: []WorkloadServiceClaim{
+			NewServiceClaim("c", corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Name: "c"}),
+			NewServiceClaim("a", corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Name: "a"}),
+		},
+		Build: &WorkloadBuild{
+			Env: []corev1.EnvVar{
+				{Name: "Z", Value: "z"},
+				{Name: "Y", Value: "y"},
+			},
+		},
+	}
+	want := &WorkloadSpec{
+		Params: []Param{
+			{Name: "b", Value: apiextensionsv1.JSON{Raw: []byte(`"b"`)}},
+			{Name: "a", Value: apiextensionsv1.JSON{Raw: []byte(`"a2"`)}},
+			{Name: "d", Value: apiextensionsv1.JSON{Raw: []byte(`"d"`)}},
+			{Name: "c", Value: apiextensionsv1.JSON{Raw: []byte(`"c"`)}},
+		},
+		Env: []corev1.EnvVar{
+			{Name: "B", Value: "b"},
+			{Name: "A", Value: "a2"},
+			{Name: "C", Value: "c"},
+		},
+		ServiceClaims: []WorkloadServiceClaim{
+			NewServiceClaim("b", corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Name: "b"}),
+			NewServiceClaim("c", corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Name: "c"}),
+			NewServiceClaim("a", corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Name: "a"}),
+		},
+		Build: &WorkloadBuild{
+			Env: []corev1.EnvVar{
+				{Name: "Z", Value: "z"},
+				{Name: "Y", Value: "y"},
+			},
+		},
+	}
+
+	got := seed.DeepCopy()
+	got.Merge(updates)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Merge() (-want, +got) = %v", diff)
+	}
+
+	// merging the same updates again must not reorder anything
+	got.Merge(updates)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Merge() again (-want, +got) = %v", diff)
+	}
+}
+
+func TestWorkload_LastAppliedConfiguration(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *Workload
+		want   string
+	}{{
+		name: "spec and metadata",
+		config: &Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "my-workload",
+				Labels: map[string]string{"foo": "bar"},
+			},
+			Spec: WorkloadSpec{
+				Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+			},
+		},
+		want: `{"apiVersion":"carto.run/v1alpha1","kind":"Workload","metadata":{"labels":{"foo":"bar"},"name":"my-workload"},"spec":{"env":[{"name":"FOO","value":"bar"}]}}`,
+	}, {
+		name: "ignores previous configuration",
+		config: &Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-workload",
+				Annotations: map[string]string{
+					"foo": "bar",
+					apis.LastAppliedConfigurationAnnotationName: `{"apiVersion":"carto.run/v1alpha1"}`,
+				},
+			},
+		},
+		want: `{"apiVersion":"carto.run/v1alpha1","kind":"Workload","metadata":{"annotations":{"foo":"bar"},"name":"my-workload"},"spec":{}}`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := &Workload{}
+			if err := got.SetLastAppliedConfiguration(test.config); err != nil {
+				t.Fatalf("SetLastAppliedConfiguration() unexpected error %v", err)
+			}
+			if diff := cmp.Diff(test.want, got.Annotations[apis.LastAppliedConfigurationAnnotationName]); diff != "" {
+				t.Errorf("SetLastAppliedConfiguration() (-want, +got) = %v", diff)
+			}
+			lastApplied, err := got.GetLastAppliedConfiguration()
+			if err != nil {
+				t.Fatalf("GetLastAppliedConfiguration() unexpected error %v", err)
+			}
+			if diff := cmp.Diff(test.config.Spec, lastApplied.Spec); diff != "" {
+				t.Errorf("GetLastAppliedConfiguration() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestWorkload_GetLastAppliedConfiguration(t *testing.T) {
+	got, err := (&Workload{}).GetLastAppliedConfiguration()
+	if err != nil || got != nil {
+		t.Errorf("GetLastAppliedConfiguration() expected nil, got %v, %v", got, err)
+	}
+	invalid := &Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{apis.LastAppliedConfigurationAnnotationName: "{"},
+		},
+	}
+	if _, err := invalid.GetLastAppliedConfiguration(); err == nil {
+		t.Errorf("GetLastAppliedConfiguration() expected error for invalid annotation")
+	}
+}
+
+func TestWorkload_PruneLastApplied(t *testing.T) {
+	serviceAccountName := "my-sa"
+	tests := []struct {
+		name        string
+		seed        *Workload
+		lastApplied *Workload
+		update      *Workload
+		want        *Workload
+	}{{
+		name:        "empty",
+		seed:        &Workload{},
+		lastApplied: &Workload{},
+		update:      &Workload{},
+		want:        &Workload{},
+	}, {
+		name: "metadata",
+		seed: &Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{"removed": "value", "kept": "value", "unmanaged": "value"},
+				Annotations: map[string]string{"removed": "value", "unmanaged": "value"},
+			},
+		},
+		lastApplied: &Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{"removed": "value", "kept": "value"},
+				Annotations: map[string]string{"removed": "value"},
+			},
+		},
+		update: &Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"kept": "value"},
+			},
+		},
+		want: &Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{"kept": "value", "unmanaged": "value"},
+				Annotations: map[string]string{"unmanaged": "value"},
+			},
+		},
+	}, {
+		name: "spec",
+		seed: &Workload{
+			Spec: WorkloadSpec{
+				Params: []Param{
+					{Name: "removed", Value: apiextensionsv1.JSON{Raw: []byte(`true`)}},
+					{Name: "unmanaged", Value: apiextensionsv1.JSON{Raw: []byte(`true`)}},
+				},
+				Env: []corev1.EnvVar{
+					{Name: "REMOVED", Value: "value"},
+					{Name: "KEPT", Value: "value"},
+				},
+				Build: &WorkloadBuild{
+					Env: []corev1.EnvVar{{Name: "REMOVED", Value: "value"}},
+				},
+				ServiceClaims: []WorkloadServiceClaim{
+					NewServiceClaim("removed", corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Name: "secret"}),
+				},
+				Resources: &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("500m"),
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("100m"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+				ServiceAccountName: &serviceAccountName,
+				Source: &Source{
+					Git: &GitSource{
+						URL: "https://example.com/repo.git",
+						Ref: GitRef{Branch: "main"},
+					},
+					Subpath: "./app",
+				},
+			},
+		},
+		lastApplied: &Workload{
+			Spec: WorkloadSpec{
+				Params: []Param{
+					{Name: "removed", Value: apiextensionsv1.JSON{Raw: []byte(`true`)}},
+				},
+				Env: []corev1.EnvVar{
+					{Name: "REMOVED", Value: "value"},
+					{Name: "KEPT", Value: "value"},
+				},
+				Build: &WorkloadBuild{
+					Env: []corev1.EnvVar{{Name: "REMOVED", Value: "value"}},
+				},
+				ServiceClaims: []WorkloadServiceClaim{
+					NewServiceClaim("removed", corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Name: "secret"}),
+				},
+				Resources: &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("500m"),
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("100m"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+				ServiceAccountName: &serviceAccountName,
+				Source: &Source{
+					Subpath: "./app",
+				},
+			},
+		},
+		update: &Workload{
+			Spec: WorkloadSpec{
+				Env: []corev1.EnvVar{
+					{Name: "KEPT", Value: "value"},
+				},
+				Resources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+			},
+		},
+		want: &Workload{
+			Spec: WorkloadSpec{
+				Params: []Param{
+					{Name: "unmanaged", Value: apiextensionsv1.JSON{Raw: []byte(`true`)}},
+				},
+				Env: []corev1.EnvVar{
+					{Name: "KEPT", Value: "value"},
+				},
+				ServiceClaims: []WorkloadServiceClaim{},
+				Resources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+				Source: &Source{
+					Git: &GitSource{
+						URL: "https://example.com/repo.git",
+						Ref: GitRef{Branch: "main"},
+					},
+				},
+			},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.seed.DeepCopy()
+			got.PruneLastApplied(test.lastApplied, test.update)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("PruneLastApplied() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
 func TestWorkloadSpec_MergeParams(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -2110,6 +2552,87 @@ func TestWorkloadSpec_RemoveEnv(t *testing.T) {
 	}
 }
 
+func TestWorkloadSpec_ClearEnv(t *testing.T) {
+	tests := []struct {
+		name string
+		seed *WorkloadSpec
+		want *WorkloadSpec
+	}{{
+		name: "with env",
+		seed: &WorkloadSpec{
+			Env: []corev1.EnvVar{
+				{Name: "FOO", Value: "foo"},
+				{Name: "BAR", Value: "bar"},
+			},
+			Image: "ubuntu:bionic",
+		},
+		want: &WorkloadSpec{
+			Image: "ubuntu:bionic",
+		},
+	}, {
+		name: "without env",
+		seed: &WorkloadSpec{},
+		want: &WorkloadSpec{},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.seed
+			got.ClearEnv()
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("ClearEnv() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestWorkloadSpec_ClearParams(t *testing.T) {
+	tests := []struct {
+		name string
+		seed *WorkloadSpec
+		want *WorkloadSpec
+	}{{
+		name: "with params",
+		seed: &WorkloadSpec{
+			Params: []Param{
+				{Name: "foo", Value: apiextensionsv1.JSON{Raw: []byte(`"foo"`)}},
+				{Name: "bar", Value: apiextensionsv1.JSON{Raw: []byte(`"bar"`)}},
+			},
+			Image: "ubuntu:bionic",
+		},
+		want: &WorkloadSpec{
+			Image: "ubuntu:bionic",
+		},
+	}, {
+		name: "keeps maven source",
+		seed: &WorkloadSpec{
+			Params: []Param{
+				{Name: "foo", Value: apiextensionsv1.JSON{Raw: []byte(`"foo"`)}},
+				{Name: WorkloadMavenParam, Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"hello","groupId":"com.example","version":"1.0.0"}`)}},
+			},
+		},
+		want: &WorkloadSpec{
+			Params: []Param{
+				{Name: WorkloadMavenParam, Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"hello","groupId":"com.example","version":"1.0.0"}`)}},
+			},
+		},
+	}, {
+		name: "without params",
+		seed: &WorkloadSpec{},
+		want: &WorkloadSpec{},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.seed
+			got.ClearParams()
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("ClearParams() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
 func TestWorkloadSpec_MergeResources(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -2283,6 +2806,139 @@ func TestWorkloadSpec_MergeServiceClaim(t *testing.T) {
 	}
 }
 
+func TestWorkloadSpec_KeepServiceClaimsOrder(t *testing.T) {
+	claim := func(name, ref string) WorkloadServiceClaim {
+		return WorkloadServiceClaim{
+			Name: name,
+			Ref: &WorkloadServiceClaimReference{
+				APIVersion: "services.tanzu.vmware.com/v1alpha1",
+				Kind:       "PostgreSQL",
+				Name:       ref,
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		seed    *WorkloadSpec
+		current *WorkloadSpec
+		want    *WorkloadSpec
+	}{{
+		name: "reordered",
+		seed: &WorkloadSpec{
+			ServiceClaims: []WorkloadServiceClaim{claim("cache", "my-cache"), claim("database", "my-prod-db")},
+		},
+		current: &WorkloadSpec{
+			ServiceClaims: []WorkloadServiceClaim{claim("database", "my-prod-db"), claim("cache", "my-cache")},
+		},
+		want: &WorkloadSpec{
+			ServiceClaims: []WorkloadServiceClaim{claim("database", "my-prod-db"), claim("cache", "my-cache")},
+		},
+	}, {
+		name: "added, removed and changed",
+		seed: &WorkloadSpec{
+			ServiceClaims: []WorkloadServiceClaim{claim("queue", "my-queue"), claim("cache", "my-cache"), claim("database", "prod-db")},
+		},
+		current: &WorkloadSpec{
+			ServiceClaims: []WorkloadServiceClaim{claim("database", "my-prod-db"), claim("search", "my-search"), claim("cache", "my-cache")},
+		},
+		want: &WorkloadSpec{
+			ServiceClaims: []WorkloadServiceClaim{claim("database", "prod-db"), claim("cache", "my-cache"), claim("queue", "my-queue")},
+		},
+	}, {
+		name: "duplicated names",
+		seed: &WorkloadSpec{
+			ServiceClaims: []WorkloadServiceClaim{claim("cache", "my-cache"), claim("database", "my-prod-db"), claim("cache", "other-cache")},
+		},
+		current: &WorkloadSpec{
+			ServiceClaims: []WorkloadServiceClaim{claim("database", "my-prod-db"), claim("cache", "my-cache")},
+		},
+		want: &WorkloadSpec{
+			ServiceClaims: []WorkloadServiceClaim{claim("cache", "my-cache"), claim("database", "my-prod-db"), claim("cache", "other-cache")},
+		},
+	}, {
+		name: "no current workload",
+		seed: &WorkloadSpec{
+			ServiceClaims: []WorkloadServiceClaim{claim("cache", "my-cache"), claim("database", "my-prod-db")},
+		},
+		want: &WorkloadSpec{
+			ServiceClaims: []WorkloadServiceClaim{claim("cache", "my-cache"), claim("database", "my-prod-db")},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.seed
+			got.KeepServiceClaimsOrder(test.current)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("KeepServiceClaimsOrder() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestWorkloadSpec_KeepEnvOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		seed    *WorkloadSpec
+		current *WorkloadSpec
+		want    *WorkloadSpec
+	}{{
+		name: "reordered",
+		seed: &WorkloadSpec{
+			Env: []corev1.EnvVar{{Name: "BAR", Value: "baz"}, {Name: "FOO", Value: "bar"}},
+			Build: &WorkloadBuild{
+				Env: []corev1.EnvVar{{Name: "BP_JVM_VERSION", Value: "17"}, {Name: "BP_MAVEN_BUILD_ARGUMENTS", Value: "package"}},
+			},
+		},
+		current: &WorkloadSpec{
+			Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}, {Name: "BAR", Value: "baz"}},
+			Build: &WorkloadBuild{
+				Env: []corev1.EnvVar{{Name: "BP_MAVEN_BUILD_ARGUMENTS", Value: "package"}, {Name: "BP_JVM_VERSION", Value: "17"}},
+			},
+		},
+		want: &WorkloadSpec{
+			Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}, {Name: "BAR", Value: "baz"}},
+			Build: &WorkloadBuild{
+				Env: []corev1.EnvVar{{Name: "BP_MAVEN_BUILD_ARGUMENTS", Value: "package"}, {Name: "BP_JVM_VERSION", Value: "17"}},
+			},
+		},
+	}, {
+		name: "added, removed and changed",
+		seed: &WorkloadSpec{
+			Env: []corev1.EnvVar{{Name: "NEW", Value: "value"}, {Name: "BAR", Value: "qux"}, {Name: "FOO", Value: "bar"}},
+		},
+		current: &WorkloadSpec{
+			Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}, {Name: "REMOVED", Value: "value"}, {Name: "BAR", Value: "baz"}},
+		},
+		want: &WorkloadSpec{
+			Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}, {Name: "BAR", Value: "qux"}, {Name: "NEW", Value: "value"}},
+		},
+	}, {
+		name: "without current build env",
+		seed: &WorkloadSpec{
+			Build: &WorkloadBuild{
+				Env: []corev1.EnvVar{{Name: "BP_JVM_VERSION", Value: "17"}, {Name: "BP_MAVEN_BUILD_ARGUMENTS", Value: "package"}},
+			},
+		},
+		current: &WorkloadSpec{},
+		want: &WorkloadSpec{
+			Build: &WorkloadBuild{
+				Env: []corev1.EnvVar{{Name: "BP_JVM_VERSION", Value: "17"}, {Name: "BP_MAVEN_BUILD_ARGUMENTS", Value: "package"}},
+			},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.seed
+			got.KeepEnvOrder(test.current)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("KeepEnvOrder() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
 func TestWorkloadSpecDeleteServiceClaim(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -2595,6 +3251,93 @@ func TestWorkloadReadyConditionFunc(t *testing.T) {
 	}
 }
 
+func TestWorkloadReadyConditionFuncFailingOnUnknown(t *testing.T) {
+	defaultNamespace := "default"
+	workloadName := "my-workload"
+	workload := func(conditions ...metav1.Condition) *Workload {
+		return &Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: defaultNamespace,
+				Name:      workloadName,
+			},
+			Status: WorkloadStatus{
+				Conditions: conditions,
+			},
+		}
+	}
+	reasons := []string{"MissingValueAtPath", "TemplateRejectedByAPIServer"}
+
+	tests := []struct {
+		name     string
+		workload *Workload
+		expected bool
+		err      error
+	}{{
+		name: "unknown status with a blocking reason",
+		workload: workload(metav1.Condition{
+			Type:    WorkloadConditionReady,
+			Status:  metav1.ConditionUnknown,
+			Reason:  "MissingValueAtPath",
+			Message: "waiting to read value [.status.latestImage] from resource [image.kpack.io/my-workload] in namespace [default]",
+		}),
+		expected: true,
+		err:      fmt.Errorf("Failed to become ready: MissingValueAtPath: waiting to read value [.status.latestImage] from resource [image.kpack.io/my-workload] in namespace [default]"),
+	}, {
+		name: "unknown status with a transient reason",
+		workload: workload(metav1.Condition{
+			Type:   WorkloadConditionReady,
+			Status: metav1.ConditionUnknown,
+			Reason: "ConditionNotMet",
+		}),
+	}, {
+		name: "unknown status with a blocking reason and wrong generation",
+		workload: func() *Workload {
+			w := workload(metav1.Condition{
+				Type:   WorkloadConditionReady,
+				Status: metav1.ConditionUnknown,
+				Reason: "MissingValueAtPath",
+			})
+			w.Status.ObservedGeneration = 10
+			return w
+		}(),
+	}, {
+		name: "other condition unknown with a blocking reason",
+		workload: workload(metav1.Condition{
+			Type:   "ResourcesSubmitted",
+			Status: metav1.ConditionUnknown,
+			Reason: "MissingValueAtPath",
+		}),
+	}, {
+		name: "false status",
+		workload: workload(metav1.Condition{
+			Type:    WorkloadConditionReady,
+			Status:  metav1.ConditionFalse,
+			Message: "something went wrong",
+		}),
+		expected: true,
+		err:      fmt.Errorf("Failed to become ready: %s", "something went wrong"),
+	}, {
+		name: "true status",
+		workload: workload(metav1.Condition{
+			Type:   WorkloadConditionReady,
+			Status: metav1.ConditionTrue,
+		}),
+		expected: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actualBool, err := WorkloadReadyConditionFuncFailingOnUnknown(reasons)(test.workload)
+
+			if expected, actual := fmt.Sprintf("%s", test.err), fmt.Sprintf("%s", err); expected != actual {
+				t.Errorf("expected error %v, actually %v", expected, actual)
+			}
+			if test.expected != actualBool {
+				t.Errorf("expected bool value %v, actually %v", test.expected, actualBool)
+			}
+		})
+	}
+}
+
 func TestMergeServiceClaimAnnotation(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -2807,12 +3550,51 @@ func TestWorkloadSpec_RemoveBuildEnv(t *testing.T) {
 	}
 }
 
+func TestWorkloadSpec_ClearBuildEnv(t *testing.T) {
+	tests := []struct {
+		name string
+		seed *WorkloadSpec
+		want *WorkloadSpec
+	}{{
+		name: "with build env",
+		seed: &WorkloadSpec{
+			Build: &WorkloadBuild{
+				Env: []corev1.EnvVar{
+					{Name: "FOO", Value: "bar"},
+				},
+			},
+			Image: "ubuntu:bionic",
+		},
+		want: &WorkloadSpec{
+			Image: "ubuntu:bionic",
+		},
+	}, {
+		name: "without build",
+		seed: &WorkloadSpec{},
+		want: &WorkloadSpec{},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.seed
+			got.ClearBuildEnv()
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("ClearBuildEnv() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
 func TestDeprecationWarnings(t *testing.T) {
 	tests := []struct {
 		name string
 		seed *Workload
-		want []string
+		want []WorkloadWarning
 	}{{
+		name: "no warnings",
+		seed: &Workload{},
+		want: []WorkloadWarning{},
+	}, {
 		name: "service claim annotation set",
 		seed: &Workload{
 			ObjectMeta: metav1.ObjectMeta{
@@ -2821,7 +3603,10 @@ func TestDeprecationWarnings(t *testing.T) {
 				},
 			},
 		},
-		want: []string{"Cross namespace service claims are deprecated. Please use `tanzu service claim create` instead."},
+		want: []WorkloadWarning{{
+			ID:      CrossNamespaceServiceClaimsWarningID,
+			Message: "Cross namespace service claims are deprecated. Please use `tanzu service claim create` instead.",
+		}},
 	}}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
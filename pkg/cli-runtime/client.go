@@ -20,22 +20,24 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	crclient "sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/printer"
 )
 
 type Client interface {
 	DefaultNamespace() string
+	CurrentUser() string
 	KubeRestConfig() *rest.Config
 	Discovery() discovery.DiscoveryInterface
 	SetLogger(logger logr.Logger)
@@ -47,18 +49,45 @@ type Client interface {
 }
 
 func (c *client) DefaultNamespace() string {
+	c.lazyLoadLock.Lock()
+	defer c.lazyLoadLock.Unlock()
 	return c.lazyLoadDefaultNamespaceOrDie()
 }
 
+// CurrentUser returns the name of the user of the current kube config context, or an empty string
+// when it can't be read
+func (c *client) CurrentUser() string {
+	c.lazyLoadLock.Lock()
+	defer c.lazyLoadLock.Unlock()
+	rawConfig, err := c.lazyLoadKubeConfig().RawConfig()
+	if err != nil {
+		return ""
+	}
+	currentContext := rawConfig.CurrentContext
+	if c.currentContext != "" {
+		currentContext = c.currentContext
+	}
+	if kubeContext, ok := rawConfig.Contexts[currentContext]; ok {
+		return kubeContext.AuthInfo
+	}
+	return ""
+}
+
 func (c *client) KubeRestConfig() *rest.Config {
+	c.lazyLoadLock.Lock()
+	defer c.lazyLoadLock.Unlock()
 	return c.lazyLoadRestConfigOrDie()
 }
 
 func (c *client) Discovery() discovery.DiscoveryInterface {
-	return c.lazyLoadKubernetesClientsetOrDie().Discovery()
+	c.lazyLoadLock.Lock()
+	defer c.lazyLoadLock.Unlock()
+	return c.lazyLoadDiscoveryClientOrDie()
 }
 
 func (c *client) Client() crclient.Client {
+	c.lazyLoadLock.Lock()
+	defer c.lazyLoadLock.Unlock()
 	return c.lazyLoadClientOrDie()
 }
 
@@ -67,8 +96,9 @@ func (c *client) ToRESTConfig() (*rest.Config, error) {
 }
 
 func (c *client) ToDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
-	discoveryClient := discovery.NewDiscoveryClientForConfigOrDie(c.KubeRestConfig())
-	return NewCacheDiscoveryClient(discoveryClient), nil
+	c.lazyLoadLock.Lock()
+	defer c.lazyLoadLock.Unlock()
+	return c.lazyLoadDiscoveryClientOrDie(), nil
 }
 
 func (c *client) ToRESTMapper() (meta.RESTMapper, error) {
@@ -76,6 +106,8 @@ func (c *client) ToRESTMapper() (meta.RESTMapper, error) {
 }
 
 func (c *client) GetClientSet() kubernetes.Interface {
+	c.lazyLoadLock.Lock()
+	defer c.lazyLoadLock.Unlock()
 	return c.lazyLoadKubernetesClientsetOrDie()
 }
 
@@ -149,7 +181,9 @@ func (c *client) Scheme() *runtime.Scheme {
 }
 
 func (c *client) RESTMapper() meta.RESTMapper {
-	return c.Client().RESTMapper()
+	c.lazyLoadLock.Lock()
+	defer c.lazyLoadLock.Unlock()
+	return c.lazyLoadRESTMapperOrDie()
 }
 
 func (c *client) SetLogger(logger logr.Logger) {
@@ -173,8 +207,13 @@ type client struct {
 	kubeConfig       clientcmd.ClientConfig
 	restConfig       *rest.Config
 	kubeClientset    *kubernetes.Clientset
+	discoveryClient  discovery.CachedDiscoveryInterface
+	restMapper       meta.RESTMapper
 	client           crclient.Client
 	log              logr.Logger
+	// lazyLoadLock guards the lazy loaded fields, the client is shared by the workloads applied
+	// concurrently
+	lazyLoadLock sync.Mutex
 }
 
 func (c *client) lazyLoadKubeConfig() clientcmd.ClientConfig {
@@ -215,16 +254,28 @@ func (c *client) lazyLoadKubernetesClientsetOrDie() *kubernetes.Clientset {
 	return c.kubeClientset
 }
 
+// lazyLoadDiscoveryClientOrDie returns the discovery client shared by the commands, the discovery
+// of the cluster is read once for the life of the process
+func (c *client) lazyLoadDiscoveryClientOrDie() discovery.CachedDiscoveryInterface {
+	if c.discoveryClient == nil {
+		c.discoveryClient = memory.NewMemCacheClient(c.lazyLoadKubernetesClientsetOrDie().Discovery())
+	}
+	return c.discoveryClient
+}
+
+// lazyLoadRESTMapperOrDie returns the RESTMapper shared by the client, the resource builder and
+// the completions, on top of the shared discovery client
+func (c *client) lazyLoadRESTMapperOrDie() meta.RESTMapper {
+	if c.restMapper == nil {
+		c.restMapper = NewDiscoveryRESTMapper(c.lazyLoadDiscoveryClientOrDie())
+	}
+	return c.restMapper
+}
+
 func (c *client) lazyLoadClientOrDie() crclient.Client {
 	if c.client == nil {
 		restConfig := c.lazyLoadRestConfigOrDie()
-		lazyLoadMapper, err := apiutil.NewDynamicRESTMapper(c.KubeRestConfig(), apiutil.WithExperimentalLazyMapper)
-		if err != nil {
-			fmt.Printf("%s Unable to create rest mapper. signk8s.io/dynamicrestmapper states %s \n", printer.Serrorf("Error:"), err)
-			c.logError(err)
-			os.Exit(2)
-		}
-		client, err := crclient.New(restConfig, crclient.Options{Scheme: c.scheme, Mapper: lazyLoadMapper})
+		client, err := crclient.New(restConfig, crclient.Options{Scheme: c.scheme, Mapper: c.lazyLoadRESTMapperOrDie()})
 		if err != nil {
 			fmt.Printf("%s Unable to connect: connection refused. Confirm kubeconfig details and try again.\n", printer.Serrorf("Error:"))
 			c.logError(err)
@@ -256,19 +307,3 @@ func (c *client) lazyLoadDefaultNamespaceOrDie() string {
 func (c *client) SubResource(subResource string) crclient.SubResourceClient {
 	return c.Client().SubResource(subResource)
 }
-
-var _ discovery.CachedDiscoveryInterface = &cachedDiscoveryClient{}
-
-type cachedDiscoveryClient struct {
-	*discovery.DiscoveryClient
-}
-
-func (d *cachedDiscoveryClient) Fresh() bool {
-	return true
-}
-
-func (d *cachedDiscoveryClient) Invalidate() {}
-
-func NewCacheDiscoveryClient(discoveryClient *discovery.DiscoveryClient) *cachedDiscoveryClient {
-	return &cachedDiscoveryClient{discoveryClient}
-}
@@ -22,10 +22,14 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"time"
 
+	"github.com/acarl005/stripansi"
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/utils/clock"
 
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/printer"
 )
@@ -47,6 +51,8 @@ type Config struct {
 	Verbose         *int32
 	Builder         *resource.Builder
 	NoColor         bool
+	// Clock is the source of the current time for ages, elapsed times and wait loops
+	Clock clock.WithTicker
 }
 
 func NewDefaultConfig(name string, scheme *runtime.Scheme) *Config {
@@ -60,51 +66,78 @@ func NewDefaultConfig(name string, scheme *runtime.Scheme) *Config {
 		Stderr:          os.Stderr,
 		Verbose:         &v,
 		TanzuIgnoreFile: defaultTanzuIgnoreFile,
+		Clock:           clock.RealClock{},
 	}
 }
 
+// Now returns the current time of the Config's Clock, or the real time when no Clock is set
+func (c *Config) Now() time.Time {
+	return c.GetClock().Now()
+}
+
+// GetClock returns the Config's Clock, or the real clock when none is set
+func (c *Config) GetClock() clock.WithTicker {
+	if c.Clock == nil {
+		return clock.RealClock{}
+	}
+	return c.Clock
+}
+
 func (c *Config) Printf(format string, a ...interface{}) (n int, err error) {
-	return fmt.Fprintf(c.Stdout, format, a...)
+	return c.fprintf(c.Stdout, nil, format, a...)
 }
 
 func (c *Config) Eprintf(format string, a ...interface{}) (n int, err error) {
-	return fmt.Fprintf(c.Stderr, format, a...)
+	return c.fprintf(c.Stderr, nil, format, a...)
+}
+
+// fprintf writes the formatted message in the color, or as is when the color is nil. With
+// NoColor, the ANSI escape sequences are stripped from the whole message, including the values
+// colored before being formatted (like diffs) that the global color switch can't catch.
+func (c *Config) fprintf(w io.Writer, clr *color.Color, format string, a ...interface{}) (n int, err error) {
+	if c.NoColor {
+		return fmt.Fprint(w, stripansi.Strip(fmt.Sprintf(format, a...)))
+	}
+	if clr == nil {
+		return fmt.Fprintf(w, format, a...)
+	}
+	return clr.Fprintf(w, format, a...)
 }
 
 func (c *Config) Infof(format string, a ...interface{}) (n int, err error) {
-	return printer.InfoColor.Fprintf(c.Stdout, format, a...)
+	return c.fprintf(c.Stdout, printer.InfoColor, format, a...)
 }
 
 func (c *Config) Einfof(format string, a ...interface{}) (n int, err error) {
-	return printer.InfoColor.Fprintf(c.Stderr, format, a...)
+	return c.fprintf(c.Stderr, printer.InfoColor, format, a...)
 }
 
 func (c *Config) Successf(format string, a ...interface{}) (n int, err error) {
-	return printer.SuccessColor.Fprintf(c.Stdout, format, a...)
+	return c.fprintf(c.Stdout, printer.SuccessColor, format, a...)
 }
 
 func (c *Config) Esuccessf(format string, a ...interface{}) (n int, err error) {
-	return printer.SuccessColor.Fprintf(c.Stderr, format, a...)
+	return c.fprintf(c.Stderr, printer.SuccessColor, format, a...)
 }
 
 func (c *Config) Errorf(format string, a ...interface{}) (n int, err error) {
-	return printer.ErrorColor.Fprintf(c.Stdout, format, a...)
+	return c.fprintf(c.Stdout, printer.ErrorColor, format, a...)
 }
 
 func (c *Config) Eerrorf(format string, a ...interface{}) (n int, err error) {
-	return printer.ErrorColor.Fprintf(c.Stderr, format, a...)
+	return c.fprintf(c.Stderr, printer.ErrorColor, format, a...)
 }
 
 func (c *Config) Faintf(format string, a ...interface{}) (n int, err error) {
-	return printer.FaintColor.Fprintf(c.Stdout, format, a...)
+	return c.fprintf(c.Stdout, printer.FaintColor, format, a...)
 }
 
 func (c *Config) Efaintf(format string, a ...interface{}) (n int, err error) {
-	return printer.FaintColor.Fprintf(c.Stderr, format, a...)
+	return c.fprintf(c.Stderr, printer.FaintColor, format, a...)
 }
 
 func (c *Config) Boldf(format string, a ...interface{}) (n int, err error) {
-	return printer.BoldColor.Fprintf(c.Stdout, format, a...)
+	return c.fprintf(c.Stdout, printer.BoldColor, format, a...)
 }
 
 func (c *Config) Emoji(emoji Icon, format string, a ...interface{}) (n int, err error) {
@@ -117,7 +150,7 @@ func (c *Config) Emoji(emoji Icon, format string, a ...interface{}) (n int, err
 }
 
 func (c *Config) Eboldf(format string, a ...interface{}) (n int, err error) {
-	return printer.BoldColor.Fprintf(c.Stderr, format, a...)
+	return c.fprintf(c.Stderr, printer.BoldColor, format, a...)
 }
 
 func PrintPrompt(shouldPrint bool, printer func(string, ...interface{}) (int, error), format string, a ...interface{}) {
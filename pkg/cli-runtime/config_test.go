@@ -20,9 +20,11 @@ import (
 	"bytes"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/fatih/color"
 	"k8s.io/apimachinery/pkg/runtime"
+	clocktesting "k8s.io/utils/clock/testing"
 
 	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/printer"
@@ -43,6 +45,21 @@ func TestNewDefaultConfig_Stdio(t *testing.T) {
 	}
 }
 
+func TestConfig_Now(t *testing.T) {
+	now := time.Date(2023, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	config := &cli.Config{Clock: clocktesting.NewFakeClock(now)}
+	if expected, actual := now, config.Now(); !expected.Equal(actual) {
+		t.Errorf("Expected now to be %v, actually %v", expected, actual)
+	}
+
+	config = &cli.Config{}
+	before := time.Now()
+	if actual := config.Now(); actual.Before(before) {
+		t.Errorf("Expected now without a clock to be the real time, actually %v", actual)
+	}
+}
+
 func TestConfig_Print(t *testing.T) {
 	noColor := color.NoColor
 	color.NoColor = false
@@ -154,6 +171,60 @@ func TestConfig_Print(t *testing.T) {
 	}
 }
 
+func TestConfig_PrintNoColor(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	scheme := runtime.NewScheme()
+	config := cli.NewDefaultConfig("test", scheme)
+	config.NoColor = true
+
+	tests := []struct {
+		name    string
+		printer func(format string, a ...interface{}) (n int, err error)
+	}{{
+		name:    "Printf",
+		printer: config.Printf,
+	}, {
+		name:    "Eprintf",
+		printer: config.Eprintf,
+	}, {
+		name:    "Infof",
+		printer: config.Infof,
+	}, {
+		name:    "Esuccessf",
+		printer: config.Esuccessf,
+	}, {
+		name:    "Errorf",
+		printer: config.Errorf,
+	}, {
+		name:    "Faintf",
+		printer: config.Faintf,
+	}, {
+		name:    "Eboldf",
+		printer: config.Eboldf,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			config.Stdout = output
+			config.Stderr = output
+
+			// the value is colored before being formatted, like a diff
+			_, err := test.printer("%s %s\n", printer.SuccessColor.Sprint("hello"), "world")
+
+			if err != nil {
+				t.Errorf("Expected no error, actually %q", err)
+			}
+			if expected, actual := "hello world\n", output.String(); expected != actual {
+				t.Errorf("Expected output to be %q, actually %q", expected, actual)
+			}
+		})
+	}
+}
+
 func TestConfig_Emoji(t *testing.T) {
 	scheme := runtime.NewScheme()
 	config := cli.NewDefaultConfig("test", scheme)
@@ -17,6 +17,7 @@ limitations under the License.
 package cli
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -36,7 +37,8 @@ func DryRunResource(ctx context.Context, resource runtime.Object, gvk schema.Gro
 	stdout := StdoutFromContext(ctx)
 	resource = defaultTypeMeta(resource, gvk)
 	b, _ := yaml.Marshal(resource)
-	fmt.Fprintf(stdout, "---\n%s", b)
+	// always a single leading separator and a single trailing newline
+	fmt.Fprintf(stdout, "---\n%s\n", bytes.TrimSpace(b))
 }
 
 func defaultTypeMeta(resource runtime.Object, gvk schema.GroupVersionKind) runtime.Object {
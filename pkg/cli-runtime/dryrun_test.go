@@ -35,15 +35,16 @@ func TestDryRunResource(t *testing.T) {
 
 	cli.DryRunResource(ctx, resource, clitestingresource.GroupVersion.WithKind("TestResource"))
 
-	expected := strings.TrimSpace(`
+	// a single leading separator and a single trailing newline
+	expected := strings.TrimPrefix(`
 ---
 apiVersion: testing.reconciler.runtime/v1
 kind: TestResource
 metadata:
   creationTimestamp: null
 spec: {}
-`)
-	actual := strings.TrimSpace(stdout.String())
+`, "\n")
+	actual := stdout.String()
 	if diff := cmp.Diff(expected, actual); diff != "" {
 		t.Errorf("Unexpected stdout (-expected, +actual): %s", diff)
 	}
@@ -16,6 +16,15 @@ limitations under the License.
 
 package cli
 
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 var SilentError = &silentError{}
 
 type silentError struct {
@@ -38,3 +47,72 @@ func (e *silentError) Is(err error) bool {
 func SilenceError(err error) error {
 	return &silentError{err: err}
 }
+
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitCodeError) Unwrap() error {
+	return e.err
+}
+
+// WithExitCode sets the code the process exits with when the command fails with the error
+func WithExitCode(err error, code int) error {
+	return &exitCodeError{code: code, err: err}
+}
+
+// ExitCode is the code the process exits with for the error of a failed command, 1 unless it
+// was set with WithExitCode
+func ExitCode(err error) int {
+	var exitErr *exitCodeError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+	return 1
+}
+
+// JSONError is the structured form of a failed command, printed instead of a human message when
+// the command is run with --output json so automation can parse the failure
+type JSONError struct {
+	Error JSONErrorDetails `json:"error"`
+}
+
+type JSONErrorDetails struct {
+	Message string `json:"message"`
+	// Reason is a machine readable category of the error, like NotFound, Conflict or Invalid
+	Reason string `json:"reason"`
+}
+
+const unknownErrorReason = "Unknown"
+
+// NewJSONError describes the error, the reason defaults to the kubernetes api reason of the
+// error when it has one
+func NewJSONError(err error, reason metav1.StatusReason) JSONError {
+	if reason == "" {
+		reason = apierrs.ReasonForError(err)
+	}
+	if reason == "" || reason == metav1.StatusReasonUnknown {
+		reason = unknownErrorReason
+	}
+	message := err.Error()
+	if message == "" && errors.Is(err, SilentError) {
+		// the command already printed the details of a silenced error
+		message = "command failed"
+	}
+	return JSONError{
+		Error: JSONErrorDetails{
+			Message: message,
+			Reason:  string(reason),
+		},
+	}
+}
+
+// WriteJSONError prints the error as a single line JSON object
+func WriteJSONError(w io.Writer, err error, reason metav1.StatusReason) error {
+	return json.NewEncoder(w).Encode(NewJSONError(err, reason))
+}
@@ -21,6 +21,11 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
 	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
 )
 
@@ -41,3 +46,58 @@ func TestSilenceError(t *testing.T) {
 		t.Errorf("errors expected to match, expected %q, actually %q", expected, actual)
 	}
 }
+
+func TestExitCode(t *testing.T) {
+	err := fmt.Errorf("test error")
+
+	if expected, actual := 1, cli.ExitCode(err); expected != actual {
+		t.Errorf("expected exit code %d, actually %d", expected, actual)
+	}
+	if expected, actual := 2, cli.ExitCode(cli.WithExitCode(err, 2)); expected != actual {
+		t.Errorf("expected exit code %d, actually %d", expected, actual)
+	}
+	silentErr := cli.SilenceError(cli.WithExitCode(err, 2))
+	if expected, actual := 2, cli.ExitCode(silentErr); expected != actual {
+		t.Errorf("expected exit code %d, actually %d", expected, actual)
+	}
+	if !errors.Is(silentErr, err) {
+		t.Errorf("expected error to wrap %v, got %#v", err, silentErr)
+	}
+	if expected, actual := err.Error(), silentErr.Error(); expected != actual {
+		t.Errorf("errors expected to match, expected %q, actually %q", expected, actual)
+	}
+}
+
+func TestNewJSONError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		reason   metav1.StatusReason
+		expected cli.JSONError
+	}{{
+		name:     "error",
+		err:      fmt.Errorf("test error"),
+		expected: cli.JSONError{Error: cli.JSONErrorDetails{Message: "test error", Reason: "Unknown"}},
+	}, {
+		name:     "error with reason",
+		err:      fmt.Errorf("test error"),
+		reason:   metav1.StatusReasonInvalid,
+		expected: cli.JSONError{Error: cli.JSONErrorDetails{Message: "test error", Reason: "Invalid"}},
+	}, {
+		name:     "api error",
+		err:      apierrors.NewConflict(schema.GroupResource{Group: "carto.run", Resource: "workloads"}, "my-workload", fmt.Errorf("test conflict")),
+		expected: cli.JSONError{Error: cli.JSONErrorDetails{Message: `Operation cannot be fulfilled on workloads.carto.run "my-workload": test conflict`, Reason: "Conflict"}},
+	}, {
+		name:     "silenced error without message",
+		err:      cli.SilenceError(errors.New("")),
+		expected: cli.JSONError{Error: cli.JSONErrorDetails{Message: "command failed", Reason: "Unknown"}},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if diff := cmp.Diff(test.expected, cli.NewJSONError(test.err, test.reason)); diff != "" {
+				t.Errorf("NewJSONError() (-expected, +actual) = %s", diff)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package cli
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
@@ -32,6 +33,9 @@ const (
 	KubeConfigFlagName    = "--kubeconfig"
 	NamespaceFlagName     = "--namespace"
 	NoColorFlagName       = "--no-color"
+	OutputFlagName        = "--output"
+	RetryBackoffFlagName  = "--retry-backoff"
+	RetryFlagName         = "--retry"
 )
 
 func AllNamespacesFlag(ctx context.Context, cmd *cobra.Command, c *Config, namespace *string, allNamespaces *bool) {
@@ -86,6 +90,12 @@ func NamespaceFlag(ctx context.Context, cmd *cobra.Command, c *Config, namespace
 	})
 }
 
+// RetryFlags binds the bounds of the retries of the api calls failing with a transient error
+func RetryFlags(cmd *cobra.Command, retry *RetryOptions) {
+	cmd.Flags().IntVar(&retry.Retries, StripDash(RetryFlagName), 3, "`number` of times an api request failing with a transient error (server timeout, too many requests, connection reset) is retried, 0 to disable retries")
+	cmd.Flags().DurationVar(&retry.Backoff, StripDash(RetryBackoffFlagName), 500*time.Millisecond, "`duration` to wait before the first retry of a failed api request, doubled for every following retry")
+}
+
 func StripDash(flagName string) string {
 	return strings.Replace(flagName, "--", "", 1)
 }
@@ -18,7 +18,7 @@ package logs
 
 import (
 	"context"
-	"time"
+	"io"
 
 	"github.com/fatih/color"
 	"github.com/stretchr/testify/mock"
@@ -31,14 +31,21 @@ var _ Tailer = &FakeTailer{}
 
 type FakeTailer struct {
 	mock.Mock
+	// Stdout is the writer the last tail printed to, tests may use it to
+	// simulate log lines flushed after the tail returned
+	Stdout io.Writer
 }
 
-func (f *FakeTailer) Tail(ctx context.Context, c *cli.Config, namespace string, selector labels.Selector, containers []string, since time.Duration, timestamps bool) error {
-	args := f.Called(ctx, namespace, selector, containers, since, timestamps)
+func (f *FakeTailer) Tail(ctx context.Context, c *cli.Config, namespace string, selector labels.Selector, opts TailOptions) error {
+	args := f.Called(ctx, namespace, selector, opts)
+	f.Stdout = c.Stdout
 	c.Printf(color.CyanString("...tail output...\n"))
 	if err := args.Error(0); err != nil {
 		return err
 	}
+	if !opts.Follow {
+		return nil
+	}
 	// simulate tailing until the context is closed
 	<-ctx.Done()
 	return nil
@@ -27,15 +27,37 @@ import (
 )
 
 type Tailer interface {
-	Tail(ctx context.Context, c *cli.Config, namespace string, selector labels.Selector, containers []string, since time.Duration, timestamps bool) error
+	Tail(ctx context.Context, c *cli.Config, namespace string, selector labels.Selector, opts TailOptions) error
 }
 
-func Tail(ctx context.Context, c *cli.Config, namespace string, selector labels.Selector, containers []string, since time.Duration, timestamps bool) error {
+// TailOptions select the logs printed by Tail and how they are printed
+type TailOptions struct {
+	// Containers are the names of the containers to print the logs of, every container is
+	// included when it's empty
+	Containers []string
+	// Since is the age of the oldest line printed, zero reads the whole logs
+	Since time.Duration
+	// TailLines is the number of last lines printed, a negative value prints every line
+	TailLines  int64
+	Timestamps bool
+	Follow     bool
+	// Output may be empty for the human readable format or OutputFormatJson
+	Output string
+	// Previous prints the logs of the previous instance of each restarted container instead,
+	// Tail fails with ErrNoPreviousLogs when no container was restarted
+	Previous bool
+	// ContainerStates are the states of the containers included, every container is included
+	// when it's empty
+	ContainerStates []string
+}
+
+// Tail prints the logs of the containers in the pods matching the selector, see TailOptions
+func Tail(ctx context.Context, c *cli.Config, namespace string, selector labels.Selector, opts TailOptions) error {
 	tailer := RetrieveTailer(ctx)
 	if tailer == nil {
 		return fmt.Errorf("unable to retrieve tailer from the context: set the tailer on context with StashTailer(ctx context.Context, tailer Tailer) context.Context")
 	}
-	return tailer.Tail(ctx, c, namespace, selector, containers, since, timestamps)
+	return tailer.Tail(ctx, c, namespace, selector, opts)
 }
 
 var tailerStashKey = struct{}{}
@@ -19,6 +19,7 @@ package logs
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"regexp"
@@ -28,30 +29,57 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/stern/stern/stern"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/pointer"
 
 	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
 )
 
 const ansi = "[\u001b\u009b][[()#;?]*(?:[0-9]{1,4}(?:;[0-9]{0,4})*)?[0-9A-ORZcf-nqry=><]"
 
+// OutputFormatJson prints each log line as a JSON object instead of the human readable format
+const OutputFormatJson = "json"
+
 var _ Tailer = &SternTailer{}
 var re = regexp.MustCompile(ansi)
 
+// wholeLogSince is older than any pod, the api server rejects a since of zero
+const wholeLogSince = 100 * 365 * 24 * time.Hour
+
+// ErrNoPreviousLogs is returned when previous logs are requested and none of the containers was
+// restarted, so there is no previous instance to read the logs from
+var ErrNoPreviousLogs = errors.New("no previous container logs found")
+
+// ContainerStates are the states containers are filtered by
+var ContainerStates = []string{stern.RUNNING, stern.WAITING, stern.TERMINATED, stern.ALL_STATES}
+
 type SternTailer struct{}
 
-func (s *SternTailer) Tail(ctx context.Context, c *cli.Config, namespace string, selector labels.Selector, containers []string, since time.Duration, timestamps bool) error {
+func (s *SternTailer) Tail(ctx context.Context, c *cli.Config, namespace string, selector labels.Selector, opts TailOptions) error {
 	containerQuery := regexp.MustCompile(".*")
-	if len(containers) != 0 {
+	if len(opts.Containers) != 0 {
 		escapedContainers := []string{}
-		for _, c := range containers {
+		for _, c := range opts.Containers {
 			escapedContainers = append(escapedContainers, regexp.QuoteMeta(c))
 		}
 		containerQuery = regexp.MustCompile(fmt.Sprintf("^(%s)$", strings.Join(escapedContainers, "|")))
 	}
 	t := "{{color .ContainerColor .PodName}}{{color .PodColor \"[\"}}{{color .PodColor .ContainerName}}{{color .PodColor \"]\"}} {{format .Message}}\n"
+	timestamps := opts.Timestamps
+	location := time.Local
+	if opts.Output == OutputFormatJson {
+		// the timestamp is always part of a json line, in UTC so lines from every source compare
+		t = "{{line . | json}}\n"
+		timestamps = true
+		location = time.UTC
+	}
 	funs := map[string]interface{}{
+		"line": func(log stern.Log) Line {
+			return NewLine(log, timestamps)
+		},
 		"json": func(in interface{}) (string, error) {
 			b, err := json.Marshal(in)
 			if err != nil {
@@ -71,19 +99,49 @@ func (s *SternTailer) Tail(ctx context.Context, c *cli.Config, namespace string,
 		panic(err)
 	}
 
+	since := opts.Since
+	if since <= 0 {
+		since = wholeLogSince
+	}
+	var tail *int64
+	if opts.TailLines >= 0 {
+		tail = &opts.TailLines
+	}
+	states := []stern.ContainerState{stern.ALL_STATES}
+	if len(opts.ContainerStates) != 0 {
+		states = []stern.ContainerState{}
+		for _, state := range opts.ContainerStates {
+			cs, err := stern.NewContainerState(state)
+			if err != nil {
+				return err
+			}
+			states = append(states, cs)
+		}
+	}
+
+	if opts.Previous {
+		options := &stern.TailOptions{
+			Timestamps:   timestamps,
+			Location:     location,
+			SinceSeconds: pointer.Int64(int64(since.Seconds())),
+			TailLines:    tail,
+			OnlyLogLines: true,
+		}
+		return tailPrevious(ctx, c, namespace, selector, containerQuery, states, template, options)
+	}
+
 	configStern := stern.Config{
-		KubeConfig:     c.KubeConfigFile,
-		ContextName:    c.CurrentContext,
-		Namespaces:     []string{namespace},
-		Timestamps:     timestamps,
-		Location:       time.Local,
-		LabelSelector:  selector,
-		ContainerQuery: containerQuery,
-		ContainerStates: []stern.ContainerState{
-			stern.ALL_STATES,
-		},
-		InitContainers: true,
-		Since:          since,
+		KubeConfig:      c.KubeConfigFile,
+		ContextName:     c.CurrentContext,
+		Namespaces:      []string{namespace},
+		Timestamps:      timestamps,
+		Location:        location,
+		LabelSelector:   selector,
+		ContainerQuery:  containerQuery,
+		ContainerStates: states,
+		InitContainers:  true,
+		Since:           since,
+		TailLines:       tail,
 
 		// PodQuery and FieldSelector are required, but we use LabelSelector instead
 		PodQuery:      regexp.MustCompile(""),
@@ -92,13 +150,59 @@ func (s *SternTailer) Tail(ctx context.Context, c *cli.Config, namespace string,
 		Template:       template,
 		Out:            c.Stdout,
 		ErrOut:         c.Stderr,
-		Follow:         true,
+		Follow:         opts.Follow,
 		MaxLogRequests: math.MaxInt16, // 32767
 	}
 
 	return stern.Run(ctx, &configStern)
 }
 
+// tailPrevious prints the logs of the previous instance of each container of the pods matching the
+// selector. The logs of a previous instance are complete, they are printed once without following.
+func tailPrevious(ctx context.Context, c *cli.Config, namespace string, selector labels.Selector, containerQuery *regexp.Regexp, states []stern.ContainerState, template *template.Template, options *stern.TailOptions) error {
+	pods, err := c.GetClientSet().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, pod := range pods.Items {
+		for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+			if !containerQuery.MatchString(status.Name) || !matchContainerState(states, status.State) {
+				continue
+			}
+			// only a restarted container has a previous instance, the kubelet keeps its logs
+			if status.LastTerminationState.Terminated == nil {
+				continue
+			}
+			found = true
+			tail := stern.NewTail(c.GetClientSet().CoreV1(), pod.Spec.NodeName, pod.Namespace, pod.Name, status.Name, template, c.Stdout, c.Stderr, options)
+			req := c.GetClientSet().CoreV1().Pods(namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+				Container:    status.Name,
+				Previous:     true,
+				Timestamps:   true,
+				SinceSeconds: options.SinceSeconds,
+				TailLines:    options.TailLines,
+			})
+			if err := tail.ConsumeRequest(ctx, req); err != nil {
+				return err
+			}
+		}
+	}
+	if !found {
+		return ErrNoPreviousLogs
+	}
+	return nil
+}
+
+func matchContainerState(states []stern.ContainerState, state corev1.ContainerState) bool {
+	for _, s := range states {
+		if s.Match(state) {
+			return true
+		}
+	}
+	return false
+}
+
 func stripANSIColor(message string) string {
 	if color.NoColor {
 		return re.ReplaceAllString(message, "")
@@ -106,3 +210,30 @@ func stripANSIColor(message string) string {
 		return message
 	}
 }
+
+// Line is a single log line split into its fields, for structured output
+type Line struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Timestamp string `json:"timestamp,omitempty"`
+	Message   string `json:"message"`
+}
+
+// NewLine splits a stern log into its fields. When timestamps are enabled stern prefixes the
+// message with the timestamp, a message that doesn't start with a valid timestamp is kept whole.
+func NewLine(log stern.Log, timestamps bool) Line {
+	line := Line{
+		Pod:       log.PodName,
+		Container: log.ContainerName,
+		Message:   re.ReplaceAllString(log.Message, ""),
+	}
+	if timestamps {
+		if ts, message, found := strings.Cut(line.Message, " "); found {
+			if _, err := time.Parse(stern.TimestampFormatDefault, ts); err == nil {
+				line.Timestamp = ts
+				line.Message = message
+			}
+		}
+	}
+	return line
+}
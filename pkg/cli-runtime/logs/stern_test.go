@@ -0,0 +1,126 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stern/stern/stern"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestNewLine(t *testing.T) {
+	tests := []struct {
+		name       string
+		log        stern.Log
+		timestamps bool
+		expected   Line
+	}{{
+		name: "message",
+		log: stern.Log{
+			PodName:       "my-pod",
+			ContainerName: "workload",
+			Message:       "hello world",
+		},
+		expected: Line{
+			Pod:       "my-pod",
+			Container: "workload",
+			Message:   "hello world",
+		},
+	}, {
+		name: "strips ansi codes",
+		log: stern.Log{
+			PodName:       "my-pod",
+			ContainerName: "workload",
+			Message:       "\x1b[32mhello\x1b[0m world",
+		},
+		expected: Line{
+			Pod:       "my-pod",
+			Container: "workload",
+			Message:   "hello world",
+		},
+	}, {
+		name: "splits timestamp",
+		log: stern.Log{
+			PodName:       "my-pod",
+			ContainerName: "workload",
+			Message:       "2023-03-01T10:15:00.000000000Z hello world",
+		},
+		timestamps: true,
+		expected: Line{
+			Pod:       "my-pod",
+			Container: "workload",
+			Timestamp: "2023-03-01T10:15:00.000000000Z",
+			Message:   "hello world",
+		},
+	}, {
+		name: "keeps message without a valid timestamp",
+		log: stern.Log{
+			PodName:       "my-pod",
+			ContainerName: "workload",
+			Message:       "hello world",
+		},
+		timestamps: true,
+		expected: Line{
+			Pod:       "my-pod",
+			Container: "workload",
+			Message:   "hello world",
+		},
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := NewLine(test.log, test.timestamps)
+			if diff := cmp.Diff(test.expected, actual); diff != "" {
+				t.Errorf("NewLine() (-expected, +actual) = %s", diff)
+			}
+		})
+	}
+}
+
+func TestMatchContainerState(t *testing.T) {
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	terminated := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}}
+	tests := []struct {
+		name     string
+		states   []stern.ContainerState
+		state    corev1.ContainerState
+		expected bool
+	}{{
+		name:     "all states",
+		states:   []stern.ContainerState{stern.ALL_STATES},
+		state:    running,
+		expected: true,
+	}, {
+		name:     "matching state",
+		states:   []stern.ContainerState{stern.WAITING, stern.TERMINATED},
+		state:    terminated,
+		expected: true,
+	}, {
+		name:     "other state",
+		states:   []stern.ContainerState{stern.TERMINATED},
+		state:    running,
+		expected: false,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := matchContainerState(test.states, test.state); actual != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
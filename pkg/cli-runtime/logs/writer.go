@@ -0,0 +1,51 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"io"
+	"sync"
+)
+
+// StoppableWriter forwards writes to the wrapped writer until it is stopped.
+// Writes received after Stop are discarded, so log lines flushed late by a
+// tailer can not be interleaved with output printed after the tail ended.
+type StoppableWriter struct {
+	mu      sync.Mutex
+	out     io.Writer
+	stopped bool
+}
+
+func NewStoppableWriter(out io.Writer) *StoppableWriter {
+	return &StoppableWriter{out: out}
+}
+
+func (w *StoppableWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stopped {
+		return len(p), nil
+	}
+	return w.out.Write(p)
+}
+
+// Stop waits for any in flight write to complete and discards all the following ones
+func (w *StoppableWriter) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stopped = true
+}
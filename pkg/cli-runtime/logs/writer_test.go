@@ -0,0 +1,39 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestStoppableWriter(t *testing.T) {
+	out := &bytes.Buffer{}
+	w := NewStoppableWriter(out)
+
+	if n, err := w.Write([]byte("before stop\n")); err != nil || n != 12 {
+		t.Errorf("Write() = (%d, %v), expected (12, nil)", n, err)
+	}
+	w.Stop()
+	if n, err := w.Write([]byte("after stop\n")); err != nil || n != 11 {
+		t.Errorf("Write() = (%d, %v), expected (11, nil)", n, err)
+	}
+
+	if expected, actual := "before stop\n", out.String(); expected != actual {
+		t.Errorf("expected output %q, actually %q", expected, actual)
+	}
+}
@@ -20,7 +20,9 @@ import (
 	"context"
 
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/printer"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/validation"
 )
 
@@ -40,7 +42,9 @@ func ValidateE(ctx context.Context, obj validation.Validatable) func(cmd *cobra.
 	return func(cmd *cobra.Command, args []string) error {
 		ctx := WithCommand(ctx, cmd)
 		if err := obj.Validate(ctx); len(err) != 0 {
-			return err.ToAggregate()
+			aggregate := err.ToAggregate()
+			writeJSONErrorForOutput(cmd, aggregate, metav1.StatusReasonInvalid)
+			return aggregate
 		}
 		cmd.SilenceUsage = true
 		return nil
@@ -71,6 +75,19 @@ func ExecE(ctx context.Context, c *Config, obj Executable) func(cmd *cobra.Comma
 			ctx = WithStdout(ctx, c.Stdout)
 			c.Stdout = c.Stderr
 		}
-		return obj.Exec(ctx, c)
+		err := obj.Exec(ctx, c)
+		if err != nil {
+			writeJSONErrorForOutput(cmd, err, "")
+		}
+		return err
+	}
+}
+
+// writeJSONErrorForOutput prints the error as JSON on the command output when the command was
+// run with --output json. The human message is still printed on stderr by the caller.
+func writeJSONErrorForOutput(cmd *cobra.Command, err error, reason metav1.StatusReason) {
+	if f := cmd.Flags().Lookup(StripDash(OutputFlagName)); f == nil || f.Value.String() != printer.OutputFormatJson {
+		return
 	}
+	_ = WriteJSONError(cmd.OutOrStdout(), err, reason)
 }
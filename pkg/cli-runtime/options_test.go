@@ -23,6 +23,8 @@ import (
 	"testing"
 
 	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/validation"
@@ -40,10 +42,12 @@ func (o *StubValidate) Validate(ctx context.Context) validation.FieldErrors {
 
 func TestValidateE(t *testing.T) {
 	tests := []struct {
-		name          string
-		opts          *StubValidate
-		expectedErr   error
-		usageSilenced bool
+		name           string
+		opts           *StubValidate
+		output         string
+		expectedErr    error
+		expectedOutput string
+		usageSilenced  bool
 	}{{
 		name:          "valid, no error",
 		opts:          &StubValidate{},
@@ -61,12 +65,32 @@ func TestValidateE(t *testing.T) {
 		},
 		expectedErr:   validation.ErrMissingField("field-name").ToAggregate(),
 		usageSilenced: false,
+	}, {
+		name: "validation error with json output",
+		opts: &StubValidate{
+			validationErr: validation.ErrMissingField("field-name"),
+		},
+		output:         "json",
+		expectedErr:    validation.ErrMissingField("field-name").ToAggregate(),
+		expectedOutput: `{"error":{"message":"field-name: Required value","reason":"Invalid"}}` + "\n",
+		usageSilenced:  false,
+	}, {
+		name: "validation error with yaml output",
+		opts: &StubValidate{
+			validationErr: validation.ErrMissingField("field-name"),
+		},
+		output:        "yaml",
+		expectedErr:   validation.ErrMissingField("field-name").ToAggregate(),
+		usageSilenced: false,
 	}}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			ctx := context.Background()
 			cmd := &cobra.Command{}
+			cmd.Flags().String(cli.StripDash(cli.OutputFlagName), test.output, "")
+			output := &bytes.Buffer{}
+			cmd.SetOut(output)
 			err := cli.ValidateE(ctx, test.opts)(cmd, []string{})
 
 			if expected, actual := true, test.opts.called; true != actual {
@@ -78,6 +102,9 @@ func TestValidateE(t *testing.T) {
 			if expected, actual := test.usageSilenced, cmd.SilenceUsage; expected != actual {
 				t.Errorf("expected cmd.SilenceUsage to be %v, actually %v", expected, actual)
 			}
+			if expected, actual := test.expectedOutput, output.String(); expected != actual {
+				t.Errorf("expected output to be %q, actually %q", expected, actual)
+			}
 		})
 	}
 }
@@ -108,9 +135,11 @@ func (o *StubExec) IsDryRun() bool {
 
 func TestExecE(t *testing.T) {
 	tests := []struct {
-		name        string
-		opts        *StubExec
-		expectedErr error
+		name           string
+		opts           *StubExec
+		output         string
+		expectedErr    error
+		expectedOutput string
 	}{{
 		name: "success",
 		opts: &StubExec{},
@@ -120,6 +149,26 @@ func TestExecE(t *testing.T) {
 			execErr: fmt.Errorf("test exec error"),
 		},
 		expectedErr: fmt.Errorf("test exec error"),
+	}, {
+		name: "failure with json output",
+		opts: &StubExec{
+			execErr: fmt.Errorf("test exec error"),
+		},
+		output:         "json",
+		expectedErr:    fmt.Errorf("test exec error"),
+		expectedOutput: `{"error":{"message":"test exec error","reason":"Unknown"}}` + "\n",
+	}, {
+		name: "api failure with json output",
+		opts: &StubExec{
+			execErr: cli.SilenceError(apierrors.NewNotFound(schema.GroupResource{Group: "carto.run", Resource: "workloads"}, "my-workload")),
+		},
+		output:         "json",
+		expectedErr:    fmt.Errorf(`workloads.carto.run "my-workload" not found`),
+		expectedOutput: `{"error":{"message":"workloads.carto.run \"my-workload\" not found","reason":"NotFound"}}` + "\n",
+	}, {
+		name:   "success with json output",
+		opts:   &StubExec{},
+		output: "json",
 	}, {
 		name: "dry run",
 		opts: &StubExec{
@@ -131,6 +180,9 @@ func TestExecE(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			ctx := context.Background()
 			cmd := &cobra.Command{}
+			cmd.Flags().String(cli.StripDash(cli.OutputFlagName), test.output, "")
+			output := &bytes.Buffer{}
+			cmd.SetOut(output)
 			config := &cli.Config{
 				Stdout: &bytes.Buffer{},
 				Stderr: &bytes.Buffer{},
@@ -149,6 +201,9 @@ func TestExecE(t *testing.T) {
 			if expected, actual := cmd, test.opts.cmd; expected != actual {
 				t.Errorf("expected command to be %v, actually %v", expected, actual)
 			}
+			if expected, actual := test.expectedOutput, output.String(); expected != actual {
+				t.Errorf("expected output to be %q, actually %q", expected, actual)
+			}
 			if test.opts.dryRun {
 				if config.Stdout != config.Stderr {
 					t.Errorf("expected stdout and stderr to be the same, actually %v %v", config.Stdout, config.Stderr)
@@ -0,0 +1,65 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parsers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	ParamTypeString = "string"
+	ParamTypeNumber = "number"
+	ParamTypeBool   = "bool"
+	ParamTypeJson   = "json"
+)
+
+var ParamTypes = []string{ParamTypeString, ParamTypeNumber, ParamTypeBool, ParamTypeJson}
+
+// TypedParamKey splits a param key with an optional type annotation ("name:type")
+// into the param name and its type. The type is empty when there is no annotation
+func TypedParamKey(key string) (string, string) {
+	if i := strings.LastIndex(key, ":"); i > 0 {
+		return key[:i], key[i+1:]
+	}
+	return key, ""
+}
+
+// TypedParamValue encodes a param value following its type annotation, values without
+// a type are kept as strings
+func TypedParamValue(paramType, value string) (interface{}, error) {
+	switch paramType {
+	case "", ParamTypeString:
+		return value, nil
+	case ParamTypeNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("%q is not a number", value)
+		}
+		return json.Number(value), nil
+	case ParamTypeBool:
+		return strconv.ParseBool(value)
+	case ParamTypeJson:
+		var obj interface{}
+		if err := json.Unmarshal([]byte(value), &obj); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	}
+	return nil, fmt.Errorf("unknown param type %q", paramType)
+}
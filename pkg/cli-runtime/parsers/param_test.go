@@ -0,0 +1,130 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parsers_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/parsers"
+)
+
+func TestTypedParamKey(t *testing.T) {
+	tests := []struct {
+		name         string
+		key          string
+		expectedName string
+		expectedType string
+	}{{
+		name:         "no type",
+		key:          "version",
+		expectedName: "version",
+	}, {
+		name:         "with type",
+		key:          "version:string",
+		expectedName: "version",
+		expectedType: "string",
+	}, {
+		name:         "leading colon",
+		key:          ":string",
+		expectedName: ":string",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotName, gotType := parsers.TypedParamKey(test.key)
+			if gotName != test.expectedName || gotType != test.expectedType {
+				t.Errorf("TypedParamKey() = (%q, %q), expected (%q, %q)", gotName, gotType, test.expectedName, test.expectedType)
+			}
+		})
+	}
+}
+
+func TestTypedParamValue(t *testing.T) {
+	tests := []struct {
+		name          string
+		paramType     string
+		value         string
+		expectedError bool
+		expected      string
+	}{{
+		name:     "no type",
+		value:    "1.10",
+		expected: `"1.10"`,
+	}, {
+		name:      "string",
+		paramType: parsers.ParamTypeString,
+		value:     "1.10",
+		expected:  `"1.10"`,
+	}, {
+		name:      "number",
+		paramType: parsers.ParamTypeNumber,
+		value:     "1.10",
+		expected:  `1.10`,
+	}, {
+		name:          "invalid number",
+		paramType:     parsers.ParamTypeNumber,
+		value:         "one",
+		expectedError: true,
+	}, {
+		name:      "bool",
+		paramType: parsers.ParamTypeBool,
+		value:     "true",
+		expected:  `true`,
+	}, {
+		name:          "invalid bool",
+		paramType:     parsers.ParamTypeBool,
+		value:         "yes please",
+		expectedError: true,
+	}, {
+		name:      "json",
+		paramType: parsers.ParamTypeJson,
+		value:     `{"key":["value"]}`,
+		expected:  `{"key":["value"]}`,
+	}, {
+		name:          "invalid json",
+		paramType:     parsers.ParamTypeJson,
+		value:         `{"key":`,
+		expectedError: true,
+	}, {
+		name:          "unknown type",
+		paramType:     "int",
+		value:         "1",
+		expectedError: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parsers.TypedParamValue(test.paramType, test.value)
+			if (err != nil) != test.expectedError {
+				t.Fatalf("TypedParamValue() errored %v, expected error %t", err, test.expectedError)
+			}
+			if test.expectedError {
+				return
+			}
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("unable to marshal value %v", err)
+			}
+			if diff := cmp.Diff(test.expected, string(b)); diff != "" {
+				t.Errorf("TypedParamValue() = (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/printer/tabwriter"
+)
+
+const OutputFormatCustomColumns = "custom-columns"
+
+// CustomColumn is a single column of a custom-columns output, the value of each cell is the
+// result of evaluating the JSONPath expression against the resource
+type CustomColumn struct {
+	Header string
+	Path   *jsonpath.JSONPath
+}
+
+// IsCustomColumns returns true when the output format is a custom-columns spec, like
+// custom-columns=NAME:.metadata.name
+func IsCustomColumns(output string) bool {
+	return strings.HasPrefix(output, OutputFormatCustomColumns+"=")
+}
+
+// ParseCustomColumns parses an output format like
+// custom-columns=NAME:.metadata.name,TYPE:.metadata.labels['apps.tanzu.vmware.com/workload-type']
+// into its columns. The JSONPath expressions may be wrapped in braces or not.
+func ParseCustomColumns(output string) ([]CustomColumn, error) {
+	if !IsCustomColumns(output) {
+		return nil, fmt.Errorf("expected %s=<header>:<json-path>[,<header>:<json-path>]", OutputFormatCustomColumns)
+	}
+	spec := strings.TrimPrefix(output, OutputFormatCustomColumns+"=")
+	if spec == "" {
+		return nil, fmt.Errorf("at least one column is required")
+	}
+
+	columns := []CustomColumn{}
+	for _, part := range strings.Split(spec, ",") {
+		header, path, found := strings.Cut(part, ":")
+		if !found || header == "" || path == "" {
+			return nil, fmt.Errorf("column %q must be in the form <header>:<json-path>", part)
+		}
+		p := jsonpath.New(header).AllowMissingKeys(true)
+		if err := p.Parse(relaxedJSONPath(path)); err != nil {
+			return nil, fmt.Errorf("column %q has an invalid json path: %v", header, err)
+		}
+		columns = append(columns, CustomColumn{Header: header, Path: p})
+	}
+	return columns, nil
+}
+
+var quotedKeyPattern = regexp.MustCompile(`\[['"]([^'"]+)['"]\]`)
+
+// relaxedJSONPath wraps the path in braces and rewrites quoted keys, like
+// .metadata.labels['apps.tanzu.vmware.com/workload-type'], into the escaped form the
+// jsonpath parser understands
+func relaxedJSONPath(path string) string {
+	path = strings.TrimSuffix(strings.TrimPrefix(path, "{"), "}")
+	path = quotedKeyPattern.ReplaceAllStringFunc(path, func(m string) string {
+		key := quotedKeyPattern.FindStringSubmatch(m)[1]
+		return "." + strings.ReplaceAll(key, ".", `\.`)
+	})
+	if !strings.HasPrefix(path, ".") && !strings.HasPrefix(path, "[") {
+		path = "." + path
+	}
+	return fmt.Sprintf("{%s}", path)
+}
+
+// OutputCustomColumns prints a table with one row per resource and one column per custom column.
+// Cells that resolve to no value are shown as <none>.
+func OutputCustomColumns(w io.Writer, objList []Object, columns []CustomColumn, noHeaders bool) error {
+	// same layout as the table printer, without its indentation
+	tw := tabwriter.NewWriter(w, 6, 4, 3, ' ', 0, tabwriter.RememberWidths|tabwriter.IgnoreAnsiCodes)
+
+	if !noHeaders {
+		headers := make([]string, 0, len(columns))
+		for _, c := range columns {
+			headers = append(headers, strings.ToUpper(c.Header))
+		}
+		fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	}
+
+	for _, obj := range objList {
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return err
+		}
+		cells := make([]string, 0, len(columns))
+		for _, c := range columns {
+			values, err := c.Path.FindResults(u)
+			if err != nil {
+				return err
+			}
+			var cell bytes.Buffer
+			for i, result := range values {
+				if i > 0 {
+					cell.WriteString(",")
+				}
+				if err := c.Path.PrintResults(&cell, result); err != nil {
+					return err
+				}
+			}
+			if cell.Len() == 0 {
+				cells = append(cells, "<none>")
+				continue
+			}
+			cells = append(cells, cell.String())
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/printer"
+)
+
+func TestParseCustomColumns(t *testing.T) {
+	tests := []struct {
+		name        string
+		output      string
+		headers     []string
+		shouldError bool
+	}{{
+		name:    "single column",
+		output:  "custom-columns=NAME:.metadata.name",
+		headers: []string{"NAME"},
+	}, {
+		name:    "multiple columns",
+		output:  "custom-columns=NAME:.metadata.name,TYPE:.metadata.labels['apps.tanzu.vmware.com/workload-type']",
+		headers: []string{"NAME", "TYPE"},
+	}, {
+		name:    "braced and relaxed paths",
+		output:  "custom-columns=NAME:{.metadata.name},IMAGE:spec.image",
+		headers: []string{"NAME", "IMAGE"},
+	}, {
+		name:        "not custom columns",
+		output:      "json",
+		shouldError: true,
+	}, {
+		name:        "no columns",
+		output:      "custom-columns=",
+		shouldError: true,
+	}, {
+		name:        "missing path",
+		output:      "custom-columns=NAME",
+		shouldError: true,
+	}, {
+		name:        "invalid path",
+		output:      "custom-columns=NAME:.metadata[",
+		shouldError: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			columns, err := printer.ParseCustomColumns(test.output)
+			if (err != nil) != test.shouldError {
+				t.Fatalf("ParseCustomColumns() errored %v, expected error %v", err, test.shouldError)
+			}
+			if test.shouldError {
+				return
+			}
+			headers := []string{}
+			for _, c := range columns {
+				headers = append(headers, c.Header)
+			}
+			if diff := cmp.Diff(test.headers, headers); diff != "" {
+				t.Errorf("ParseCustomColumns() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestOutputCustomColumns(t *testing.T) {
+	workloads := []printer.Object{
+		&cartov1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "my-workload",
+				Labels:    map[string]string{"apps.tanzu.vmware.com/workload-type": "web"},
+			},
+			Spec: cartov1alpha1.WorkloadSpec{
+				Image: "ubuntu:bionic",
+			},
+		},
+		&cartov1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "my-other-workload",
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		output    string
+		noHeaders bool
+		want      string
+	}{{
+		name:   "columns",
+		output: "custom-columns=NAME:.metadata.name,TYPE:.metadata.labels['apps.tanzu.vmware.com/workload-type'],IMAGE:.spec.image",
+		want: `
+NAME                TYPE     IMAGE
+my-workload         web      ubuntu:bionic
+my-other-workload   <none>   <none>
+`,
+	}, {
+		name:      "no headers",
+		output:    "custom-columns=NAME:.metadata.name,NAMESPACE:.metadata.namespace",
+		noHeaders: true,
+		want: `
+my-workload         default
+my-other-workload   default
+`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			columns, err := printer.ParseCustomColumns(test.output)
+			if err != nil {
+				t.Fatalf("ParseCustomColumns() errored %v", err)
+			}
+			out := &bytes.Buffer{}
+			if err := printer.OutputCustomColumns(out, workloads, columns, test.noHeaders); err != nil {
+				t.Fatalf("OutputCustomColumns() errored %v", err)
+			}
+			if diff := cmp.Diff(strings.TrimPrefix(test.want, "\n"), out.String()); diff != "" {
+				t.Errorf("OutputCustomColumns() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
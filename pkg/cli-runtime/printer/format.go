@@ -31,6 +31,15 @@ func TimestampSince(timestamp metav1.Time, now time.Time) string {
 	return duration.HumanDuration(now.Sub(timestamp.Time))
 }
 
+// Timestamp formats the timestamp as the age relative to now, like 3d or 45m, or as an absolute
+// RFC3339 time when full is set
+func Timestamp(timestamp metav1.Time, now time.Time, full bool) string {
+	if timestamp.IsZero() || !full {
+		return TimestampSince(timestamp, now)
+	}
+	return timestamp.UTC().Format(time.RFC3339)
+}
+
 func EmptyString(str string) string {
 	if str == "" {
 		return Sfaintf("<empty>")
@@ -59,6 +59,49 @@ func TestTimestampSince(t *testing.T) {
 	}
 }
 
+func TestTimestamp(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	now := time.Date(2023, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		input  metav1.Time
+		full   bool
+		output string
+	}{{
+		name:   "empty",
+		output: printer.Swarnf("<unknown>"),
+	}, {
+		name:   "empty full",
+		full:   true,
+		output: printer.Swarnf("<unknown>"),
+	}, {
+		name:   "45 minutes ago",
+		input:  metav1.Time{Time: now.Add(-45 * time.Minute)},
+		output: "45m",
+	}, {
+		name:   "3 days ago",
+		input:  metav1.Time{Time: now.AddDate(0, 0, -3)},
+		output: "3d",
+	}, {
+		name:   "3 days ago full",
+		input:  metav1.Time{Time: now.AddDate(0, 0, -3).In(time.FixedZone("EST", -5*60*60))},
+		full:   true,
+		output: "2023-03-07T12:00:00Z",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if expected, actual := test.output, printer.Timestamp(test.input, now, test.full); expected != actual {
+				t.Errorf("Expected formated string to be %q, actually %q", expected, actual)
+			}
+		})
+	}
+}
+
 func TestEmptyString(t *testing.T) {
 	noColor := color.NoColor
 	color.NoColor = true
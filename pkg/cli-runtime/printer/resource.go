@@ -17,12 +17,18 @@ limitations under the License.
 package printer
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"regexp"
+	"sort"
 	"strings"
+	"unicode/utf8"
 
+	"github.com/acarl005/stripansi"
 	"github.com/fatih/color"
+	unifieddiff "github.com/pmezard/go-difflib/difflib"
 	"github.com/vmware-tanzu/difflib"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -92,6 +98,56 @@ func setGVK(obj Object, scheme *runtime.Scheme) (Object, error) {
 }
 
 func OutputResource(obj Object, format OutputFormat, scheme *runtime.Scheme) (string, error) {
+	return outputResource(obj, format, scheme, false)
+}
+
+// OutputResourceWithManagedFields prints the resource like OutputResource but keeps
+// metadata.managedFields, which helps debugging server-side apply conflicts
+func OutputResourceWithManagedFields(obj Object, format OutputFormat, scheme *runtime.Scheme) (string, error) {
+	return outputResource(obj, format, scheme, true)
+}
+
+// OutputResourceFields prints only the subtrees of the resource at the dotted paths, like
+// spec.source, keeping their position in the resource. Paths not found in the resource are skipped.
+func OutputResourceFields(obj Object, format OutputFormat, scheme *runtime.Scheme, paths []string) (string, error) {
+	copy, err := setGVK(obj, scheme)
+	if err != nil {
+		return "", err
+	}
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(copy)
+	if err != nil {
+		return "", err
+	}
+
+	selected := map[string]interface{}{}
+	for _, path := range paths {
+		fields := strings.Split(path, ".")
+		value, found, err := unstructured.NestedFieldNoCopy(u, fields...)
+		if err != nil || !found {
+			continue
+		}
+		if err := unstructured.SetNestedField(selected, value, fields...); err != nil {
+			return "", err
+		}
+	}
+
+	return printObject(selected, format)
+}
+
+// OutputResourceStatus prints only the status of the resource, an empty object when it has none
+func OutputResourceStatus(obj Object, format OutputFormat) (string, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return "", err
+	}
+	status, found, err := unstructured.NestedFieldNoCopy(u, "status")
+	if err != nil || !found || status == nil {
+		status = map[string]interface{}{}
+	}
+	return printObject(status, format)
+}
+
+func outputResource(obj Object, format OutputFormat, scheme *runtime.Scheme, showManagedFields bool) (string, error) {
 	copy, err := setGVK(obj, scheme)
 	if err != nil {
 		return "", err
@@ -101,29 +157,49 @@ func OutputResource(obj Object, format OutputFormat, scheme *runtime.Scheme) (st
 		return "", err
 	}
 
-	unstructured.RemoveNestedField(u, "metadata", "managedFields")
+	if !showManagedFields {
+		unstructured.RemoveNestedField(u, "metadata", "managedFields")
+	}
 
 	return printObject(u, format)
 }
 
+// List wraps a set of resources of any kind the same way kubectl does, so the output is a single
+// document that can be read back by other tools
+type List struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []Object `json:"items"`
+}
+
 func OutputResources(objList []Object, format OutputFormat, scheme *runtime.Scheme) (string, error) {
-	updatedList := []Object{}
+	list := &List{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "List",
+		},
+		Items: []Object{},
+	}
 
 	for _, o := range objList {
 		copy, err := setGVK(o, scheme)
 		if err != nil {
 			return "", err
 		}
-		updatedList = append(updatedList, copy)
+		list.Items = append(list.Items, copy)
 	}
-	return printObject(updatedList, format)
+	return printObject(list, format)
 }
 
 func printObject(obj interface{}, format OutputFormat) (string, error) {
 	// render according to desired format
 	switch format {
 	case OutputFormatJson:
-		b, err := json.MarshalIndent(obj, "", "\t")
+		sorted, err := sortJsonKeys(obj)
+		if err != nil {
+			return "", err
+		}
+		b, err := json.MarshalIndent(sorted, "", "\t")
 		return strings.TrimSpace(string(b)), err
 	case OutputFormatYaml, OutputFormatYml:
 		b, err := yaml.Marshal(obj)
@@ -133,11 +209,35 @@ func printObject(obj interface{}, format OutputFormat) (string, error) {
 	}
 }
 
+// sortJsonKeys decodes obj into generic maps so every object key, including the ones from
+// struct fields and raw param values, is marshaled in sorted order
+func sortJsonKeys(obj interface{}) (interface{}, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var sorted interface{}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(&sorted); err != nil {
+		return nil, err
+	}
+	return sorted, nil
+}
+
+// YamlTransform changes how a resource exported to yaml is rendered in a diff, like the style of
+// some of its fields, without changing its content
+type YamlTransform func(string) (string, error)
+
 var (
 	DiffAdditionColor    = color.New(color.FgGreen)
 	DiffSubtractionColor = color.New(color.FgRed)
 	DiffUnchangedColor   = color.New()
 	DiffContextToShow    = 4
+	// DiffAdditionWordColor and DiffSubtractionWordColor highlight the changed part of a modified
+	// line in the diffs returned by ResourceWordDiff
+	DiffAdditionWordColor    = color.New(color.FgGreen, color.Bold, color.Underline)
+	DiffSubtractionWordColor = color.New(color.FgRed, color.Bold, color.Underline)
 )
 
 // ResourceDiff returns the results of diffing left and right as an pretty
@@ -149,17 +249,47 @@ var (
 // the line.
 // When the right and left are equal it will prepend a "   |" before
 // the line.
-func ResourceDiff(left, right Object, scheme *runtime.Scheme) (string, bool, error) {
-	leftLines, err := yamlLines(left, scheme)
+func ResourceDiff(left, right Object, scheme *runtime.Scheme, transforms ...YamlTransform) (string, bool, error) {
+	return ResourceDiffWithOptions(left, right, scheme, DiffOptions{}, transforms...)
+}
+
+// ResourceWordDiff returns the same diff as ResourceDiff, with the part of a modified line that
+// changed highlighted, like the patch number of a version going from 2.6.0 to 2.6.1. The lines
+// removed are paired with the lines added right after them when there are as many of both. Without
+// colors the diff is the same as the one of ResourceDiff.
+func ResourceWordDiff(left, right Object, scheme *runtime.Scheme, transforms ...YamlTransform) (string, bool, error) {
+	return ResourceDiffWithOptions(left, right, scheme, DiffOptions{WordDiff: true}, transforms...)
+}
+
+// DiffOptions change how the diffs returned by ResourceDiffWithOptions are rendered
+type DiffOptions struct {
+	// WordDiff highlights the changed part of the modified lines, see ResourceWordDiff
+	WordDiff bool
+	// CollapseUnchanged replaces each top level section of the spec that has nested fields and is
+	// unchanged with a single "<field>: (unchanged)" line
+	CollapseUnchanged bool
+}
+
+// ResourceDiffWithOptions returns the same diff as ResourceDiff, rendered with the options
+func ResourceDiffWithOptions(left, right Object, scheme *runtime.Scheme, options DiffOptions, transforms ...YamlTransform) (string, bool, error) {
+	leftLines, err := yamlLines(left, scheme, transforms)
 	if err != nil {
 		return "", false, err
 	}
-	rightLines, err := yamlLines(right, scheme)
+	rightLines, err := yamlLines(right, scheme, transforms)
 	if err != nil {
 		return "", false, err
 	}
 
 	diff := difflib.Diff(leftLines, rightLines)
+	modified := map[int]string{}
+	if options.WordDiff {
+		modified = modifiedLines(diff)
+	}
+	collapsed := map[int]int{}
+	if options.CollapseUnchanged {
+		collapsed = unchangedSpecSections(diff)
+	}
 
 	var sb strings.Builder
 	inElipsis := false
@@ -170,12 +300,32 @@ func ResourceDiff(left, right Object, scheme *runtime.Scheme) (string, bool, err
 		case difflib.RightOnly:
 			inElipsis = false
 			hasDiff = true
-			sb.WriteString(DiffAdditionColor.Sprintf("%3s %3d + |%s\n", "", record.LineRight+1, record.Payload))
+			prefix := fmt.Sprintf("%3s %3d + |", "", record.LineRight+1)
+			if other, ok := modified[lineNum]; ok {
+				sb.WriteString(highlightChange(DiffAdditionColor, DiffAdditionWordColor, prefix, record.Payload, other))
+				continue
+			}
+			sb.WriteString(DiffAdditionColor.Sprintf("%s%s\n", prefix, record.Payload))
 		case difflib.LeftOnly:
 			inElipsis = false
 			hasDiff = true
-			sb.WriteString(DiffSubtractionColor.Sprintf("%3d %3s - |%s\n", record.LineLeft+1, "", record.Payload))
+			prefix := fmt.Sprintf("%3d %3s - |", record.LineLeft+1, "")
+			if other, ok := modified[lineNum]; ok {
+				sb.WriteString(highlightChange(DiffSubtractionColor, DiffSubtractionWordColor, prefix, record.Payload, other))
+				continue
+			}
+			sb.WriteString(DiffSubtractionColor.Sprintf("%s%s\n", prefix, record.Payload))
 		case difflib.Common:
+			if end, ok := collapsed[lineNum]; ok {
+				if end == -1 {
+					continue
+				}
+				if inContext(lineNum, diff) || inContext(end-1, diff) {
+					inElipsis = false
+					sb.WriteString(DiffUnchangedColor.Sprintf("%3d,%3d   |%s (unchanged)\n", record.LineLeft+1, record.LineRight+1, record.Payload))
+					continue
+				}
+			}
 			if !inContext(lineNum, diff) {
 				if !inElipsis {
 					sb.WriteString(DiffUnchangedColor.Sprintf("...\n"))
@@ -190,6 +340,167 @@ func ResourceDiff(left, right Object, scheme *runtime.Scheme) (string, bool, err
 	return sb.String(), !hasDiff, nil
 }
 
+// unchangedSpecSections finds the top level sections of the spec with nested fields that are the
+// same on both sides. The first line of each section, holding its field name, is returned with the
+// end of the section to be shown once as unchanged, the following lines of the section are returned
+// with -1 to be hidden.
+func unchangedSpecSections(diff []difflib.DiffRecord) map[int]int {
+	collapsed := map[int]int{}
+	inSpec := false
+	start := -1
+	unchanged := true
+	closeSection := func(end int) {
+		if start != -1 && unchanged && end-start > 1 {
+			collapsed[start] = end
+			for i := start + 1; i < end; i++ {
+				collapsed[i] = -1
+			}
+		}
+		start = -1
+	}
+	for i, record := range diff {
+		line := record.Payload
+		switch {
+		case !strings.HasPrefix(line, " "):
+			// a top level field ends the spec
+			closeSection(i)
+			inSpec = line == "spec:"
+		case !inSpec:
+		case len(line) > 2 && line[:2] == "  " && line[2] != ' ' && line[2] != '-':
+			closeSection(i)
+			start = i
+			unchanged = record.Delta == difflib.Common && strings.HasSuffix(line, ":")
+		default:
+			unchanged = unchanged && record.Delta == difflib.Common
+		}
+	}
+	closeSection(len(diff))
+	return collapsed
+}
+
+// modifiedLines pairs each line of a run of removed lines with the line at the same position in
+// the run of added lines following it, when both runs have the same length. The paired lines are
+// returned by their position in the diff, with the line they are paired with.
+func modifiedLines(diff []difflib.DiffRecord) map[int]string {
+	modified := map[int]string{}
+	for i := 0; i < len(diff); {
+		if diff[i].Delta != difflib.LeftOnly {
+			i++
+			continue
+		}
+		removed := i
+		for i < len(diff) && diff[i].Delta == difflib.LeftOnly {
+			i++
+		}
+		added := i
+		for i < len(diff) && diff[i].Delta == difflib.RightOnly {
+			i++
+		}
+		if added-removed != i-added {
+			continue
+		}
+		for j := 0; j < added-removed; j++ {
+			modified[removed+j] = diff[added+j].Payload
+			modified[added+j] = diff[removed+j].Payload
+		}
+	}
+	return modified
+}
+
+// highlightChange renders a line of the diff with the part of its payload that differs from the
+// line it is paired with in the change color, the common start and end in the line color
+func highlightChange(lineColor, changeColor *color.Color, prefix, payload, other string) string {
+	start, end := changedRange(payload, other)
+	if start == end {
+		return lineColor.Sprintf("%s%s\n", prefix, payload)
+	}
+	return lineColor.Sprintf("%s%s", prefix, payload[:start]) + changeColor.Sprint(payload[start:end]) + lineColor.Sprintf("%s\n", payload[end:])
+}
+
+// changedRange returns the byte range of s left once the start and the end it has in common with
+// other are trimmed, without splitting a rune
+func changedRange(s, other string) (int, int) {
+	start := 0
+	for start < len(s) && start < len(other) {
+		r, size := utf8.DecodeRuneInString(s[start:])
+		if o, _ := utf8.DecodeRuneInString(other[start:]); r != o {
+			break
+		}
+		start += size
+	}
+	end, otherEnd := len(s), len(other)
+	for end > start && otherEnd > start {
+		r, size := utf8.DecodeLastRuneInString(s[:end])
+		o, otherSize := utf8.DecodeLastRuneInString(other[:otherEnd])
+		if r != o {
+			break
+		}
+		end, otherEnd = end-size, otherEnd-otherSize
+	}
+	return start, end
+}
+
+// ResourceUnifiedDiff returns the results of diffing left and right in the
+// unified format used by git and patch, with --- and +++ headers and @@ hunks.
+// When left is nil the resource is shown as a new file.
+func ResourceUnifiedDiff(left, right Object, scheme *runtime.Scheme, transforms ...YamlTransform) (string, bool, error) {
+	leftLines, err := yamlLines(left, scheme, transforms)
+	if err != nil {
+		return "", false, err
+	}
+	rightLines, err := yamlLines(right, scheme, transforms)
+	if err != nil {
+		return "", false, err
+	}
+
+	fileName := fmt.Sprintf("%s.yaml", right.GetName())
+	fromFile := "a/" + fileName
+	if len(leftLines) == 0 {
+		fromFile = "/dev/null"
+	}
+	diff, err := unifieddiff.GetUnifiedDiffString(unifieddiff.UnifiedDiff{
+		A:        withLineEndings(leftLines),
+		B:        withLineEndings(rightLines),
+		FromFile: fromFile,
+		ToFile:   "b/" + fileName,
+		Context:  3,
+	})
+	if err != nil {
+		return "", false, err
+	}
+	return diff, diff == "", nil
+}
+
+var (
+	diffAdditionPattern    = regexp.MustCompile(`^\s*\d+ \+ \|`)
+	diffSubtractionPattern = regexp.MustCompile(`^\s*\d+\s+- \|`)
+)
+
+// DiffStat counts the added and removed lines of a diff returned by ResourceDiff or
+// ResourceUnifiedDiff, colored or not. The unified diff headers are not counted.
+func DiffStat(diff string) (int, int) {
+	added, removed := 0, 0
+	for _, line := range strings.Split(stripansi.Strip(diff), "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++ "), strings.HasPrefix(line, "--- "):
+			continue
+		case diffAdditionPattern.MatchString(line), strings.HasPrefix(line, "+"):
+			added++
+		case diffSubtractionPattern.MatchString(line), strings.HasPrefix(line, "-"):
+			removed++
+		}
+	}
+	return added, removed
+}
+
+func withLineEndings(lines []string) []string {
+	res := make([]string, len(lines))
+	for i, l := range lines {
+		res[i] = l + "\n"
+	}
+	return res
+}
+
 func inContext(lineNum int, diff []difflib.DiffRecord) bool {
 	start := max(0, lineNum-DiffContextToShow)
 	end := min(len(diff), lineNum+DiffContextToShow+1)
@@ -215,7 +526,72 @@ func max(a, b int) int {
 	return b
 }
 
-func yamlLines(obj Object, scheme *runtime.Scheme) ([]string, error) {
+// ResourceRemovedFields returns the paths of the fields that are set in left and
+// are no longer set in right. Only the top most removed field of each branch is
+// returned. Only the spec, labels and annotations are compared, the status and
+// the metadata managed by the system are ignored.
+func ResourceRemovedFields(left, right Object) ([]string, error) {
+	if left == nil || reflect.ValueOf(left).IsNil() || right == nil || reflect.ValueOf(right).IsNil() {
+		return []string{}, nil
+	}
+	l, err := runtime.DefaultUnstructuredConverter.ToUnstructured(left)
+	if err != nil {
+		return nil, err
+	}
+	r, err := runtime.DefaultUnstructuredConverter.ToUnstructured(right)
+	if err != nil {
+		return nil, err
+	}
+
+	removed := []string{}
+	for _, key := range []string{"labels", "annotations"} {
+		lm, _, _ := unstructured.NestedMap(l, "metadata", key)
+		rm, _, _ := unstructured.NestedMap(r, "metadata", key)
+		removed = append(removed, removedFields("metadata."+key, lm, rm)...)
+	}
+	ls, _, _ := unstructured.NestedMap(l, "spec")
+	rs, _, _ := unstructured.NestedMap(r, "spec")
+	removed = append(removed, removedFields("spec", ls, rs)...)
+	return removed, nil
+}
+
+func removedFields(path string, left, right map[string]interface{}) []string {
+	keys := make([]string, 0, len(left))
+	for k := range left {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	removed := []string{}
+	for _, k := range keys {
+		p := fieldPath(path, k)
+		rv, ok := right[k]
+		if !ok || rv == nil {
+			if left[k] != nil {
+				removed = append(removed, p)
+			}
+			continue
+		}
+		lm, lok := left[k].(map[string]interface{})
+		rm, rok := rv.(map[string]interface{})
+		if lok && rok {
+			removed = append(removed, removedFields(p, lm, rm)...)
+		}
+	}
+	return removed
+}
+
+func fieldPath(path, key string) string {
+	if strings.ContainsAny(key, "./") {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func yamlLines(obj Object, scheme *runtime.Scheme, transforms []YamlTransform) ([]string, error) {
 	if obj == nil || reflect.ValueOf(obj).IsNil() {
 		return []string{}, nil
 	}
@@ -223,6 +599,11 @@ func yamlLines(obj Object, scheme *runtime.Scheme) ([]string, error) {
 	if err != nil {
 		return []string{}, err
 	}
+	for _, transform := range transforms {
+		if yaml, err = transform(yaml); err != nil {
+			return []string{}, err
+		}
+	}
 	return strings.Split(strings.TrimSpace(string(yaml)), "\n"), nil
 
 }
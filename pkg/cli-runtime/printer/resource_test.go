@@ -5,11 +5,16 @@ import (
 	"testing"
 	"time"
 
+	"github.com/fatih/color"
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
 
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/apis"
 	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
@@ -458,6 +463,122 @@ status:
 	}
 }
 
+func TestOutputResourceWithManagedFields(t *testing.T) {
+	scheme := runtime.NewScheme()
+	cartov1alpha1.AddToScheme(scheme)
+
+	obj := &cartov1alpha1.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-workload",
+			Namespace: "default",
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "tanzu", Operation: metav1.ManagedFieldsOperationApply},
+			},
+		},
+	}
+	want := `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  managedFields:
+  - manager: tanzu
+    operation: Apply
+  name: my-workload
+  namespace: default
+spec: {}
+status:
+  supplyChainRef: {}
+`
+
+	got, err := printer.OutputResourceWithManagedFields(obj, printer.OutputFormatYaml, scheme)
+	if err != nil {
+		t.Fatalf("OutputResourceWithManagedFields() errored %v", err)
+	}
+	if diff := cmp.Diff(strings.TrimSpace(want), got); diff != "" {
+		t.Errorf("OutputResourceWithManagedFields() (-want, +got) = %v", diff)
+	}
+}
+
+func TestOutputResourceFields(t *testing.T) {
+	scheme := runtime.NewScheme()
+	cartov1alpha1.AddToScheme(scheme)
+
+	obj := &cartov1alpha1.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-workload",
+			Namespace: "default",
+		},
+		Spec: cartov1alpha1.WorkloadSpec{
+			Image: "ubuntu:bionic",
+			Source: &cartov1alpha1.Source{
+				Git: &cartov1alpha1.GitSource{
+					URL: "https://example.com/repo.git",
+					Ref: cartov1alpha1.GitRef{
+						Branch: "main",
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		paths  []string
+		format printer.OutputFormat
+		want   string
+	}{{
+		name:   "single subtree",
+		paths:  []string{"spec.source"},
+		format: printer.OutputFormatYaml,
+		want: `
+---
+spec:
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://example.com/repo.git
+`,
+	}, {
+		name:   "multiple subtrees",
+		paths:  []string{"metadata.name", "spec.image"},
+		format: printer.OutputFormatJson,
+		want: `
+{
+	"metadata": {
+		"name": "my-workload"
+	},
+	"spec": {
+		"image": "ubuntu:bionic"
+	}
+}
+`,
+	}, {
+		name:   "missing path is skipped",
+		paths:  []string{"spec.image", "spec.build.env"},
+		format: printer.OutputFormatYaml,
+		want: `
+---
+spec:
+  image: ubuntu:bionic
+`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := printer.OutputResourceFields(obj, test.format, scheme, test.paths)
+			if err != nil {
+				t.Fatalf("OutputResourceFields() errored %v", err)
+			}
+			if diff := cmp.Diff(strings.TrimSpace(test.want), got); diff != "" {
+				t.Errorf("OutputResourceFields() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
 func TestOutputResources(t *testing.T) {
 	scheme := runtime.NewScheme()
 	cartov1alpha1.AddToScheme(scheme)
@@ -558,6 +679,8 @@ func TestOutputResources(t *testing.T) {
 		},
 		want: `
 ---
+apiVersion: v1
+items:
 - apiVersion: carto.run/v1alpha1
   kind: Workload
   metadata:
@@ -624,6 +747,8 @@ func TestOutputResources(t *testing.T) {
       status: "True"
       type: Ready
     supplyChainRef: {}
+kind: List
+metadata: {}
 `}, {
 		name:         "print output with json",
 		outputFormat: printer.OutputFormatJson,
@@ -709,114 +834,126 @@ func TestOutputResources(t *testing.T) {
 				},
 			},
 		},
-		want: `
-[
-	{
-		"kind": "Workload",
-		"apiVersion": "carto.run/v1alpha1",
-		"metadata": {
-			"name": "my-workload",
-			"namespace": "default",
-			"selfLink": "/default/my-workload",
-			"uid": "uid-xyz",
-			"resourceVersion": "999",
-			"generation": 1,
-			"creationTimestamp": "2021-09-10T15:00:00Z",
-			"deletionTimestamp": "2021-09-10T15:00:00Z",
-			"deletionGracePeriodSeconds": 5,
-			"labels": {
-				"name": "value"
-			},
-			"ownerReferences": [
-				{
-					"apiVersion": "v1",
-					"kind": "Pod",
-					"name": "workload-owner",
-					"uid": ""
-				}
-			],
-			"finalizers": [
-				"my.finalizer"
-			],
-			"managedFields": [
-				{
-					"manager": "tanzu"
-				}
-			]
-		},
-		"spec": {},
-		"status": {
-			"conditions": [
-				{
-					"type": "Ready",
-					"status": "True",
-					"lastTransitionTime": "2019-06-29T01:44:05Z",
-					"reason": "No printing status",
-					"message": "a hopefully informative message about what went wrong"
-				}
-			],
-			"supplyChainRef": {}
-		}
-	},
-	{
-		"kind": "Workload",
-		"apiVersion": "carto.run/v1alpha1",
-		"metadata": {
-			"name": "another-workload",
-			"namespace": "default",
-			"selfLink": "/default/my-workload",
-			"uid": "uid-abc",
-			"resourceVersion": "1000",
-			"generation": 1,
-			"creationTimestamp": "2021-09-10T15:00:00Z",
-			"deletionTimestamp": "2021-09-10T15:00:00Z",
-			"deletionGracePeriodSeconds": 5,
-			"annotations": {
-				"name": "value"
-			},
-			"ownerReferences": [
-				{
-					"apiVersion": "v1",
-					"kind": "Pod",
-					"name": "workload-owner",
-					"uid": ""
-				}
-			],
-			"finalizers": [
-				"my.finalizer"
-			],
-			"managedFields": [
-				{
-					"manager": "tanzu"
-				}
-			]
-		},
-		"spec": {},
-		"status": {
-			"conditions": [
-				{
-					"type": "Ready",
-					"status": "True",
-					"lastTransitionTime": "2019-06-29T01:44:05Z",
-					"reason": "No printing status",
-					"message": "a hopefully informative message about what went wrong"
-				}
-			],
-			"supplyChainRef": {}
+		want: `{
+	"apiVersion": "v1",
+	"items": [
+		{
+			"apiVersion": "carto.run/v1alpha1",
+			"kind": "Workload",
+			"metadata": {
+				"creationTimestamp": "2021-09-10T15:00:00Z",
+				"deletionGracePeriodSeconds": 5,
+				"deletionTimestamp": "2021-09-10T15:00:00Z",
+				"finalizers": [
+					"my.finalizer"
+				],
+				"generation": 1,
+				"labels": {
+					"name": "value"
+				},
+				"managedFields": [
+					{
+						"manager": "tanzu"
+					}
+				],
+				"name": "my-workload",
+				"namespace": "default",
+				"ownerReferences": [
+					{
+						"apiVersion": "v1",
+						"kind": "Pod",
+						"name": "workload-owner",
+						"uid": ""
+					}
+				],
+				"resourceVersion": "999",
+				"selfLink": "/default/my-workload",
+				"uid": "uid-xyz"
+			},
+			"spec": {},
+			"status": {
+				"conditions": [
+					{
+						"lastTransitionTime": "2019-06-29T01:44:05Z",
+						"message": "a hopefully informative message about what went wrong",
+						"reason": "No printing status",
+						"status": "True",
+						"type": "Ready"
+					}
+				],
+				"supplyChainRef": {}
+			}
+		},
+		{
+			"apiVersion": "carto.run/v1alpha1",
+			"kind": "Workload",
+			"metadata": {
+				"annotations": {
+					"name": "value"
+				},
+				"creationTimestamp": "2021-09-10T15:00:00Z",
+				"deletionGracePeriodSeconds": 5,
+				"deletionTimestamp": "2021-09-10T15:00:00Z",
+				"finalizers": [
+					"my.finalizer"
+				],
+				"generation": 1,
+				"managedFields": [
+					{
+						"manager": "tanzu"
+					}
+				],
+				"name": "another-workload",
+				"namespace": "default",
+				"ownerReferences": [
+					{
+						"apiVersion": "v1",
+						"kind": "Pod",
+						"name": "workload-owner",
+						"uid": ""
+					}
+				],
+				"resourceVersion": "1000",
+				"selfLink": "/default/my-workload",
+				"uid": "uid-abc"
+			},
+			"spec": {},
+			"status": {
+				"conditions": [
+					{
+						"lastTransitionTime": "2019-06-29T01:44:05Z",
+						"message": "a hopefully informative message about what went wrong",
+						"reason": "No printing status",
+						"status": "True",
+						"type": "Ready"
+					}
+				],
+				"supplyChainRef": {}
+			}
 		}
-	}
-]`,
+	],
+	"kind": "List",
+	"metadata": {}
+}`,
 	}, {
 		name:         "empty list with json format",
 		outputFormat: printer.OutputFormatJson,
 		objs:         []printer.Object{},
-		want:         "[]",
+		want: `{
+	"apiVersion": "v1",
+	"items": [],
+	"kind": "List",
+	"metadata": {}
+}`,
 	}, {
 		name:         "empty list with yaml format",
 		outputFormat: printer.OutputFormatYaml,
 		objs:         []printer.Object{},
 		want: `---
-[]`,
+apiVersion: v1
+items: []
+kind: List
+metadata: {}`,
 	}, {
 		name:         "not valid output",
 		outputFormat: "myFormat",
@@ -839,6 +976,104 @@ func TestOutputResources(t *testing.T) {
 	}
 }
 
+func TestOutputResources_SingleDocumentList(t *testing.T) {
+	scheme := runtime.NewScheme()
+	cartov1alpha1.AddToScheme(scheme)
+
+	objs := []printer.Object{
+		&cartov1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-workload"}},
+		&cartov1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "another-workload"}},
+	}
+
+	for _, format := range []printer.OutputFormat{printer.OutputFormatJson, printer.OutputFormatYaml} {
+		t.Run(string(format), func(t *testing.T) {
+			out, err := printer.OutputResources(objs, format, scheme)
+			if err != nil {
+				t.Fatalf("OutputResources() unexpected error = %v", err)
+			}
+			b, err := yaml.YAMLToJSON([]byte(out))
+			if err != nil {
+				t.Fatalf("output is not a single document: %v", err)
+			}
+			list := &unstructured.UnstructuredList{}
+			if err := list.UnmarshalJSON(b); err != nil {
+				t.Fatalf("output is not a list: %v", err)
+			}
+			if diff := cmp.Diff("List", list.GetKind()); diff != "" {
+				t.Errorf("kind (-want, +got) = %v", diff)
+			}
+			names := []string{}
+			for _, item := range list.Items {
+				names = append(names, item.GetName())
+			}
+			if diff := cmp.Diff([]string{"my-workload", "another-workload"}, names); diff != "" {
+				t.Errorf("items (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestOutputResource_SortedJsonKeys(t *testing.T) {
+	scheme := runtime.NewScheme()
+	cartov1alpha1.AddToScheme(scheme)
+
+	workload := func(params string) *cartov1alpha1.Workload {
+		return &cartov1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "my-workload",
+				Labels: map[string]string{
+					"z-label": "value",
+					"a-label": "value",
+				},
+			},
+			Spec: cartov1alpha1.WorkloadSpec{
+				Params: []cartov1alpha1.Param{{
+					Name:  "ports",
+					Value: apiextensionsv1.JSON{Raw: []byte(params)},
+				}},
+			},
+		}
+	}
+	left := workload(`{"port":8080,"name":"http","nested":{"z":1,"a":2}}`)
+	right := workload(`{"nested":{"a":2,"z":1},"name":"http","port":8080}`)
+
+	for _, output := range []struct {
+		name  string
+		print func(*cartov1alpha1.Workload) (string, error)
+	}{{
+		name: "OutputResource",
+		print: func(w *cartov1alpha1.Workload) (string, error) {
+			return printer.OutputResource(w, printer.OutputFormatJson, scheme)
+		},
+	}, {
+		name: "OutputResources",
+		print: func(w *cartov1alpha1.Workload) (string, error) {
+			return printer.OutputResources([]printer.Object{w}, printer.OutputFormatJson, scheme)
+		},
+	}} {
+		t.Run(output.name, func(t *testing.T) {
+			l, err := output.print(left)
+			if err != nil {
+				t.Fatalf("unexpected error = %v", err)
+			}
+			r, err := output.print(right)
+			if err != nil {
+				t.Fatalf("unexpected error = %v", err)
+			}
+			if diff := cmp.Diff(l, r); diff != "" {
+				t.Errorf("serializations are not equal (-left, +right) = %v", diff)
+			}
+			if !strings.Contains(l, "\"a-label\": \"value\",\n") {
+				t.Errorf("labels are not sorted:\n%s", l)
+			}
+			if a, z := strings.Index(l, "\"a\": 2"), strings.Index(l, "\"z\": 1"); a == -1 || a > z {
+				t.Errorf("param value keys are not sorted:\n%s", l)
+			}
+		})
+	}
+}
+
 func TestResourceDiff(t *testing.T) {
 	scheme := runtime.NewScheme()
 	cartov1alpha1.AddToScheme(scheme)
@@ -986,3 +1221,393 @@ func TestResourceDiff(t *testing.T) {
 		})
 	}
 }
+
+func TestResourceWordDiff(t *testing.T) {
+	scheme := runtime.NewScheme()
+	cartov1alpha1.AddToScheme(scheme)
+
+	workload := &cartov1alpha1.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-workload",
+		},
+		Spec: cartov1alpha1.WorkloadSpec{
+			Image: "registry.example.com/app:2.6.0",
+			Env: []corev1.EnvVar{
+				{Name: "FOO", Value: "bar"},
+			},
+		},
+	}
+	updated := workload.DeepCopy()
+	updated.Spec.Image = "registry.example.com/app:2.6.1"
+	updated.Spec.Env[0].Value = "baz"
+
+	tests := []struct {
+		name    string
+		noColor bool
+		want    string
+	}{{
+		name: "highlights the changed part of the modified lines",
+		want: "" +
+			"\x1b[m...\n\x1b[0m" +
+			"\x1b[m  6,  6   |  namespace: default\n\x1b[0m" +
+			"\x1b[m  7,  7   |spec:\n\x1b[0m" +
+			"\x1b[m  8,  8   |  env:\n\x1b[0m" +
+			"\x1b[m  9,  9   |  - name: FOO\n\x1b[0m" +
+			"\x1b[31m 10     - |    value: ba\x1b[0m\x1b[31;1;4mr\x1b[0m\x1b[31m\n\x1b[0m" +
+			"\x1b[31m 11     - |  image: registry.example.com/app:2.6.\x1b[0m\x1b[31;1;4m0\x1b[0m\x1b[31m\n\x1b[0m" +
+			"\x1b[32m     10 + |    value: ba\x1b[0m\x1b[32;1;4mz\x1b[0m\x1b[32m\n\x1b[0m" +
+			"\x1b[32m     11 + |  image: registry.example.com/app:2.6.\x1b[0m\x1b[32;1;4m1\x1b[0m\x1b[32m\n\x1b[0m",
+	}, {
+		name:    "same as the line diff without colors",
+		noColor: true,
+		want: `
+...
+  6,  6   |  namespace: default
+  7,  7   |spec:
+  8,  8   |  env:
+  9,  9   |  - name: FOO
+ 10     - |    value: bar
+ 11     - |  image: registry.example.com/app:2.6.0
+     10 + |    value: baz
+     11 + |  image: registry.example.com/app:2.6.1
+`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			noColor := color.NoColor
+			color.NoColor = test.noColor
+			defer func() { color.NoColor = noColor }()
+
+			got, noChange, err := printer.ResourceWordDiff(workload, updated, scheme)
+			if err != nil {
+				t.Fatalf("ResourceWordDiff() unexpected error = %v", err)
+			}
+			if noChange {
+				t.Errorf("ResourceWordDiff() noChange = true, expected false")
+			}
+			if diff := cmp.Diff(strings.TrimPrefix(test.want, "\n"), got); diff != "" {
+				t.Errorf("ResourceWordDiff() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestResourceDiffWithOptions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	cartov1alpha1.AddToScheme(scheme)
+
+	workload := &cartov1alpha1.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-workload",
+		},
+		Spec: cartov1alpha1.WorkloadSpec{
+			Env: []corev1.EnvVar{
+				{Name: "FOO", Value: "bar"},
+				{Name: "BAR", Value: "baz"},
+			},
+			Image: "ubuntu:bionic",
+			Resources: &corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+		},
+	}
+	updated := workload.DeepCopy()
+	updated.Spec.Image = "ubuntu:jammy"
+	updatedEnv := workload.DeepCopy()
+	updatedEnv.Spec.Env[1].Value = "qux"
+
+	tests := []struct {
+		name    string
+		left    printer.Object
+		right   printer.Object
+		options printer.DiffOptions
+		want    string
+	}{{
+		name:  "full diff",
+		left:  workload,
+		right: updated,
+		want: `
+...
+  9,  9   |  - name: FOO
+ 10, 10   |    value: bar
+ 11, 11   |  - name: BAR
+ 12, 12   |    value: baz
+ 13     - |  image: ubuntu:bionic
+     13 + |  image: ubuntu:jammy
+ 14, 14   |  resources:
+ 15, 15   |    limits:
+ 16, 16   |      cpu: 500m
+ 17, 17   |      memory: 1Gi
+`,
+	}, {
+		name:    "collapse unchanged",
+		left:    workload,
+		right:   updated,
+		options: printer.DiffOptions{CollapseUnchanged: true},
+		want: `
+...
+  8,  8   |  env: (unchanged)
+ 13     - |  image: ubuntu:bionic
+     13 + |  image: ubuntu:jammy
+ 14, 14   |  resources: (unchanged)
+`,
+	}, {
+		name:    "keep changed section",
+		left:    workload,
+		right:   updatedEnv,
+		options: printer.DiffOptions{CollapseUnchanged: true},
+		want: `
+...
+  8,  8   |  env:
+  9,  9   |  - name: FOO
+ 10, 10   |    value: bar
+ 11, 11   |  - name: BAR
+ 12     - |    value: baz
+     12 + |    value: qux
+ 13, 13   |  image: ubuntu:bionic
+ 14, 14   |  resources: (unchanged)
+`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			noColor := color.NoColor
+			color.NoColor = true
+			defer func() { color.NoColor = noColor }()
+
+			got, _, err := printer.ResourceDiffWithOptions(test.left, test.right, scheme, test.options)
+			if err != nil {
+				t.Fatalf("ResourceDiffWithOptions() unexpected error = %v", err)
+			}
+			if diff := cmp.Diff(strings.TrimPrefix(test.want, "\n"), got); diff != "" {
+				t.Errorf("ResourceDiffWithOptions() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestResourceUnifiedDiff(t *testing.T) {
+	scheme := runtime.NewScheme()
+	cartov1alpha1.AddToScheme(scheme)
+
+	workload := &cartov1alpha1.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-workload",
+		},
+		Spec: cartov1alpha1.WorkloadSpec{
+			Image: "ubuntu:bionic",
+			Env: []corev1.EnvVar{
+				{Name: "FOO", Value: "bar"},
+			},
+		},
+	}
+	updated := workload.DeepCopy()
+	updated.Spec.Image = "ubuntu:jammy"
+
+	tests := []struct {
+		name     string
+		left     printer.Object
+		right    printer.Object
+		want     string
+		noChange bool
+	}{{
+		name:  "create",
+		right: workload,
+		want: `
+--- /dev/null
++++ b/my-workload.yaml
+@@ -0,0 +1,11 @@
++---
++apiVersion: carto.run/v1alpha1
++kind: Workload
++metadata:
++  name: my-workload
++  namespace: default
++spec:
++  env:
++  - name: FOO
++    value: bar
++  image: ubuntu:bionic
+`,
+	}, {
+		name:  "update",
+		left:  workload,
+		right: updated,
+		want: `
+--- a/my-workload.yaml
++++ b/my-workload.yaml
+@@ -8,4 +8,4 @@
+   env:
+   - name: FOO
+     value: bar
+-  image: ubuntu:bionic
++  image: ubuntu:jammy
+`,
+	}, {
+		name:     "no change",
+		left:     workload,
+		right:    workload.DeepCopy(),
+		noChange: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, noChange, err := printer.ResourceUnifiedDiff(test.left, test.right, scheme)
+			if err != nil {
+				t.Fatalf("ResourceUnifiedDiff() errored %v", err)
+			}
+			if noChange != test.noChange {
+				t.Errorf("ResourceUnifiedDiff() noChange = %v, expected %v", noChange, test.noChange)
+			}
+			if diff := cmp.Diff(strings.TrimPrefix(test.want, "\n"), got); diff != "" {
+				t.Errorf("ResourceUnifiedDiff() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestDiffStat(t *testing.T) {
+	scheme := runtime.NewScheme()
+	cartov1alpha1.AddToScheme(scheme)
+
+	workload := &cartov1alpha1.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-workload",
+		},
+		Spec: cartov1alpha1.WorkloadSpec{
+			Image: "ubuntu:bionic",
+			Env: []corev1.EnvVar{
+				{Name: "FOO", Value: "bar"},
+			},
+		},
+	}
+	updated := workload.DeepCopy()
+	updated.Spec.Image = "ubuntu:jammy"
+	updated.Spec.Env = nil
+
+	tests := []struct {
+		name        string
+		diff        func(left, right printer.Object, scheme *runtime.Scheme, transforms ...printer.YamlTransform) (string, bool, error)
+		left        printer.Object
+		right       printer.Object
+		wantAdded   int
+		wantRemoved int
+	}{{
+		name:      "create",
+		diff:      printer.ResourceDiff,
+		right:     workload,
+		wantAdded: 11,
+	}, {
+		name:        "update",
+		diff:        printer.ResourceDiff,
+		left:        workload,
+		right:       updated,
+		wantAdded:   1,
+		wantRemoved: 4,
+	}, {
+		name:      "unified create",
+		diff:      printer.ResourceUnifiedDiff,
+		right:     workload,
+		wantAdded: 11,
+	}, {
+		name:        "unified update",
+		diff:        printer.ResourceUnifiedDiff,
+		left:        workload,
+		right:       updated,
+		wantAdded:   1,
+		wantRemoved: 4,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diff, _, err := test.diff(test.left, test.right, scheme)
+			if err != nil {
+				t.Fatalf("diff errored %v", err)
+			}
+			added, removed := printer.DiffStat(diff)
+			if added != test.wantAdded || removed != test.wantRemoved {
+				t.Errorf("DiffStat() = +%d -%d, expected +%d -%d", added, removed, test.wantAdded, test.wantRemoved)
+			}
+		})
+	}
+}
+
+func TestResourceRemovedFields(t *testing.T) {
+	serviceAccountName := "my-sa"
+	workload := &cartov1alpha1.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-workload",
+			Labels: map[string]string{
+				"apps.tanzu.vmware.com/workload-type": "web",
+				"keep":                                "me",
+			},
+		},
+		Spec: cartov1alpha1.WorkloadSpec{
+			Image:              "ubuntu:bionic",
+			ServiceAccountName: &serviceAccountName,
+			Env: []corev1.EnvVar{
+				{Name: "FOO", Value: "bar"},
+			},
+		},
+		Status: cartov1alpha1.WorkloadStatus{
+			SupplyChainRef: cartov1alpha1.ObjectReference{Name: "my-supply-chain"},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		left  printer.Object
+		right printer.Object
+		want  []string
+	}{{
+		name:  "create",
+		right: workload,
+		want:  []string{},
+	}, {
+		name:  "no change",
+		left:  workload,
+		right: workload.DeepCopy(),
+		want:  []string{},
+	}, {
+		name: "removed fields",
+		left: workload,
+		right: &cartov1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "my-workload",
+				Labels: map[string]string{
+					"keep": "me",
+				},
+			},
+			Spec: cartov1alpha1.WorkloadSpec{
+				Image: "ubuntu:jammy",
+			},
+		},
+		want: []string{
+			`metadata.labels["apps.tanzu.vmware.com/workload-type"]`,
+			"spec.env",
+			"spec.serviceAccountName",
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := printer.ResourceRemovedFields(test.left, test.right)
+			if err != nil {
+				t.Fatalf("ResourceRemovedFields() errored %v", err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("ResourceRemovedFields() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ package table
 
 import (
 	"io"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -61,4 +62,7 @@ type PrintOptions struct {
 	AllowMissingKeys bool
 
 	PaddingStart int
+
+	// Now is the time ages are relative to, defaults to the current time
+	Now time.Time
 }
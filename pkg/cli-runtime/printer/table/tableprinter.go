@@ -425,17 +425,20 @@ func printObjectMeta(obj runtime.Object, options PrintOptions) ([]metav1beta1.Ta
 	row := metav1beta1.TableRow{
 		Object: runtime.RawExtension{Object: obj},
 	}
-	row.Cells = append(row.Cells, m.GetName(), translateTimestampSince(m.GetCreationTimestamp()))
+	row.Cells = append(row.Cells, m.GetName(), translateTimestampSince(m.GetCreationTimestamp(), options.Now))
 	rows = append(rows, row)
 	return rows, nil
 }
 
 // translateTimestampSince returns the elapsed time since timestamp in
 // human-readable approximation.
-func translateTimestampSince(timestamp metav1.Time) string {
+func translateTimestampSince(timestamp metav1.Time, now time.Time) string {
 	if timestamp.IsZero() {
 		return "<unknown>"
 	}
+	if now.IsZero() {
+		now = time.Now()
+	}
 
-	return duration.HumanDuration(time.Since(timestamp.Time))
+	return duration.HumanDuration(now.Sub(timestamp.Time))
 }
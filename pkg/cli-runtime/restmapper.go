@@ -0,0 +1,85 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/restmapper"
+)
+
+var _ meta.ResettableRESTMapper = &discoveryRESTMapper{}
+
+// discoveryRESTMapper maps the kinds and resources from the discovery of the cluster, read once
+// for the life of the process. When a kind or a resource is not found, like a CRD installed since
+// the discovery was read, the discovery is read again and the mapping retried once.
+type discoveryRESTMapper struct {
+	*restmapper.DeferredDiscoveryRESTMapper
+}
+
+// NewDiscoveryRESTMapper returns a RESTMapper sharing the cached discovery client
+func NewDiscoveryRESTMapper(discoveryClient discovery.CachedDiscoveryInterface) meta.ResettableRESTMapper {
+	return &discoveryRESTMapper{restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient)}
+}
+
+// retryOnNoMatch calls the mapping func again, with a fresh discovery, when it fails with a no
+// match error
+func retryOnNoMatch[T any](m *discoveryRESTMapper, mapping func() (T, error)) (T, error) {
+	result, err := mapping()
+	if meta.IsNoMatchError(err) {
+		m.Reset()
+		return mapping()
+	}
+	return result, err
+}
+
+func (m *discoveryRESTMapper) KindFor(resource schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	return retryOnNoMatch(m, func() (schema.GroupVersionKind, error) {
+		return m.DeferredDiscoveryRESTMapper.KindFor(resource)
+	})
+}
+
+func (m *discoveryRESTMapper) KindsFor(resource schema.GroupVersionResource) ([]schema.GroupVersionKind, error) {
+	return retryOnNoMatch(m, func() ([]schema.GroupVersionKind, error) {
+		return m.DeferredDiscoveryRESTMapper.KindsFor(resource)
+	})
+}
+
+func (m *discoveryRESTMapper) ResourceFor(input schema.GroupVersionResource) (schema.GroupVersionResource, error) {
+	return retryOnNoMatch(m, func() (schema.GroupVersionResource, error) {
+		return m.DeferredDiscoveryRESTMapper.ResourceFor(input)
+	})
+}
+
+func (m *discoveryRESTMapper) ResourcesFor(input schema.GroupVersionResource) ([]schema.GroupVersionResource, error) {
+	return retryOnNoMatch(m, func() ([]schema.GroupVersionResource, error) {
+		return m.DeferredDiscoveryRESTMapper.ResourcesFor(input)
+	})
+}
+
+func (m *discoveryRESTMapper) RESTMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	return retryOnNoMatch(m, func() (*meta.RESTMapping, error) {
+		return m.DeferredDiscoveryRESTMapper.RESTMapping(gk, versions...)
+	})
+}
+
+func (m *discoveryRESTMapper) RESTMappings(gk schema.GroupKind, versions ...string) ([]*meta.RESTMapping, error) {
+	return retryOnNoMatch(m, func() ([]*meta.RESTMapping, error) {
+		return m.DeferredDiscoveryRESTMapper.RESTMappings(gk, versions...)
+	})
+}
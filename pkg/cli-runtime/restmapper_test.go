@@ -0,0 +1,100 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// fakeDiscovery serves the resources of the groups and counts the discovery reads
+type fakeDiscovery struct {
+	discovery.DiscoveryInterface
+	groups    []*metav1.APIGroup
+	resources []*metav1.APIResourceList
+	reads     int
+	fresh     bool
+}
+
+func (d *fakeDiscovery) ServerGroupsAndResources() ([]*metav1.APIGroup, []*metav1.APIResourceList, error) {
+	d.reads++
+	d.fresh = true
+	return d.groups, d.resources, nil
+}
+
+func (d *fakeDiscovery) Fresh() bool {
+	return d.fresh
+}
+
+func (d *fakeDiscovery) Invalidate() {
+	d.fresh = false
+}
+
+func (d *fakeDiscovery) addGroup(group, version, kind, resource string) {
+	groupVersion := schema.GroupVersion{Group: group, Version: version}.String()
+	d.groups = append(d.groups, &metav1.APIGroup{
+		Name:             group,
+		Versions:         []metav1.GroupVersionForDiscovery{{GroupVersion: groupVersion, Version: version}},
+		PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: groupVersion, Version: version},
+	})
+	d.resources = append(d.resources, &metav1.APIResourceList{
+		GroupVersion: groupVersion,
+		APIResources: []metav1.APIResource{{Name: resource, Kind: kind, Namespaced: true}},
+	})
+}
+
+func TestDiscoveryRESTMapper(t *testing.T) {
+	dc := &fakeDiscovery{}
+	dc.addGroup("carto.run", "v1alpha1", "Workload", "workloads")
+	mapper := NewDiscoveryRESTMapper(dc)
+
+	workload := schema.GroupKind{Group: "carto.run", Kind: "Workload"}
+	if _, err := mapper.RESTMapping(workload); err != nil {
+		t.Fatalf("RESTMapping() unexpected error = %v", err)
+	}
+	if _, err := mapper.KindFor(schema.GroupVersionResource{Group: "carto.run", Resource: "workloads"}); err != nil {
+		t.Fatalf("KindFor() unexpected error = %v", err)
+	}
+	if dc.reads != 1 {
+		t.Errorf("expected the discovery to be read once, got %d reads", dc.reads)
+	}
+
+	// a kind installed after the discovery was read
+	dc.addGroup("serving.knative.dev", "v1", "Service", "services")
+	mapping, err := mapper.RESTMapping(schema.GroupKind{Group: "serving.knative.dev", Kind: "Service"})
+	if err != nil {
+		t.Fatalf("RESTMapping() unexpected error = %v", err)
+	}
+	if expected := (schema.GroupVersionResource{Group: "serving.knative.dev", Version: "v1", Resource: "services"}); mapping.Resource != expected {
+		t.Errorf("RESTMapping() expected resource %v, got %v", expected, mapping.Resource)
+	}
+	if dc.reads != 2 {
+		t.Errorf("expected the discovery to be read again once, got %d reads", dc.reads)
+	}
+
+	// a kind the cluster doesn't serve
+	if _, err := mapper.RESTMapping(schema.GroupKind{Group: "example.com", Kind: "Missing"}); !meta.IsNoMatchError(err) {
+		t.Errorf("RESTMapping() expected a no match error, got %v", err)
+	}
+	if dc.reads != 3 {
+		t.Errorf("expected the discovery to be read again only once, got %d reads", dc.reads)
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/utils/clock"
+)
+
+// RetryOptions bounds the retries of the api calls failing with a transient error
+type RetryOptions struct {
+	// Retries is the number of times a failed call is retried, zero disables the retries
+	Retries int
+	// Backoff is the wait before the first retry, it is doubled for every following retry
+	Backoff time.Duration
+}
+
+// IsRetryable returns true for the errors caused by an overloaded api server or a flaky
+// connection, that are likely to succeed when the call is retried
+func IsRetryable(err error) bool {
+	return apierrs.IsServerTimeout(err) ||
+		apierrs.IsTooManyRequests(err) ||
+		apierrs.IsTimeout(err) ||
+		utilnet.IsConnectionReset(err) ||
+		utilnet.IsProbableEOF(err)
+}
+
+// IsRetryableWrite returns true for the errors of a write the api server rejected without
+// processing it. A timeout or a dropped connection may hide a write that was applied, retrying it
+// would fail with a conflict or an already exists error for a change that did succeed.
+func IsRetryableWrite(err error) bool {
+	return apierrs.IsTooManyRequests(err)
+}
+
+// Retry calls fn until it succeeds, fails with an error that is not retryable or the retries are
+// exhausted. The backoff is waited on the given clock. The last error is returned.
+func Retry(ctx context.Context, clk clock.Clock, log logr.Logger, opts RetryOptions, fn func() error) error {
+	return retry(ctx, clk, log, opts, IsRetryable, fn)
+}
+
+// RetryWrite is Retry for the calls creating or updating an object, that are only retried when
+// the api server didn't process them, see IsRetryableWrite
+func RetryWrite(ctx context.Context, clk clock.Clock, log logr.Logger, opts RetryOptions, fn func() error) error {
+	return retry(ctx, clk, log, opts, IsRetryableWrite, fn)
+}
+
+func retry(ctx context.Context, clk clock.Clock, log logr.Logger, opts RetryOptions, retryable func(error) bool, fn func() error) error {
+	backoff := opts.Backoff
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt > opts.Retries || !retryable(err) {
+			return err
+		}
+		log.V(2).Info("retrying api request", "attempt", attempt, "retries", opts.Retries, "backoff", backoff.String(), "error", err.Error())
+		select {
+		case <-ctx.Done():
+			return err
+		case <-clk.After(backoff):
+		}
+		backoff *= 2
+	}
+}
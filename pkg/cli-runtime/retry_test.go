@@ -0,0 +1,181 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli_test
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/clock"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+)
+
+func TestRetry(t *testing.T) {
+	gr := schema.GroupResource{Group: "carto.run", Resource: "workloads"}
+	tests := []struct {
+		name          string
+		retries       int
+		errs          []error
+		expectedCalls int
+		shouldError   bool
+	}{{
+		name:          "success",
+		retries:       3,
+		expectedCalls: 1,
+	}, {
+		name:          "server timeout then success",
+		retries:       3,
+		errs:          []error{apierrors.NewServerTimeout(gr, "get", 1)},
+		expectedCalls: 2,
+	}, {
+		name:          "too many requests then connection reset then success",
+		retries:       3,
+		errs:          []error{apierrors.NewTooManyRequests("slow down", 1), syscall.ECONNRESET},
+		expectedCalls: 3,
+	}, {
+		name:          "retries exhausted",
+		retries:       2,
+		errs:          []error{apierrors.NewServerTimeout(gr, "get", 1), apierrors.NewServerTimeout(gr, "get", 1), apierrors.NewServerTimeout(gr, "get", 1), apierrors.NewServerTimeout(gr, "get", 1)},
+		expectedCalls: 3,
+		shouldError:   true,
+	}, {
+		name:          "retries disabled",
+		retries:       0,
+		errs:          []error{apierrors.NewServerTimeout(gr, "get", 1)},
+		expectedCalls: 1,
+		shouldError:   true,
+	}, {
+		name:          "not found is not retried",
+		retries:       3,
+		errs:          []error{apierrors.NewNotFound(gr, "my-workload")},
+		expectedCalls: 1,
+		shouldError:   true,
+	}, {
+		name:          "conflict is not retried",
+		retries:       3,
+		errs:          []error{apierrors.NewConflict(gr, "my-workload", fmt.Errorf("test conflict"))},
+		expectedCalls: 1,
+		shouldError:   true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := 0
+			err := cli.Retry(context.TODO(), clock.RealClock{}, logr.Discard(), cli.RetryOptions{Retries: test.retries}, func() error {
+				calls++
+				if calls <= len(test.errs) {
+					return test.errs[calls-1]
+				}
+				return nil
+			})
+			if (err != nil) != test.shouldError {
+				t.Errorf("Retry() expected error %v, got %v", test.shouldError, err)
+			}
+			if calls != test.expectedCalls {
+				t.Errorf("Retry() expected %d calls, got %d", test.expectedCalls, calls)
+			}
+		})
+	}
+}
+
+func TestRetryWrite(t *testing.T) {
+	gr := schema.GroupResource{Group: "carto.run", Resource: "workloads"}
+	tests := []struct {
+		name          string
+		errs          []error
+		expectedCalls int
+		shouldError   bool
+	}{{
+		name:          "too many requests then success",
+		errs:          []error{apierrors.NewTooManyRequests("slow down", 1)},
+		expectedCalls: 2,
+	}, {
+		name:          "server timeout is not retried",
+		errs:          []error{apierrors.NewServerTimeout(gr, "update", 1)},
+		expectedCalls: 1,
+		shouldError:   true,
+	}, {
+		name:          "connection reset is not retried",
+		errs:          []error{syscall.ECONNRESET},
+		expectedCalls: 1,
+		shouldError:   true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := 0
+			err := cli.RetryWrite(context.TODO(), clock.RealClock{}, logr.Discard(), cli.RetryOptions{Retries: 3}, func() error {
+				calls++
+				if calls <= len(test.errs) {
+					return test.errs[calls-1]
+				}
+				return nil
+			})
+			if (err != nil) != test.shouldError {
+				t.Errorf("RetryWrite() expected error %v, got %v", test.shouldError, err)
+			}
+			if calls != test.expectedCalls {
+				t.Errorf("RetryWrite() expected %d calls, got %d", test.expectedCalls, calls)
+			}
+		})
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	gr := schema.GroupResource{Group: "carto.run", Resource: "workloads"}
+	fakeClock := clocktesting.NewFakeClock(time.Date(2021, time.September, 10, 15, 0, 0, 0, time.UTC))
+	calls := make(chan int, 3)
+	done := make(chan error)
+	go func() {
+		count := 0
+		done <- cli.Retry(context.TODO(), fakeClock, logr.Discard(), cli.RetryOptions{Retries: 2, Backoff: time.Minute}, func() error {
+			count++
+			calls <- count
+			if count < 3 {
+				return apierrors.NewServerTimeout(gr, "get", 1)
+			}
+			return nil
+		})
+	}()
+
+	// the backoff is doubled for every retry, the call is only retried once the clock reaches it
+	for _, backoff := range []time.Duration{time.Minute, 2 * time.Minute} {
+		<-calls
+		for !fakeClock.HasWaiters() {
+			time.Sleep(time.Millisecond)
+		}
+		fakeClock.Step(backoff - time.Nanosecond)
+		if !fakeClock.HasWaiters() {
+			t.Fatalf("Retry() expected to wait %s before retrying", backoff)
+		}
+		fakeClock.Step(time.Nanosecond)
+	}
+	if call := <-calls; call != 3 {
+		t.Errorf("Retry() expected 3 calls, got %d", call)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Retry() unexpected error %v", err)
+	}
+}
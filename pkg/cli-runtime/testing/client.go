@@ -18,12 +18,14 @@ package testing
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"sync"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -48,6 +50,10 @@ func (c *fakeclient) DefaultNamespace() string {
 	return "default"
 }
 
+func (c *fakeclient) CurrentUser() string {
+	return "default-user"
+}
+
 func (c *fakeclient) KubeRestConfig() *rest.Config {
 	if c.kubeConfig != nil {
 		return c.kubeConfig
@@ -74,6 +80,16 @@ func NewFakeCliClient(c crclient.Client) cli.Client {
 	}
 }
 
+// NewFakeCliClientWithDiscovery returns a fake client whose discovery client serves the given
+// groups and resources
+func NewFakeCliClientWithDiscovery(c crclient.Client, d discovery.CachedDiscoveryInterface) cli.Client {
+	return &fakeclient{
+		defaultNamespace: "default",
+		Client:           c,
+		discovery:        d,
+	}
+}
+
 func NewFakeCliClientWithTransport(c crclient.Client, transport http.RoundTripper) cli.Client {
 	var t http.RoundTripper
 	if transport != nil {
@@ -112,6 +128,9 @@ func (c *fakeclient) ToRESTMapper() (meta.RESTMapper, error) {
 }
 
 func (c *fakeclient) ToDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
+	if c.discovery != nil {
+		return c.discovery, nil
+	}
 	return disk.NewCachedDiscoveryClientForConfig(&rest.Config{}, "", "", 0) // need alignment with sash
 }
 
@@ -131,6 +150,18 @@ func (d *FakeCachedDiscoveryClient) ServerGroupsAndResources() ([]*metav1.APIGro
 	return d.Groups, d.Resources, nil
 }
 
+func (d *FakeCachedDiscoveryClient) ServerPreferredResources() ([]*metav1.APIResourceList, error) {
+	return d.PreferredResources, nil
+}
+
+func (d *FakeCachedDiscoveryClient) Fresh() bool {
+	return true
+}
+
+func (d *FakeCachedDiscoveryClient) Invalidate() {
+	d.Invalidations++
+}
+
 // NewBuilder returns a new resource builder for structured api objects.
 func (c *fakeclient) NewBuilder() *resource.Builder {
 	panic(fmt.Errorf("not implemented"))
@@ -148,6 +179,52 @@ type fakeclient struct {
 	defaultNamespace string
 	crclient.Client
 	kubeConfig *rest.Config
+	discovery  discovery.CachedDiscoveryInterface
+	// lock serializes the requests, the reactors and the recorded actions of the reconciler-runtime
+	// fake client are not safe for concurrent use
+	lock sync.Mutex
+}
+
+func (c *fakeclient) Get(ctx context.Context, key crclient.ObjectKey, obj crclient.Object, opts ...crclient.GetOption) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func (c *fakeclient) List(ctx context.Context, list crclient.ObjectList, opts ...crclient.ListOption) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.Client.List(ctx, list, opts...)
+}
+
+func (c *fakeclient) Create(ctx context.Context, obj crclient.Object, opts ...crclient.CreateOption) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *fakeclient) Delete(ctx context.Context, obj crclient.Object, opts ...crclient.DeleteOption) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *fakeclient) Update(ctx context.Context, obj crclient.Object, opts ...crclient.UpdateOption) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *fakeclient) Patch(ctx context.Context, obj crclient.Object, patch crclient.Patch, opts ...crclient.PatchOption) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *fakeclient) DeleteAllOf(ctx context.Context, obj crclient.Object, opts ...crclient.DeleteAllOfOption) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
 }
 
 func newClientSet() *fakeClientSet {
@@ -0,0 +1,44 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// FixedClock reports a fixed time as now, while tickers and timers still run in real time so
+// commands that poll or wait are not blocked
+type FixedClock struct {
+	clock.RealClock
+	Time time.Time
+}
+
+var _ clock.WithTicker = (*FixedClock)(nil)
+
+func NewFixedClock(now time.Time) *FixedClock {
+	return &FixedClock{Time: now}
+}
+
+func (c *FixedClock) Now() time.Time {
+	return c.Time
+}
+
+func (c *FixedClock) Since(ts time.Time) time.Duration {
+	return c.Time.Sub(ts)
+}
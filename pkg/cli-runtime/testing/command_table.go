@@ -121,6 +121,9 @@ type CommandTestCase struct {
 	// }
 	// ```
 	ExecHelper string
+	// Now fixes the current time reported by the Config's Clock, so ages and elapsed times in the
+	// output are deterministic. When not set, the real time is used.
+	Now time.Time
 
 	// inputs
 
@@ -231,6 +234,9 @@ func (tc CommandTestCase) Run(t *testing.T, scheme *k8sruntime.Scheme, cmdFactor
 		if tc.ExecHelper != "" {
 			c.Exec = fakeExecCommand(tc.ExecHelper)
 		}
+		if !tc.Now.IsZero() {
+			c.Clock = NewFixedClock(tc.Now)
+		}
 
 		if tc.CleanUp != nil {
 			defer func() {
@@ -0,0 +1,42 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"strings"
+
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ConfigMapKeyReference validates a reference to a ConfigMap key in the form "namespace/name/key"
+func ConfigMapKeyReference(ref, field string) FieldErrors {
+	errs := FieldErrors{}
+
+	parts := strings.Split(ref, "/")
+	if len(parts) != 3 {
+		return errs.Also(ErrInvalidValue(ref, field))
+	}
+	if out := k8svalidation.IsDNS1123Label(parts[0]); len(out) != 0 {
+		errs = errs.Also(ErrInvalidValue(ref, field))
+	} else if out := k8svalidation.IsDNS1123Subdomain(parts[1]); len(out) != 0 {
+		errs = errs.Also(ErrInvalidValue(ref, field))
+	} else if out := k8svalidation.IsConfigMapKey(parts[2]); len(out) != 0 {
+		errs = errs.Also(ErrInvalidValue(ref, field))
+	}
+
+	return errs
+}
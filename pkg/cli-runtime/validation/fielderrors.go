@@ -29,8 +29,20 @@ func ErrMissingFieldWithDetail(field string, detail string) FieldErrors {
 	}
 }
 
+func ErrInvalidValueWithDetail(value interface{}, field string, detail string) FieldErrors {
+	return FieldErrors{
+		k8sfield.Invalid(k8sfield.NewPath(field), value, detail),
+	}
+}
+
 func ErrMultipleSources(names ...string) FieldErrors {
 	return FieldErrors{
 		k8sfield.Required(k8sfield.NewPath(fmt.Sprintf("[%s]", strings.Join(names, ", "))), "expected exactly one, got multiple"),
 	}
 }
+
+func ErrMissingOneOfWithDetail(detail string, names ...string) FieldErrors {
+	return FieldErrors{
+		k8sfield.Required(k8sfield.NewPath(fmt.Sprintf("[%s]", strings.Join(names, ", "))), detail),
+	}
+}
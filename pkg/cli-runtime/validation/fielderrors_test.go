@@ -96,3 +96,38 @@ func TestErrMultipleSources(t *testing.T) {
 		})
 	}
 }
+
+func TestErrMissingOneOfWithDetail(t *testing.T) {
+	tests := []struct {
+		testName string
+		detail   string
+		names    []string
+		expected validation.FieldErrors
+	}{
+		{
+			testName: "multiple fields",
+			detail:   "expected one when switching source",
+			names:    []string{flags.GitBranchFlagName, flags.GitTagFlagName},
+			expected: validation.FieldErrors{
+				k8sfield.Required(k8sfield.NewPath(fmt.Sprintf("[%s]", strings.Join([]string{flags.GitBranchFlagName, flags.GitTagFlagName}, ", "))), "expected one when switching source"),
+			},
+		}, {
+			testName: "single field",
+			detail:   "",
+			names:    []string{flags.GitBranchFlagName},
+			expected: validation.FieldErrors{
+				k8sfield.Required(k8sfield.NewPath(fmt.Sprintf("[%s]", flags.GitBranchFlagName)), ""),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			expected := test.expected
+			actual := validation.ErrMissingOneOfWithDetail(test.detail, test.names...)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.testName, diff)
+			}
+		})
+	}
+}
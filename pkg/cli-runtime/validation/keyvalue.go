@@ -17,6 +17,7 @@ limitations under the License.
 package validation
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/parsers"
@@ -66,6 +67,33 @@ func DeletableKeyValues(kvs []string, field string) FieldErrors {
 	return errs
 }
 
+// TypedParamKeyValues validates params with an optional type annotation
+// ("name:type=value"), the value must be valid for the annotated type
+func TypedParamKeyValues(kvs []string, field string) FieldErrors {
+	errs := FieldErrors{}
+
+	for i, kv := range kvs {
+		kvErrs := DeletableKeyValue(kv, CurrentField)
+		if len(kvErrs) != 0 {
+			errs = errs.Also(kvErrs.ViaFieldIndex(field, i))
+			continue
+		}
+		keyValue := parsers.DeletableKeyValue(kv)
+		_, paramType := parsers.TypedParamKey(keyValue[0])
+		if paramType != "" && !contains(paramType, parsers.ParamTypes) {
+			errs = errs.Also(ErrInvalidValueWithDetail(kv, CurrentField, fmt.Sprintf("supported param types are %s", strings.Join(parsers.ParamTypes, ", "))).ViaFieldIndex(field, i))
+			continue
+		}
+		if len(keyValue) > 1 {
+			if _, err := parsers.TypedParamValue(paramType, keyValue[1]); err != nil {
+				errs = errs.Also(ErrInvalidValueWithDetail(kv, CurrentField, err.Error()).ViaFieldIndex(field, i))
+			}
+		}
+	}
+
+	return errs
+}
+
 func JsonOrYamlKeyValues(kvs []string, field string) FieldErrors {
 	errs := FieldErrors{}
 	for i, kv := range kvs {
@@ -211,3 +211,41 @@ func TestJsonOrYamlKeyValues(t *testing.T) {
 		})
 	}
 }
+
+func TestTypedParamKeyValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected validation.FieldErrors
+		values   []string
+	}{{
+		name:     "untyped",
+		expected: validation.FieldErrors{},
+		values:   []string{"version=1.10", "version-"},
+	}, {
+		name:     "typed",
+		expected: validation.FieldErrors{},
+		values:   []string{"version:string=1.10", "replicas:number=2", "debug:bool=true", "config:json={\"key\":\"value\"}", "version:string-"},
+	}, {
+		name:     "invalid key value",
+		expected: validation.ErrInvalidValue("version", validation.CurrentField).ViaFieldIndex(clitesting.TestField, 0),
+		values:   []string{"version"},
+	}, {
+		name:     "unknown type",
+		expected: validation.ErrInvalidValueWithDetail("version:int=1", validation.CurrentField, "supported param types are string, number, bool, json").ViaFieldIndex(clitesting.TestField, 0),
+		values:   []string{"version:int=1"},
+	}, {
+		name:     "invalid value for type",
+		expected: validation.ErrInvalidValueWithDetail("replicas:number=two", validation.CurrentField, `"two" is not a number`).ViaFieldIndex(clitesting.TestField, 1),
+		values:   []string{"version:string=1.10", "replicas:number=two"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := validation.TypedParamKeyValues(test.values, clitesting.TestField)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
@@ -17,7 +17,13 @@ limitations under the License.
 package validation
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/parsers"
 )
 
 func K8sLabelValue(value, field string) FieldErrors {
@@ -28,3 +34,58 @@ func K8sLabelValue(value, field string) FieldErrors {
 
 	return errs
 }
+
+// K8sLabel validates a label represented as a "key=value" pair, or "key-" for removal.
+// Whitespace around the key and the value is ignored.
+func K8sLabel(kv, field string) FieldErrors {
+	errs := DeletableKeyValue(kv, field)
+	if len(errs) != 0 {
+		return errs
+	}
+
+	parts := parsers.DeletableKeyValue(kv)
+	key := strings.TrimSpace(parts[0])
+	if errmsgs := validation.IsQualifiedName(key); len(errmsgs) != 0 {
+		errs = errs.Also(ErrInvalidValueWithDetail(key, field, strings.Join(errmsgs, "; ")))
+	}
+	if len(parts) > 1 {
+		value := strings.TrimSpace(parts[1])
+		if errmsgs := validation.IsValidLabelValue(value); len(errmsgs) != 0 {
+			errs = errs.Also(ErrInvalidValueWithDetail(value, field, strings.Join(errmsgs, "; ")))
+		}
+	}
+
+	return errs
+}
+
+func K8sLabels(kvs []string, field string) FieldErrors {
+	errs := FieldErrors{}
+
+	for i, kv := range kvs {
+		errs = errs.Also(K8sLabel(kv, CurrentField).ViaFieldIndex(field, i))
+	}
+
+	return errs
+}
+
+// K8sLabelMap validates the keys and values of labels already set on a resource
+func K8sLabelMap(labels map[string]string, field string) FieldErrors {
+	errs := FieldErrors{}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if errmsgs := validation.IsQualifiedName(k); len(errmsgs) != 0 {
+			errs = errs.Also(ErrInvalidValueWithDetail(k, field, strings.Join(errmsgs, "; ")))
+		}
+		if errmsgs := validation.IsValidLabelValue(labels[k]); len(errmsgs) != 0 {
+			errs = errs.Also(ErrInvalidValueWithDetail(labels[k], fmt.Sprintf("%s[%s]", field, k), strings.Join(errmsgs, "; ")))
+		}
+	}
+
+	return errs
+}
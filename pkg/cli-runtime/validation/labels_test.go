@@ -17,9 +17,11 @@ limitations under the License.
 package validation_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 
 	clitesting "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/testing"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/validation"
@@ -53,3 +55,110 @@ func TestLabelValue(t *testing.T) {
 		})
 	}
 }
+
+func TestK8sLabel(t *testing.T) {
+	invalidKeyMsg := strings.Join(k8svalidation.IsQualifiedName("Invalid Key"), "; ")
+	invalidValueMsg := strings.Join(k8svalidation.IsValidLabelValue("-x"), "; ")
+	tests := []struct {
+		name     string
+		expected validation.FieldErrors
+		value    string
+	}{{
+		name:     "valid",
+		expected: validation.FieldErrors{},
+		value:    "app.kubernetes.io/part-of=petclinic",
+	}, {
+		name:     "valid with whitespace",
+		expected: validation.FieldErrors{},
+		value:    " foo = bar ",
+	}, {
+		name:     "valid empty value",
+		expected: validation.FieldErrors{},
+		value:    "foo=",
+	}, {
+		name:     "valid delete",
+		expected: validation.FieldErrors{},
+		value:    "foo-",
+	}, {
+		name:     "missing value",
+		expected: validation.ErrInvalidValue("foo", clitesting.TestField),
+		value:    "foo",
+	}, {
+		name:     "invalid key",
+		expected: validation.ErrInvalidValueWithDetail("Invalid Key", clitesting.TestField, invalidKeyMsg),
+		value:    "Invalid Key=x",
+	}, {
+		name:     "invalid delete key",
+		expected: validation.ErrInvalidValueWithDetail("Invalid Key", clitesting.TestField, invalidKeyMsg),
+		value:    "Invalid Key-",
+	}, {
+		name:     "invalid value",
+		expected: validation.ErrInvalidValueWithDetail("-x", clitesting.TestField, invalidValueMsg),
+		value:    "foo=-x",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := validation.K8sLabel(test.value, clitesting.TestField)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestK8sLabels(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected validation.FieldErrors
+		values   []string
+	}{{
+		name:     "valid",
+		expected: validation.FieldErrors{},
+		values:   []string{"foo=bar", "bar-"},
+	}, {
+		name:     "invalid",
+		expected: validation.ErrInvalidValueWithDetail("Invalid Key", validation.CurrentField, strings.Join(k8svalidation.IsQualifiedName("Invalid Key"), "; ")).ViaFieldIndex(clitesting.TestField, 1),
+		values:   []string{"foo=bar", "Invalid Key=x"},
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := validation.K8sLabels(test.values, clitesting.TestField)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestK8sLabelMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected validation.FieldErrors
+		labels   map[string]string
+	}{{
+		name:     "empty",
+		expected: validation.FieldErrors{},
+	}, {
+		name:     "valid",
+		expected: validation.FieldErrors{},
+		labels:   map[string]string{"foo": "bar", "apps.tanzu.vmware.com/workload-type": "web"},
+	}, {
+		name: "invalid",
+		expected: validation.FieldErrors{}.Also(
+			validation.ErrInvalidValueWithDetail("Invalid Key", clitesting.TestField, strings.Join(k8svalidation.IsQualifiedName("Invalid Key"), "; ")),
+			validation.ErrInvalidValueWithDetail("-x", clitesting.TestField+"[foo]", strings.Join(k8svalidation.IsValidLabelValue("-x"), "; ")),
+		),
+		labels: map[string]string{"foo": "-x", "Invalid Key": "bar"},
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := validation.K8sLabelMap(test.labels, clitesting.TestField)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
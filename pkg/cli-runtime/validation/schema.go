@@ -0,0 +1,211 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Schema validates a value decoded from json, like an unstructured resource, against the OpenAPI v3
+// schema of a custom resource. The errors have the paths of the invalid fields, under the path of
+// the value. Only the keywords of the structural schemas are checked: the types, the required and
+// unknown fields, the enums, the lengths and the bounds. A null value is left for the api server to
+// default or prune.
+func Schema(value interface{}, schema *apiextensionsv1.JSONSchemaProps, path *k8sfield.Path) FieldErrors {
+	errs := FieldErrors{}
+	if schema == nil || value == nil {
+		return errs
+	}
+
+	if schema.XIntOrString {
+		switch value.(type) {
+		case string, int64, float64:
+		default:
+			errs = append(errs, k8sfield.TypeInvalid(path, value, "must be an integer or a string"))
+		}
+		return errs
+	}
+
+	switch schema.Type {
+	case "object":
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return append(errs, k8sfield.TypeInvalid(path, value, "must be of type object"))
+		}
+		errs = append(errs, schemaObject(m, schema, path)...)
+	case "array":
+		a, ok := value.([]interface{})
+		if !ok {
+			return append(errs, k8sfield.TypeInvalid(path, value, "must be of type array"))
+		}
+		if schema.MaxItems != nil && int64(len(a)) > *schema.MaxItems {
+			errs = append(errs, k8sfield.TooMany(path, len(a), int(*schema.MaxItems)))
+		}
+		if schema.MinItems != nil && int64(len(a)) < *schema.MinItems {
+			errs = append(errs, k8sfield.Invalid(path, len(a), fmt.Sprintf("must have at least %d items", *schema.MinItems)))
+		}
+		if schema.Items != nil && schema.Items.Schema != nil {
+			for i, item := range a {
+				errs = append(errs, Schema(item, schema.Items.Schema, path.Index(i))...)
+			}
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return append(errs, k8sfield.TypeInvalid(path, value, "must be of type string"))
+		}
+		if schema.MaxLength != nil && int64(len(s)) > *schema.MaxLength {
+			errs = append(errs, k8sfield.TooLong(path, s, int(*schema.MaxLength)))
+		}
+		if schema.MinLength != nil && int64(len(s)) < *schema.MinLength {
+			errs = append(errs, k8sfield.Invalid(path, s, fmt.Sprintf("must be at least %d characters long", *schema.MinLength)))
+		}
+		if schema.Pattern != "" {
+			// a pattern go can't compile is left for the api server to check
+			if re, err := regexp.Compile(schema.Pattern); err == nil && !re.MatchString(s) {
+				errs = append(errs, k8sfield.Invalid(path, s, fmt.Sprintf("must match the pattern %q", schema.Pattern)))
+			}
+		}
+	case "integer":
+		n, ok := schemaNumber(value)
+		if !ok || n != float64(int64(n)) {
+			return append(errs, k8sfield.TypeInvalid(path, value, "must be of type integer"))
+		}
+		errs = append(errs, schemaBounds(n, schema, path)...)
+	case "number":
+		n, ok := schemaNumber(value)
+		if !ok {
+			return append(errs, k8sfield.TypeInvalid(path, value, "must be of type number"))
+		}
+		errs = append(errs, schemaBounds(n, schema, path)...)
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return append(errs, k8sfield.TypeInvalid(path, value, "must be of type boolean"))
+		}
+	}
+
+	if len(schema.Enum) != 0 && !schemaEnumContains(schema.Enum, value) {
+		values := make([]string, len(schema.Enum))
+		for i, e := range schema.Enum {
+			values[i] = string(e.Raw)
+		}
+		errs = append(errs, k8sfield.NotSupported(path, value, values))
+	}
+
+	return errs
+}
+
+func schemaObject(m map[string]interface{}, schema *apiextensionsv1.JSONSchemaProps, path *k8sfield.Path) FieldErrors {
+	errs := FieldErrors{}
+	for _, name := range schema.Required {
+		if _, ok := m[name]; !ok {
+			errs = append(errs, k8sfield.Required(path.Child(name), ""))
+		}
+	}
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if property, ok := schema.Properties[k]; ok {
+			errs = append(errs, Schema(m[k], &property, path.Child(k))...)
+			continue
+		}
+		if additional := schema.AdditionalProperties; additional != nil {
+			if additional.Schema != nil {
+				errs = append(errs, Schema(m[k], additional.Schema, path.Child(k))...)
+			} else if !additional.Allows {
+				errs = append(errs, k8sfield.NotSupported(path.Child(k), k, schemaPropertyNames(schema)))
+			}
+			continue
+		}
+		// an object without properties nor additional properties, like the metadata, is not checked
+		if len(schema.Properties) != 0 && (schema.XPreserveUnknownFields == nil || !*schema.XPreserveUnknownFields) {
+			errs = append(errs, k8sfield.NotSupported(path.Child(k), k, schemaPropertyNames(schema)))
+		}
+	}
+	return errs
+}
+
+func schemaPropertyNames(schema *apiextensionsv1.JSONSchemaProps) []string {
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// schemaNumber returns the number decoded from json, as an int64 by the unstructured converter or
+// as a float64 by encoding/json
+func schemaNumber(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case int64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+func schemaBounds(n float64, schema *apiextensionsv1.JSONSchemaProps, path *k8sfield.Path) FieldErrors {
+	errs := FieldErrors{}
+	if min := schema.Minimum; min != nil && (n < *min || (schema.ExclusiveMinimum && n == *min)) {
+		errs = append(errs, k8sfield.Invalid(path, n, fmt.Sprintf("must be greater than %s%v", orEqualTo(schema.ExclusiveMinimum), *min)))
+	}
+	if max := schema.Maximum; max != nil && (n > *max || (schema.ExclusiveMaximum && n == *max)) {
+		errs = append(errs, k8sfield.Invalid(path, n, fmt.Sprintf("must be less than %s%v", orEqualTo(schema.ExclusiveMaximum), *max)))
+	}
+	return errs
+}
+
+func orEqualTo(exclusive bool) string {
+	if exclusive {
+		return ""
+	}
+	return "or equal to "
+}
+
+func schemaEnumContains(enum []apiextensionsv1.JSON, value interface{}) bool {
+	// the value is compared once decoded the same way as the enum values
+	b, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return false
+	}
+	for _, e := range enum {
+		var ev interface{}
+		if err := json.Unmarshal(e.Raw, &ev); err == nil && reflect.DeepEqual(ev, v) {
+			return true
+		}
+	}
+	return false
+}
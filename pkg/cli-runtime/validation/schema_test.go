@@ -0,0 +1,200 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/validation"
+)
+
+func TestSchema(t *testing.T) {
+	schema := &apiextensionsv1.JSONSchemaProps{
+		Type:     "object",
+		Required: []string{"name"},
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"name": {
+				Type:      "string",
+				MinLength: pointer.Int64(1),
+				MaxLength: pointer.Int64(10),
+				Pattern:   "^[a-z-]+$",
+			},
+			"replicas": {
+				Type:    "integer",
+				Minimum: pointer.Float64(0),
+				Maximum: pointer.Float64(5),
+			},
+			"ratio": {
+				Type:             "number",
+				Maximum:          pointer.Float64(1),
+				ExclusiveMaximum: true,
+			},
+			"enabled": {
+				Type: "boolean",
+			},
+			"mode": {
+				Type: "string",
+				Enum: []apiextensionsv1.JSON{{Raw: []byte(`"fast"`)}, {Raw: []byte(`"slow"`)}},
+			},
+			"port": {
+				XIntOrString: true,
+			},
+			"env": {
+				Type:     "array",
+				MaxItems: pointer.Int64(2),
+				Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+					Schema: &apiextensionsv1.JSONSchemaProps{
+						Type:     "object",
+						Required: []string{"name"},
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"name":  {Type: "string"},
+							"value": {Type: "string"},
+						},
+					},
+				},
+			},
+			"labels": {
+				Type: "object",
+				AdditionalProperties: &apiextensionsv1.JSONSchemaPropsOrBool{
+					Schema: &apiextensionsv1.JSONSchemaProps{Type: "string"},
+				},
+			},
+			"value": {
+				XPreserveUnknownFields: pointer.Bool(true),
+			},
+		},
+	}
+	path := k8sfield.NewPath("spec")
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected validation.FieldErrors
+	}{{
+		name: "valid",
+		value: map[string]interface{}{
+			"name":     "my-name",
+			"replicas": int64(3),
+			"ratio":    0.5,
+			"enabled":  true,
+			"mode":     "fast",
+			"port":     "http",
+			"env": []interface{}{
+				map[string]interface{}{"name": "FOO", "value": "bar"},
+			},
+			"labels": map[string]interface{}{"app": "my-app"},
+			"value":  map[string]interface{}{"any": []interface{}{int64(1), "two"}},
+		},
+		expected: validation.FieldErrors{},
+	}, {
+		name:     "null",
+		value:    nil,
+		expected: validation.FieldErrors{},
+	}, {
+		name:  "not an object",
+		value: "my-name",
+		expected: validation.FieldErrors{
+			k8sfield.TypeInvalid(path, "my-name", "must be of type object"),
+		},
+	}, {
+		name:  "missing required field",
+		value: map[string]interface{}{},
+		expected: validation.FieldErrors{
+			k8sfield.Required(path.Child("name"), ""),
+		},
+	}, {
+		name: "unknown field",
+		value: map[string]interface{}{
+			"name":  "my-name",
+			"image": "ubuntu",
+		},
+		expected: validation.FieldErrors{
+			k8sfield.NotSupported(path.Child("image"), "image", []string{"enabled", "env", "labels", "mode", "name", "port", "ratio", "replicas", "value"}),
+		},
+	}, {
+		name: "invalid strings",
+		value: map[string]interface{}{
+			"name": "My_Name_Is_Too_Long",
+			"mode": "medium",
+		},
+		expected: validation.FieldErrors{
+			k8sfield.NotSupported(path.Child("mode"), "medium", []string{`"fast"`, `"slow"`}),
+			k8sfield.TooLong(path.Child("name"), "My_Name_Is_Too_Long", 10),
+			k8sfield.Invalid(path.Child("name"), "My_Name_Is_Too_Long", `must match the pattern "^[a-z-]+$"`),
+		},
+	}, {
+		name: "out of bounds",
+		value: map[string]interface{}{
+			"name":     "my-name",
+			"replicas": int64(6),
+			"ratio":    float64(1),
+		},
+		expected: validation.FieldErrors{
+			k8sfield.Invalid(path.Child("ratio"), float64(1), "must be less than 1"),
+			k8sfield.Invalid(path.Child("replicas"), float64(6), "must be less than or equal to 5"),
+		},
+	}, {
+		name: "invalid types",
+		value: map[string]interface{}{
+			"name":     int64(1),
+			"replicas": 1.5,
+			"ratio":    "half",
+			"enabled":  "true",
+			"port":     true,
+			"env":      "FOO=bar",
+			"labels":   map[string]interface{}{"app": int64(1)},
+		},
+		expected: validation.FieldErrors{
+			k8sfield.TypeInvalid(path.Child("enabled"), "true", "must be of type boolean"),
+			k8sfield.TypeInvalid(path.Child("env"), "FOO=bar", "must be of type array"),
+			k8sfield.TypeInvalid(path.Child("labels", "app"), int64(1), "must be of type string"),
+			k8sfield.TypeInvalid(path.Child("name"), int64(1), "must be of type string"),
+			k8sfield.TypeInvalid(path.Child("port"), true, "must be an integer or a string"),
+			k8sfield.TypeInvalid(path.Child("ratio"), "half", "must be of type number"),
+			k8sfield.TypeInvalid(path.Child("replicas"), 1.5, "must be of type integer"),
+		},
+	}, {
+		name: "invalid items",
+		value: map[string]interface{}{
+			"name": "my-name",
+			"env": []interface{}{
+				map[string]interface{}{"name": "FOO"},
+				map[string]interface{}{"value": "bar"},
+				map[string]interface{}{"name": "BAZ", "valueFrom": "secret"},
+			},
+		},
+		expected: validation.FieldErrors{
+			k8sfield.TooMany(path.Child("env"), 3, 2),
+			k8sfield.Required(path.Child("env").Index(1).Child("name"), ""),
+			k8sfield.NotSupported(path.Child("env").Index(2).Child("valueFrom"), "valueFrom", []string{"name", "value"}),
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := validation.Schema(test.value, schema, path)
+			if diff := cmp.Diff(test.expected, actual); diff != "" {
+				t.Errorf("(-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
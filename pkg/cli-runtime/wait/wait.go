@@ -23,6 +23,7 @@ import (
 
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -33,44 +34,75 @@ var (
 type ConditionFunc = func(client.Object) (bool, error)
 
 func UntilCondition(ctx context.Context, watchClient client.WithWatch, target types.NamespacedName, listType client.ObjectList, condition ConditionFunc) error {
+	return UntilConditionEvery(ctx, clock.RealClock{}, watchClient, target, listType, 0, condition)
+}
+
+// UntilConditionEvery is like UntilCondition, but evaluates the condition at most once per
+// interval, as measured by the clock. The events received in between are coalesced and only the
+// latest object is evaluated once the interval elapsed. A zero interval evaluates every event.
+func UntilConditionEvery(ctx context.Context, clk clock.Clock, watchClient client.WithWatch, target types.NamespacedName, listType client.ObjectList, interval time.Duration, condition ConditionFunc) error {
 	eventWatcher, err := watchClient.Watch(ctx, listType, &client.ListOptions{Namespace: target.Namespace})
 	if err != nil {
 		return err
 	}
 	defer eventWatcher.Stop()
+
+	var last time.Time
+	var pending client.Object
+	var throttle <-chan time.Time
+	evaluate := func(obj client.Object) (bool, error) {
+		last = clk.Now()
+		return condition(obj)
+	}
+
 	for {
 		select {
 		case event := <-eventWatcher.ResultChan():
-			if event.Object != nil {
-				if obj, ok := event.Object.(client.Object); !ok {
-					continue
-				} else {
-					if obj.GetName() != target.Name || obj.GetNamespace() != target.Namespace {
-						continue
-					}
-					cond, err := condition(obj)
-					if err != nil {
-						return err
-					}
-					if cond {
-						return nil
+			obj, ok := event.Object.(client.Object)
+			if !ok || obj.GetName() != target.Name || obj.GetNamespace() != target.Namespace {
+				continue
+			}
+			if interval > 0 && !last.IsZero() {
+				if remaining := interval - clk.Since(last); remaining > 0 {
+					pending = obj
+					if throttle == nil {
+						throttle = clk.After(remaining)
 					}
+					continue
 				}
 			}
+			if done, err := evaluate(obj); err != nil || done {
+				return err
+			}
+		case <-throttle:
+			throttle = nil
+			if done, err := evaluate(pending); err != nil || done {
+				return err
+			}
 		case <-ctx.Done():
 			return ctx.Err()
 		}
 	}
 }
 
-func UntilDelete(ctx context.Context, c client.Client, obj client.Object) error {
-	t := time.NewTicker(BackOffTime)
+// UntilDelete polls for the object every BackOffTime, as measured by the clock, until it is gone
+func UntilDelete(ctx context.Context, clk clock.WithTicker, c client.Client, obj client.Object) error {
+	return UntilDeleteEvery(ctx, clk, c, obj, BackOffTime)
+}
+
+// UntilDeleteEvery polls for the object every interval, as measured by the clock, until it is
+// gone. A zero interval polls every BackOffTime.
+func UntilDeleteEvery(ctx context.Context, clk clock.WithTicker, c client.Client, obj client.Object, interval time.Duration) error {
+	if interval <= 0 {
+		interval = BackOffTime
+	}
+	t := clk.NewTicker(interval)
 	defer t.Stop()
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-t.C:
+		case <-t.C():
 			if err := c.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: obj.GetName()}, obj); err != nil {
 				if apierrs.IsNotFound(err) {
 					return nil
@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -151,6 +152,52 @@ func TestUntilReady(t *testing.T) {
 	}
 }
 
+func TestUntilConditionEvery(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = cartov1alpha1.AddToScheme(scheme)
+
+	ctx := context.Background()
+	workload := &cartov1alpha1.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-workload",
+		},
+	}
+	fakeWithWatcher := fake.NewClientBuilder().WithScheme(scheme).WithObjects(workload.DeepCopy()).Build()
+
+	evaluations := 0
+	condFunc := func(obj client.Object) (bool, error) {
+		evaluations++
+		return obj.GetLabels()["step"] == "3", nil
+	}
+
+	done := make(chan error, 1)
+	defer close(done)
+	go func() {
+		done <- UntilConditionEvery(ctx, clock.RealClock{}, fakeWithWatcher, types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}, &cartov1alpha1.WorkloadList{}, 200*time.Millisecond, condFunc)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	for _, step := range []string{"1", "2", "3"} {
+		update := &cartov1alpha1.Workload{}
+		if err := fakeWithWatcher.Get(ctx, types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}, update); err != nil {
+			t.Fatalf("Get error %v", err)
+		}
+		update.SetLabels(map[string]string{"step": step})
+		if err := fakeWithWatcher.Update(ctx, update); err != nil {
+			t.Fatalf("Update error %v", err)
+		}
+	}
+
+	if err := <-done; err != nil {
+		t.Errorf("expected no error, actually %v", err)
+	}
+	// the first event is evaluated right away, the following ones are coalesced into one evaluation
+	if expected, actual := 2, evaluations; expected != actual {
+		t.Errorf("expected %d evaluations, actually %d", expected, actual)
+	}
+}
+
 func TestUntilDelete(t *testing.T) {
 	defaultNamespace := "default"
 	workloadName := "my-workload"
@@ -222,7 +269,7 @@ func TestUntilDelete(t *testing.T) {
 
 			// reactor fails with retryable error for 1st call and then fails with ResourseNotFoundError
 			client.AddReactor("get", "*", test.reactorFunc)
-			err := UntilDelete(ctx, client, workload)
+			err := UntilDelete(ctx, clock.RealClock{}, client, workload)
 			if expected, actual := fmt.Sprintf("%s", test.err), fmt.Sprintf("%s", err); expected != actual {
 				t.Errorf("expected error %v, actually %v", expected, actual)
 			}
@@ -20,7 +20,6 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
@@ -62,7 +61,7 @@ func (opts *ClusterSupplyChainListOptions) Exec(ctx context.Context, c *cli.Conf
 	}
 
 	tablePrinter := table.NewTablePrinter(table.PrintOptions{
-		// none for now
+		Now: c.Now(),
 	}).With(func(h table.PrintHandler) {
 		columns := opts.printColumns()
 		h.TableHandler(columns, opts.printList)
@@ -114,15 +113,14 @@ func (opts *ClusterSupplyChainListOptions) printList(supplyChains *cartov1alpha1
 	return rows, nil
 }
 
-func (opts *ClusterSupplyChainListOptions) print(supplyChain *cartov1alpha1.ClusterSupplyChain, _ table.PrintOptions) ([]metav1beta1.TableRow, error) {
-	now := time.Now()
+func (opts *ClusterSupplyChainListOptions) print(supplyChain *cartov1alpha1.ClusterSupplyChain, printOpts table.PrintOptions) ([]metav1beta1.TableRow, error) {
 	row := metav1beta1.TableRow{
 		Object: runtime.RawExtension{Object: supplyChain},
 	}
 	row.Cells = append(row.Cells,
 		supplyChain.Name,
 		printer.ConditionStatus(printer.FindCondition(supplyChain.Status.Conditions, "Ready")),
-		printer.TimestampSince(supplyChain.CreationTimestamp, now),
+		printer.TimestampSince(supplyChain.CreationTimestamp, printOpts.Now),
 	)
 	return []metav1beta1.TableRow{row}, nil
 }
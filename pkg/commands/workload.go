@@ -18,7 +18,9 @@ package commands
 
 import (
 	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -27,19 +29,31 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/acarl005/stripansi"
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	k8swatch "k8s.io/apimachinery/pkg/watch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	"github.com/vmware-tanzu/carvel-imgpkg/pkg/imgpkg/registry"
 
@@ -67,8 +81,42 @@ const (
 	MavenFlagWildcard         = "--maven*"
 	// --source-image can be a source for workload without local path and vice versa.
 	LocalPathAndSource = "--local-path with/without --source-image"
+	// --file can reference a key within a ConfigMap as configmap://namespace/name/key
+	ConfigMapFilePathPrefix = "configmap://"
 )
 
+const (
+	defaultDiffFormat = "default"
+	unifiedDiffFormat = "unified"
+)
+
+// defaultMaxValueWidth is the number of bytes of a value shown in the workload changes by default
+const defaultMaxValueWidth = 2048
+
+const (
+	noneDryRunStrategy   = "none"
+	clientDryRunStrategy = "client"
+	serverDryRunStrategy = "server"
+)
+
+// ids of the warnings printed by the workload commands, see --suppress-warnings
+const (
+	NoSupplyChainWarningID          = "no-supply-chain"
+	SchemaUnavailableWarningID      = "schema-unavailable"
+	ServiceAccountNotFoundWarningID = "service-account-not-found"
+	UpdateStrategyWarningID         = "update-strategy"
+	ValidationDisabledWarningID     = "validation-disabled"
+)
+
+var warningIDs = []string{
+	cartov1alpha1.CrossNamespaceServiceClaimsWarningID,
+	NoSupplyChainWarningID,
+	SchemaUnavailableWarningID,
+	ServiceAccountNotFoundWarningID,
+	UpdateStrategyWarningID,
+	ValidationDisabledWarningID,
+}
+
 const (
 	waitErrorForStatusChange   = "Error waiting for status change"
 	waitErrorForReadyCondition = "Error waiting for ready condition"
@@ -114,17 +162,31 @@ type WorkloadOptions struct {
 	LiveUpdate  bool
 
 	FilePath        string
+	InputFormat     string
+	ContextDir      string
+	Profile         string
+	Retry           cli.RetryOptions
+	AllowedHosts    []string
 	GitRepo         string
 	GitCommit       string
 	GitBranch       string
 	GitTag          string
+	GitRefExclusive bool
+	GitSecret       string
 	SourceImage     string
 	LocalPath       string
 	ExcludePathFile string
 	Image           string
+	PinImage        bool
 	SubPath         string
+	PackSubPath     bool
+	NoCache         bool
 	BuildEnv        []string
+	BuildParams     []string
+	BuildParamsYaml []string
 	Env             []string
+	ExpandEnv       string
+	EnvConfigMaps   []string
 	ServiceRefs     []string
 
 	ServiceAccountName string
@@ -144,14 +206,51 @@ type WorkloadOptions struct {
 
 	RequestCPU    string
 	RequestMemory string
+	Guaranteed    bool
 
 	Wait           bool
 	WaitTimeout    time.Duration
+	WaitInterval   time.Duration
 	Tail           bool
 	TailTimestamps bool
 	DryRun         bool
+	DryRunStrategy string
 	Yes            bool
 	Output         string
+	ShowSecrets    bool
+	DiffFormat     string
+	// WordDiff highlights the changed part of the modified lines in the workload changes
+	WordDiff       bool
+	YamlFlowParams bool
+	// CollapseUnchanged shows the unchanged sections of the spec on a single line in the workload
+	// changes, see --collapse-unchanged
+	CollapseUnchanged bool
+	// AnnotateForOverlay annotates the yaml output for it to be used as a ytt overlay, see
+	// --annotate-for-overlay
+	AnnotateForOverlay bool
+	// MaxValueWidth is the number of bytes of a value shown in the workload changes, see
+	// --max-value-width
+	MaxValueWidth int
+	// Bare leaves out the labels the CLI adds by default, see --bare
+	Bare       bool
+	DiffFile   string
+	AlwaysShow bool
+
+	// FailOnUnknownReasons are the reasons of an Unknown Ready condition that end the wait
+	FailOnUnknownReasons []string
+
+	SuppressWarnings []string
+	WarningsAsErrors bool
+	// warnings counts the warnings printed, see failOnWarnings
+	warnings int
+	// warningMessages are the messages of the warnings printed, see WorkloadApplyResult
+	warningMessages []string
+	// paramFlags records the flag name of every --param and --param-yaml value in the order they
+	// were given, see orderedParams
+	paramFlags []string
+	// prunePlan lists the entries of the workload removed by the pruning, they are confirmed
+	// apart in the update prompt
+	prunePlan []string
 }
 
 func (opts *WorkloadOptions) Validate(ctx context.Context) validation.FieldErrors {
@@ -160,30 +259,16 @@ func (opts *WorkloadOptions) Validate(ctx context.Context) validation.FieldError
 	sources := []string{}
 
 	errs = errs.Also(validation.K8sName(opts.Namespace, flags.NamespaceFlagName))
+	if opts.ContextDir != "" {
+		if info, err := os.Stat(opts.ContextDir); err != nil || !info.IsDir() {
+			errs = errs.Also(validation.ErrInvalidValueWithDetail(opts.ContextDir, flags.ContextDirFlagName, "must be an existing directory"))
+		}
+	}
 	if opts.FilePath == "" {
 		errs = errs.Also(validation.K8sName(opts.Name, cli.NameArgumentName))
 	}
-	errs = errs.Also(validation.DeletableKeyValues(opts.Labels, flags.LabelFlagName))
-	errs = errs.Also(validation.DeletableKeyValues(opts.Annotations, flags.AnnotationFlagName))
-	errs = errs.Also(validation.DeletableKeyValues(opts.Params, flags.ParamFlagName))
-	errs = errs.Also(validation.JsonOrYamlKeyValues(opts.ParamsYaml, flags.ParamYamlFlagName))
-	errs = errs.Also(validation.DeletableEnvVars(opts.Env, flags.EnvFlagName))
-	errs = errs.Also(validation.DeletableEnvVars(opts.BuildEnv, flags.BuildEnvFlagName))
-	errs = errs.Also(validation.DeletableKeyObjectReferences(opts.ServiceRefs, flags.ServiceRefFlagName))
-
-	if opts.LimitCPU != "" {
-		errs = errs.Also(validation.Quantity(opts.LimitCPU, flags.LimitCPUFlagName))
-	}
-	if opts.LimitMemory != "" {
-		errs = errs.Also(validation.Quantity(opts.LimitMemory, flags.LimitMemoryFlagName))
-	}
-
-	if opts.RequestCPU != "" {
-		errs = errs.Also(validation.Quantity(opts.RequestCPU, flags.RequestCPUFlagName))
-	}
-	if opts.RequestMemory != "" {
-		errs = errs.Also(validation.Quantity(opts.RequestMemory, flags.RequestMemoryFlagName))
-	}
+	errs = errs.Also(validation.K8sLabels(opts.Labels, flags.LabelFlagName))
+	errs = errs.Also(opts.validateParsedValues())
 
 	if opts.RequestCPU != "" && opts.LimitCPU != "" {
 		errs = errs.Also(validation.CompareQuantity(opts.LimitCPU, opts.RequestCPU, flags.RequestCPUFlagName))
@@ -192,6 +277,18 @@ func (opts *WorkloadOptions) Validate(ctx context.Context) validation.FieldError
 		errs = errs.Also(validation.CompareQuantity(opts.LimitMemory, opts.RequestMemory, flags.RequestMemoryFlagName))
 	}
 
+	if opts.GitSecret != "" {
+		errs = errs.Also(validation.K8sName(opts.GitSecret, flags.GitSecretFlagName))
+	}
+
+	// a repository ending with "/" is completed and checked once the workload name is known, see
+	// expandSourceImage
+	if opts.SourceImage != "" && !strings.HasSuffix(opts.SourceImage, "/") {
+		if _, err := name.ParseReference(opts.SourceImage, name.WeakValidation); err != nil {
+			errs = errs.Also(validation.ErrInvalidValueWithDetail(opts.SourceImage, flags.SourceImageFlagName, fmt.Sprintf("not a valid image reference: %v", err)))
+		}
+	}
+
 	if opts.RegistryPassword != "" || opts.RegistryUsername != "" || opts.RegistryToken != "" || len(opts.CACertPaths) != 0 {
 		if opts.SourceImage == "" {
 			errs = errs.Also(validation.ErrMissingField(flags.SourceImageFlagName))
@@ -201,10 +298,49 @@ func (opts *WorkloadOptions) Validate(ctx context.Context) validation.FieldError
 		}
 	}
 
-	if opts.Output != "" {
+	// the patch and helm-values outputs of workload apply are validated by the command
+	if opts.Output != "" && opts.Output != patchOutputFormat && opts.Output != helmValuesOutputFormat {
 		errs = errs.Also(validation.Enum(opts.Output, flags.OutputFlagName, []string{printer.OutputFormatJson, printer.OutputFormatYaml, printer.OutputFormatYml}))
 	}
 
+	if opts.DiffFormat != "" {
+		errs = errs.Also(validation.Enum(opts.DiffFormat, flags.DiffFormatFlagName, []string{defaultDiffFormat, unifiedDiffFormat}))
+	}
+	if opts.WordDiff && opts.DiffFormat == unifiedDiffFormat {
+		errs = errs.Also(validation.ErrMultipleOneOf(flags.WordDiffFlagName, flags.DiffFormatFlagName))
+	}
+	if opts.CollapseUnchanged && opts.DiffFormat == unifiedDiffFormat {
+		errs = errs.Also(validation.ErrMultipleOneOf(flags.CollapseUnchangedFlagName, flags.DiffFormatFlagName))
+	}
+
+	if opts.AnnotateForOverlay {
+		if opts.Output == printer.OutputFormatJson || opts.Output == patchOutputFormat || opts.Output == helmValuesOutputFormat {
+			errs = errs.Also(validation.ErrInvalidValueWithDetail(opts.Output, flags.OutputFlagName, "only yaml can be annotated by "+flags.AnnotateForOverlayFlagName))
+		} else if opts.Output == "" && !opts.DryRun {
+			errs = errs.Also(validation.ErrMissingOneOfWithDetail("required by "+flags.AnnotateForOverlayFlagName, flags.DryRunFlagName, flags.OutputFlagName))
+		}
+	}
+
+	if opts.MaxValueWidth < 0 {
+		errs = errs.Also(validation.ErrInvalidValueWithDetail(strconv.Itoa(opts.MaxValueWidth), flags.MaxValueWidthFlagName, "must be 0 or more"))
+	}
+
+	errs = errs.Also(opts.validateDiffFile())
+
+	if opts.PackSubPath {
+		if opts.LocalPath == "" {
+			errs = errs.Also(validation.ErrMissingField(flags.LocalPathFlagName))
+		} else if opts.SubPath != "" && source.IsDir(opts.LocalPath) {
+			if _, err := subPathDir(opts.LocalPath, opts.SubPath); err != nil {
+				errs = errs.Also(validation.ErrInvalidValueWithDetail(opts.SubPath, flags.SubPathFlagName, err.Error()))
+			}
+		}
+	}
+
+	if opts.NoCache && opts.LocalPath == "" {
+		errs = errs.Also(validation.ErrMissingField(flags.LocalPathFlagName))
+	}
+
 	// validating sources as the source options are mutually exclusive
 	if opts.MavenArtifact != "" || opts.MavenVersion != "" || opts.MavenGroup != "" || opts.MavenType != "" {
 		mavenSource = true
@@ -238,8 +374,252 @@ func (opts *WorkloadOptions) Validate(ctx context.Context) validation.FieldError
 	return errs
 }
 
+// ValidateInputs only validates the flag values that have to be parsed to build the workload,
+// leaving any other check to the cluster. It is used when the client validation is disabled
+func (opts *WorkloadOptions) ValidateInputs(ctx context.Context) validation.FieldErrors {
+	errs := validation.FieldErrors{}
+
+	errs = errs.Also(validation.DeletableKeyValues(opts.Labels, flags.LabelFlagName))
+	errs = errs.Also(opts.validateParsedValues())
+	// an existing diff file is not overwritten by mistake, the request doesn't matter
+	errs = errs.Also(opts.validateDiffFile())
+
+	// the patch and helm-values outputs of workload apply are validated by the command
+	if opts.Output != "" && opts.Output != patchOutputFormat && opts.Output != helmValuesOutputFormat {
+		errs = errs.Also(validation.Enum(opts.Output, flags.OutputFlagName, []string{printer.OutputFormatJson, printer.OutputFormatYaml, printer.OutputFormatYml}))
+	}
+	return errs
+}
+
+// validateDiffFile requires --yes to overwrite an existing --diff-file
+func (opts *WorkloadOptions) validateDiffFile() validation.FieldErrors {
+	errs := validation.FieldErrors{}
+	if opts.DiffFile != "" && !opts.Yes {
+		if _, err := os.Stat(opts.DiffFile); err == nil {
+			errs = errs.Also(validation.ErrInvalidValueWithDetail(opts.DiffFile, flags.DiffFileFlagName, fmt.Sprintf("file already exists, use %s to overwrite it", flags.YesFlagName)))
+		}
+	}
+	return errs
+}
+
+func (opts *WorkloadOptions) validateParsedValues() validation.FieldErrors {
+	errs := validation.FieldErrors{}
+
+	// the ConfigMap reference is split in its parts to read the file, see getConfigMapFileContent
+	if isConfigMapRef(opts.FilePath) {
+		errs = errs.Also(validation.ConfigMapKeyReference(strings.TrimPrefix(opts.FilePath, ConfigMapFilePathPrefix), flags.FilePathFlagName))
+	}
+
+	errs = errs.Also(validation.DeletableKeyValues(opts.Annotations, flags.AnnotationFlagName))
+	errs = errs.Also(validation.TypedParamKeyValues(opts.Params, flags.ParamFlagName))
+	errs = errs.Also(validation.JsonOrYamlKeyValues(opts.ParamsYaml, flags.ParamYamlFlagName))
+	errs = errs.Also(validation.DeletableEnvVars(opts.Env, flags.EnvFlagName))
+	errs = errs.Also(validation.K8sNames(opts.EnvConfigMaps, flags.EnvFromConfigMapFlagName))
+	errs = errs.Also(validation.DeletableEnvVars(opts.BuildEnv, flags.BuildEnvFlagName))
+	errs = errs.Also(validateBuildParams(opts.BuildParams, flags.BuildParamFlagName))
+	errs = errs.Also(validateBuildParamsYaml(opts.BuildParamsYaml, flags.BuildParamYamlFlagName))
+	errs = errs.Also(validation.DeletableKeyObjectReferences(opts.ServiceRefs, flags.ServiceRefFlagName))
+
+	if opts.WaitInterval < 0 {
+		errs = errs.Also(validation.ErrInvalidValueWithDetail(opts.WaitInterval.String(), flags.WaitIntervalFlagName, "must be a positive duration, or 0 to check the status on every change"))
+	}
+	if len(opts.FailOnUnknownReasons) != 0 && !opts.Wait && !opts.Tail && !opts.TailTimestamps {
+		errs = errs.Also(validation.ErrMissingOneOfWithDetail("required by "+flags.FailOnUnknownReasonFlagName, flags.WaitFlagName, flags.TailFlagName))
+	}
+
+	if opts.LimitCPU != "" {
+		errs = errs.Also(validation.Quantity(opts.LimitCPU, flags.LimitCPUFlagName))
+	}
+	if opts.LimitMemory != "" {
+		errs = errs.Also(validation.Quantity(opts.LimitMemory, flags.LimitMemoryFlagName))
+	}
+
+	if opts.RequestCPU != "" {
+		errs = errs.Also(validation.Quantity(opts.RequestCPU, flags.RequestCPUFlagName))
+	}
+	if opts.RequestMemory != "" {
+		errs = errs.Also(validation.Quantity(opts.RequestMemory, flags.RequestMemoryFlagName))
+	}
+	return errs
+}
+
+// buildParam is a build setting that can be set with --build-param. Each setting is stored either
+// as a workload param or as a build environment variable read by the buildpacks. New build settings
+// only need a new entry in buildParams.
+type buildParam struct {
+	// Param is the workload param that holds the value
+	Param string
+	// BuildEnv is the build environment variable that holds the value
+	BuildEnv string
+	// Validate checks the value, any value is accepted when nil
+	Validate func(value, field string) validation.FieldErrors
+}
+
+var buildParams = map[string]buildParam{
+	"builder": {
+		Param:    "clusterBuilder",
+		Validate: validation.K8sName,
+	},
+	"jvm-version": {
+		BuildEnv: "BP_JVM_VERSION",
+		Validate: validateBuildParamNumber,
+	},
+	"maven-build-arguments": {
+		BuildEnv: "BP_MAVEN_BUILD_ARGUMENTS",
+	},
+	"native-image": {
+		BuildEnv: "BP_NATIVE_IMAGE",
+		Validate: validateBuildParamBool,
+	},
+	"node-version": {
+		BuildEnv: "BP_NODE_VERSION",
+	},
+}
+
+func buildParamNames() []string {
+	names := make([]string, 0, len(buildParams))
+	for name := range buildParams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func validateBuildParams(kvs []string, field string) validation.FieldErrors {
+	errs := validation.FieldErrors{}
+	for i, kv := range kvs {
+		kvErrs := validation.DeletableKeyValue(kv, validation.CurrentField)
+		if len(kvErrs) != 0 {
+			errs = errs.Also(kvErrs.ViaFieldIndex(field, i))
+			continue
+		}
+		keyValue := parsers.DeletableKeyValue(kv)
+		param, ok := buildParams[keyValue[0]]
+		if !ok {
+			errs = errs.Also(validation.ErrInvalidValueWithDetail(kv, validation.CurrentField, fmt.Sprintf("supported build params are %s", strings.Join(buildParamNames(), ", "))).ViaFieldIndex(field, i))
+			continue
+		}
+		if len(keyValue) > 1 && param.Validate != nil {
+			errs = errs.Also(param.Validate(keyValue[1], validation.CurrentField).ViaFieldIndex(field, i))
+		}
+	}
+	return errs
+}
+
+func validateBuildParamsYaml(kvs []string, field string) validation.FieldErrors {
+	errs := validation.FieldErrors{}
+	for i, kv := range kvs {
+		kvErrs := validation.DeletableKeyValue(kv, validation.CurrentField)
+		if len(kvErrs) != 0 {
+			errs = errs.Also(kvErrs.ViaFieldIndex(field, i))
+			continue
+		}
+		keyValue := parsers.DeletableKeyValue(kv)
+		param, ok := buildParams[keyValue[0]]
+		if !ok {
+			errs = errs.Also(validation.ErrInvalidValueWithDetail(kv, validation.CurrentField, fmt.Sprintf("supported build params are %s", strings.Join(buildParamNames(), ", "))).ViaFieldIndex(field, i))
+			continue
+		}
+		if len(keyValue) == 1 {
+			continue
+		}
+		_, value, err := buildParamYamlValue(keyValue[1])
+		if err != nil {
+			errs = errs.Also(validation.ErrInvalidValueWithDetail(kv, validation.CurrentField, err.Error()).ViaFieldIndex(field, i))
+			continue
+		}
+		if param.Validate != nil {
+			errs = errs.Also(param.Validate(value, validation.CurrentField).ViaFieldIndex(field, i))
+		}
+	}
+	return errs
+}
+
+// buildParamYamlValue parses a YAML or JSON value into an object and its string form. Build
+// environment variables can only hold strings, so structured values are kept as compact JSON.
+func buildParamYamlValue(raw string) (interface{}, string, error) {
+	o, err := parsers.JsonYamlToObject(raw)
+	if err != nil {
+		return nil, "", err
+	}
+	switch v := o.(type) {
+	case string:
+		return o, v, nil
+	case map[string]interface{}, []interface{}:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, "", err
+		}
+		return o, string(b), nil
+	default:
+		return o, fmt.Sprint(v), nil
+	}
+}
+
+func validateBuildParamNumber(value, field string) validation.FieldErrors {
+	if n, err := strconv.Atoi(value); err != nil || n <= 0 {
+		return validation.ErrInvalidValueWithDetail(value, field, "must be a positive whole number")
+	}
+	return validation.FieldErrors{}
+}
+
+func validateBuildParamBool(value, field string) validation.FieldErrors {
+	if _, err := strconv.ParseBool(value); err != nil {
+		return validation.ErrInvalidValueWithDetail(value, field, "must be true or false")
+	}
+	return validation.FieldErrors{}
+}
+
+// applyBuildParams sets or removes ("name-") each build param and YAML build param in the workload
+// params or build env
+func (opts *WorkloadOptions) applyBuildParams(workload *cartov1alpha1.Workload) {
+	for _, p := range opts.BuildParams {
+		kv := parsers.DeletableKeyValue(p)
+		param := buildParams[kv[0]]
+		switch {
+		case param.Param != "" && len(kv) == 1:
+			workload.Spec.RemoveParam(param.Param)
+		case param.Param != "":
+			workload.Spec.MergeParams(param.Param, kv[1])
+		case len(kv) == 1:
+			workload.Spec.RemoveBuildEnv(param.BuildEnv)
+		default:
+			workload.Spec.MergeBuildEnv(corev1.EnvVar{Name: param.BuildEnv, Value: kv[1]})
+		}
+	}
+
+	for _, p := range opts.BuildParamsYaml {
+		kv := parsers.DeletableKeyValue(p)
+		param := buildParams[kv[0]]
+		if len(kv) == 1 {
+			if param.Param != "" {
+				workload.Spec.RemoveParam(param.Param)
+			} else {
+				workload.Spec.RemoveBuildEnv(param.BuildEnv)
+			}
+			continue
+		}
+		o, value, err := buildParamYamlValue(kv[1])
+		if err != nil {
+			// errors should be caught during the validation phase
+			panic(err)
+		}
+		if param.Param != "" {
+			workload.Spec.MergeParams(param.Param, o)
+		} else {
+			workload.Spec.MergeBuildEnv(corev1.EnvVar{Name: param.BuildEnv, Value: value})
+		}
+	}
+}
+
 func (opts *WorkloadOptions) OutputWorkload(c *cli.Config, workload *cartov1alpha1.Workload) error {
+	if !opts.ShowSecrets {
+		workload = maskSecretValues(workload)
+	}
 	export, err := printer.OutputResource(workload, printer.OutputFormat(opts.Output), c.Scheme)
+	if err == nil {
+		export, err = opts.renderYaml(export, printer.OutputFormat(opts.Output))
+	}
 	if err != nil {
 		c.Eprintf("%s %s\n", printer.Serrorf("Failed to output workload:"), err)
 		return cli.SilenceError(err)
@@ -249,6 +629,115 @@ func (opts *WorkloadOptions) OutputWorkload(c *cli.Config, workload *cartov1alph
 	return nil
 }
 
+const maskedSecretValue = "*****"
+
+// secretNamePattern matches the names of env vars and params that are likely to hold a secret
+var secretNamePattern = regexp.MustCompile(`(?i)(password|passwd|secret|token|api[-_]?key|private[-_]?key|credential)`)
+
+// secretReferenceParams are the params holding the name of a Secret rather than a credential,
+// they are never masked
+var secretReferenceParams = sets.NewString(
+	cartov1alpha1.WorkloadGitSecretParam,
+)
+
+// maskSecretValues returns a copy of the workload where the inline values of the env vars, build
+// env vars and params with a secret like name are masked. Env vars read from a secret have no
+// inline value and are kept as is, like the params referencing a secret by name. The workload
+// itself is not modified.
+func maskSecretValues(workload *cartov1alpha1.Workload) *cartov1alpha1.Workload {
+	masked := workload.DeepCopy()
+	maskEnv := func(env []corev1.EnvVar) {
+		for i := range env {
+			if env[i].Value != "" && secretNamePattern.MatchString(env[i].Name) {
+				env[i].Value = maskedSecretValue
+			}
+		}
+	}
+	maskEnv(masked.Spec.Env)
+	if masked.Spec.Build != nil {
+		maskEnv(masked.Spec.Build.Env)
+	}
+	for i, p := range masked.Spec.Params {
+		if secretNamePattern.MatchString(p.Name) && !secretReferenceParams.Has(p.Name) {
+			masked.Spec.Params[i].Value = apiextensionsv1.JSON{Raw: []byte(`"` + maskedSecretValue + `"`)}
+		}
+	}
+	return masked
+}
+
+// dryRunValue binds --dry-run to DryRun and DryRunStrategy. The flag keeps accepting the boolean
+// values it had before the strategies were added, true being the client strategy.
+type dryRunValue struct {
+	opts *WorkloadOptions
+}
+
+var _ pflag.Value = (*dryRunValue)(nil)
+
+func (v *dryRunValue) String() string {
+	if v.opts == nil || !v.opts.DryRun {
+		return ""
+	}
+	return v.opts.DryRunStrategy
+}
+
+func (v *dryRunValue) Set(s string) error {
+	switch s {
+	case clientDryRunStrategy, "true":
+		v.opts.DryRun, v.opts.DryRunStrategy = true, clientDryRunStrategy
+	case serverDryRunStrategy:
+		v.opts.DryRun, v.opts.DryRunStrategy = true, serverDryRunStrategy
+	case noneDryRunStrategy, "false":
+		v.opts.DryRun, v.opts.DryRunStrategy = false, ""
+	default:
+		return fmt.Errorf("must be one of %s, %s or %s", noneDryRunStrategy, clientDryRunStrategy, serverDryRunStrategy)
+	}
+	return nil
+}
+
+func (v *dryRunValue) Type() string {
+	return "strategy"
+}
+
+// DryRunWorkload prints the workload to the dry run output, rendered like the workload is printed
+// with --output, defaulting to yaml. The secret like values are not masked, as the dry run output
+// is meant to be saved and applied later, like the file written with --output-file
+func (opts *WorkloadOptions) DryRunWorkload(ctx context.Context, c *cli.Config, workload *cartov1alpha1.Workload) error {
+	if opts.DryRunStrategy == serverDryRunStrategy {
+		// the api server validates and defaults the workload without persisting it
+		if err := c.Create(ctx, workload, client.DryRunAll); err != nil {
+			return err
+		}
+	}
+	format := printer.OutputFormat(opts.Output)
+	if format == "" {
+		format = printer.OutputFormat(printer.OutputFormatYaml)
+	}
+	export, err := opts.outputResource(workload, format, c.Scheme)
+	if err == nil {
+		export, err = opts.renderYaml(export, format)
+	}
+	if err != nil {
+		c.Eprintf("%s %s\n", printer.Serrorf("Failed to output workload:"), err)
+		return cli.SilenceError(err)
+	}
+	fmt.Fprintf(cli.StdoutFromContext(ctx), "%s\n", export)
+
+	return nil
+}
+
+// outputResource formats the workload generated by the command. With --bare, the status and the
+// metadata populated by the cluster are left out, like in an exported workload.
+func (opts *WorkloadOptions) outputResource(workload *cartov1alpha1.Workload, format printer.OutputFormat, scheme *k8sruntime.Scheme) (string, error) {
+	if opts.Bare {
+		return printer.ExportResource(workload, format, scheme)
+	}
+	return printer.OutputResource(workload, format, scheme)
+}
+
+// DisplayCommandNextSteps prints the commands to follow up on the workload. The hints include
+// --namespace whenever the workload namespace differs from the kube config default, no matter if
+// it came from a flag, the TANZU_APPS_NAMESPACE env var or the workload file, so they can be
+// copied as is.
 func DisplayCommandNextSteps(c *cli.Config, workload *cartov1alpha1.Workload) {
 	if workload.Namespace != c.Client.DefaultNamespace() {
 		c.Infof("To see logs:   \"tanzu apps workload tail %s %s %s %s %s 1h\"\n", workload.Name, flags.NamespaceFlagName, workload.Namespace, flags.TimestampFlagName, flags.SinceFlagName)
@@ -268,9 +757,9 @@ func (opts *WorkloadOptions) ApplyOptionsToWorkload(ctx context.Context, current
 	for _, label := range opts.Labels {
 		parts := parsers.DeletableKeyValue(label)
 		if len(parts) == 1 {
-			delete(workload.Labels, parts[0])
+			delete(workload.Labels, strings.TrimSpace(parts[0]))
 		} else {
-			workload.MergeLabels(parts[0], parts[1])
+			workload.MergeLabels(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
 		}
 	}
 	for _, annotation := range opts.Annotations {
@@ -282,15 +771,6 @@ func (opts *WorkloadOptions) ApplyOptionsToWorkload(ctx context.Context, current
 		}
 	}
 
-	for _, p := range opts.Params {
-		kv := parsers.DeletableKeyValue(p)
-		if len(kv) == 1 {
-			workload.Spec.RemoveParam(kv[0])
-		} else {
-			workload.Spec.MergeParams(kv[0], kv[1])
-		}
-	}
-
 	var mavenSourceViaFlags bool
 	if opts.MavenArtifact != "" || opts.MavenVersion != "" || opts.MavenGroup != "" || opts.MavenType != "" {
 		mavenInfo := cartov1alpha1.MavenSource{}
@@ -310,24 +790,34 @@ func (opts *WorkloadOptions) ApplyOptionsToWorkload(ctx context.Context, current
 		workload.Spec.MergeMavenSource(mavenInfo)
 	}
 
-	for _, p := range opts.ParamsYaml {
-		kv := parsers.DeletableKeyValue(p)
+	// --param and --param-yaml are applied in the order they were given, the last value for a name wins
+	for _, p := range opts.orderedParams() {
+		kv := parsers.DeletableKeyValue(p.value)
+		name, paramType := kv[0], ""
+		if !p.yaml {
+			name, paramType = parsers.TypedParamKey(kv[0])
+		}
 		if len(kv) == 1 {
-			workload.Spec.RemoveParam(kv[0])
+			workload.Spec.RemoveParam(name)
+			continue
+		}
+		// if maven artifact was already set via flags, skip using params yaml
+		if p.yaml && name == cartov1alpha1.WorkloadMavenParam && mavenSourceViaFlags {
+			ctx = cartov1alpha1.StashWorkloadNotice(ctx, MavenOverwrittenNoticeMsg)
+			continue
+		}
+		var value interface{}
+		var err error
+		if p.yaml {
+			value, err = parsers.JsonYamlToObject(kv[1])
 		} else {
-			// if maven artifact was already set via flags, skip using params yaml
-			if kv[0] == cartov1alpha1.WorkloadMavenParam && mavenSourceViaFlags {
-				ctx = cartov1alpha1.StashWorkloadNotice(ctx, MavenOverwrittenNoticeMsg)
-				continue
-			}
-			o, err := parsers.JsonYamlToObject(kv[1])
-			if err != nil {
-				// errors should be caught during the validation phase
-				panic(err)
-			}
-
-			workload.Spec.MergeParams(kv[0], o)
+			value, err = parsers.TypedParamValue(paramType, kv[1])
+		}
+		if err != nil {
+			// errors should be caught during the validation phase
+			panic(err)
 		}
+		workload.Spec.MergeParams(name, value)
 	}
 
 	if opts.App != "" {
@@ -335,7 +825,7 @@ func (opts *WorkloadOptions) ApplyOptionsToWorkload(ctx context.Context, current
 	}
 
 	if (cli.CommandFromContext(ctx).Flags().Changed(cli.StripDash(flags.TypeFlagName)) ||
-		(!workload.IsLabelExists(apis.WorkloadTypeLabelName) && !workloadExists)) && opts.Type != "" {
+		(!workload.IsLabelExists(apis.WorkloadTypeLabelName) && !workloadExists && !opts.Bare)) && opts.Type != "" {
 		workload.MergeLabels(apis.WorkloadTypeLabelName, opts.Type)
 	}
 
@@ -355,6 +845,14 @@ func (opts *WorkloadOptions) ApplyOptionsToWorkload(ctx context.Context, current
 
 	opts.checkGitValues(ctx, workload)
 
+	if cli.CommandFromContext(ctx).Flags().Changed(cli.StripDash(flags.GitSecretFlagName)) {
+		if opts.GitSecret == "" {
+			workload.Spec.RemoveParam(cartov1alpha1.WorkloadGitSecretParam)
+		} else {
+			workload.Spec.MergeParams(cartov1alpha1.WorkloadGitSecretParam, opts.GitSecret)
+		}
+	}
+
 	if opts.isLocalSource(currentWorkload) {
 		workload.Spec.MergeSourceImage(getLocalSourceProxyTaggedImage(workload))
 	} else if opts.LocalPath != "" || opts.SourceImage != "" {
@@ -377,6 +875,7 @@ func (opts *WorkloadOptions) ApplyOptionsToWorkload(ctx context.Context, current
 	}
 
 	for _, ev := range opts.Env {
+		ev, _ = opts.expandEnvValue(ev)
 		env, delete := parsers.DeletableEnvVar(ev)
 		if delete {
 			workload.Spec.RemoveEnv(env.Name)
@@ -394,6 +893,8 @@ func (opts *WorkloadOptions) ApplyOptionsToWorkload(ctx context.Context, current
 		}
 	}
 
+	opts.applyBuildParams(workload)
+
 	for _, ref := range opts.ServiceRefs {
 		parts := parsers.DeletableKeyValue(ref)
 		serviceRefKey := parts[0]
@@ -448,6 +949,10 @@ func (opts *WorkloadOptions) ApplyOptionsToWorkload(ctx context.Context, current
 		})
 	}
 
+	if opts.Guaranteed {
+		opts.applyGuaranteedResources(workload)
+	}
+
 	if cli.CommandFromContext(ctx).Flags().Changed(cli.StripDash(flags.ServiceAccountFlagName)) {
 		workload.Spec.MergeServiceAccountName(opts.ServiceAccountName)
 	}
@@ -455,6 +960,35 @@ func (opts *WorkloadOptions) ApplyOptionsToWorkload(ctx context.Context, current
 	return ctx
 }
 
+// applyGuaranteedResources sets the same cpu and memory limits and requests on the workload, for
+// a Guaranteed QoS class, with --guaranteed. A limit given through the flags without the request
+// is copied to the request, otherwise the request is copied to the limit, or the limit to the
+// request when the workload has no request.
+func (opts *WorkloadOptions) applyGuaranteedResources(workload *cartov1alpha1.Workload) {
+	resources := workload.Spec.Resources
+	if resources == nil {
+		return
+	}
+	explicitLimits := map[corev1.ResourceName]bool{
+		corev1.ResourceCPU:    opts.LimitCPU != "" && opts.RequestCPU == "",
+		corev1.ResourceMemory: opts.LimitMemory != "" && opts.RequestMemory == "",
+	}
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		request, hasRequest := resources.Requests[name]
+		limit, hasLimit := resources.Limits[name]
+		switch {
+		case hasLimit && (explicitLimits[name] || !hasRequest):
+			workload.Spec.MergeResources(&corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{name: limit},
+			})
+		case hasRequest:
+			workload.Spec.MergeResources(&corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{name: request},
+			})
+		}
+	}
+}
+
 func (opts *WorkloadOptions) checkGitValues(ctx context.Context, workload *cartov1alpha1.Workload) {
 	isGitSource := false
 	var gitRepo, gitBranch, gitCommit, gitTag string
@@ -466,6 +1000,18 @@ func (opts *WorkloadOptions) checkGitValues(ctx context.Context, workload *carto
 		gitTag = workload.Spec.Source.Git.Ref.Tag
 	}
 
+	if opts.GitRefExclusive {
+		// the refs given through the flags replace the refs of the workload instead of being
+		// added to them, so a tag doesn't end up next to the branch it was meant to replace
+		fs := cli.CommandFromContext(ctx).Flags()
+		branchChanged := fs.Changed(cli.StripDash(flags.GitBranchFlagName))
+		commitChanged := fs.Changed(cli.StripDash(flags.GitCommitFlagName))
+		tagChanged := fs.Changed(cli.StripDash(flags.GitTagFlagName))
+		if branchChanged || commitChanged || tagChanged {
+			gitBranch, gitCommit, gitTag = "", "", ""
+		}
+	}
+
 	if cli.CommandFromContext(ctx).Flags().Changed(cli.StripDash(flags.GitRepoFlagName)) {
 		isGitSource = true
 		gitRepo = opts.GitRepo
@@ -554,7 +1100,6 @@ func (opts *WorkloadOptions) PublishLocalSource(ctx context.Context, c *cli.Conf
 	var fileExclusions []string
 	if source.IsDir(opts.LocalPath) {
 		contentDir = opts.LocalPath
-		fileExclusions = opts.loadExcludedPaths(c, shouldPrint)
 	} else if source.IsZip(opts.LocalPath) {
 		zipContentsDir, err := ioutil.TempDir("", "")
 		defer os.RemoveAll(zipContentsDir)
@@ -566,15 +1111,27 @@ func (opts *WorkloadOptions) PublishLocalSource(ctx context.Context, c *cli.Conf
 			return err
 		}
 		contentDir = zipContentsDir
-		tmpOpts := &WorkloadOptions{
-			LocalPath:       zipContentsDir,
-			ExcludePathFile: opts.ExcludePathFile,
-		}
-		fileExclusions = tmpOpts.loadExcludedPaths(c, shouldPrint)
 	} else {
 		return fmt.Errorf("unsupported file format %q", opts.LocalPath)
 	}
 
+	tmpOpts := &WorkloadOptions{
+		LocalPath:       contentDir,
+		ExcludePathFile: opts.ExcludePathFile,
+	}
+	fileExclusions = tmpOpts.loadExcludedPaths(c, shouldPrint)
+
+	// only pack the sub path tree, the sub path becomes the root of the source image
+	packSubPath := opts.PackSubPath && workload.Spec.Source != nil && workload.Spec.Source.Subpath != ""
+	if packSubPath {
+		dir, err := subPathDir(contentDir, workload.Spec.Source.Subpath)
+		if err != nil {
+			return fmt.Errorf("unable to pack %s %q: %w", flags.SubPathFlagName, workload.Spec.Source.Subpath, err)
+		}
+		contentDir = dir
+		fileExclusions = subPathExcludedPaths(fileExclusions, workload.Spec.Source.Subpath)
+	}
+
 	localTransport := &source.Wrapper{}
 	if isLocal {
 		var err error
@@ -602,7 +1159,7 @@ func (opts *WorkloadOptions) PublishLocalSource(ctx context.Context, c *cli.Conf
 
 	cli.PrintPrompt(shouldPrint, c.Infof, "Publishing source in %q to %q...\n", opts.LocalPath, taggedImage)
 
-	digestedImage, err := source.ImgpkgPush(ctx, contentDir, fileExclusions, reg, taggedImage)
+	digestedImage, _, err := source.ImgpkgPushWithCache(ctx, contentDir, fileExclusions, reg, taggedImage, opts.pushCache())
 	if err != nil {
 		return err
 	}
@@ -619,6 +1176,9 @@ func (opts *WorkloadOptions) PublishLocalSource(ctx context.Context, c *cli.Conf
 	}
 
 	workload.Spec.Source.Image = digestedImage
+	if packSubPath {
+		workload.Spec.Source.Subpath = ""
+	}
 
 	if currentWorkload != nil && currentWorkload.Spec.Source != nil && currentWorkload.Spec.Source.Image == workload.Spec.Source.Image {
 		cli.PrintPrompt(shouldPrint, c.Infof, "No source code is changed\n\n")
@@ -628,10 +1188,54 @@ func (opts *WorkloadOptions) PublishLocalSource(ctx context.Context, c *cli.Conf
 	return nil
 }
 
+// pushCache returns the cache of the sources pushed last, so an unchanged --local-path is not
+// packaged and pushed again, unless --no-cache is set
+func (opts *WorkloadOptions) pushCache() *source.PushCache {
+	if opts.NoCache {
+		return nil
+	}
+	path, err := source.DefaultPushCachePath()
+	if err != nil {
+		return nil
+	}
+	return &source.PushCache{Path: path}
+}
+
+// subPathDir returns the directory of the sub path within root, the sub path must be a
+// relative path to an existing directory that does not leave root
+func subPathDir(root, subPath string) (string, error) {
+	rel := filepath.Clean(filepath.FromSlash(subPath))
+	if filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path must be relative to %q", root)
+	}
+	dir := filepath.Join(root, rel)
+	if !source.IsDir(dir) {
+		return "", fmt.Errorf("directory not found in %q", root)
+	}
+	return dir, nil
+}
+
+// subPathExcludedPaths makes the paths excluded from the local path relative to its sub path,
+// leaving out the ones outside of the sub path
+func subPathExcludedPaths(exclude []string, subPath string) []string {
+	rebased := []string{}
+	for _, p := range exclude {
+		rel, err := filepath.Rel(filepath.FromSlash(subPath), filepath.FromSlash(p))
+		if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		rebased = append(rebased, rel)
+	}
+	return rebased
+}
+
 func (opts *WorkloadOptions) loadExcludedPaths(c *cli.Config, displayInfo bool) []string {
 	exclude := []string{}
 	if opts.ExcludePathFile != "" {
-		p := filepath.Join(opts.LocalPath, opts.ExcludePathFile)
+		p := opts.ExcludePathFile
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(opts.LocalPath, p)
+		}
 		if _, err := os.Stat(p); errors.Is(err, os.ErrNotExist) {
 			return exclude
 		}
@@ -695,45 +1299,210 @@ func loadNamespace(ctx context.Context, c *cli.Config, name string) (*corev1.Nam
 	return ns, nil
 }
 
-func validateNamespace(ctx context.Context, c *cli.Config, name string) error {
-	if _, nsErr := loadNamespace(ctx, c, name); nsErr != nil {
-		c.Eprintf("%s %s\n", printer.Serrorf("Error:"), fmt.Sprintf("namespace %q not found, it may not exist or user does not have permissions to read it.", name))
-		return cli.SilenceError(nsErr)
+// errNamespaceNotFound is wrapped in the error of getWorkloadValidatingNamespace when the
+// namespace of the workload is not found
+var errNamespaceNotFound = errors.New("namespace not found")
+
+// getWorkloadValidatingNamespace gets the workload and, when it's not found, checks that its
+// namespace exists. A missing namespace is printed and takes precedence over the not found error
+// of the workload, the returned error is silenced and wraps errNamespaceNotFound. The namespace is
+// only read when the workload is not found, so the user doesn't need to be allowed to read it to
+// update a workload.
+func getWorkloadValidatingNamespace(ctx context.Context, c *cli.Config, retry cli.RetryOptions, key client.ObjectKey, workload *cartov1alpha1.Workload, validateNamespace bool) error {
+	err := cli.Retry(ctx, c.GetClock(), retryLogger(c), retry, func() error {
+		return c.Get(ctx, key, workload)
+	})
+	if !validateNamespace || !apierrs.IsNotFound(err) {
+		return err
+	}
+	if _, nsErr := loadNamespace(ctx, c, key.Namespace); nsErr != nil {
+		c.Eprintf("%s %s\n", printer.Serrorf("Error:"), fmt.Sprintf("namespace %q not found, it may not exist or user does not have permissions to read it.", key.Namespace))
+		return cli.SilenceError(fmt.Errorf("%w: %w", errNamespaceNotFound, nsErr))
+	}
+	return err
+}
+
+func (opts *WorkloadOptions) resourceDiff(left, right *cartov1alpha1.Workload, scheme *k8sruntime.Scheme) (string, bool, error) {
+	if left != nil && right != nil {
+		// the env vars are compared by name, reordering them is not a change. The workload keeps
+		// its order when applied.
+		right = right.DeepCopy()
+		right.Spec.KeepEnvOrder(&left.Spec)
+	}
+	render := func(transforms ...printer.YamlTransform) (string, bool, error) {
+		if opts.DiffFormat == unifiedDiffFormat {
+			return printer.ResourceUnifiedDiff(left, right, scheme, transforms...)
+		}
+		return printer.ResourceDiffWithOptions(left, right, scheme, printer.DiffOptions{WordDiff: opts.WordDiff, CollapseUnchanged: opts.CollapseUnchanged}, transforms...)
+	}
+	// the long values are cut before the other transforms render them, the applied workload keeps
+	// them in full
+	diff, noChange, err := render(append([]printer.YamlTransform{printer.TruncateLongValues(opts.MaxValueWidth)}, opts.yamlTransforms()...)...)
+	if err != nil || !noChange || opts.MaxValueWidth == 0 {
+		return diff, noChange, err
+	}
+	// a change past the cut-off renders the same on both sides, whether anything changed is only
+	// known from the values in full, which are then shown
+	return render(opts.yamlTransforms()...)
+}
+
+// yamlTransforms returns the rendering options of the workload yaml shown to the user
+func (opts *WorkloadOptions) yamlTransforms() []printer.YamlTransform {
+	transforms := []printer.YamlTransform{printer.MultilineEnvValues}
+	if opts.YamlFlowParams {
+		transforms = append(transforms, printer.FlowStyleParams)
+	}
+	return transforms
+}
+
+// renderYaml applies the rendering options to the workload exported in the format, only yaml is
+// affected
+func (opts *WorkloadOptions) renderYaml(export string, format printer.OutputFormat) (string, error) {
+	if format != printer.OutputFormat(printer.OutputFormatYaml) && format != printer.OutputFormat(printer.OutputFormatYml) {
+		return export, nil
+	}
+	transforms := opts.yamlTransforms()
+	if opts.AnnotateForOverlay {
+		// the annotations are only added to the output, not to the workload changes
+		transforms = append(transforms, printer.YttOverlayAnnotations)
+	}
+	var err error
+	for _, transform := range transforms {
+		if export, err = transform(export); err != nil {
+			return "", err
+		}
+	}
+	return export, nil
+}
+
+// writeDiffFile saves the diff shown in the terminal, without colors, to the --diff-file path
+func (opts *WorkloadOptions) writeDiffFile(diff string) error {
+	if opts.DiffFile == "" {
+		return nil
+	}
+	if err := os.WriteFile(opts.DiffFile, []byte(stripansi.Strip(diff)), 0644); err != nil {
+		return fmt.Errorf("unable to write diff file %q: %w", opts.DiffFile, err)
 	}
 	return nil
 }
 
+// informationalWarningIDs are the warnings about the CLI rather than the workload, like the
+// update strategy change printed for every file, they don't fail --warnings-as-errors
+var informationalWarningIDs = sets.NewString(
+	UpdateStrategyWarningID,
+)
+
+// warn prints a warning message and counts it for --warnings-as-errors, unless the warning id
+// is suppressed
+func (opts *WorkloadOptions) warn(c *cli.Config, id, msg string) {
+	if opts.isWarningSuppressed(id) {
+		return
+	}
+	if !informationalWarningIDs.Has(id) {
+		opts.warnings++
+	}
+	opts.warningMessages = append(opts.warningMessages, msg)
+	c.Emoji(cli.Exclamation, cliprinter.Sinfof("WARNING: %s\n", msg))
+}
+
+func (opts *WorkloadOptions) isWarningSuppressed(id string) bool {
+	for _, suppressed := range opts.SuppressWarnings {
+		if strings.TrimSpace(suppressed) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// logUnknownSuppressedWarnings notes, at debug verbosity, the suppressed ids that don't match
+// any warning. They are otherwise ignored so older ids don't break scripts.
+func (opts *WorkloadOptions) logUnknownSuppressedWarnings(c *cli.Config) {
+	if c.Verbose == nil {
+		return
+	}
+	log := logger.NewSinkLogger(c.Name, c.Verbose, c.Stderr)
+	known := sets.NewString(warningIDs...)
+	for _, id := range opts.SuppressWarnings {
+		id = strings.TrimSpace(id)
+		if id != "" && !known.Has(id) {
+			log.V(2).Info("ignoring unknown warning id", "id", id, "flag", flags.SuppressWarningsFlagName)
+		}
+	}
+}
+
+// retryLogger logs the retries of the failed api requests under --verbose
+func retryLogger(c *cli.Config) logr.Logger {
+	if c.Verbose == nil {
+		return logr.Discard()
+	}
+	return logger.NewSinkLogger(c.Name, c.Verbose, c.Stderr)
+}
+
+// failOnWarnings returns an error when --warnings-as-errors is set and a warning was printed.
+// Notices are not warnings and never fail the command.
+func (opts *WorkloadOptions) failOnWarnings(c *cli.Config) error {
+	if !opts.WarningsAsErrors || opts.warnings == 0 {
+		return nil
+	}
+	c.Printf("%s %d warning(s) treated as errors (%s), the workload was not applied\n", printer.Serrorf("Error:"), opts.warnings, flags.WarningsAsErrorsFlagName)
+	return cli.SilenceError(fmt.Errorf("%d warning(s) treated as errors", opts.warnings))
+}
+
 func (opts *WorkloadOptions) Update(ctx context.Context, c *cli.Config, currentWorkload *cartov1alpha1.Workload, workload *cartov1alpha1.Workload) (bool, error) {
 	okToUpdate := false
 
-	if msgs := workload.DeprecationWarnings(); len(msgs) != 0 {
-		for _, msg := range msgs {
-			c.Emoji(cli.Exclamation, cliprinter.Sinfof("WARNING: %s\n", msg))
-		}
+	for _, warning := range workload.DeprecationWarnings() {
+		opts.warn(c, warning.ID, warning.Message)
 	}
 
-	difference, noChange, err := printer.ResourceDiff(currentWorkload, workload, c.Scheme)
+	difference, noChange, err := opts.resourceDiff(currentWorkload, workload, c.Scheme)
 	if err != nil {
 		return okToUpdate, err
 	}
 
 	if noChange {
+		if opts.AlwaysShow {
+			current, err := printer.ExportResource(currentWorkload, printer.OutputFormat(printer.OutputFormatYaml), c.Scheme)
+			if err != nil {
+				return okToUpdate, err
+			}
+			c.Emoji(cli.Magnifying, "Current workload:\n")
+			c.Printf("%s\n", cliprinter.Sfaintf("%s", current))
+		}
 		c.Infof("Workload is unchanged, skipping update\n")
-		return okToUpdate, nil
+		return okToUpdate, opts.failOnWarnings(c)
 	}
 	c.Emoji(cli.Magnifying, "Update workload:\n")
 	c.Printf("%s", difference)
+	if err := opts.writeDiffFile(difference); err != nil {
+		return okToUpdate, err
+	}
 
 	if noticeMsgs := workload.GetNotices(ctx); len(noticeMsgs) != 0 {
 		for _, msg := range noticeMsgs {
 			c.Emoji(cli.Exclamation, cliprinter.Sinfof("NOTICE: %s\n", msg))
 		}
 	}
+	if err := opts.failOnWarnings(c); err != nil {
+		return false, err
+	}
 
 	if !opts.Yes {
 		if opts.FilePath == "-" {
 			c.Errorf("Skipping workload, cannot confirm intent. Run command with %s flag to confirm intent when providing input from stdin\n", flags.YesFlagName)
 			return okToUpdate, nil
+		} else if len(opts.prunePlan) != 0 {
+			// the pruned entries are easy to miss in the changes, they are listed and confirmed apart
+			c.Emoji(cli.Exclamation, "The update prunes the entries not set through the file or flags:\n")
+			for _, entry := range opts.prunePlan {
+				c.Printf("  - %s\n", entry)
+			}
+			c.Printf("\n")
+			err := cli.NewConfirmSurvey(c, "Really update the workload %q and prune %d entries?", workload.Name, len(opts.prunePlan)).Resolve(&okToUpdate)
+			if err != nil || !okToUpdate {
+				c.Infof("Skipping workload %q\n", workload.Name)
+				return okToUpdate, nil
+			}
 		} else {
 			err := cli.NewConfirmSurvey(c, "Really update the workload %q?", workload.Name).Resolve(&okToUpdate)
 			if err != nil || !okToUpdate {
@@ -745,7 +1514,9 @@ func (opts *WorkloadOptions) Update(ctx context.Context, c *cli.Config, currentW
 		okToUpdate = opts.Yes
 	}
 
-	if err := c.Update(ctx, workload); err != nil {
+	if err := cli.RetryWrite(ctx, c.GetClock(), retryLogger(c), opts.Retry, func() error {
+		return c.Update(ctx, workload)
+	}); err != nil {
 		okToUpdate = false
 		if apierrs.IsConflict(err) {
 			c.Printf("%s conflict updating workload, the object was modified by another user; please run the update command again\n", printer.Serrorf("Error:"))
@@ -761,25 +1532,29 @@ func (opts *WorkloadOptions) Update(ctx context.Context, c *cli.Config, currentW
 func (opts *WorkloadOptions) Create(ctx context.Context, c *cli.Config, workload *cartov1alpha1.Workload) (bool, error) {
 	okToCreate := false
 
-	if msgs := workload.DeprecationWarnings(); len(msgs) != 0 {
-		for _, msg := range msgs {
-			c.Emoji(cli.Exclamation, cliprinter.Sinfof("WARNING: %s\n", msg))
-		}
+	for _, warning := range workload.DeprecationWarnings() {
+		opts.warn(c, warning.ID, warning.Message)
 	}
 
-	diff, _, err := printer.ResourceDiff(nil, workload, c.Scheme)
+	diff, _, err := opts.resourceDiff(nil, workload, c.Scheme)
 	if err != nil {
 		return okToCreate, err
 	}
 
 	c.Emoji(cli.Magnifying, "Create workload:\n")
 	c.Printf("%s", diff)
+	if err := opts.writeDiffFile(diff); err != nil {
+		return okToCreate, err
+	}
 
 	if noticeMsgs := workload.GetNotices(ctx); len(noticeMsgs) != 0 {
 		for _, msg := range noticeMsgs {
 			c.Emoji(cli.Exclamation, cliprinter.Sinfof("NOTICE: %s\n", msg))
 		}
 	}
+	if err := opts.failOnWarnings(c); err != nil {
+		return false, err
+	}
 	if !opts.Yes {
 		if opts.FilePath == "-" {
 			c.Errorf("Skipping workload, cannot confirm intent. Run command with %s flag to confirm intent when providing input from stdin\n", flags.YesFlagName)
@@ -795,7 +1570,9 @@ func (opts *WorkloadOptions) Create(ctx context.Context, c *cli.Config, workload
 		okToCreate = opts.Yes
 	}
 
-	if err := c.Create(ctx, workload); err != nil {
+	if err := cli.RetryWrite(ctx, c.GetClock(), retryLogger(c), opts.Retry, func() error {
+		return c.Create(ctx, workload)
+	}); err != nil {
 		return okToCreate, err
 	}
 
@@ -803,7 +1580,7 @@ func (opts *WorkloadOptions) Create(ctx context.Context, c *cli.Config, workload
 	return okToCreate, nil
 }
 
-func (opts *WorkloadOptions) LoadInputWorkload(input io.Reader, workload *cartov1alpha1.Workload) error {
+func (opts *WorkloadOptions) LoadInputWorkload(ctx context.Context, c *cli.Config, workload *cartov1alpha1.Workload) error {
 	var in io.Reader
 
 	isURL, err := isUrl(opts.FilePath)
@@ -811,28 +1588,100 @@ func (opts *WorkloadOptions) LoadInputWorkload(input io.Reader, workload *cartov
 		return fmt.Errorf("unable to check if filepath %q is a valid url: %w", opts.FilePath, err)
 	}
 
-	if isURL {
+	if isConfigMapRef(opts.FilePath) {
+		in, err = opts.getConfigMapFileContent(ctx, c)
+		if err != nil {
+			return fmt.Errorf("unable to read from configmap %q: %w", opts.FilePath, err)
+		}
+	} else if isURL {
+		if err := opts.checkAllowedHost(opts.FilePath); err != nil {
+			return fmt.Errorf("unable to read from url %q: %w", opts.FilePath, err)
+		}
 		in, err = opts.getUrlFileContent()
 		if err != nil {
 			return fmt.Errorf("unable to read from url %q: %w", opts.FilePath, err)
 		}
 	} else if opts.FilePath == "-" {
-		in = input
+		in = c.Stdin
 	} else {
 		f, err := os.Open(opts.FilePath)
 		if err != nil {
 			return fmt.Errorf("unable to open file %q: %w", opts.FilePath, err)
 		}
-		in = f
 		defer f.Close()
+		if info, err := f.Stat(); err == nil && info.IsDir() {
+			return fmt.Errorf("unable to load file %q: expected a file, got a directory; did you mean %s?", opts.FilePath, flags.LocalPathFlagName)
+		}
+		in = f
 	}
 
-	if err := workload.Load(in); err != nil {
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return fmt.Errorf("unable to read file %q: %w", opts.FilePath, err)
+	}
+	format, err := opts.inputFormat(b)
+	if err != nil {
+		return fmt.Errorf("unable to load file %q: %w", opts.FilePath, err)
+	}
+	if format == jsonInputFormat {
+		// the yaml decoder would report a JSON syntax error as a confusing yaml one
+		if err := json.Unmarshal(b, &json.RawMessage{}); err != nil {
+			return fmt.Errorf("unable to load file %q: invalid JSON: %w", opts.FilePath, err)
+		}
+	}
+	if err := workload.Load(bytes.NewReader(b)); err != nil {
 		return fmt.Errorf("unable to load file %q: %w", opts.FilePath, err)
 	}
 	return nil
 }
 
+const (
+	autoInputFormat = "auto"
+	yamlInputFormat = "yaml"
+	jsonInputFormat = "json"
+)
+
+// inputFormat detects the format of the workload file from its first non whitespace character, a
+// JSON object starts with "{". A format set through --input-format must match the detected one.
+func (opts *WorkloadOptions) inputFormat(b []byte) (string, error) {
+	detected := yamlInputFormat
+	if content := bytes.TrimLeft(b, " \t\r\n"); len(content) > 0 && content[0] == '{' {
+		detected = jsonInputFormat
+	}
+	if opts.InputFormat != "" && opts.InputFormat != autoInputFormat && opts.InputFormat != detected {
+		return "", fmt.Errorf("expected %s content (%s %s), the content is %s", strings.ToUpper(opts.InputFormat), flags.InputFormatFlagName, opts.InputFormat, strings.ToUpper(detected))
+	}
+	return detected, nil
+}
+
+const (
+	noExpandEnv         = "false"
+	strictExpandEnv     = "true"
+	allowEmptyExpandEnv = "allow-empty"
+)
+
+// expandEnvValue replaces the $VAR and ${VAR} references in the value of an --env flag with the
+// variables of the CLI environment, when --expand-env is set. The names of the referenced
+// variables that are not defined are returned, they expand to an empty value.
+func (opts *WorkloadOptions) expandEnvValue(ev string) (string, []string) {
+	if opts.ExpandEnv == "" || opts.ExpandEnv == noExpandEnv {
+		return ev, nil
+	}
+	name, value, found := strings.Cut(ev, "=")
+	if !found {
+		return ev, nil
+	}
+	undefined := []string{}
+	value = os.Expand(value, func(key string) string {
+		v, ok := os.LookupEnv(key)
+		if !ok {
+			undefined = append(undefined, key)
+		}
+		return v
+	})
+	return fmt.Sprintf("%s=%s", name, value), undefined
+}
+
 func (opts *WorkloadOptions) getUrlFileContent() (io.Reader, error) {
 	resp, err := http.Get(opts.FilePath)
 	if err != nil {
@@ -850,6 +1699,263 @@ func (opts *WorkloadOptions) getUrlFileContent() (io.Reader, error) {
 	return r, err
 }
 
+func (opts *WorkloadOptions) getConfigMapFileContent(ctx context.Context, c *cli.Config) (io.Reader, error) {
+	// the reference format is checked by validateParsedValues, even with the client validation disabled
+	parts := strings.Split(strings.TrimPrefix(opts.FilePath, ConfigMapFilePathPrefix), "/")
+	namespace, name, key := parts[0], parts[1], parts[2]
+
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, cm); err != nil {
+		return nil, err
+	}
+	data, ok := cm.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found in configmap %q", key, fmt.Sprintf("%s/%s", namespace, name))
+	}
+
+	return strings.NewReader(data), nil
+}
+
+func isConfigMapRef(str string) bool {
+	return strings.HasPrefix(str, ConfigMapFilePathPrefix)
+}
+
+// resolveContextDir joins the relative paths of the path flags with --context-dir, so the files are
+// found no matter the directory the command is run from. Absolute paths, urls, ConfigMap references
+// and stdin are kept as is.
+func (opts *WorkloadOptions) resolveContextDir() {
+	if opts.ContextDir == "" {
+		return
+	}
+	resolve := func(path string) string {
+		if path == "" || path == "-" || filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(opts.ContextDir, path)
+	}
+
+	if isURL, _ := isUrl(opts.FilePath); !isURL && !isConfigMapRef(opts.FilePath) {
+		opts.FilePath = resolve(opts.FilePath)
+	}
+	// a relative ignore file is read from the local path, so resolving the local path rebases it
+	// as well, see loadExcludedPaths
+	opts.LocalPath = resolve(opts.LocalPath)
+	opts.DiffFile = resolve(opts.DiffFile)
+	for i := range opts.CACertPaths {
+		opts.CACertPaths[i] = resolve(opts.CACertPaths[i])
+	}
+}
+
+// expandSourceImage completes a --source-image ending with "/" with the workload name, like
+// my-registry/<workload-name>-source, so the same registry prefix can be shared by many workloads.
+// Full references are kept as they are.
+func (opts *WorkloadOptions) expandSourceImage(workloadName string) error {
+	if !strings.HasSuffix(opts.SourceImage, "/") {
+		return nil
+	}
+	image := fmt.Sprintf("%s%s-source", opts.SourceImage, workloadName)
+	if _, err := name.ParseReference(image, name.WeakValidation); err != nil {
+		return validation.ErrInvalidValueWithDetail(opts.SourceImage, flags.SourceImageFlagName, fmt.Sprintf("%q is not a valid image reference: %v", image, err)).ToAggregate()
+	}
+	opts.SourceImage = image
+	return nil
+}
+
+// gitHostShorthands maps the --git-repo shorthand prefixes to the base url of the git host, more
+// prefixes can be added in ~/.config/tanzu/apps/git-hosts.yaml
+var gitHostShorthands = map[string]string{
+	"gh": "https://github.com/",
+	"gl": "https://gitlab.com/",
+	"bb": "https://bitbucket.org/",
+}
+
+// gitRepoPathPattern matches the org/repo part of a --git-repo shorthand
+var gitRepoPathPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+(/[A-Za-z0-9_.-]+)+$`)
+
+// gitHostsFilePath is the file the additional --git-repo shorthand prefixes are read from
+func gitHostsFilePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "tanzu", "apps", "git-hosts.yaml"), nil
+}
+
+// loadGitHostShorthands returns the built-in shorthand prefixes merged with the ones defined in the
+// git hosts file, which take precedence. A missing file is not an error.
+func loadGitHostShorthands() (map[string]string, error) {
+	hosts := map[string]string{}
+	for prefix, url := range gitHostShorthands {
+		hosts[prefix] = url
+	}
+
+	path, err := gitHostsFilePath()
+	if err != nil {
+		return hosts, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return hosts, nil
+		}
+		return nil, fmt.Errorf("unable to read git hosts from %s: %w", path, err)
+	}
+	custom := map[string]string{}
+	if err := yaml.Unmarshal(b, &custom); err != nil {
+		return nil, fmt.Errorf("unable to parse git hosts from %s: %w", path, err)
+	}
+	for prefix, url := range custom {
+		if !strings.HasSuffix(url, "/") {
+			url = url + "/"
+		}
+		hosts[prefix] = url
+	}
+	return hosts, nil
+}
+
+// expandGitRepo expands a --git-repo shorthand, like gh:org/repo, into the https url of the
+// repository, like https://github.com/org/repo.git. Values that don't start with a known prefix,
+// like full urls, are kept as they are.
+func (opts *WorkloadOptions) expandGitRepo() error {
+	prefix, path, found := strings.Cut(opts.GitRepo, ":")
+	if !found || strings.HasPrefix(path, "//") {
+		return nil
+	}
+	hosts, err := loadGitHostShorthands()
+	if err != nil {
+		return err
+	}
+	baseURL, ok := hosts[prefix]
+	if !ok {
+		return nil
+	}
+	path = strings.TrimSuffix(path, ".git")
+	if !gitRepoPathPattern.MatchString(path) {
+		return validation.ErrInvalidValueWithDetail(opts.GitRepo, flags.GitRepoFlagName, fmt.Sprintf("expected %s:<org>/<repo>", prefix)).ToAggregate()
+	}
+	opts.GitRepo = fmt.Sprintf("%s%s.git", baseURL, path)
+	return nil
+}
+
+// profilesFilePath is the file the presets of --profile are read from
+func profilesFilePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "tanzu", "apps", "profiles.yaml"), nil
+}
+
+// orderedParamsValue is a string array flag that also records its name in a list shared by the
+// --param and --param-yaml flags, so their values can be applied in the order they were given
+type orderedParamsValue struct {
+	values *[]string
+	flag   string
+	order  *[]string
+}
+
+func (v *orderedParamsValue) Set(value string) error {
+	*v.values = append(*v.values, value)
+	*v.order = append(*v.order, v.flag)
+	return nil
+}
+
+func (v *orderedParamsValue) Type() string {
+	return "stringArray"
+}
+
+func (v *orderedParamsValue) String() string {
+	if v.values == nil || len(*v.values) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(*v.values, ",") + "]"
+}
+
+type paramArg struct {
+	value string
+	yaml  bool
+}
+
+// orderedParams returns the --param and --param-yaml values in the order they were given. When
+// the options were not set from the command line, the --param values come first.
+func (opts *WorkloadOptions) orderedParams() []paramArg {
+	args := make([]paramArg, 0, len(opts.Params)+len(opts.ParamsYaml))
+	if len(opts.paramFlags) != len(opts.Params)+len(opts.ParamsYaml) {
+		for _, p := range opts.Params {
+			args = append(args, paramArg{value: p})
+		}
+		for _, p := range opts.ParamsYaml {
+			args = append(args, paramArg{value: p, yaml: true})
+		}
+		return args
+	}
+	var i, j int
+	for _, f := range opts.paramFlags {
+		if f == flags.ParamYamlFlagName {
+			args = append(args, paramArg{value: opts.ParamsYaml[j], yaml: true})
+			j++
+		} else {
+			args = append(args, paramArg{value: opts.Params[i]})
+			i++
+		}
+	}
+	return args
+}
+
+// applyProfile sets the flags of the --profile preset. Each profile maps flag names, without the
+// leading dashes, to a value or a list of values. Flags set explicitly in the command line are
+// not overridden by the profile.
+func (opts *WorkloadOptions) applyProfile(cmd *cobra.Command) error {
+	if opts.Profile == "" {
+		return nil
+	}
+
+	path, err := profilesFilePath()
+	if err != nil {
+		return fmt.Errorf("unable to find the profiles file: %w", err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return validation.ErrInvalidValueWithDetail(opts.Profile, flags.ProfileFlagName, fmt.Sprintf("unable to read profiles from %s", path)).ToAggregate()
+	}
+	profiles := map[string]map[string]interface{}{}
+	if err := yaml.Unmarshal(b, &profiles); err != nil {
+		return fmt.Errorf("unable to parse profiles from %s: %w", path, err)
+	}
+	profile, ok := profiles[opts.Profile]
+	if !ok {
+		return validation.ErrInvalidValueWithDetail(opts.Profile, flags.ProfileFlagName, fmt.Sprintf("profile not found in %s", path)).ToAggregate()
+	}
+
+	keys := make([]string, 0, len(profile))
+	for k := range profile {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	errs := validation.FieldErrors{}
+	for _, key := range keys {
+		f := cmd.Flags().Lookup(key)
+		if f == nil || key == cli.StripDash(flags.ProfileFlagName) {
+			errs = errs.Also(validation.ErrInvalidValueWithDetail(key, flags.ProfileFlagName, fmt.Sprintf("profile %q sets an unknown flag", opts.Profile)))
+			continue
+		}
+		if f.Changed {
+			continue
+		}
+		values, ok := profile[key].([]interface{})
+		if !ok {
+			values = []interface{}{profile[key]}
+		}
+		for _, v := range values {
+			if err := cmd.Flags().Set(key, fmt.Sprintf("%v", v)); err != nil {
+				errs = errs.Also(validation.ErrInvalidValueWithDetail(v, flags.ProfileFlagName, fmt.Sprintf("profile %q sets an invalid value for %q: %v", opts.Profile, key, err)))
+			}
+		}
+	}
+	return errs.ToAggregate()
+}
+
 func isUrl(str string) (bool, error) {
 	if u, err := url.Parse(str); err != nil {
 		return false, err
@@ -861,6 +1967,130 @@ func isUrl(str string) (bool, error) {
 	}
 }
 
+// checkAllowedHost returns an error when allowed hosts are set and the host of the given url is
+// not part of them. Both URLs and scp-like git addresses (e.g. git@github.com:org/repo.git) are supported
+func (opts *WorkloadOptions) checkAllowedHost(rawURL string) error {
+	if len(opts.AllowedHosts) == 0 {
+		return nil
+	}
+
+	host := urlHost(rawURL)
+	for _, allowed := range opts.AllowedHosts {
+		if strings.EqualFold(strings.TrimSpace(allowed), host) {
+			return nil
+		}
+	}
+	return fmt.Errorf("host %q is not in the allowed hosts (%s)", host, strings.Join(opts.AllowedHosts, ", "))
+}
+
+// checkAllowedGitHost validates the git repository host of the workload when it differs from the one
+// in the cluster, so existing workloads can still be updated after the allowed hosts change
+func (opts *WorkloadOptions) checkAllowedGitHost(currentWorkload, workload *cartov1alpha1.Workload) error {
+	if workload.Spec.Source == nil || workload.Spec.Source.Git == nil || workload.Spec.Source.Git.URL == "" {
+		return nil
+	}
+	gitRepo := workload.Spec.Source.Git.URL
+	if currentWorkload != nil && currentWorkload.Spec.Source != nil && currentWorkload.Spec.Source.Git != nil && currentWorkload.Spec.Source.Git.URL == gitRepo {
+		return nil
+	}
+	if err := opts.checkAllowedHost(gitRepo); err != nil {
+		return fmt.Errorf("unable to use git repository %q: %w", gitRepo, err)
+	}
+	return nil
+}
+
+// ApplyEnvFromConfigMaps adds an env var to the workload for each key in the ConfigMaps set with
+// --env-from-configmap. The env vars reference the ConfigMap keys, so the values are read when the
+// workload runs. Env vars set with --env take precedence over the ConfigMap keys.
+func (opts *WorkloadOptions) ApplyEnvFromConfigMaps(ctx context.Context, c *cli.Config, workload *cartov1alpha1.Workload) error {
+	if len(opts.EnvConfigMaps) == 0 {
+		return nil
+	}
+
+	explicitEnv := map[string]bool{}
+	for _, ev := range opts.Env {
+		explicitEnv[parsers.DeletableKeyValue(ev)[0]] = true
+	}
+
+	for _, name := range opts.EnvConfigMaps {
+		configMap := &corev1.ConfigMap{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: workload.Namespace, Name: name}, configMap); err != nil {
+			if apierrs.IsNotFound(err) {
+				return fmt.Errorf("configmap %q not found in namespace %q", name, workload.Namespace)
+			}
+			return err
+		}
+
+		keys := make([]string, 0, len(configMap.Data))
+		for key := range configMap.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if explicitEnv[key] {
+				continue
+			}
+			workload.Spec.MergeEnv(corev1.EnvVar{
+				Name: key,
+				ValueFrom: &corev1.EnvVarSource{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: name},
+						Key:                  key,
+					},
+				},
+			})
+		}
+	}
+	return nil
+}
+
+// PinImageDigest resolves the tag of the workload image to a digest using the registry options
+// provided through flags, and sets the image by digest so it does not move with the tag
+func (opts *WorkloadOptions) PinImageDigest(ctx context.Context, workload *cartov1alpha1.Workload) error {
+	if !opts.PinImage || workload.Spec.Image == "" {
+		return nil
+	}
+
+	image := workload.Spec.Image
+	ref, err := name.ParseReference(image, name.WeakValidation)
+	if err != nil {
+		return fmt.Errorf("unable to parse image %q: %w", image, err)
+	}
+	tag, ok := ref.(name.Tag)
+	if !ok {
+		// already pinned to a digest
+		return nil
+	}
+
+	reg, err := source.NewRegistry(ctx, &source.RegistryOpts{CACertPaths: opts.CACertPaths, RegistryUsername: opts.RegistryUsername, RegistryPassword: opts.RegistryPassword, RegistryToken: opts.RegistryToken})
+	if err != nil {
+		return err
+	}
+	digest, err := reg.Digest(ref)
+	if err != nil {
+		return fmt.Errorf("unable to resolve digest for image %q: %w", image, err)
+	}
+
+	workload.Spec.Image = fmt.Sprintf("%s@%s", strings.TrimSuffix(image, ":"+tag.TagStr()), digest)
+	return nil
+}
+
+func urlHost(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		return u.Hostname()
+	}
+	// scp-like syntax, [user@]host:path
+	host := rawURL
+	if i := strings.Index(host, "@"); i >= 0 {
+		host = host[i+1:]
+	}
+	if i := strings.Index(host, ":"); i >= 0 {
+		host = host[:i]
+	}
+	return host
+}
+
 func raceWithTimeout(ctx context.Context, c *cli.Config, workload *cartov1alpha1.Workload, timeout time.Duration, shouldPrint bool, errMsg string, workers []wait.Worker) error {
 	err := wait.Race(ctx, timeout, workers)
 	// print wait error only if output is not set or it was not used with --yes
@@ -874,14 +2104,14 @@ func raceWithTimeout(ctx context.Context, c *cli.Config, workload *cartov1alpha1
 	return err
 }
 
-func getStatusChangeWorker(c *cli.Config, workload *cartov1alpha1.Workload) wait.Worker {
+func getStatusChangeWorker(c *cli.Config, workload *cartov1alpha1.Workload, interval time.Duration) wait.Worker {
 	worker := wait.Worker(func(ctx context.Context) error {
 		previousReadyCond := printer.FindCondition(workload.Status.Conditions, cartov1alpha1.WorkloadConditionReady)
 		clientWithWatch, err := watch.GetWatcher(ctx, c)
 		if err != nil {
 			return err
 		}
-		return wait.UntilCondition(ctx, clientWithWatch, types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}, &cartov1alpha1.WorkloadList{}, func(target client.Object) (bool, error) {
+		return wait.UntilConditionEvery(ctx, c.GetClock(), clientWithWatch, types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}, &cartov1alpha1.WorkloadList{}, interval, func(target client.Object) (bool, error) {
 			obj, ok := target.(*cartov1alpha1.Workload)
 			if !ok {
 				return false, nil
@@ -901,13 +2131,13 @@ func getStatusChangeWorker(c *cli.Config, workload *cartov1alpha1.Workload) wait
 	return worker
 }
 
-func getReadyConditionWorker(c *cli.Config, workload *cartov1alpha1.Workload) wait.Worker {
+func getReadyConditionWorker(c *cli.Config, workload *cartov1alpha1.Workload, interval time.Duration, failOnUnknownReasons []string) wait.Worker {
 	worker := wait.Worker(func(ctx context.Context) error {
 		clientWithWatch, err := watch.GetWatcher(ctx, c)
 		if err != nil {
 			return err
 		}
-		return wait.UntilCondition(ctx, clientWithWatch, types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}, &cartov1alpha1.WorkloadList{}, cartov1alpha1.WorkloadReadyConditionFunc)
+		return wait.UntilConditionEvery(ctx, c.GetClock(), clientWithWatch, types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}, &cartov1alpha1.WorkloadList{}, interval, cartov1alpha1.WorkloadReadyConditionFuncFailingOnUnknown(failOnUnknownReasons))
 	})
 
 	return worker
@@ -920,12 +2150,116 @@ func getTailWorker(c *cli.Config, workload *cartov1alpha1.Workload, tailTimestam
 			return err
 		}
 		containers := []string{}
-		return logs.Tail(ctx, c, workload.Namespace, selector, containers, time.Minute, tailTimestamps)
+
+		// stop forwarding logs as soon as the tail is done, so no log line is printed
+		// after the messages that follow the wait (e.g. the workload is ready)
+		stdout, stderr := logs.NewStoppableWriter(c.Stdout), logs.NewStoppableWriter(c.Stderr)
+		defer stdout.Stop()
+		defer stderr.Stop()
+		tailConfig := *c
+		tailConfig.Stdout = stdout
+		tailConfig.Stderr = stderr
+
+		return logs.Tail(ctx, &tailConfig, workload.Namespace, selector, logs.TailOptions{
+			Containers: containers,
+			Since:      time.Minute,
+			TailLines:  -1,
+			Timestamps: tailTimestamps,
+			Follow:     true,
+		})
+	})
+
+	return worker
+}
+
+// getEventsWorker prints the events of the workload and of the resources it owns, found by their
+// workload label or owner reference, until the context is done. The worker never ends the wait by
+// itself, a failure to watch the events (e.g. missing permissions) is printed and the wait goes on
+// without them.
+func getEventsWorker(c *cli.Config, workload *cartov1alpha1.Workload) wait.Worker {
+	worker := wait.Worker(func(ctx context.Context) error {
+		since := c.Now()
+		// whether the object involved in an event belongs to the workload, so each object is
+		// looked up once
+		workloadObjects := map[corev1.ObjectReference]bool{}
+		clientWithWatch, err := watch.GetWatcher(ctx, c)
+		if err == nil {
+			var eventWatcher k8swatch.Interface
+			eventWatcher, err = clientWithWatch.Watch(ctx, &corev1.EventList{}, &client.ListOptions{Namespace: workload.Namespace})
+			if err == nil {
+				defer eventWatcher.Stop()
+				for {
+					select {
+					case <-ctx.Done():
+						return ctx.Err()
+					case e, ok := <-eventWatcher.ResultChan():
+						if !ok {
+							<-ctx.Done()
+							return ctx.Err()
+						}
+						event, ok := e.Object.(*corev1.Event)
+						if !ok || !isEventSince(event, since) {
+							continue
+						}
+						ref := event.InvolvedObject
+						ref.ResourceVersion, ref.FieldPath = "", ""
+						belongs, found := workloadObjects[ref]
+						if !found {
+							belongs = isWorkloadObject(ctx, c, workload, ref)
+							workloadObjects[ref] = belongs
+						}
+						if belongs {
+							c.Emoji(cli.IncomingEnvelop, "%s %s %s/%s: %s\n", event.Type, event.Reason, event.InvolvedObject.Kind, event.InvolvedObject.Name, event.Message)
+						}
+					}
+				}
+			}
+		}
+		c.Infof("Unable to watch events for workload %q: %v\n", workload.Name, err)
+		<-ctx.Done()
+		return ctx.Err()
 	})
 
 	return worker
 }
 
+// isEventSince returns true for the events recorded since the given time
+func isEventSince(event *corev1.Event, since time.Time) bool {
+	last := event.LastTimestamp.Time
+	if !event.EventTime.IsZero() && event.EventTime.Time.After(last) {
+		last = event.EventTime.Time
+	}
+	return !last.Before(since)
+}
+
+// isWorkloadObject returns true when the referenced object is the workload, or is labeled with the
+// workload name or owned by the workload. Objects that can't be read are not considered part of
+// the workload.
+func isWorkloadObject(ctx context.Context, c *cli.Config, workload *cartov1alpha1.Workload, ref corev1.ObjectReference) bool {
+	if ref.Kind == cartov1alpha1.WorkloadKind && ref.APIVersion == cartov1alpha1.SchemeGroupVersion.String() {
+		return ref.Name == workload.Name
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(ref.APIVersion)
+	obj.SetKind(ref.Kind)
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = workload.Namespace
+	}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, obj); err != nil {
+		return false
+	}
+	if obj.GetLabels()[cartov1alpha1.WorkloadLabelName] == workload.Name {
+		return true
+	}
+	for _, owner := range obj.GetOwnerReferences() {
+		if owner.Kind == cartov1alpha1.WorkloadKind && owner.Name == workload.Name {
+			return true
+		}
+	}
+	return false
+}
+
 func getClusterSupplyChainTypeSelectors(fields []metav1.LabelSelectorRequirement) []string {
 	var values []string
 	for _, v := range fields {
@@ -961,7 +2295,27 @@ func checkLSPHealth(ctx context.Context, c *cli.Config) error {
 
 func (opts *WorkloadOptions) DefineFlags(ctx context.Context, c *cli.Config, cmd *cobra.Command) {
 	cli.NamespaceFlag(ctx, cmd, c, &opts.Namespace)
-	cmd.Flags().StringVarP(&opts.FilePath, cli.StripDash(flags.FilePathFlagName), "f", "", "`file path` containing the description of a single workload, other flags are layered on top of this resource. Use value \"-\" to read from stdin")
+	prior := cmd.PreRunE
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		// the profile is applied first so its paths are resolved as well
+		if err := opts.applyProfile(cmd); err != nil {
+			return err
+		}
+		// resolve the paths before they are validated
+		opts.resolveContextDir()
+		if err := opts.expandGitRepo(); err != nil {
+			return err
+		}
+		if prior != nil {
+			return prior(cmd, args)
+		}
+		return nil
+	}
+	cmd.Flags().StringVar(&opts.ContextDir, cli.StripDash(flags.ContextDirFlagName), "", "base `directory` relative paths of "+flags.FilePathFlagName+", "+flags.LocalPathFlagName+", "+flags.RegistryCertFlagName+" and "+flags.DiffFileFlagName+" are resolved from, defaults to the current directory")
+	cmd.MarkFlagDirname(cli.StripDash(flags.ContextDirFlagName))
+	cmd.Flags().StringVar(&opts.Profile, cli.StripDash(flags.ProfileFlagName), "", "`name` of a preset of flags read from ~/.config/tanzu/apps/profiles.yaml, flags set in the command line take precedence")
+	cmd.Flags().StringVarP(&opts.FilePath, cli.StripDash(flags.FilePathFlagName), "f", "", "`file path` containing the description of a single workload, other flags are layered on top of this resource. Use value \"-\" to read from stdin or \"configmap://namespace/name/key\" to read from a ConfigMap")
+	cmd.Flags().StringSliceVar(&opts.AllowedHosts, cli.StripDash(flags.AllowedHostsFlagName), []string{}, "`hosts` workload files and git repositories can be fetched from, when not set any host is allowed (can also be set through "+flags.FlagToEnvVar(flags.AllowedHostsFlagName)+")")
 	cmd.Flags().StringVarP(&opts.App, cli.StripDash(flags.AppFlagName), "a", "", "application `name` the workload is a part of")
 	cmd.Flags().StringVarP(&opts.Type, cli.StripDash(flags.TypeFlagName), "t", WebTypeReservedKey, "distinguish workload `type`")
 	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.TypeFlagName), func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -980,22 +2334,37 @@ func (opts *WorkloadOptions) DefineFlags(ctx context.Context, c *cli.Config, cmd
 	})
 	cmd.Flags().StringSliceVarP(&opts.Labels, cli.StripDash(flags.LabelFlagName), "l", []string{}, "label is represented as a `\"key=value\" pair` (\"key-\" to remove, flag can be used multiple times)")
 	cmd.Flags().StringSliceVar(&opts.Annotations, cli.StripDash(flags.AnnotationFlagName), []string{}, "annotation is represented as a `\"key=value\" pair` (\"key-\" to remove, flag can be used multiple times)")
-	cmd.Flags().StringArrayVarP(&opts.Params, cli.StripDash(flags.ParamFlagName), "p", []string{}, "additional parameters represented as a `\"key=value\" pair` (\"key-\" to remove, flag can be used multiple times)")
-	cmd.Flags().StringArrayVar(&opts.ParamsYaml, cli.StripDash(flags.ParamYamlFlagName), []string{}, "specify nested parameters using YAML or JSON formatted values represented as a `\"key=value\" pair` (\"key-\" to remove, flag can be used multiple times)")
+	cmd.Flags().VarP(&orderedParamsValue{values: &opts.Params, flag: flags.ParamFlagName, order: &opts.paramFlags}, cli.StripDash(flags.ParamFlagName), "p", "additional parameters represented as a `\"key=value\" pair`, the value is stored as a string, or \"key:type=value\" to set the value type (string, number, bool or json) (\"key-\" to remove, flag can be used multiple times)")
+	cmd.Flags().Var(&orderedParamsValue{values: &opts.ParamsYaml, flag: flags.ParamYamlFlagName, order: &opts.paramFlags}, cli.StripDash(flags.ParamYamlFlagName), "specify nested parameters using YAML or JSON formatted values represented as a `\"key=value\" pair` (\"key-\" to remove, flag can be used multiple times)")
 	cmd.Flags().BoolVar(&opts.Debug, cli.StripDash(flags.DebugFlagName), false, "put the workload in debug mode ("+flags.DebugFlagName+"=false to deactivate)")
 	cmd.Flags().BoolVar(&opts.LiveUpdate, cli.StripDash(flags.LiveUpdateFlagName), false, "put the workload in live update mode ("+flags.LiveUpdateFlagName+"=false to deactivate)")
-	cmd.Flags().StringVar(&opts.GitRepo, cli.StripDash(flags.GitRepoFlagName), "", "git `url` to remote source code (to unset, pass empty string \"\")")
+	cmd.Flags().StringVar(&opts.GitRepo, cli.StripDash(flags.GitRepoFlagName), "", "git `url` to remote source code, or a shorthand like gh:org/repo, gl:org/repo or bb:org/repo for GitHub, GitLab and Bitbucket (to unset, pass empty string \"\")")
 	cmd.Flags().StringVar(&opts.GitBranch, cli.StripDash(flags.GitBranchFlagName), "", "`branch` within the git repo to checkout (to unset, pass empty string \"\")")
 	cmd.Flags().StringVar(&opts.GitCommit, cli.StripDash(flags.GitCommitFlagName), "", "commit `SHA` within the git repo to checkout (to unset, pass empty string \"\")")
 	cmd.Flags().StringVar(&opts.GitTag, cli.StripDash(flags.GitTagFlagName), "", "`tag` within the git repo to checkout (to unset, pass empty string \"\")")
-	cmd.Flags().StringVarP(&opts.SourceImage, cli.StripDash(flags.SourceImageFlagName), "s", "", "destination `image` repository where source code is staged before being built")
+	cmd.Flags().StringVar(&opts.GitSecret, cli.StripDash(flags.GitSecretFlagName), "", "`name` of the secret with the credentials to fetch the git repo, ssh key or https token, set as the "+cartov1alpha1.WorkloadGitSecretParam+" param (to unset, pass empty string \"\")")
+	cmd.Flags().StringVarP(&opts.SourceImage, cli.StripDash(flags.SourceImageFlagName), "s", "", "destination `image` repository where source code is staged before being built, a value ending with \"/\" is completed with \"<workload name>-source\"")
 	cmd.Flags().StringVar(&opts.SubPath, cli.StripDash(flags.SubPathFlagName), "", "relative `path` inside the repo or image to treat as application root (to unset, pass empty string \"\")")
 	cmd.Flags().StringVar(&opts.LocalPath, cli.StripDash(flags.LocalPathFlagName), "", "`path` to a directory, .zip, .jar or .war file containing workload source code")
+	cmd.Flags().BoolVar(&opts.NoCache, cli.StripDash(flags.NoCacheFlagName), false, "always package and publish the "+flags.LocalPathFlagName+" source code, even when it is unchanged since it was last published to the same image")
+	cmd.Flags().BoolVar(&opts.PackSubPath, cli.StripDash(flags.PackSubPathFlagName), false, "only publish the "+flags.SubPathFlagName+" directory of "+flags.LocalPathFlagName+" and use it as the root of the source code")
 	cmd.MarkFlagDirname(cli.StripDash(flags.LocalPathFlagName))
 	cmd.Flags().StringVarP(&opts.Image, cli.StripDash(flags.ImageFlagName), "i", "", "pre-built `image`, skips the source resolution and build phases of the supply chain")
+	cmd.Flags().BoolVar(&opts.PinImage, cli.StripDash(flags.PinImageFlagName), false, "resolve the tag of the pre-built image to a digest and set the image by digest")
 	cmd.Flags().StringArrayVarP(&opts.Env, cli.StripDash(flags.EnvFlagName), "e", []string{}, "environment variables represented as a `\"key=value\" pair` (\"key-\" to remove, flag can be used multiple times)")
+	cmd.Flags().StringArrayVar(&opts.EnvConfigMaps, cli.StripDash(flags.EnvFromConfigMapFlagName), []string{}, "ConfigMap `name` whose keys are set as environment variables referencing the ConfigMap (flag can be used multiple times)")
 	cmd.Flags().StringArrayVar(&opts.BuildEnv, cli.StripDash(flags.BuildEnvFlagName), []string{}, "build environment variables represented as a `\"key=value\" pair` (\"key-\" to remove, flag can be used multiple times)")
+	cmd.Flags().StringArrayVar(&opts.BuildParams, cli.StripDash(flags.BuildParamFlagName), []string{}, fmt.Sprintf("build settings represented as a `\"key=value\" pair`, supported keys are %s (\"key-\" to remove, flag can be used multiple times)", strings.Join(buildParamNames(), ", ")))
+	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.BuildParamFlagName), func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		suggestions := []string{}
+		for _, name := range buildParamNames() {
+			suggestions = append(suggestions, name+"=")
+		}
+		return suggestions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	})
+	cmd.Flags().StringArrayVar(&opts.BuildParamsYaml, cli.StripDash(flags.BuildParamYamlFlagName), []string{}, "specify build settings using YAML or JSON formatted values represented as a `\"key=value\" pair`, with the same keys as "+flags.BuildParamFlagName+" (\"key-\" to remove, flag can be used multiple times)")
 	cmd.Flags().StringArrayVar(&opts.ServiceRefs, cli.StripDash(flags.ServiceRefFlagName), []string{}, "`object reference` for a service to bind to the workload \"service-ref-name=apiVersion:kind:service-binding-name\" (\"service-ref-name-\" to remove, flag can be used multiple times)")
+	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.ServiceRefFlagName), completion.SuggestServiceRefs(ctx, c))
 	cmd.Flags().StringVar(&opts.ServiceAccountName, cli.StripDash(flags.ServiceAccountFlagName), "", "name of service account permitted to create resources submitted by the supply chain (to unset, pass empty string \"\")")
 	cmd.Flags().StringVar(&opts.LimitCPU, cli.StripDash(flags.LimitCPUFlagName), "", "the maximum amount of cpu allowed, in CPU `cores` (500m = .5 cores)")
 	cmd.Flags().StringVar(&opts.LimitMemory, cli.StripDash(flags.LimitMemoryFlagName), "", "the maximum amount of memory allowed, in `bytes` (500Mi = 500MiB = 500 * 1024 * 1024)")
@@ -1004,6 +2373,7 @@ func (opts *WorkloadOptions) DefineFlags(ctx context.Context, c *cli.Config, cmd
 	cmd.Flags().StringVar(&opts.MavenVersion, cli.StripDash(flags.MavenVersionFlagName), "", "version number of maven artifact")
 	cmd.Flags().StringVar(&opts.MavenType, cli.StripDash(flags.MavenTypeFlagName), "", "maven packaging type, defaults to jar")
 	cmd.Flags().StringVarP(&opts.Output, cli.StripDash(flags.OutputFlagName), "o", "", "output the Workload formatted. Supported formats: \"json\", \"yaml\", \"yml\"")
+	cmd.Flags().BoolVar(&opts.ShowSecrets, cli.StripDash(flags.ShowSecretsFlagName), false, "show the values of env vars and params with a secret like name (password, token, ...) in the workload printed with "+flags.OutputFlagName+" instead of masking them")
 	cmd.Flags().StringArrayVar(&opts.CACertPaths, cli.StripDash(flags.RegistryCertFlagName), []string{}, "file path to CA certificate used to authenticate with registry, flag can be used multiple times")
 	cmd.Flags().StringVar(&opts.RegistryPassword, cli.StripDash(flags.RegistryPasswordFlagName), "", "username for authenticating with registry")
 	cmd.Flags().StringVar(&opts.RegistryUsername, cli.StripDash(flags.RegistryUsernameFlagName), "", "password for authenticating with registry")
@@ -1013,10 +2383,33 @@ func (opts *WorkloadOptions) DefineFlags(ctx context.Context, c *cli.Config, cmd
 	cmd.Flags().BoolVar(&opts.Wait, cli.StripDash(flags.WaitFlagName), false, "waits for workload to become ready")
 	cmd.Flags().DurationVar(&opts.WaitTimeout, cli.StripDash(flags.WaitTimeoutFlagName), 10*time.Minute, "timeout for workload to become ready when waiting")
 	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.WaitTimeoutFlagName), completion.SuggestDurationUnits(ctx, completion.CommonDurationUnits))
+	cmd.Flags().StringSliceVar(&opts.FailOnUnknownReasons, cli.StripDash(flags.FailOnUnknownReasonFlagName), []string{}, "`reasons` of the Unknown Ready condition that fail the wait right away instead of waiting for the timeout, comma separated (e.g. MissingValueAtPath)")
+	cmd.Flags().DurationVar(&opts.WaitInterval, cli.StripDash(flags.WaitIntervalFlagName), 0, "minimum `duration` between checks of the workload status when waiting, changes in between are coalesced (0 checks on every change)")
+	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.WaitIntervalFlagName), completion.SuggestDurationUnits(ctx, completion.CommonDurationUnits))
 	cmd.Flags().BoolVar(&opts.Tail, cli.StripDash(flags.TailFlagName), false, "show logs while waiting for workload to become ready")
 	cmd.Flags().BoolVar(&opts.TailTimestamps, cli.StripDash(flags.TailTimestampFlagName), false, "show logs and add timestamp to each log line while waiting for workload to become ready")
 	cmd.MarkFlagFilename(cli.StripDash(flags.FilePathFlagName), ".yaml", ".yml")
-	cmd.Flags().BoolVar(&opts.DryRun, cli.StripDash(flags.DryRunFlagName), false, "print kubernetes resources to stdout rather than apply them to the cluster, messages normally on stdout will be sent to stderr")
+	cmd.Flags().StringVar(&opts.DiffFormat, cli.StripDash(flags.DiffFormatFlagName), defaultDiffFormat, fmt.Sprintf("format of the workload changes shown before applying them (supported formats: %s, %s)", defaultDiffFormat, unifiedDiffFormat))
+	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.DiffFormatFlagName), func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{defaultDiffFormat, unifiedDiffFormat}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().BoolVar(&opts.WordDiff, cli.StripDash(flags.WordDiffFlagName), false, "highlight the changed part of the modified lines in the workload changes, like the tag of an image (ignored without colors)")
+	cmd.Flags().BoolVar(&opts.CollapseUnchanged, cli.StripDash(flags.CollapseUnchangedFlagName), false, "show each top level section of the spec that is unchanged, like resources, as a single \"<field>: (unchanged)\" line in the workload changes")
+	cmd.Flags().BoolVar(&opts.YamlFlowParams, cli.StripDash(flags.YamlFlowParamsFlagName), false, "render the structured param values in flow style, like {a: 1, b: 2}, in the workload changes and the yaml output")
+	cmd.Flags().BoolVar(&opts.AnnotateForOverlay, cli.StripDash(flags.AnnotateForOverlayFlagName), false, "annotate the yaml output of "+flags.DryRunFlagName+" or "+flags.OutputFlagName+" for it to be used as a Carvel ytt overlay matching the workload by name")
+	cmd.Flags().IntVar(&opts.MaxValueWidth, cli.StripDash(flags.MaxValueWidthFlagName), defaultMaxValueWidth, "number of `bytes` of a value shown in the workload changes, longer values are truncated (0 shows them in full)")
+	cmd.Flags().StringVar(&opts.DiffFile, cli.StripDash(flags.DiffFileFlagName), "", "also write the workload changes to the file at `path`, in the "+flags.DiffFormatFlagName+" format (an existing file is only overwritten with "+flags.YesFlagName+")")
+	cmd.Flags().Var(&dryRunValue{opts: opts}, cli.StripDash(flags.DryRunFlagName), fmt.Sprintf("print kubernetes resources to stdout rather than apply them to the cluster, messages normally on stdout will be sent to stderr (`strategy` %s, %s or %s; %s, only in workload create, has the api server validate the workload without persisting it)", noneDryRunStrategy, clientDryRunStrategy, serverDryRunStrategy, serverDryRunStrategy))
+	cmd.Flags().Lookup(cli.StripDash(flags.DryRunFlagName)).NoOptDefVal = clientDryRunStrategy
+	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.DryRunFlagName), func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{noneDryRunStrategy, clientDryRunStrategy, serverDryRunStrategy}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().StringSliceVar(&opts.SuppressWarnings, cli.StripDash(flags.SuppressWarningsFlagName), []string{}, fmt.Sprintf("`ids` of the warnings not to print, comma separated (supported ids: %s)", strings.Join(warningIDs, ", ")))
+	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.SuppressWarningsFlagName), func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return warningIDs, cobra.ShellCompDirectiveNoFileComp
+	})
+	cli.RetryFlags(cmd, &opts.Retry)
+	cmd.Flags().BoolVar(&opts.WarningsAsErrors, cli.StripDash(flags.WarningsAsErrorsFlagName), false, "exit with an error when a warning is printed, before the workload is applied (notices are not affected)")
 	cmd.Flags().BoolVarP(&opts.Yes, cli.StripDash(flags.YesFlagName), "y", false, "accept all prompts")
 }
 
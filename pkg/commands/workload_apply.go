@@ -17,26 +17,73 @@ limitations under the License.
 package commands
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/acarl005/stripansi"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/apis"
 	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	servicesv1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/services/v1alpha1"
 	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/validation"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/wait"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/completion"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/flags"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/printer"
 )
 
 type WorkloadApplyOptions struct {
 	WorkloadOptions
-	UpdateStrategy string
+	UpdateStrategy     string
+	SaveConfig         bool
+	PruneEnv           bool
+	PruneBuildEnv      bool
+	PruneParams        bool
+	PruneAllowlist     []string
+	Validation         bool
+	ValidateSchema     bool
+	Recursive          bool
+	Quiet              bool
+	NoProgress         bool
+	Concurrency        int
+	UpdateOnly         bool
+	IgnoreNotFound     bool
+	ForceReplaceSource bool
+	Diff               bool
+	ExitCode           bool
+	PrintFlags         bool
+	TimeoutAction      string
+	SetImageTag        string
+	Events             bool
+	RollbackOnError    bool
+	NoSACheck          bool
+	Message            string
+	MessageMode        string
+
+	// loadedFileWorkload is the workload of FilePath when it's already loaded, see applyDir
+	loadedFileWorkload *cartov1alpha1.Workload
 }
 
 var (
@@ -50,11 +97,51 @@ const (
 	replaceUpdateStrategy = "replace"
 )
 
+// the workload fields the pruning can remove entries from, see --prune-allowlist
+const (
+	pruneLabels         = "labels"
+	pruneAnnotations    = "annotations"
+	pruneParams         = "params"
+	pruneEnv            = "env"
+	pruneBuildEnv       = "build-env"
+	pruneServiceClaims  = "service-claims"
+	pruneResources      = "resources"
+	pruneServiceAccount = "service-account"
+	pruneSubPath        = "sub-path"
+)
+
+var prunableFields = []string{pruneLabels, pruneAnnotations, pruneParams, pruneEnv, pruneBuildEnv, pruneServiceClaims, pruneResources, pruneServiceAccount, pruneSubPath}
+
+const (
+	appendMessageMode  = "append"
+	replaceMessageMode = "replace"
+	// maxChangeLogEntries bounds the entries kept in the change log annotation by --message
+	maxChangeLogEntries = 10
+)
+
+const (
+	failTimeoutAction     = "fail"
+	ignoreTimeoutAction   = "ignore"
+	rollbackTimeoutAction = "rollback"
+)
+
+type applyResult int
+
+const (
+	applyResultUnchanged applyResult = iota
+	applyResultCreated
+	applyResultUpdated
+)
+
 type WorkloadTimeoutStashKey struct{}
 
 func (opts *WorkloadApplyOptions) Validate(ctx context.Context) validation.FieldErrors {
 	errs := validation.FieldErrors{}
-	errs = errs.Also(opts.WorkloadOptions.Validate(ctx))
+	if opts.isValidationDisabled(ctx) {
+		errs = errs.Also(opts.WorkloadOptions.ValidateInputs(ctx))
+	} else {
+		errs = errs.Also(opts.WorkloadOptions.Validate(ctx))
+	}
 
 	if opts.UpdateStrategy != "" && cli.CommandFromContext(ctx).Flags().Changed(cli.StripDash(flags.UpdateStrategyFlagName)) {
 		if opts.FilePath == "" {
@@ -63,20 +150,836 @@ func (opts *WorkloadApplyOptions) Validate(ctx context.Context) validation.Field
 		errs = errs.Also(validation.Enum(opts.UpdateStrategy, flags.UpdateStrategyFlagName, []string{mergeUpdateStrategy, replaceUpdateStrategy}))
 	}
 
+	if opts.InputFormat != "" && opts.InputFormat != autoInputFormat {
+		if opts.FilePath == "" {
+			errs = errs.Also(validation.ErrMissingField(flags.FilePathFlagName))
+		}
+		errs = errs.Also(validation.Enum(opts.InputFormat, flags.InputFormatFlagName, []string{autoInputFormat, yamlInputFormat, jsonInputFormat}))
+	}
+
+	if opts.ExpandEnv != "" && opts.ExpandEnv != noExpandEnv {
+		errs = errs.Also(validation.Enum(opts.ExpandEnv, flags.ExpandEnvFlagName, []string{noExpandEnv, strictExpandEnv, allowEmptyExpandEnv}))
+		if opts.ExpandEnv == strictExpandEnv {
+			for _, ev := range opts.Env {
+				if _, undefined := opts.expandEnvValue(ev); len(undefined) != 0 {
+					errs = errs.Also(validation.ErrInvalidValueWithDetail(ev, flags.EnvFlagName, fmt.Sprintf("environment variable %q is not defined, use %s=%s to expand it to an empty value", undefined[0], flags.ExpandEnvFlagName, allowEmptyExpandEnv)))
+				}
+			}
+		}
+	}
+
+	if opts.Guaranteed {
+		if opts.RequestCPU != "" && opts.LimitCPU != "" && !quantitiesEqual(opts.RequestCPU, opts.LimitCPU) {
+			errs = errs.Also(validation.ErrInvalidValueWithDetail(opts.LimitCPU, flags.LimitCPUFlagName, fmt.Sprintf("must match %s %s with %s", flags.RequestCPUFlagName, opts.RequestCPU, flags.GuaranteedFlagName)))
+		}
+		if opts.RequestMemory != "" && opts.LimitMemory != "" && !quantitiesEqual(opts.RequestMemory, opts.LimitMemory) {
+			errs = errs.Also(validation.ErrInvalidValueWithDetail(opts.LimitMemory, flags.LimitMemoryFlagName, fmt.Sprintf("must match %s %s with %s", flags.RequestMemoryFlagName, opts.RequestMemory, flags.GuaranteedFlagName)))
+		}
+	}
+
+	if opts.SaveConfig && opts.FilePath == "" {
+		errs = errs.Also(validation.ErrMissingField(flags.FilePathFlagName))
+	}
+
+	if len(opts.PruneAllowlist) != 0 {
+		for _, field := range opts.PruneAllowlist {
+			errs = errs.Also(validation.Enum(field, flags.PruneAllowlistFlagName, prunableFields))
+		}
+		// the prune flags can't remove entries out of the allowlist either
+		allowed := sets.NewString(opts.PruneAllowlist...)
+		for _, prune := range []struct {
+			set   bool
+			flag  string
+			field string
+		}{
+			{opts.PruneEnv, flags.PruneEnvFlagName, pruneEnv},
+			{opts.PruneBuildEnv, flags.PruneBuildEnvFlagName, pruneBuildEnv},
+			{opts.PruneParams, flags.PruneParamsFlagName, pruneParams},
+		} {
+			if prune.set && !allowed.Has(prune.field) {
+				errs = errs.Also(validation.ErrInvalidValueWithDetail(strings.Join(opts.PruneAllowlist, ","), flags.PruneAllowlistFlagName, fmt.Sprintf("must include %q to use %s", prune.field, prune.flag)))
+			}
+		}
+	}
+
+	if opts.Recursive {
+		if opts.FilePath == "" {
+			errs = errs.Also(validation.ErrMissingField(flags.FilePathFlagName))
+		}
+		if opts.Name != "" {
+			errs = errs.Also(validation.ErrInvalidValueWithDetail(opts.Name, cli.NameArgumentName, fmt.Sprintf("the workload name is read from each file when %s is set", flags.RecursiveFlagName)))
+		}
+	}
+	if (opts.Quiet || opts.NoProgress) && !opts.Recursive {
+		errs = errs.Also(validation.ErrMissingField(flags.RecursiveFlagName))
+	}
+	if opts.Concurrency < 0 {
+		errs = errs.Also(validation.ErrInvalidValueWithDetail(strconv.Itoa(opts.Concurrency), flags.ConcurrencyFlagName, "must be 0 or more"))
+	} else if opts.Concurrency > 1 {
+		if !opts.Recursive {
+			errs = errs.Also(validation.ErrMissingField(flags.RecursiveFlagName))
+		}
+		// the files applied at once can't prompt nor stream their logs
+		if !opts.Yes {
+			errs = errs.Also(validation.ErrMissingField(flags.YesFlagName))
+		}
+		if opts.Tail || opts.TailTimestamps {
+			errs = errs.Also(validation.ErrMultipleOneOf(flags.ConcurrencyFlagName, flags.TailFlagName))
+		}
+	}
+	if opts.Quiet {
+		// the prompts and the dry run workloads would not be seen
+		if !opts.Yes {
+			errs = errs.Also(validation.ErrMissingField(flags.YesFlagName))
+		}
+		if opts.DryRun {
+			errs = errs.Also(validation.ErrMultipleOneOf(flags.QuietFlagName, flags.DryRunFlagName))
+		}
+	}
+
+	if opts.ValidateSchema && opts.isValidationDisabled(ctx) {
+		errs = errs.Also(validation.ErrMultipleOneOf(flags.ValidateSchemaFlagName, flags.ValidateFlagName+"=false"))
+	}
+
+	if opts.IgnoreNotFound && !opts.UpdateOnly {
+		errs = errs.Also(validation.ErrMissingField(flags.UpdateOnlyFlagName))
+	}
+
+	if opts.ForceReplaceSource {
+		errs = errs.Also(opts.validateReplacementSource())
+	}
+
+	if opts.ExitCode && !opts.Diff {
+		errs = errs.Also(validation.ErrMissingField(flags.DiffFlagName))
+	}
+	if opts.DryRunStrategy == serverDryRunStrategy {
+		errs = errs.Also(validation.ErrInvalidValueWithDetail(serverDryRunStrategy, flags.DryRunFlagName, "only supported by workload create"))
+	}
+	if opts.Diff && opts.DryRun {
+		errs = errs.Also(validation.ErrMultipleOneOf(flags.DiffFlagName, flags.DryRunFlagName))
+	}
+	if opts.MessageMode != "" && cli.CommandFromContext(ctx).Flags().Changed(cli.StripDash(flags.MessageModeFlagName)) {
+		if opts.Message == "" {
+			errs = errs.Also(validation.ErrMissingField(flags.MessageFlagName))
+		}
+		errs = errs.Also(validation.Enum(opts.MessageMode, flags.MessageModeFlagName, []string{appendMessageMode, replaceMessageMode}))
+	}
+	if opts.Bare && !opts.DryRun {
+		errs = errs.Also(validation.ErrMissingField(flags.DryRunFlagName))
+	}
+	if opts.Diff && opts.Recursive {
+		errs = errs.Also(validation.ErrMultipleOneOf(flags.DiffFlagName, flags.RecursiveFlagName))
+	}
+	if opts.PrintFlags && opts.DryRun {
+		errs = errs.Also(validation.ErrMultipleOneOf(flags.PrintFlagsFlagName, flags.DryRunFlagName))
+	}
+	if opts.PrintFlags && opts.Diff {
+		errs = errs.Also(validation.ErrMultipleOneOf(flags.PrintFlagsFlagName, flags.DiffFlagName))
+	}
+	if opts.SetImageTag != "" {
+		if !imageTagPattern.MatchString(opts.SetImageTag) && !imageDigestPattern.MatchString(opts.SetImageTag) {
+			errs = errs.Also(validation.ErrInvalidValueWithDetail(opts.SetImageTag, flags.SetImageTagFlagName, "expected an image tag, like v1.2.3, or a digest, like sha256:<hex>"))
+		}
+		if opts.Image != "" {
+			errs = errs.Also(validation.ErrMultipleOneOf(flags.SetImageTagFlagName, flags.ImageFlagName))
+		}
+	}
+	if opts.TimeoutAction != "" && opts.TimeoutAction != failTimeoutAction {
+		errs = errs.Also(validation.Enum(opts.TimeoutAction, flags.TimeoutActionFlagName, []string{failTimeoutAction, ignoreTimeoutAction, rollbackTimeoutAction}))
+		if !opts.Wait && !opts.Tail && !opts.TailTimestamps {
+			errs = errs.Also(validation.ErrMissingField(flags.WaitFlagName))
+		}
+	}
+	if opts.Events && !opts.Wait && !opts.Tail && !opts.TailTimestamps {
+		errs = errs.Also(validation.ErrMissingField(flags.WaitFlagName))
+	}
+	if opts.Output == helmValuesOutputFormat {
+		// the values are rendered without the cluster or a registry
+		for _, f := range []struct {
+			set  bool
+			name string
+		}{
+			{opts.LocalPath != "", flags.LocalPathFlagName},
+			{len(opts.EnvConfigMaps) != 0, flags.EnvFromConfigMapFlagName},
+			{opts.PinImage, flags.PinImageFlagName},
+			{opts.Wait, flags.WaitFlagName},
+			{opts.Tail || opts.TailTimestamps, flags.TailFlagName},
+			{opts.Recursive, flags.RecursiveFlagName},
+			{opts.Diff, flags.DiffFlagName},
+			{opts.PrintFlags, flags.PrintFlagsFlagName},
+		} {
+			if f.set {
+				errs = errs.Also(validation.ErrInvalidValueWithDetail(helmValuesOutputFormat, flags.OutputFlagName, "the cluster is not contacted, it can't be used with "+f.name))
+			}
+		}
+	}
+
+	return errs
+}
+
+var (
+	imageTagPattern    = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+	imageDigestPattern = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+)
+
+// setImageTag replaces the tag or digest of the workload image, keeping its repository
+func (opts *WorkloadApplyOptions) setImageTag(workload *cartov1alpha1.Workload) error {
+	if opts.SetImageTag == "" {
+		return nil
+	}
+	if workload.Spec.Image == "" {
+		return validation.ErrInvalidValueWithDetail(opts.SetImageTag, flags.SetImageTagFlagName, fmt.Sprintf("workload %q has no image to set the tag of", workload.Name)).ToAggregate()
+	}
+
+	repository := imageRepository(workload.Spec.Image)
+	if imageDigestPattern.MatchString(opts.SetImageTag) {
+		workload.Spec.Image = repository + "@" + opts.SetImageTag
+	} else {
+		workload.Spec.Image = repository + ":" + opts.SetImageTag
+	}
+	return nil
+}
+
+// imageRepository returns the image reference without its tag and digest. The registry port,
+// like in localhost:5000/app, is kept.
+func imageRepository(image string) string {
+	if i := strings.Index(image, "@"); i != -1 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i != -1 && !strings.Contains(image[i:], "/") {
+		image = image[:i]
+	}
+	return image
+}
+
+// showDrift prints the changes apply would make to the workload in the cluster, without making
+// them. With --exit-code a drift fails the command with exit code 2.
+func (opts *WorkloadApplyOptions) showDrift(c *cli.Config, currentWorkload, workload *cartov1alpha1.Workload) error {
+	difference, noChange, err := opts.resourceDiff(currentWorkload, workload, c.Scheme)
+	if err != nil {
+		return err
+	}
+	if noChange {
+		c.Infof("No drift detected for workload %q\n", workload.Name)
+		return nil
+	}
+
+	c.Emoji(cli.Magnifying, "Workload drift:\n")
+	c.Printf("%s", difference)
+	if err := opts.writeDiffFile(difference); err != nil {
+		return err
+	}
+	summary := "the workload does not exist"
+	changed := driftedFields(&cartov1alpha1.Workload{}, workload)
+	if currentWorkload != nil {
+		changed = driftedFields(currentWorkload, workload)
+		summary = strings.Join(changed, ", ")
+	}
+	added, removed := printer.DiffStat(difference)
+	c.Printf("Changes: +%d -%d lines across %s\n", added, removed, strings.Join(changedSections(changed), ", "))
+	c.Infof("Drift detected for workload %q: %s\n", workload.Name, summary)
+
+	if opts.ExitCode {
+		return cli.SilenceError(cli.WithExitCode(fmt.Errorf("workload %q has drifted", workload.Name), 2))
+	}
+	return nil
+}
+
+// printWorkloadFlags prints the workload apply command whose flags reproduce the workload,
+// followed by the fields no flag can set
+func printWorkloadFlags(c *cli.Config, workload *cartov1alpha1.Workload) error {
+	args, unmapped := workloadFlags(workload)
+	if workload.Namespace != c.Client.DefaultNamespace() {
+		args = append([][]string{{flags.NamespaceFlagName, workload.Namespace}}, args...)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("tanzu apps workload apply " + shellQuote(workload.Name))
+	for _, arg := range args {
+		sb.WriteString(" \\\n  " + arg[0])
+		// boolean flags have no value
+		if len(arg) == 2 {
+			sb.WriteString(" " + shellQuote(arg[1]))
+		}
+	}
+	c.Printf("%s\n", sb.String())
+
+	if len(unmapped) != 0 {
+		c.Infof("\nFields that can't be set through flags: %s\n", strings.Join(unmapped, ", "))
+	}
+	return nil
+}
+
+// cliManagedAnnotations are set by the flags, or by apply itself, and are not reported as unmapped
+var cliManagedAnnotations = sets.NewString(
+	apis.ServiceClaimAnnotationName,
+	apis.LocalSourceProxyAnnotationName,
+	apis.LastAppliedConfigurationAnnotationName,
+)
+
+// workloadFlags maps the workload fields to the apply flags setting them, each flag is followed
+// by its value unless it's a boolean flag. The fields that can't be set through a flag are
+// returned as unmapped.
+func workloadFlags(workload *cartov1alpha1.Workload) ([][]string, []string) {
+	args := [][]string{}
+	unmapped := []string{}
+
+	labels := sets.StringKeySet(workload.Labels)
+	for _, k := range labels.List() {
+		switch k {
+		case apis.AppPartOfLabelName:
+			args = append(args, []string{flags.AppFlagName, workload.Labels[k]})
+		case apis.WorkloadTypeLabelName:
+			args = append(args, []string{flags.TypeFlagName, workload.Labels[k]})
+		default:
+			args = append(args, []string{flags.LabelFlagName, fmt.Sprintf("%s=%s", k, workload.Labels[k])})
+		}
+	}
+	for _, k := range sets.StringKeySet(workload.Annotations).List() {
+		if !cliManagedAnnotations.Has(k) {
+			unmapped = append(unmapped, fmt.Sprintf("metadata.annotations[%s]", k))
+		}
+	}
+
+	spec := workload.Spec
+	if spec.Source != nil {
+		if git := spec.Source.Git; git != nil {
+			args = append(args, []string{flags.GitRepoFlagName, git.URL})
+			if git.Ref.Branch != "" {
+				args = append(args, []string{flags.GitBranchFlagName, git.Ref.Branch})
+			}
+			if git.Ref.Tag != "" {
+				args = append(args, []string{flags.GitTagFlagName, git.Ref.Tag})
+			}
+			if git.Ref.Commit != "" {
+				args = append(args, []string{flags.GitCommitFlagName, git.Ref.Commit})
+			}
+		}
+		if spec.Source.Image != "" {
+			args = append(args, []string{flags.SourceImageFlagName, spec.Source.Image})
+		}
+		if spec.Source.Subpath != "" {
+			args = append(args, []string{flags.SubPathFlagName, spec.Source.Subpath})
+		}
+	}
+	if spec.Image != "" {
+		args = append(args, []string{flags.ImageFlagName, spec.Image})
+	}
+
+	for _, p := range spec.Params {
+		var value interface{}
+		if err := json.Unmarshal(p.Value.Raw, &value); err != nil {
+			unmapped = append(unmapped, fmt.Sprintf("spec.params[%s]", p.Name))
+			continue
+		}
+		switch {
+		case p.Name == cartov1alpha1.WorkloadMavenParam:
+			if maven := spec.GetMavenSource(); maven != nil {
+				args = append(args, []string{flags.MavenArtifactFlagName, maven.ArtifactId}, []string{flags.MavenGroupFlagName, maven.GroupId}, []string{flags.MavenVersionFlagName, maven.Version})
+				if maven.Type != nil {
+					args = append(args, []string{flags.MavenTypeFlagName, *maven.Type})
+				}
+			}
+		case p.Name == "annotations":
+			annotations, ok := value.(map[string]interface{})
+			if !ok {
+				unmapped = append(unmapped, fmt.Sprintf("spec.params[%s]", p.Name))
+				continue
+			}
+			keys := make([]string, 0, len(annotations))
+			for k := range annotations {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				args = append(args, []string{flags.AnnotationFlagName, fmt.Sprintf("%s=%v", k, annotations[k])})
+			}
+		case p.Name == "debug" && value == "true":
+			args = append(args, []string{flags.DebugFlagName})
+		case p.Name == "live-update" && value == "true":
+			args = append(args, []string{flags.LiveUpdateFlagName})
+		default:
+			if str, ok := value.(string); ok {
+				args = append(args, []string{flags.ParamFlagName, fmt.Sprintf("%s=%s", p.Name, str)})
+			} else {
+				args = append(args, []string{flags.ParamYamlFlagName, fmt.Sprintf("%s=%s", p.Name, p.Value.Raw)})
+			}
+		}
+	}
+
+	for _, env := range spec.Env {
+		if env.ValueFrom != nil {
+			unmapped = append(unmapped, fmt.Sprintf("spec.env[%s]", env.Name))
+			continue
+		}
+		args = append(args, []string{flags.EnvFlagName, fmt.Sprintf("%s=%s", env.Name, env.Value)})
+	}
+	if spec.Build != nil {
+		for _, env := range spec.Build.Env {
+			if env.ValueFrom != nil {
+				unmapped = append(unmapped, fmt.Sprintf("spec.build.env[%s]", env.Name))
+				continue
+			}
+			args = append(args, []string{flags.BuildEnvFlagName, fmt.Sprintf("%s=%s", env.Name, env.Value)})
+		}
+	}
+
+	if r := spec.Resources; r != nil {
+		resourceFlags := []struct {
+			list     corev1.ResourceList
+			resource corev1.ResourceName
+			flag     string
+		}{
+			{r.Limits, corev1.ResourceCPU, flags.LimitCPUFlagName},
+			{r.Limits, corev1.ResourceMemory, flags.LimitMemoryFlagName},
+			{r.Requests, corev1.ResourceCPU, flags.RequestCPUFlagName},
+			{r.Requests, corev1.ResourceMemory, flags.RequestMemoryFlagName},
+		}
+		for _, rf := range resourceFlags {
+			if q, ok := rf.list[rf.resource]; ok {
+				args = append(args, []string{rf.flag, q.String()})
+			}
+		}
+		for _, list := range []corev1.ResourceList{r.Limits, r.Requests} {
+			for name := range list {
+				if name != corev1.ResourceCPU && name != corev1.ResourceMemory {
+					unmapped = append(unmapped, fmt.Sprintf("spec.resources[%s]", name))
+				}
+			}
+		}
+	}
+
+	if spec.ServiceAccountName != nil {
+		args = append(args, []string{flags.ServiceAccountFlagName, *spec.ServiceAccountName})
+	}
+
+	claimNamespaces := map[string]string{}
+	if config, err := servicesv1alpha1.NewServiceClaimWorkloadConfigFromAnnotation(workload.Annotations[apis.ServiceClaimAnnotationName]); err == nil {
+		for name, value := range config.Spec.ServiceClaims {
+			if v, ok := value.(map[string]interface{}); ok {
+				if ns, ok := v["namespace"].(string); ok {
+					claimNamespaces[name] = ns
+				}
+			}
+		}
+	}
+	for _, sc := range spec.ServiceClaims {
+		if sc.Ref == nil {
+			unmapped = append(unmapped, fmt.Sprintf("spec.serviceClaims[%s]", sc.Name))
+			continue
+		}
+		ref := fmt.Sprintf("%s:%s:%s", sc.Ref.APIVersion, sc.Ref.Kind, sc.Ref.Name)
+		if ns, ok := claimNamespaces[sc.Name]; ok {
+			ref = fmt.Sprintf("%s:%s:%s:%s", sc.Ref.APIVersion, sc.Ref.Kind, ns, sc.Ref.Name)
+		}
+		args = append(args, []string{flags.ServiceRefFlagName, fmt.Sprintf("%s=%s", sc.Name, ref)})
+	}
+
+	return args, unmapped
+}
+
+// shellQuote wraps the value in single quotes when the shell would otherwise split or expand it
+func shellQuote(value string) string {
+	if value != "" && strings.Trim(value, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:=@,+%") == "" {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// driftedFields lists the labels, annotations and top level spec fields that differ between the
+// workloads
+func driftedFields(current, workload *cartov1alpha1.Workload) []string {
+	fields := []string{}
+	if !equality.Semantic.DeepEqual(current.Labels, workload.Labels) {
+		fields = append(fields, "metadata.labels")
+	}
+	if !equality.Semantic.DeepEqual(current.Annotations, workload.Annotations) {
+		fields = append(fields, "metadata.annotations")
+	}
+
+	currentSpec, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(&current.Spec)
+	spec, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(&workload.Spec)
+	keys := sets.NewString()
+	for k := range currentSpec {
+		keys.Insert(k)
+	}
+	for k := range spec {
+		keys.Insert(k)
+	}
+	for _, k := range keys.List() {
+		if !equality.Semantic.DeepEqual(currentSpec[k], spec[k]) {
+			fields = append(fields, "spec."+k)
+		}
+	}
+	return fields
+}
+
+// quantitiesEqual compares two resource quantities, like 1 and 1000m. Invalid quantities are
+// reported by the quantity validation and compare as equal here.
+func quantitiesEqual(a, b string) bool {
+	qa, errA := resource.ParseQuantity(a)
+	qb, errB := resource.ParseQuantity(b)
+	if errA != nil || errB != nil {
+		return true
+	}
+	return qa.Cmp(qb) == 0
+}
+
+// changedSections shortens the drifted field paths to the name of the section, like env for
+// spec.env or labels for metadata.labels
+func changedSections(fields []string) []string {
+	sections := make([]string, 0, len(fields))
+	for _, f := range fields {
+		sections = append(sections, f[strings.LastIndex(f, ".")+1:])
+	}
+	return sections
+}
+
+// validateReplacementSource checks the source given with --force-replace-source is complete on
+// its own, since nothing from the previous source is kept to fill the gaps
+func (opts *WorkloadApplyOptions) validateReplacementSource() validation.FieldErrors {
+	errs := validation.FieldErrors{}
+	detail := fmt.Sprintf("required by %s", flags.ForceReplaceSourceFlagName)
+
+	switch {
+	case opts.GitRepo != "" || opts.GitBranch != "" || opts.GitCommit != "" || opts.GitTag != "":
+		if opts.GitRepo == "" {
+			errs = errs.Also(validation.ErrMissingFieldWithDetail(flags.GitRepoFlagName, detail))
+		}
+		if opts.GitBranch == "" && opts.GitTag == "" && opts.GitCommit == "" {
+			errs = errs.Also(validation.ErrMissingOneOfWithDetail(fmt.Sprintf("expected exactly one, got neither: %s", detail), flags.GitBranchFlagName, flags.GitTagFlagName, flags.GitCommitFlagName))
+		}
+	case opts.MavenArtifact != "" || opts.MavenVersion != "" || opts.MavenGroup != "" || opts.MavenType != "":
+		if opts.MavenArtifact == "" {
+			errs = errs.Also(validation.ErrMissingFieldWithDetail(flags.MavenArtifactFlagName, detail))
+		}
+		if opts.MavenGroup == "" {
+			errs = errs.Also(validation.ErrMissingFieldWithDetail(flags.MavenGroupFlagName, detail))
+		}
+		if opts.MavenVersion == "" {
+			errs = errs.Also(validation.ErrMissingFieldWithDetail(flags.MavenVersionFlagName, detail))
+		}
+	case opts.Image != "" || opts.LocalPath != "" || opts.SourceImage != "":
+		// a single value is a complete source
+	default:
+		errs = errs.Also(validation.ErrMissingOneOfWithDetail(fmt.Sprintf("expected exactly one, got neither: %s", detail), flags.GitFlagWildcard, flags.ImageFlagName, flags.LocalPathFlagName, flags.SourceImageFlagName, MavenFlagWildcard))
+	}
+
 	return errs
 }
 
 func (opts *WorkloadApplyOptions) Exec(ctx context.Context, c *cli.Config) error {
-	var okToApply bool
-	shouldPrint := opts.Output == "" || (opts.Output != "" && !opts.Yes)
+	opts.logUnknownSuppressedWarnings(c)
+	// the prompts and the messages are only printed along with the workload yaml, when --output
+	// is set they're printed unless --yes is set
+	shouldPrint := opts.Output == "" || (opts.Output != helmValuesOutputFormat && !opts.Yes)
+	if shouldPrint && opts.isValidationDisabled(ctx) {
+		opts.warn(c, ValidationDisabledWarningID, fmt.Sprintf("client validation is disabled (%s=false), the workload is only validated by the cluster", flags.ValidateFlagName))
+	}
+	if shouldPrint && opts.FilePath != "" && !opts.isWarningSuppressed(UpdateStrategyWarningID) {
+		opts.warn(c, UpdateStrategyWarningID, fmt.Sprintf("Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use %q to control strategy explicitly).", flags.UpdateStrategyFlagName))
+		c.Printf("\n")
+	}
 
-	fileWorkload := &cartov1alpha1.Workload{}
-	if opts.FilePath != "" {
-		cli.PrintPromptWithEmoji(shouldPrint, c.Emoji, cli.Exclamation, fmt.Sprintf("WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use %q to control strategy explicitly).\n\n", flags.UpdateStrategyFlagName))
-		if err := opts.WorkloadOptions.LoadInputWorkload(c.Stdin, fileWorkload); err != nil {
+	if opts.Recursive {
+		if info, err := os.Stat(opts.FilePath); err == nil && info.IsDir() {
+			return opts.applyDir(ctx, c, shouldPrint)
+		}
+	}
+	_, err := opts.apply(ctx, c, shouldPrint)
+	return err
+}
+
+// applyDir applies every workload file found in the --file directory and its sub directories.
+// A failure to apply one file doesn't stop the others, all the failures are reported at the end.
+// The file being applied is shown on stderr, unless --no-progress is set, and the result of each
+// file is summarized in a table. --quiet leaves out the output of each file but its failure.
+// With --concurrency, up to that many files are applied at once and the output of each file is
+// printed, in the order of the files, once they are all applied. The warnings printed for every
+// file count toward --warnings-as-errors.
+func (opts *WorkloadApplyOptions) applyDir(ctx context.Context, c *cli.Config, shouldPrint bool) error {
+	files, err := workloadFilesInDir(opts.FilePath)
+	if err != nil {
+		return fmt.Errorf("unable to read directory %q: %w", opts.FilePath, err)
+	}
+	if len(files) == 0 {
+		c.Infof("No workload files found in %q\n", opts.FilePath)
+		return nil
+	}
+
+	results := make([]applyResult, len(files))
+	names := make([]string, len(files))
+	errs := make([]error, len(files))
+	fileOptions := make([]*WorkloadApplyOptions, len(files))
+	outputs := make([]*bytes.Buffer, len(files))
+	// with --dry-run, the resources are printed apart from the messages, see cli.ExecE
+	resourcesOut := cli.StdoutFromContext(ctx)
+	resources := make([]*bytes.Buffer, len(files))
+	workloads := make([]*cartov1alpha1.Workload, len(files))
+	var progressLock sync.Mutex
+	applyFile := func(i int) {
+		fileConfig := *c
+		fileCtx := ctx
+		if opts.Quiet {
+			fileConfig.Stdout = io.Discard
+			fileConfig.Stderr = io.Discard
+			if resourcesOut != nil {
+				fileCtx = cli.WithStdout(ctx, io.Discard)
+			}
+		} else if opts.Concurrency > 1 {
+			outputs[i] = &bytes.Buffer{}
+			fileConfig.Stdout = outputs[i]
+			fileConfig.Stderr = outputs[i]
+			if resourcesOut != nil {
+				resources[i] = &bytes.Buffer{}
+				fileCtx = cli.WithStdout(ctx, resources[i])
+			}
+		}
+		if !opts.Quiet && !opts.NoProgress {
+			progressLock.Lock()
+			c.Eprintf("[%d/%d] applying %s\n", i+1, len(files), files[i])
+			progressLock.Unlock()
+		}
+		fileOpts := *opts
+		fileOpts.FilePath = files[i]
+		fileOpts.Recursive = false
+		fileOpts.loadedFileWorkload = workloads[i]
+		// the files applied concurrently must not append to the same array
+		fileOpts.warningMessages = opts.warningMessages[:len(opts.warningMessages):len(opts.warningMessages)]
+		fileOptions[i] = &fileOpts
+
+		results[i], errs[i] = fileOpts.apply(fileCtx, &fileConfig, shouldPrint)
+		// the name is read from the file when it is loaded
+		names[i] = fileOpts.Name
+	}
+	reportFile := func(i int) {
+		if outputs[i] != nil {
+			c.Stdout.Write(outputs[i].Bytes())
+		}
+		if resources[i] != nil {
+			resourcesOut.Write(resources[i].Bytes())
+		}
+		if err := errs[i]; err != nil {
+			if errors.Is(err, cli.SilentError) {
+				c.Eprintf("%s %s\n", printer.Serrorf("Failed to apply workload file:"), files[i])
+			} else {
+				c.Eprintf("%s %s: %s\n", printer.Serrorf("Failed to apply workload file:"), files[i], err)
+			}
+		}
+	}
+
+	if opts.Concurrency > 1 {
+		var keys []client.ObjectKey
+		workloads, keys = opts.loadFileWorkloads(ctx, c, files)
+		seen := map[client.ObjectKey]string{}
+		slots := make(chan struct{}, opts.Concurrency)
+		var wg sync.WaitGroup
+		for i, key := range keys {
+			if previous, ok := seen[key]; ok && key.Name != "" {
+				// the result would depend on which file is applied last
+				names[i] = key.Name
+				errs[i] = fmt.Errorf("workload %q in namespace %q is also in file %q, they can't be applied concurrently", key.Name, key.Namespace, previous)
+				continue
+			}
+			seen[key] = files[i]
+			wg.Add(1)
+			slots <- struct{}{}
+			go func(i int) {
+				defer wg.Done()
+				defer func() { <-slots }()
+				applyFile(i)
+			}(i)
+		}
+		wg.Wait()
+		for i := range files {
+			reportFile(i)
+		}
+	} else {
+		for i := range files {
+			applyFile(i)
+			reportFile(i)
+		}
+	}
+
+	// the warnings of every file count for --warnings-as-errors, on top of the ones printed before
+	// the files are applied
+	baseWarnings, baseMessages := opts.warnings, len(opts.warningMessages)
+	for _, fileOpts := range fileOptions {
+		if fileOpts == nil {
+			continue
+		}
+		opts.warnings += fileOpts.warnings - baseWarnings
+		opts.warningMessages = append(opts.warningMessages, fileOpts.warningMessages[baseMessages:]...)
+	}
+
+	var created, updated, unchanged, failed int
+	summary := make([]printer.ApplyResult, len(files))
+	for i, file := range files {
+		summary[i] = printer.ApplyResult{File: file, Workload: names[i], Result: applyResultName(results[i], errs[i])}
+		switch {
+		case errs[i] != nil:
+			failed++
+		case results[i] == applyResultCreated:
+			created++
+		case results[i] == applyResultUpdated:
+			updated++
+		default:
+			unchanged++
+		}
+	}
+
+	if opts.Quiet || errs[len(files)-1] != nil {
+		// the output of each file ends with an empty line, unlike the failures
+		c.Printf("\n")
+	}
+	if err := printer.ApplySummaryPrinter(c.Stdout, summary); err != nil {
+		return err
+	}
+	c.Printf("\n")
+	c.Infof("Applied %d workload files: %d created, %d updated, %d unchanged, %d failed\n", len(files), created, updated, unchanged, failed)
+	if warnings := opts.warnings - baseWarnings; warnings != 0 {
+		c.Infof("%d warning(s) printed while applying the workload files\n", warnings)
+	}
+	if failed != 0 {
+		return cli.SilenceError(fmt.Errorf("%d of %d workload files failed to apply", failed, len(files)))
+	}
+	return nil
+}
+
+// loadFileWorkloads loads the workload of each file, along with its namespace and name. The
+// workload and the key of a file that can't be read are empty, the file is left to fail when
+// applied.
+func (opts *WorkloadApplyOptions) loadFileWorkloads(ctx context.Context, c *cli.Config, files []string) ([]*cartov1alpha1.Workload, []client.ObjectKey) {
+	workloads := make([]*cartov1alpha1.Workload, len(files))
+	keys := make([]client.ObjectKey, len(files))
+	for i, file := range files {
+		fileOpts := *opts
+		fileOpts.FilePath = file
+		workload := &cartov1alpha1.Workload{}
+		if err := fileOpts.LoadInputWorkload(ctx, c, workload); err != nil {
+			continue
+		}
+		workloads[i] = workload
+		if workload.Name == "" {
+			continue
+		}
+		keys[i] = client.ObjectKey{Namespace: opts.Namespace, Name: workload.Name}
+		if workload.Namespace != "" && !cli.CommandFromContext(ctx).Flags().Changed(cli.StripDash(flags.NamespaceFlagName)) {
+			keys[i].Namespace = workload.Namespace
+		}
+	}
+	return workloads, keys
+}
+
+// applyResultName is how the result of applying a file is shown in the summary table
+func applyResultName(result applyResult, err error) string {
+	switch {
+	case err != nil:
+		return "failed"
+	case result == applyResultCreated:
+		return "created"
+	case result == applyResultUpdated:
+		return "updated"
+	default:
+		return "unchanged"
+	}
+}
+
+// workloadApplyResult is the WorkloadApplyResult of the workload, for the tools embedding the command
+func (opts *WorkloadApplyOptions) workloadApplyResult(workload *cartov1alpha1.Workload, result applyResult, diff string) WorkloadApplyResult {
+	r := WorkloadApplyResult{
+		Namespace: workload.Namespace,
+		Name:      workload.Name,
+		Action:    WorkloadApplyAction(applyResultName(result, nil)),
+		Warnings:  append([]string(nil), opts.warningMessages...),
+	}
+	if result != applyResultUnchanged {
+		r.Diff = stripansi.Strip(diff)
+	}
+	return r
+}
+
+// recordChangeMessage adds the --message, with the time and the user of the current kube config
+// context, to the change log annotation of the workload. The entries of the workload in the
+// cluster are kept, unless replaced with --message-mode=replace.
+func (opts *WorkloadApplyOptions) recordChangeMessage(c *cli.Config, currentWorkload, workload *cartov1alpha1.Workload) {
+	if opts.Message == "" {
+		return
+	}
+	if opts.MessageMode == replaceMessageMode {
+		delete(workload.Annotations, apis.ChangeLogAnnotationName)
+	} else if currentWorkload != nil && workload.GetAnnotations()[apis.ChangeLogAnnotationName] == "" {
+		// the workload replacing the one in the cluster doesn't carry its change log
+		if log := currentWorkload.GetAnnotations()[apis.ChangeLogAnnotationName]; log != "" {
+			workload.MergeAnnotations(apis.ChangeLogAnnotationName, log)
+		}
+	}
+	user := c.Client.CurrentUser()
+	if user == "" {
+		user = "unknown"
+	}
+	workload.AddChangeLogEntry(fmt.Sprintf("%s %s: %s", c.Now().UTC().Format(time.RFC3339), user, opts.Message), maxChangeLogEntries)
+}
+
+// checkServiceAccount warns when the service account set on the workload, or changed from the one
+// of the workload in the cluster, can't be found in its namespace. It's not an error since the
+// user may not have permissions to read service accounts.
+func (opts *WorkloadApplyOptions) checkServiceAccount(ctx context.Context, c *cli.Config, currentWorkload, workload *cartov1alpha1.Workload) error {
+	if opts.NoSACheck || workload.Spec.ServiceAccountName == nil || *workload.Spec.ServiceAccountName == "" {
+		return nil
+	}
+	name := *workload.Spec.ServiceAccountName
+	if currentWorkload != nil && currentWorkload.Spec.ServiceAccountName != nil && *currentWorkload.Spec.ServiceAccountName == name {
+		return nil
+	}
+
+	err := c.Get(ctx, client.ObjectKey{Namespace: workload.Namespace, Name: name}, &corev1.ServiceAccount{})
+	switch {
+	case err == nil:
+	case apierrs.IsNotFound(err):
+		opts.warn(c, ServiceAccountNotFoundWarningID, fmt.Sprintf("service account %q not found in namespace %q, the workload may fail to run (use %s to skip this check)", name, workload.Namespace, flags.NoSACheckFlagName))
+	case apierrs.IsForbidden(err):
+		opts.warn(c, ServiceAccountNotFoundWarningID, fmt.Sprintf("unable to check service account %q exists in namespace %q, user does not have permissions to read it", name, workload.Namespace))
+	default:
+		return err
+	}
+	return nil
+}
+
+// workloadFilesInDir returns the yaml files in dir and all its sub directories in lexical order
+func workloadFilesInDir(dir string) ([]string, error) {
+	files := []string{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
 			return err
 		}
+		if d.IsDir() {
+			return nil
+		}
+		if ext := filepath.Ext(path); ext == ".yaml" || ext == ".yml" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// apply creates or updates a single workload and reports what was done to it. The prompts and the
+// messages are only printed with shouldPrint, see Exec.
+func (opts *WorkloadApplyOptions) apply(ctx context.Context, c *cli.Config, shouldPrint bool) (applyResult, error) {
+	var okToApply bool
 
+	validationDisabled := opts.isValidationDisabled(ctx)
+
+	fileWorkload := &cartov1alpha1.Workload{}
+	if opts.loadedFileWorkload != nil {
+		fileWorkload = opts.loadedFileWorkload.DeepCopy()
+	} else if opts.FilePath != "" {
+		if err := opts.WorkloadOptions.LoadInputWorkload(ctx, c, fileWorkload); err != nil {
+			return applyResultUnchanged, err
+		}
+	}
+	if opts.FilePath != "" {
 		if opts.Name == "" {
 			opts.Name = fileWorkload.Name
 		}
@@ -92,27 +995,45 @@ func (opts *WorkloadApplyOptions) Exec(ctx context.Context, c *cli.Config) error
 	}
 	if opts.Namespace == "" {
 		errs = errs.Also(validation.ErrMissingField(flags.NamespaceFlagName))
+	} else {
+		// the namespace may come from the file, that is read after the flags are validated
+		errs = errs.Also(validation.K8sName(opts.Namespace, flags.NamespaceFlagName))
 	}
 	if err := errs.ToAggregate(); err != nil {
-		return err
+		return applyResultUnchanged, err
+	}
+	if err := opts.expandSourceImage(opts.Name); err != nil {
+		return applyResultUnchanged, err
+	}
+
+	if opts.Output == helmValuesOutputFormat {
+		return applyResultUnchanged, opts.outputHelmValues(ctx, c, fileWorkload, validationDisabled)
 	}
 
 	workload := &cartov1alpha1.Workload{}
 	var currentWorkload *cartov1alpha1.Workload
-	err := c.Get(ctx, client.ObjectKey{Namespace: opts.Namespace, Name: opts.Name}, workload)
+	// update only flows report a missing workload instead of a missing namespace
+	err := getWorkloadValidatingNamespace(ctx, c, opts.Retry, client.ObjectKey{Namespace: opts.Namespace, Name: opts.Name}, workload, !opts.UpdateOnly)
 	if err == nil {
 		currentWorkload = workload.DeepCopy()
 	} else {
-		if !apierrs.IsNotFound(err) {
-			return err
+		if !apierrs.IsNotFound(err) || errors.Is(err, errNamespaceNotFound) {
+			return applyResultUnchanged, err
 		}
-		if apierrs.IsNotFound(err) {
-			if nsErr := validateNamespace(ctx, c, opts.Namespace); nsErr != nil {
-				return nsErr
+		// update only flows must not resurrect a workload that was deleted
+		if opts.UpdateOnly {
+			if opts.IgnoreNotFound {
+				return applyResultUnchanged, nil
 			}
+			c.Errorf("Workload %q not found\n", fmt.Sprintf("%s/%s", opts.Namespace, opts.Name))
+			return applyResultUnchanged, cli.SilenceError(err)
 		}
 	}
 
+	// the workload before pruning, to keep the fields out of --prune-allowlist and to list the
+	// pruned entries
+	unpruned := workload.DeepCopy()
+	prunePlan := []string{}
 	if opts.UpdateStrategy == mergeUpdateStrategy {
 		if opts.FilePath != "" {
 			var serviceAccountCopy string
@@ -122,7 +1043,31 @@ func (opts *WorkloadApplyOptions) Exec(ctx context.Context, c *cli.Config) error
 			}
 
 			workload.Spec.MergeServiceAccountName(serviceAccountCopy)
+
+			// remove the fields that were dropped from the file since the last apply
+			if opts.SaveConfig && currentWorkload != nil {
+				lastApplied, err := currentWorkload.GetLastAppliedConfiguration()
+				if err != nil {
+					return applyResultUnchanged, fmt.Errorf("unable to read last applied configuration: %w", err)
+				}
+				if lastApplied != nil {
+					workload.PruneLastApplied(lastApplied, fileWorkload)
+				}
+			}
+		}
+
+		// drop the env entries and params that are not supplied through the file or flags in this invocation
+		if opts.PruneEnv {
+			workload.Spec.ClearEnv()
 		}
+		if opts.PruneBuildEnv {
+			workload.Spec.ClearBuildEnv()
+		}
+		if opts.PruneParams {
+			workload.Spec.ClearParams()
+		}
+		opts.keepUnprunableFields(workload, unpruned)
+		prunePlan = prunedEntries(unpruned, workload)
 		workload.Merge(fileWorkload)
 	}
 
@@ -133,53 +1078,141 @@ func (opts *WorkloadApplyOptions) Exec(ctx context.Context, c *cli.Config) error
 		// if there is a workload in the cluster with all metadata populated
 		// re assign the system populated fields so we won't find an error because of some missing fields
 		workload.ReplaceMetadata(currentWorkload)
+		if currentWorkload != nil {
+			// the service claims are a set, reordering them in the file is not a change
+			workload.Spec.KeepServiceClaimsOrder(&currentWorkload.Spec)
+		}
+	}
+
+	if opts.SaveConfig {
+		if err := workload.SetLastAppliedConfiguration(fileWorkload); err != nil {
+			return applyResultUnchanged, fmt.Errorf("unable to save last applied configuration: %w", err)
+		}
 	}
 
 	workload.Name = opts.Name
 	workload.Namespace = opts.Namespace
 	workloadExists := currentWorkload != nil
 
+	if opts.ForceReplaceSource {
+		// drop every trace of the previous source so the new one is not merged with it
+		workload.Spec.ResetSource()
+		workload.Spec.RemoveParam(cartov1alpha1.WorkloadMavenParam)
+	}
+
 	ctx = opts.ApplyOptionsToWorkload(ctx, currentWorkload, workload)
+	if err := opts.ApplyEnvFromConfigMaps(ctx, c, workload); err != nil {
+		return applyResultUnchanged, err
+	}
+	if err := opts.setImageTag(workload); err != nil {
+		return applyResultUnchanged, err
+	}
+	opts.recordChangeMessage(c, currentWorkload, workload)
+	opts.prunePlan = stillPruned(prunePlan, prunedEntries(unpruned, workload))
 
 	// validate complex flag interactions with existing state
-	errs = workload.Validate()
+	if !validationDisabled {
+		if workloadExists {
+			errs = workload.Spec.ValidateSourceChange(&currentWorkload.Spec)
+		}
+		if len(errs) == 0 {
+			errs = workload.Validate()
+		}
+		if len(errs) == 0 && opts.ValidateSchema {
+			errs = opts.validateSchema(ctx, c, workload)
+		}
+	}
 	if err := errs.ToAggregate(); err != nil {
 		// show command usage before error
 		cli.CommandFromContext(ctx).SilenceUsage = false
-		return err
+		return applyResultUnchanged, err
+	}
+
+	if err := opts.checkAllowedGitHost(currentWorkload, workload); err != nil {
+		return applyResultUnchanged, err
+	}
+
+	if err := opts.PinImageDigest(ctx, workload); err != nil {
+		return applyResultUnchanged, err
+	}
+
+	if opts.PrintFlags {
+		return applyResultUnchanged, printWorkloadFlags(c, workload)
+	}
+
+	if opts.Diff {
+		return applyResultUnchanged, opts.showDrift(c, currentWorkload, workload)
+	}
+
+	// with --output json, the removed fields are reported apart from the workload
+	removed := []string{}
+	if opts.Output == printer.OutputFormatJson {
+		if removed, err = workloadRemovedFields(currentWorkload, workload); err != nil {
+			return applyResultUnchanged, err
+		}
 	}
 
 	if opts.DryRun {
-		cli.DryRunResource(ctx, workload, workload.GetGroupVersionKind())
-		return nil
+		if opts.Output == patchOutputFormat {
+			return applyResultUnchanged, opts.outputWorkloadPatch(c, cli.StdoutFromContext(ctx), currentWorkload, workload)
+		}
+		if err := opts.DryRunWorkload(ctx, c, workload); err != nil {
+			return applyResultUnchanged, err
+		}
+		return applyResultUnchanged, outputRemovedFields(c, removed)
 	}
 
 	if opts.useLSP(currentWorkload) {
 		if err := checkLSPHealth(ctx, c); err != nil {
-			return err
+			return applyResultUnchanged, err
 		}
 	}
 
 	if err := opts.PublishLocalSource(ctx, c, currentWorkload, workload, shouldPrint); err != nil {
-		return err
+		return applyResultUnchanged, err
 	}
 	opts.ManageLocalSourceProxyAnnotation(fileWorkload, currentWorkload, workload)
 
+	// the diff is rendered before the cluster populates the workload, like it's shown to the user
+	results := RetrieveWorkloadApplyResults(ctx)
+	diff := ""
+	if results != nil {
+		if diff, _, err = opts.resourceDiff(currentWorkload, workload, c.Scheme); err != nil {
+			return applyResultUnchanged, err
+		}
+	}
+
+	// the patch is the change sent to the cluster, before the cluster populates the workload
+	patch := &bytes.Buffer{}
+	if opts.Output == patchOutputFormat {
+		if err := opts.outputWorkloadPatch(c, patch, currentWorkload, workload); err != nil {
+			return applyResultUnchanged, err
+		}
+	}
+
 	// if output flag was not set or it was not used with yes flag, then proceed to show
 	// surveys and all other output
 	if shouldPrint {
 		var okToCreate, okToUpdate bool
 		var createError, updateError error
+		if err := opts.checkServiceAccount(ctx, c, currentWorkload, workload); err != nil {
+			return applyResultUnchanged, err
+		}
 		// If there is no workload, create a new one
 		if !workloadExists {
 			okToCreate, createError = opts.Create(ctx, c, workload)
 			if createError != nil {
-				return createError
+				return applyResultUnchanged, createError
 			}
 		} else {
+			if opts.UpdateStrategy == replaceUpdateStrategy {
+				if err := printRemovedFields(c, currentWorkload, workload); err != nil {
+					return applyResultUnchanged, err
+				}
+			}
 			okToUpdate, updateError = opts.Update(ctx, c, currentWorkload, workload)
 			if updateError != nil {
-				return updateError
+				return applyResultUnchanged, updateError
 			}
 		}
 
@@ -194,15 +1227,26 @@ func (opts *WorkloadApplyOptions) Exec(ctx context.Context, c *cli.Config) error
 		okToApply = opts.Yes
 		if !workloadExists {
 			if err := c.Create(ctx, workload); err != nil {
-				return err
+				return applyResultUnchanged, err
 			}
 		} else {
 			if err := c.Update(ctx, workload); err != nil {
-				return err
+				return applyResultUnchanged, err
 			}
 		}
 	}
 
+	result := applyResultUnchanged
+	if okToApply {
+		result = applyResultUpdated
+		if !workloadExists {
+			result = applyResultCreated
+		}
+	}
+	if results != nil {
+		results.add(opts.workloadApplyResult(workload, result, diff))
+	}
+
 	if okToApply {
 		anyTail := opts.Tail || opts.TailTimestamps
 		var workers []wait.Worker
@@ -210,7 +1254,7 @@ func (opts *WorkloadApplyOptions) Exec(ctx context.Context, c *cli.Config) error
 			cli.PrintPrompt(shouldPrint, c.Infof, "Waiting for workload %q to become ready...\n", opts.Name)
 
 			if workloadExists {
-				statusChangeWorkers := []wait.Worker{getStatusChangeWorker(c, currentWorkload)}
+				statusChangeWorkers := []wait.Worker{getStatusChangeWorker(c, currentWorkload, opts.WaitInterval)}
 
 				timeout := opts.WaitTimeout
 				stashedTimeout, ok := ctx.Value(WorkloadTimeoutStashKey{}).(string)
@@ -221,19 +1265,29 @@ func (opts *WorkloadApplyOptions) Exec(ctx context.Context, c *cli.Config) error
 				}
 
 				if waitErr := raceWithTimeout(ctx, c, workload, timeout, shouldPrint, waitErrorForStatusChange, statusChangeWorkers); waitErr != nil && opts.Output == "" {
-					return cli.SilenceError(waitErr)
+					if err := opts.handleWaitError(ctx, c, workload, workloadExists, waitErr); err != nil {
+						return applyResultUnchanged, err
+					}
+					return result, nil
 				}
 			}
 
-			workers = append(workers, getReadyConditionWorker(c, workload))
+			workers = append(workers, getReadyConditionWorker(c, workload, opts.WaitInterval, opts.FailOnUnknownReasons))
 
 			if anyTail {
 				workers = append(workers, getTailWorker(c, workload, opts.TailTimestamps))
 			}
 
+			if opts.Events && shouldPrint {
+				workers = append(workers, getEventsWorker(c, workload))
+			}
+
 			waitErr := raceWithTimeout(ctx, c, workload, opts.WaitTimeout, shouldPrint, waitErrorForReadyCondition, workers)
 			if waitErr != nil && opts.Output == "" {
-				return cli.SilenceError(waitErr)
+				if err := opts.handleWaitError(ctx, c, workload, workloadExists, waitErr); err != nil {
+					return applyResultUnchanged, opts.rollbackOnError(ctx, c, workload, result, err)
+				}
+				return result, nil
 			}
 
 			// since there is a possibility that wait failed but did not return
@@ -243,20 +1297,232 @@ func (opts *WorkloadApplyOptions) Exec(ctx context.Context, c *cli.Config) error
 			}
 		}
 
-		if opts.Output != "" {
+		if opts.Output == patchOutputFormat {
+			c.Printf("%s", patch)
+		} else if opts.Output != "" {
 			// once the workload is applied, get it as is in the cluster
 			if err := c.Get(ctx, client.ObjectKey{Namespace: opts.Namespace, Name: opts.Name}, workload); err != nil {
-				return err
+				return applyResultUnchanged, opts.rollbackOnError(ctx, c, workload, result, err)
 			}
 			if err := opts.OutputWorkload(c, workload); err != nil {
-				return err
+				return applyResultUnchanged, opts.rollbackOnError(ctx, c, workload, result, err)
+			}
+			if err := outputRemovedFields(c, removed); err != nil {
+				return applyResultUnchanged, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// handleWaitError applies the --timeout-action once waiting for the workload timed out. Other wait
+// errors always fail the command. A rollback only deletes a workload this command created, a
+// workload that existed before is left as applied.
+func (opts *WorkloadApplyOptions) handleWaitError(ctx context.Context, c *cli.Config, workload *cartov1alpha1.Workload, workloadExists bool, waitErr error) error {
+	if waitErr != context.DeadlineExceeded {
+		return cli.SilenceError(waitErr)
+	}
+
+	switch opts.TimeoutAction {
+	case ignoreTimeoutAction:
+		c.Infof("Workload %q was applied but is not ready yet, to check its status: \"tanzu apps workload get %s %s %s\"\n", workload.Name, workload.Name, flags.NamespaceFlagName, workload.Namespace)
+		return nil
+	case rollbackTimeoutAction:
+		if workloadExists {
+			c.Infof("Workload %q existed before this command, it is not rolled back\n", workload.Name)
+			return cli.SilenceError(waitErr)
+		}
+		if err := c.Delete(ctx, workload); err != nil {
+			if apierrs.IsNotFound(err) {
+				return cli.SilenceError(waitErr)
 			}
+			c.Eprintf("%s %s\n", printer.Serrorf("Failed to roll back workload:"), err)
+			return cli.SilenceError(err)
 		}
+		c.Infof("Deleted workload %q created by this command\n", workload.Name)
 	}
+	return cli.SilenceError(waitErr)
+}
 
+// rollbackOnError deletes the workload created by this command once a following step failed, with
+// --rollback-on-error. A workload that existed before the command, even if it was updated, is
+// never deleted. The error of the failed step is returned.
+func (opts *WorkloadApplyOptions) rollbackOnError(ctx context.Context, c *cli.Config, workload *cartov1alpha1.Workload, result applyResult, err error) error {
+	if !opts.RollbackOnError || result != applyResultCreated {
+		return err
+	}
+	if deleteErr := c.Delete(ctx, workload); deleteErr != nil {
+		// already rolled back, e.g. by --timeout-action rollback
+		if !apierrs.IsNotFound(deleteErr) {
+			c.Eprintf("%s %s\n", printer.Serrorf("Failed to roll back workload:"), deleteErr)
+		}
+		return err
+	}
+	c.Infof("Rolled back workload %q created by this command\n", workload.Name)
+	return err
+}
+
+// printRemovedFields lists the fields the replace update strategy drops from the workload in the
+// cluster, so they are visible before the update is confirmed
+func printRemovedFields(c *cli.Config, currentWorkload, workload *cartov1alpha1.Workload) error {
+	removed, err := printer.ResourceRemovedFields(currentWorkload, workload)
+	if err != nil {
+		return err
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+	c.Emoji(cli.Exclamation, "The %q update strategy removes these fields from the workload:\n", replaceUpdateStrategy)
+	for _, field := range removed {
+		c.Printf("  - %s\n", field)
+	}
+	c.Printf("\n")
 	return nil
 }
 
+// keepUnprunableFields restores the fields out of --prune-allowlist to their value before pruning
+func (opts *WorkloadApplyOptions) keepUnprunableFields(workload, unpruned *cartov1alpha1.Workload) {
+	if len(opts.PruneAllowlist) == 0 {
+		return
+	}
+	allowed := sets.NewString(opts.PruneAllowlist...)
+	if !allowed.Has(pruneLabels) {
+		workload.Labels = unpruned.Labels
+	}
+	if !allowed.Has(pruneAnnotations) {
+		workload.Annotations = unpruned.Annotations
+	}
+	if !allowed.Has(pruneParams) {
+		workload.Spec.Params = unpruned.Spec.Params
+	}
+	if !allowed.Has(pruneEnv) {
+		workload.Spec.Env = unpruned.Spec.Env
+	}
+	if !allowed.Has(pruneBuildEnv) {
+		workload.Spec.Build = unpruned.Spec.Build
+	}
+	if !allowed.Has(pruneServiceClaims) {
+		workload.Spec.ServiceClaims = unpruned.Spec.ServiceClaims
+	}
+	if !allowed.Has(pruneResources) {
+		workload.Spec.Resources = unpruned.Spec.Resources
+	}
+	if !allowed.Has(pruneServiceAccount) {
+		workload.Spec.ServiceAccountName = unpruned.Spec.ServiceAccountName
+	}
+	if !allowed.Has(pruneSubPath) && workload.Spec.Source != nil && unpruned.Spec.Source != nil {
+		workload.Spec.Source.Subpath = unpruned.Spec.Source.Subpath
+	}
+}
+
+// prunedEntries lists the entries of the unpruned workload that are missing from the workload,
+// like "env FOO" or "label app.kubernetes.io/part-of"
+func prunedEntries(unpruned, workload *cartov1alpha1.Workload) []string {
+	entries := []string{}
+	for _, k := range sets.StringKeySet(unpruned.Labels).List() {
+		if _, ok := workload.Labels[k]; !ok {
+			entries = append(entries, fmt.Sprintf("label %s", k))
+		}
+	}
+	for _, k := range sets.StringKeySet(unpruned.Annotations).List() {
+		if _, ok := workload.Annotations[k]; !ok && k != apis.LastAppliedConfigurationAnnotationName {
+			entries = append(entries, fmt.Sprintf("annotation %s", k))
+		}
+	}
+	params := sets.NewString()
+	for _, p := range workload.Spec.Params {
+		params.Insert(p.Name)
+	}
+	for _, p := range unpruned.Spec.Params {
+		if !params.Has(p.Name) {
+			entries = append(entries, fmt.Sprintf("param %s", p.Name))
+		}
+	}
+	env := sets.NewString()
+	for _, e := range workload.Spec.Env {
+		env.Insert(e.Name)
+	}
+	for _, e := range unpruned.Spec.Env {
+		if !env.Has(e.Name) {
+			entries = append(entries, fmt.Sprintf("env %s", e.Name))
+		}
+	}
+	if unpruned.Spec.Build != nil {
+		buildEnv := sets.NewString()
+		if workload.Spec.Build != nil {
+			for _, e := range workload.Spec.Build.Env {
+				buildEnv.Insert(e.Name)
+			}
+		}
+		for _, e := range unpruned.Spec.Build.Env {
+			if !buildEnv.Has(e.Name) {
+				entries = append(entries, fmt.Sprintf("build-env %s", e.Name))
+			}
+		}
+	}
+	claims := sets.NewString()
+	for _, sc := range workload.Spec.ServiceClaims {
+		claims.Insert(sc.Name)
+	}
+	for _, sc := range unpruned.Spec.ServiceClaims {
+		if !claims.Has(sc.Name) {
+			entries = append(entries, fmt.Sprintf("service-claim %s", sc.Name))
+		}
+	}
+	if unpruned.Spec.Resources != nil {
+		resources := workload.Spec.Resources
+		if resources == nil {
+			resources = &corev1.ResourceRequirements{}
+		}
+		limits, requests := sets.NewString(), sets.NewString()
+		for k := range unpruned.Spec.Resources.Limits {
+			if _, ok := resources.Limits[k]; !ok {
+				limits.Insert(string(k))
+			}
+		}
+		for k := range unpruned.Spec.Resources.Requests {
+			if _, ok := resources.Requests[k]; !ok {
+				requests.Insert(string(k))
+			}
+		}
+		for _, k := range limits.List() {
+			entries = append(entries, fmt.Sprintf("limit %s", k))
+		}
+		for _, k := range requests.List() {
+			entries = append(entries, fmt.Sprintf("request %s", k))
+		}
+	}
+	if unpruned.Spec.ServiceAccountName != nil && workload.Spec.ServiceAccountName == nil {
+		entries = append(entries, "service-account")
+	}
+	if unpruned.Spec.Source != nil && unpruned.Spec.Source.Subpath != "" && (workload.Spec.Source == nil || workload.Spec.Source.Subpath == "") {
+		entries = append(entries, "sub-path")
+	}
+	return entries
+}
+
+// stillPruned keeps the pruned entries that were not set back through the file or flags
+func stillPruned(pruned, missing []string) []string {
+	missingSet := sets.NewString(missing...)
+	entries := []string{}
+	for _, entry := range pruned {
+		if missingSet.Has(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// isValidationDisabled returns true only when the client validation was explicitly turned off
+func (opts *WorkloadApplyOptions) isValidationDisabled(ctx context.Context) bool {
+	if opts.Validation {
+		return false
+	}
+	cmd := cli.CommandFromContext(ctx)
+	return cmd != nil && cmd.Flags().Changed(cli.StripDash(flags.ValidateFlagName))
+}
+
 func (opts *WorkloadApplyOptions) IsDryRun() bool {
 	return opts.DryRun
 }
@@ -279,6 +1545,7 @@ Workload configuration options include:
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s workload apply %s workload.yaml", c.Name, flags.FilePathFlagName),
+			fmt.Sprintf("%s workload apply %s ./workloads %s", c.Name, flags.FilePathFlagName, flags.RecursiveFlagName),
 		}, "\n"),
 		PreRunE:           cli.ValidateE(ctx, opts),
 		RunE:              cli.ExecE(ctx, c, opts),
@@ -291,10 +1558,51 @@ Workload configuration options include:
 
 	// Define common flags
 	opts.DefineFlags(ctx, c, cmd)
+	cmd.Flags().Lookup(cli.StripDash(flags.OutputFlagName)).Usage = fmt.Sprintf("output the Workload formatted. Supported formats: \"json\", \"yaml\", \"yml\", \"%s\" (the JSON merge patch of the changes to the workload, or the workload to create), \"%s\" (the workload set through the file and the flags as Helm values, without contacting the cluster)", patchOutputFormat, helmValuesOutputFormat)
 	cmd.Flags().StringVar(&opts.UpdateStrategy, cli.StripDash(flags.UpdateStrategyFlagName), mergeUpdateStrategy, fmt.Sprintf("specify configuration file update strategy (supported strategies: %s, %s)", mergeUpdateStrategy, replaceUpdateStrategy))
 	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.UpdateStrategyFlagName), func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return []string{replaceUpdateStrategy, mergeUpdateStrategy}, cobra.ShellCompDirectiveNoFileComp
 	})
+	cmd.Flags().StringVar(&opts.InputFormat, cli.StripDash(flags.InputFormatFlagName), autoInputFormat, fmt.Sprintf("`format` of the "+flags.FilePathFlagName+" content: %s, %s, or %s to detect it from its first character", yamlInputFormat, jsonInputFormat, autoInputFormat))
+	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.InputFormatFlagName), func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{autoInputFormat, yamlInputFormat, jsonInputFormat}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().StringVar(&opts.ExpandEnv, cli.StripDash(flags.ExpandEnvFlagName), noExpandEnv, fmt.Sprintf("expand the $VAR and ${VAR} references in the "+flags.EnvFlagName+" values from the CLI environment, failing on undefined variables, or %s to expand them to an empty value", allowEmptyExpandEnv))
+	cmd.Flags().Lookup(cli.StripDash(flags.ExpandEnvFlagName)).NoOptDefVal = strictExpandEnv
+	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.ExpandEnvFlagName), func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{noExpandEnv, strictExpandEnv, allowEmptyExpandEnv}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().BoolVar(&opts.Bare, cli.StripDash(flags.BareFlagName), false, "with "+flags.DryRunFlagName+", leave out the "+apis.WorkloadTypeLabelName+" label added by default when "+flags.TypeFlagName+" is not set, the status and the metadata populated by the cluster, for a minimal manifest")
+	cmd.Flags().BoolVar(&opts.Guaranteed, cli.StripDash(flags.GuaranteedFlagName), false, "set the cpu and memory limits of the workload to its requests, or the requests to the limits when only limits are set, for a Guaranteed QoS class")
+	cmd.Flags().BoolVar(&opts.SaveConfig, cli.StripDash(flags.SaveConfigFlagName), false, "store the configuration file in an annotation so following merge updates remove the fields dropped from the file")
+	cmd.Flags().BoolVar(&opts.PruneEnv, cli.StripDash(flags.PruneEnvFlagName), false, "remove environment variables not set through the file or flags when merging with an existing workload")
+	cmd.Flags().BoolVar(&opts.PruneParams, cli.StripDash(flags.PruneParamsFlagName), false, "remove params not set through the file or flags when merging with an existing workload, the maven source is kept")
+	cmd.Flags().BoolVar(&opts.PruneBuildEnv, cli.StripDash(flags.PruneBuildEnvFlagName), false, "remove build environment variables not set through the file or flags when merging with an existing workload")
+	cmd.Flags().StringSliceVar(&opts.PruneAllowlist, cli.StripDash(flags.PruneAllowlistFlagName), []string{}, "workload `fields` the pruning can remove entries from, any of "+strings.Join(prunableFields, ", ")+" (flag can be used multiple times), all of them when not set")
+	cmd.Flags().BoolVarP(&opts.Recursive, cli.StripDash(flags.RecursiveFlagName), "R", false, "apply every workload file (*.yaml, *.yml) in the "+flags.FilePathFlagName+" directory and its sub directories")
+	cmd.Flags().BoolVar(&opts.Quiet, cli.StripDash(flags.QuietFlagName), false, "with "+flags.RecursiveFlagName+" and "+flags.YesFlagName+", only print the summary of the applied files and their failures")
+	cmd.Flags().BoolVar(&opts.NoProgress, cli.StripDash(flags.NoProgressFlagName), false, "with "+flags.RecursiveFlagName+", don't print the file being applied to stderr")
+	cmd.Flags().IntVar(&opts.Concurrency, cli.StripDash(flags.ConcurrencyFlagName), 1, "with "+flags.RecursiveFlagName+" and "+flags.YesFlagName+", `number` of workload files applied at once, 0 or 1 to apply them one at a time")
+	cmd.Flags().BoolVar(&opts.UpdateOnly, cli.StripDash(flags.UpdateOnlyFlagName), false, "only update an existing workload, fail instead of creating it when it doesn't exist")
+	cmd.Flags().BoolVar(&opts.IgnoreNotFound, cli.StripDash(flags.IgnoreNotFoundFlagName), false, "with "+flags.UpdateOnlyFlagName+", exit successfully without changes when the workload doesn't exist")
+	cmd.Flags().BoolVar(&opts.Diff, cli.StripDash(flags.DiffFlagName), false, "show the changes apply would make to the workload in the cluster without applying them")
+	cmd.Flags().BoolVar(&opts.ExitCode, cli.StripDash(flags.ExitCodeFlagName), false, "with "+flags.DiffFlagName+", exit with code 2 when the workload in the cluster differs, 0 when it doesn't and 1 on errors")
+	cmd.Flags().BoolVar(&opts.AlwaysShow, cli.StripDash(flags.AlwaysShowFlagName), false, "print the current workload, dimmed, even when it is unchanged")
+	cmd.Flags().BoolVar(&opts.PrintFlags, cli.StripDash(flags.PrintFlagsFlagName), false, "print the workload apply command with the flags reproducing the workload, instead of applying it")
+	cmd.Flags().StringVar(&opts.TimeoutAction, cli.StripDash(flags.TimeoutActionFlagName), failTimeoutAction, fmt.Sprintf("`action` taken when waiting for the workload times out: %s the command, %s the timeout, or %s a workload created by this command", failTimeoutAction, ignoreTimeoutAction, rollbackTimeoutAction))
+	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.TimeoutActionFlagName), func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{failTimeoutAction, ignoreTimeoutAction, rollbackTimeoutAction}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().BoolVar(&opts.Events, cli.StripDash(flags.EventsFlagName), false, "while waiting, print the events of the workload and the resources it owns (requires permission to watch events in the namespace)")
+	cmd.Flags().BoolVar(&opts.RollbackOnError, cli.StripDash(flags.RollbackOnErrorFlagName), false, "delete the workload created by this command when a following step, like waiting for it to become ready, fails; an existing workload is never deleted")
+	cmd.Flags().StringVar(&opts.SetImageTag, cli.StripDash(flags.SetImageTagFlagName), "", "replace only the `tag` or digest of the workload pre-built image, keeping its repository")
+	cmd.Flags().BoolVar(&opts.GitRefExclusive, cli.StripDash(flags.GitRefExclusiveFlagName), false, "with "+flags.GitBranchFlagName+", "+flags.GitTagFlagName+" or "+flags.GitCommitFlagName+", clear the git refs of the workload that are not given through the flags")
+	cmd.Flags().BoolVar(&opts.ForceReplaceSource, cli.StripDash(flags.ForceReplaceSourceFlagName), false, "clear the git, image, sub path and maven source of the workload before setting the source given through flags")
+	cmd.Flags().StringVar(&opts.Message, cli.StripDash(flags.MessageFlagName), "", fmt.Sprintf("record the reason of the change, with the time and the kube config context user, in the %s annotation of the workload", apis.ChangeLogAnnotationName))
+	cmd.Flags().StringVar(&opts.MessageMode, cli.StripDash(flags.MessageModeFlagName), appendMessageMode, fmt.Sprintf("with %s, %s the message to the change log, keeping the last %d entries, or %s the change log with it", flags.MessageFlagName, appendMessageMode, maxChangeLogEntries, replaceMessageMode))
+	cmd.Flags().BoolVar(&opts.NoSACheck, cli.StripDash(flags.NoSACheckFlagName), false, "skip checking that the service account set through "+flags.ServiceAccountFlagName+" or the file exists in the namespace")
+	cmd.Flags().BoolVar(&opts.Validation, cli.StripDash(flags.ValidateFlagName), true, "validate the workload in the client before sending it to the cluster ("+flags.ValidateFlagName+"=false to only rely on the cluster validation)")
+	cmd.Flags().BoolVar(&opts.ValidateSchema, cli.StripDash(flags.ValidateSchemaFlagName), false, "also validate the workload spec against the schema of the Workload CRD read from the cluster, reporting the invalid fields by path")
 
 	// Bind flags to environment variables
 	opts.DefineEnvVars(ctx, c, cmd)
@@ -0,0 +1,79 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"sync"
+)
+
+// WorkloadApplyAction is what a workload apply did to the workload in the cluster
+type WorkloadApplyAction string
+
+const (
+	WorkloadApplyCreated   WorkloadApplyAction = "created"
+	WorkloadApplyUpdated   WorkloadApplyAction = "updated"
+	WorkloadApplyUnchanged WorkloadApplyAction = "unchanged"
+)
+
+// WorkloadApplyResult is the outcome of applying a workload, for the tools embedding the apply
+// command, and the tests, to check without reading the output
+type WorkloadApplyResult struct {
+	Namespace string
+	Name      string
+	Action    WorkloadApplyAction
+	// Diff is the change shown to the user, without the ANSI colors. It's empty when the workload
+	// is unchanged.
+	Diff string
+	// Warnings are the messages of the warnings printed
+	Warnings []string
+}
+
+// WorkloadApplyResults collects the result of each workload applied with the context it's stashed
+// on, see StashWorkloadApplyResults. A recursive apply adds a result per workload file, in the
+// order they are applied. A workload that fails before it's applied has no result.
+type WorkloadApplyResults struct {
+	lock    sync.Mutex
+	results []WorkloadApplyResult
+}
+
+// Results returns a copy of the results collected so far
+func (r *WorkloadApplyResults) Results() []WorkloadApplyResult {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]WorkloadApplyResult{}, r.results...)
+}
+
+func (r *WorkloadApplyResults) add(result WorkloadApplyResult) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.results = append(r.results, result)
+}
+
+type workloadApplyResultsStashKey struct{}
+
+func StashWorkloadApplyResults(ctx context.Context, results *WorkloadApplyResults) context.Context {
+	return context.WithValue(ctx, workloadApplyResultsStashKey{}, results)
+}
+
+func RetrieveWorkloadApplyResults(ctx context.Context) *WorkloadApplyResults {
+	results, ok := ctx.Value(workloadApplyResultsStashKey{}).(*WorkloadApplyResults)
+	if !ok {
+		return nil
+	}
+	return results
+}
@@ -16,7 +16,9 @@ limitations under the License.
 package commands_test
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -30,8 +32,11 @@ import (
 	diecorev1 "dies.dev/apis/core/v1"
 	diemetav1 "dies.dev/apis/meta/v1"
 	"github.com/Netflix/go-expect"
+	"github.com/fatih/color"
+	"github.com/google/go-cmp/cmp"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/mock"
+	rtesting "github.com/vmware-labs/reconciler-runtime/testing"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
@@ -41,6 +46,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/apis"
@@ -63,6 +69,7 @@ func TestWorkloadApplyOptionsValidate(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = cartov1alpha1.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
+	t.Setenv("APPS_TEST_VERSION", "1.2.3")
 	table := clitesting.ValidatableTestSuite{
 		{
 			Name: "valid options",
@@ -86,6 +93,45 @@ func TestWorkloadApplyOptionsValidate(t *testing.T) {
 			},
 			ExpectFieldErrors: validation.ErrInvalidArrayValue("FOO", flags.EnvFlagName, 0),
 		},
+		{
+			Name: "output patch",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+					Output:    "patch",
+				},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "output helm values",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+					Output:    "helm-values",
+				},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "output helm values with wait and local path",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace:   "default",
+					Name:        "my-resource",
+					Output:      "helm-values",
+					LocalPath:   ".",
+					SourceImage: "repo.example/image:tag",
+					Wait:        true,
+				},
+			},
+			ExpectFieldErrors: validation.FieldErrors{}.Also(
+				validation.ErrInvalidValueWithDetail("helm-values", flags.OutputFlagName, "the cluster is not contacted, it can't be used with "+flags.LocalPathFlagName),
+				validation.ErrInvalidValueWithDetail("helm-values", flags.OutputFlagName, "the cluster is not contacted, it can't be used with "+flags.WaitFlagName),
+			),
+		},
 		{
 			Name: "update strategy without filepath",
 			Validatable: &commands.WorkloadApplyOptions{
@@ -105,6 +151,65 @@ func TestWorkloadApplyOptionsValidate(t *testing.T) {
 			},
 			ExpectFieldErrors: validation.ErrMissingField(flags.FilePathFlagName),
 		},
+		{
+			Name: "validate schema with validation disabled",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+					Image:     "ubuntu:bionic",
+				},
+				ValidateSchema: true,
+			},
+			Prepare: func(t *testing.T, ctx context.Context) (context.Context, error) {
+				cmd := commands.NewWorkloadApplyCommand(ctx, cli.NewDefaultConfig("test", scheme))
+				if err := cmd.Flags().Set(cli.StripDash(flags.ValidateFlagName), "false"); err != nil {
+					return ctx, err
+				}
+				ctx = cli.WithCommand(ctx, cmd)
+				return ctx, nil
+			},
+			ExpectFieldErrors: validation.ErrMultipleOneOf(flags.ValidateSchemaFlagName, flags.ValidateFlagName+"=false"),
+		},
+		{
+			Name: "message mode without message",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+				},
+				MessageMode: "replace",
+			},
+			Prepare: func(t *testing.T, ctx context.Context) (context.Context, error) {
+				cmd := commands.NewWorkloadApplyCommand(ctx, cli.NewDefaultConfig("test", scheme))
+				if err := cmd.Flags().Set(cli.StripDash(flags.MessageModeFlagName), "replace"); err != nil {
+					return ctx, err
+				}
+				ctx = cli.WithCommand(ctx, cmd)
+				return ctx, nil
+			},
+			ExpectFieldErrors: validation.ErrMissingField(flags.MessageFlagName),
+		},
+		{
+			Name: "invalid message mode",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+				},
+				Message:     "rotate credentials",
+				MessageMode: "prepend",
+			},
+			Prepare: func(t *testing.T, ctx context.Context) (context.Context, error) {
+				cmd := commands.NewWorkloadApplyCommand(ctx, cli.NewDefaultConfig("test", scheme))
+				if err := cmd.Flags().Set(cli.StripDash(flags.MessageModeFlagName), "prepend"); err != nil {
+					return ctx, err
+				}
+				ctx = cli.WithCommand(ctx, cmd)
+				return ctx, nil
+			},
+			ExpectFieldErrors: validation.EnumInvalidValue("prepend", flags.MessageModeFlagName, []string{"append", "replace"}),
+		},
 		{
 			Name: "filepath with update strategy",
 			Validatable: &commands.WorkloadApplyOptions{
@@ -146,284 +251,5377 @@ func TestWorkloadApplyOptionsValidate(t *testing.T) {
 			ExpectFieldErrors: validation.EnumInvalidValue("invalid", flags.UpdateStrategyFlagName, []string{"merge", "replace"}),
 		},
 		{
-			Name: "apply with multiple sources",
+			Name: "save config without filepath",
 			Validatable: &commands.WorkloadApplyOptions{
 				WorkloadOptions: commands.WorkloadOptions{
-					Namespace:     "default",
-					Name:          "my-resource",
-					GitRepo:       "https://example.com/repo.git",
-					GitBranch:     "main",
-					SourceImage:   "repo.example/image:tag",
-					Image:         "repo.example/image:tag",
-					MavenArtifact: "hello-world",
-					MavenType:     "jar",
-					MavenVersion:  "0.0.1",
+					Namespace: "default",
+					Name:      "my-resource",
 				},
+				SaveConfig: true,
 			},
-			ExpectFieldErrors: validation.ErrMultipleSources(commands.MavenFlagWildcard, commands.LocalPathAndSource, flags.ImageFlagName, flags.GitFlagWildcard),
-		},
-	}
-
-	table.Run(t)
-}
-
-func TestWorkloadApplyCommand(t *testing.T) {
-	defaultNamespace := "default"
-	workloadName := "my-workload"
-	file := "testdata/workload.yaml"
-	gitRepo := "https://example.com/repo.git"
-	gitBranch := "main"
-	serviceAccountName := "my-service-account"
-	serviceAccountNameUpdated := "my-service-account-updated"
-	fileFromUrl := "https://raw.githubusercontent.com/vmware-tanzu/apps-cli-plugin/main/pkg/commands/testdata/workload.yaml"
-
-	scheme := runtime.NewScheme()
-	_ = cartov1alpha1.AddToScheme(scheme)
-	_ = corev1.AddToScheme(scheme)
-
-	var cmd *cobra.Command
-
-	parent := diecartov1alpha1.WorkloadBlank.
-		MetadataDie(func(d *diemetav1.ObjectMetaDie) {
-			d.Name(workloadName)
-			d.Namespace(defaultNamespace)
-			d.Labels(map[string]string{
-				apis.WorkloadTypeLabelName: "web",
-			})
-		})
-
-	givenNamespaceDefault := []client.Object{
-		diecorev1.NamespaceBlank.
-			MetadataDie(func(d *diemetav1.ObjectMetaDie) {
-				d.Name(defaultNamespace)
-			}),
-	}
-
-	myWorkloadHeader := http.Header{
-		"Content-Type":          []string{"text/html", "application/json", "application/octet-stream"},
-		"Docker-Content-Digest": []string{"sha256:111d543b7736846f502387eed53be08c5ceb0a6010faaaf043409702074cf652"},
-	}
-	respCreator := func(status int, body string, headers map[string][]string) *http.Response {
-		return &http.Response{
-			Status:     http.StatusText(status),
-			StatusCode: status,
-			Body:       io.NopCloser(strings.NewReader(body)),
-			Header:     headers,
-		}
-	}
-
-	table := clitesting.CommandTestSuite{
-		{
-			Name:        "invalid args",
-			Args:        []string{},
-			ShouldError: true,
+			ExpectFieldErrors: validation.ErrMissingField(flags.FilePathFlagName),
 		},
 		{
-			Name: "get failed",
-			Args: []string{flags.FilePathFlagName, file},
-			WithReactors: []clitesting.ReactionFunc{
-				clitesting.InduceFailure("get", "Workload"),
+			Name: "recursive with filepath",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					FilePath:  "my-folder",
+				},
+				Recursive: true,
 			},
-			ShouldError: true,
+			ShouldValidate: true,
 		},
 		{
-			Name:         "dry run",
-			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.DryRunFlagName, flags.YesFlagName},
-			GivenObjects: givenNamespaceDefault,
-			ExpectOutput: `
----
-apiVersion: carto.run/v1alpha1
-kind: Workload
-metadata:
-  creationTimestamp: null
-  labels:
-    apps.tanzu.vmware.com/workload-type: web
-  name: my-workload
-  namespace: default
-spec:
-  source:
-    git:
-      ref:
-        branch: main
-      url: https://example.com/repo.git
-status:
-  supplyChainRef: {}
-`,
+			Name: "recursive without filepath",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+				},
+				Recursive: true,
+			},
+			ExpectFieldErrors: validation.ErrInvalidValue("", cli.NameArgumentName).Also(validation.ErrMissingField(flags.FilePathFlagName)),
 		},
 		{
-			Name:         "git source with subPath",
-			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.SubPathFlagName, "./app", flags.YesFlagName},
-			GivenObjects: givenNamespaceDefault,
-			ExpectCreates: []client.Object{
-				&cartov1alpha1.Workload{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: defaultNamespace,
-						Name:      workloadName,
-						Labels: map[string]string{
-							apis.WorkloadTypeLabelName: "web",
-						},
-					},
-					Spec: cartov1alpha1.WorkloadSpec{
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: gitRepo,
-								Ref: cartov1alpha1.GitRef{
-									Branch: gitBranch,
-								},
-							},
-							Subpath: "./app",
-						},
-					},
+			Name: "recursive with name",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+					FilePath:  "my-folder",
 				},
+				Recursive: true,
 			},
-			ExpectOutput: `
-🔎 Create workload:
-      1 + |---
-      2 + |apiVersion: carto.run/v1alpha1
-      3 + |kind: Workload
-      4 + |metadata:
-      5 + |  labels:
-      6 + |    apps.tanzu.vmware.com/workload-type: web
-      7 + |  name: my-workload
-      8 + |  namespace: default
-      9 + |spec:
-     10 + |  source:
-     11 + |    git:
-     12 + |      ref:
-     13 + |        branch: main
-     14 + |      url: https://example.com/repo.git
-     15 + |    subPath: ./app
-👍 Created workload "my-workload"
-
-To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
-To get status: "tanzu apps workload get my-workload"
-
-`,
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("my-resource", cli.NameArgumentName, "the workload name is read from each file when --recursive is set"),
 		},
 		{
-			Name: "create - output yaml",
-			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch,
-				flags.OutputFlagName, printer.OutputFormatYaml, flags.YesFlagName},
-			GivenObjects: givenNamespaceDefault,
-			ExpectCreates: []client.Object{
-				&cartov1alpha1.Workload{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: defaultNamespace,
-						Name:      workloadName,
-						Labels: map[string]string{
-							apis.WorkloadTypeLabelName: "web",
-						},
-					},
-					Spec: cartov1alpha1.WorkloadSpec{
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: gitRepo,
-								Ref: cartov1alpha1.GitRef{
-									Branch: gitBranch,
-								},
-							},
-						},
-					},
+			Name: "recursive quiet",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					FilePath:  "my-folder",
+					Yes:       true,
 				},
+				Recursive:  true,
+				Quiet:      true,
+				NoProgress: true,
 			},
-			ExpectOutput: `
----
-apiVersion: carto.run/v1alpha1
-kind: Workload
+			ShouldValidate: true,
+		},
+		{
+			Name: "quiet without recursive",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+					FilePath:  "my-folder",
+					Yes:       true,
+				},
+				Quiet: true,
+			},
+			ExpectFieldErrors: validation.ErrMissingField(flags.RecursiveFlagName),
+		},
+		{
+			Name: "no progress without recursive",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+				},
+				NoProgress: true,
+			},
+			ExpectFieldErrors: validation.ErrMissingField(flags.RecursiveFlagName),
+		},
+		{
+			Name: "quiet without yes",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					FilePath:  "my-folder",
+				},
+				Recursive: true,
+				Quiet:     true,
+			},
+			ExpectFieldErrors: validation.ErrMissingField(flags.YesFlagName),
+		},
+		{
+			Name: "quiet with dry run",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					FilePath:  "my-folder",
+					Yes:       true,
+					DryRun:    true,
+				},
+				Recursive: true,
+				Quiet:     true,
+			},
+			ExpectFieldErrors: validation.ErrMultipleOneOf(flags.QuietFlagName, flags.DryRunFlagName),
+		},
+		{
+			Name: "recursive concurrency",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					FilePath:  "my-folder",
+					Yes:       true,
+				},
+				Recursive:   true,
+				Concurrency: 4,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "negative concurrency",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					FilePath:  "my-folder",
+					Yes:       true,
+				},
+				Recursive:   true,
+				Concurrency: -1,
+			},
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("-1", flags.ConcurrencyFlagName, "must be 0 or more"),
+		},
+		{
+			Name: "concurrency without recursive and yes",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+				},
+				Concurrency: 2,
+			},
+			ExpectFieldErrors: validation.ErrMissingField(flags.RecursiveFlagName).Also(validation.ErrMissingField(flags.YesFlagName)),
+		},
+		{
+			Name: "concurrency with tail",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					FilePath:  "my-folder",
+					Yes:       true,
+					Tail:      true,
+				},
+				Recursive:   true,
+				Concurrency: 2,
+			},
+			ExpectFieldErrors: validation.ErrMultipleOneOf(flags.ConcurrencyFlagName, flags.TailFlagName),
+		},
+		{
+			Name: "diff file already exists",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+					DiffFile:  "testdata/workload.yaml",
+				},
+			},
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("testdata/workload.yaml", flags.DiffFileFlagName, "file already exists, use --yes to overwrite it"),
+		},
+		{
+			Name: "diff file already exists with yes",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+					Image:     "ubuntu:bionic",
+					DiffFile:  "testdata/workload.yaml",
+					Yes:       true,
+				},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "context dir",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace:  "default",
+					Name:       "my-resource",
+					Image:      "ubuntu:bionic",
+					ContextDir: "testdata",
+				},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "prune allowlist",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+					Image:     "ubuntu:bionic",
+				},
+				PruneEnv:       true,
+				PruneAllowlist: []string{"env", "labels"},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "prune allowlist with an unknown field",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+					Image:     "ubuntu:bionic",
+				},
+				PruneAllowlist: []string{"env", "secrets"},
+			},
+			ExpectFieldErrors: validation.EnumInvalidValue("secrets", flags.PruneAllowlistFlagName, []string{"labels", "annotations", "params", "env", "build-env", "service-claims", "resources", "service-account", "sub-path"}),
+		},
+		{
+			Name: "prune allowlist without the field of a prune flag",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+					Image:     "ubuntu:bionic",
+				},
+				PruneEnv:       true,
+				PruneAllowlist: []string{"params"},
+			},
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("params", flags.PruneAllowlistFlagName, fmt.Sprintf("must include %q to use %s", "env", flags.PruneEnvFlagName)),
+		},
+		{
+			Name: "missing context dir",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace:  "default",
+					Name:       "my-resource",
+					ContextDir: "testdata/missing",
+				},
+			},
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("testdata/missing", flags.ContextDirFlagName, "must be an existing directory"),
+		},
+		{
+			Name: "context dir is a file",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace:  "default",
+					Name:       "my-resource",
+					ContextDir: "testdata/workload.yaml",
+				},
+			},
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("testdata/workload.yaml", flags.ContextDirFlagName, "must be an existing directory"),
+		},
+		{
+			Name: "ignore not found without update only",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+				},
+				IgnoreNotFound: true,
+			},
+			ExpectFieldErrors: validation.ErrMissingField(flags.UpdateOnlyFlagName),
+		},
+		{
+			Name: "force replace source without a source",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+				},
+				ForceReplaceSource: true,
+			},
+			ExpectFieldErrors: validation.ErrMissingOneOfWithDetail("expected exactly one, got neither: required by "+flags.ForceReplaceSourceFlagName, flags.GitFlagWildcard, flags.ImageFlagName, flags.LocalPathFlagName, flags.SourceImageFlagName, commands.MavenFlagWildcard),
+		},
+		{
+			Name: "force replace source with incomplete git source",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+					GitBranch: "main",
+				},
+				ForceReplaceSource: true,
+			},
+			ExpectFieldErrors: validation.ErrMissingFieldWithDetail(flags.GitRepoFlagName, "required by "+flags.ForceReplaceSourceFlagName),
+		},
+		{
+			Name: "force replace source with incomplete maven source",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace:     "default",
+					Name:          "my-resource",
+					MavenArtifact: "hello-world",
+					MavenVersion:  "0.0.1",
+				},
+				ForceReplaceSource: true,
+			},
+			ExpectFieldErrors: validation.ErrMissingFieldWithDetail(flags.MavenGroupFlagName, "required by "+flags.ForceReplaceSourceFlagName),
+		},
+		{
+			Name: "force replace source with image",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+					Image:     "repo.example/image:tag",
+				},
+				ForceReplaceSource: true,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "print flags with dry run",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+					DryRun:    true,
+				},
+				PrintFlags: true,
+			},
+			ExpectFieldErrors: validation.ErrMultipleOneOf(flags.PrintFlagsFlagName, flags.DryRunFlagName),
+		},
+		{
+			Name: "exit code without diff",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+				},
+				ExitCode: true,
+			},
+			ExpectFieldErrors: validation.ErrMissingField(flags.DiffFlagName),
+		},
+		{
+			Name: "bare without dry run",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+					Bare:      true,
+				},
+			},
+			ExpectFieldErrors: validation.ErrMissingField(flags.DryRunFlagName),
+		},
+		{
+			Name: "diff with dry run",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+					DryRun:    true,
+				},
+				Diff: true,
+			},
+			ExpectFieldErrors: validation.ErrMultipleOneOf(flags.DiffFlagName, flags.DryRunFlagName),
+		},
+		{
+			Name: "timeout action with wait",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-workload",
+					Image:     "ubuntu:bionic",
+					Wait:      true,
+				},
+				TimeoutAction: "rollback",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "timeout action without wait",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-workload",
+					Image:     "ubuntu:bionic",
+				},
+				TimeoutAction: "ignore",
+			},
+			ExpectFieldErrors: validation.ErrMissingField(flags.WaitFlagName),
+		},
+		{
+			Name: "input format",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace:   "default",
+					FilePath:    "-",
+					InputFormat: "json",
+				},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "input format without file",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace:   "default",
+					Name:        "my-workload",
+					Image:       "ubuntu:bionic",
+					InputFormat: "json",
+				},
+			},
+			ExpectFieldErrors: validation.ErrMissingField(flags.FilePathFlagName),
+		},
+		{
+			Name: "invalid input format",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace:   "default",
+					FilePath:    "-",
+					InputFormat: "toml",
+				},
+			},
+			ExpectFieldErrors: validation.EnumInvalidValue("toml", flags.InputFormatFlagName, []string{"auto", "yaml", "json"}),
+		},
+		{
+			Name: "guaranteed with matching requests and limits",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace:   "default",
+					Name:        "my-workload",
+					Image:       "ubuntu:bionic",
+					RequestCPU:  "1",
+					LimitCPU:    "1000m",
+					Guaranteed:  true,
+					LimitMemory: "1Gi",
+				},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "guaranteed with conflicting requests and limits",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace:     "default",
+					Name:          "my-workload",
+					Image:         "ubuntu:bionic",
+					RequestCPU:    "500m",
+					LimitCPU:      "1",
+					RequestMemory: "1Gi",
+					LimitMemory:   "2Gi",
+					Guaranteed:    true,
+				},
+			},
+			ExpectFieldErrors: validation.FieldErrors{}.Also(
+				validation.ErrInvalidValueWithDetail("1", flags.LimitCPUFlagName, "must match --request-cpu 500m with --guaranteed"),
+				validation.ErrInvalidValueWithDetail("2Gi", flags.LimitMemoryFlagName, "must match --request-memory 1Gi with --guaranteed"),
+			),
+		},
+		{
+			Name: "server dry run",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace:      "default",
+					Name:           "my-workload",
+					Image:          "ubuntu:bionic",
+					DryRun:         true,
+					DryRunStrategy: "server",
+				},
+			},
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("server", flags.DryRunFlagName, "only supported by workload create"),
+		},
+		{
+			Name: "expand env",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-workload",
+					Image:     "ubuntu:bionic",
+					Env:       []string{"VERSION=$APPS_TEST_VERSION", "NAME=${APPS_TEST_VERSION}-beta"},
+					ExpandEnv: "true",
+				},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "expand env with undefined variable",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-workload",
+					Image:     "ubuntu:bionic",
+					Env:       []string{"VERSION=$APPS_TEST_UNDEFINED"},
+					ExpandEnv: "true",
+				},
+			},
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("VERSION=$APPS_TEST_UNDEFINED", flags.EnvFlagName, `environment variable "APPS_TEST_UNDEFINED" is not defined, use --expand-env=allow-empty to expand it to an empty value`),
+		},
+		{
+			Name: "expand env allow empty with undefined variable",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-workload",
+					Image:     "ubuntu:bionic",
+					Env:       []string{"VERSION=$APPS_TEST_UNDEFINED"},
+					ExpandEnv: "allow-empty",
+				},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "literal env with undefined variable",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-workload",
+					Image:     "ubuntu:bionic",
+					Env:       []string{"VERSION=$APPS_TEST_UNDEFINED"},
+				},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid expand env",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-workload",
+					Image:     "ubuntu:bionic",
+					ExpandEnv: "always",
+				},
+			},
+			ExpectFieldErrors: validation.EnumInvalidValue("always", flags.ExpandEnvFlagName, []string{"false", "true", "allow-empty"}),
+		},
+		{
+			Name: "events without wait",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-workload",
+					Image:     "ubuntu:bionic",
+				},
+				Events: true,
+			},
+			ExpectFieldErrors: validation.ErrMissingField(flags.WaitFlagName),
+		},
+		{
+			Name: "invalid timeout action",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-workload",
+					Image:     "ubuntu:bionic",
+					Wait:      true,
+				},
+				TimeoutAction: "retry",
+			},
+			ExpectFieldErrors: validation.EnumInvalidValue("retry", flags.TimeoutActionFlagName, []string{"fail", "ignore", "rollback"}),
+		},
+		{
+			Name: "set image tag",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-workload",
+				},
+				SetImageTag: "v1.2.3",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid image tag",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-workload",
+				},
+				SetImageTag: "v1/2",
+			},
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("v1/2", flags.SetImageTagFlagName, "expected an image tag, like v1.2.3, or a digest, like sha256:<hex>"),
+		},
+		{
+			Name: "set image tag with image",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-workload",
+					Image:     "ubuntu:bionic",
+				},
+				SetImageTag: "jammy",
+			},
+			ExpectFieldErrors: validation.ErrMultipleOneOf(flags.SetImageTagFlagName, flags.ImageFlagName),
+		},
+		{
+			Name: "apply with multiple sources",
+			Validatable: &commands.WorkloadApplyOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace:     "default",
+					Name:          "my-resource",
+					GitRepo:       "https://example.com/repo.git",
+					GitBranch:     "main",
+					SourceImage:   "repo.example/image:tag",
+					Image:         "repo.example/image:tag",
+					MavenArtifact: "hello-world",
+					MavenType:     "jar",
+					MavenVersion:  "0.0.1",
+				},
+			},
+			ExpectFieldErrors: validation.ErrMultipleSources(commands.MavenFlagWildcard, commands.LocalPathAndSource, flags.ImageFlagName, flags.GitFlagWildcard),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestWorkloadApplyCommand(t *testing.T) {
+	defaultNamespace := "default"
+	workloadName := "my-workload"
+	file := "testdata/workload.yaml"
+	gitRepo := "https://example.com/repo.git"
+	gitBranch := "main"
+	serviceAccountName := "my-service-account"
+	serviceAccountNameUpdated := "my-service-account-updated"
+	fileFromUrl := "https://raw.githubusercontent.com/vmware-tanzu/apps-cli-plugin/main/pkg/commands/testdata/workload.yaml"
+	diffFile := filepath.Join(t.TempDir(), "diff.txt")
+	contextDir := t.TempDir()
+
+	scheme := runtime.NewScheme()
+	_ = cartov1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = apiextensionsv1.AddToScheme(scheme)
+
+	var cmd *cobra.Command
+
+	parent := diecartov1alpha1.WorkloadBlank.
+		MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+			d.Name(workloadName)
+			d.Namespace(defaultNamespace)
+			d.Labels(map[string]string{
+				apis.WorkloadTypeLabelName: "web",
+			})
+		})
+
+	givenNamespaceDefault := []client.Object{
+		diecorev1.NamespaceBlank.
+			MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+				d.Name(defaultNamespace)
+			}),
+	}
+
+	givenServiceAccounts := []client.Object{
+		diecorev1.ServiceAccountBlank.
+			MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+				d.Name(serviceAccountName)
+				d.Namespace(defaultNamespace)
+			}),
+		diecorev1.ServiceAccountBlank.
+			MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+				d.Name(serviceAccountNameUpdated)
+				d.Namespace(defaultNamespace)
+			}),
+	}
+
+	workloadCRD := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "workloads.carto.run",
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "carto.run",
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+				Name: "v1alpha1",
+				Schema: &apiextensionsv1.CustomResourceValidation{
+					OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"spec": {
+								Type: "object",
+								Properties: map[string]apiextensionsv1.JSONSchemaProps{
+									"image":              {Type: "string"},
+									"serviceAccountName": {Type: "string", MaxLength: pointer.Int64(20)},
+									"env": {
+										Type: "array",
+										Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+											Schema: &apiextensionsv1.JSONSchemaProps{
+												Type:     "object",
+												Required: []string{"name"},
+												Properties: map[string]apiextensionsv1.JSONSchemaProps{
+													"name":  {Type: "string"},
+													"value": {Type: "string"},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}},
+		},
+	}
+
+	myWorkloadHeader := http.Header{
+		"Content-Type":          []string{"text/html", "application/json", "application/octet-stream"},
+		"Docker-Content-Digest": []string{"sha256:111d543b7736846f502387eed53be08c5ceb0a6010faaaf043409702074cf652"},
+	}
+	respCreator := func(status int, body string, headers map[string][]string) *http.Response {
+		return &http.Response{
+			Status:     http.StatusText(status),
+			StatusCode: status,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     headers,
+		}
+	}
+
+	table := clitesting.CommandTestSuite{
+		{
+			Name:        "invalid args",
+			Args:        []string{},
+			ShouldError: true,
+		},
+		{
+			Name: "get failed",
+			Args: []string{flags.FilePathFlagName, file},
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("get", "Workload"),
+			},
+			ShouldError: true,
+		},
+		{
+			Name:         "print flags",
+			Args:         []string{flags.FilePathFlagName, "./testdata/workload-print-flags.yaml", flags.EnvFlagName, "GREETING=hello world", flags.PrintFlagsFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
+tanzu apps workload apply spring-petclinic \
+  --app spring-petclinic \
+  --type web \
+  --git-repo https://github.com/spring-projects/spring-petclinic.git \
+  --git-branch main \
+  --sub-path app \
+  --annotation autoscaling.knative.dev/minScale=2 \
+  --param-yaml 'ports=[{"port":8080}]' \
+  --debug \
+  --env SPRING_PROFILES_ACTIVE=mysql \
+  --env 'GREETING=hello world' \
+  --request-cpu 100m \
+  --request-memory 1Gi \
+  --service-ref database=services.apps.tanzu.vmware.com/v1alpha1:ResourceClaim:db
+
+Fields that can't be set through flags: metadata.annotations[owner], spec.env[DB_PASSWORD]
+`,
+		},
+		{
+			Name:         "dry run",
+			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.DryRunFlagName, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  labels:
+    apps.tanzu.vmware.com/workload-type: web
+  name: my-workload
+  namespace: default
+spec:
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://example.com/repo.git
+status:
+  supplyChainRef: {}
+`,
+		},
+		{
+			Name:         "dry run with yaml output",
+			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.DryRunFlagName, flags.OutputFlagName, printer.OutputFormatYaml},
+			GivenObjects: givenNamespaceDefault,
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  labels:
+    apps.tanzu.vmware.com/workload-type: web
+  name: my-workload
+  namespace: default
+spec:
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://example.com/repo.git
+status:
+  supplyChainRef: {}
+`,
+		},
+		{
+			Name:         "dry run with json output",
+			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.DryRunFlagName, flags.OutputFlagName, printer.OutputFormatJson},
+			GivenObjects: givenNamespaceDefault,
+			ExpectOutput: `
+{
+	"apiVersion": "carto.run/v1alpha1",
+	"kind": "Workload",
+	"metadata": {
+		"creationTimestamp": null,
+		"labels": {
+			"apps.tanzu.vmware.com/workload-type": "web"
+		},
+		"name": "my-workload",
+		"namespace": "default"
+	},
+	"spec": {
+		"source": {
+			"git": {
+				"ref": {
+					"branch": "main"
+				},
+				"url": "https://example.com/repo.git"
+			}
+		}
+	},
+	"status": {
+		"supplyChainRef": {}
+	}
+}
+`,
+		},
+		{
+			Name:         "git source with subPath",
+			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.SubPathFlagName, "./app", flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+							Subpath: "./app",
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  source:
+     11 + |    git:
+     12 + |      ref:
+     13 + |        branch: main
+     14 + |      url: https://example.com/repo.git
+     15 + |    subPath: ./app
+👍 Created workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name:         "update only - workload not found",
+			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.UpdateOnlyFlagName, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ShouldError:  true,
+			ExpectOutput: `
+Workload "default/my-workload" not found
+`,
+		},
+		{
+			Name:         "update only - ignore not found",
+			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.UpdateOnlyFlagName, flags.IgnoreNotFoundFlagName, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			Verify: func(t *testing.T, output string, err error) {
+				if output != "" {
+					t.Errorf("expected no output, got %q", output)
+				}
+			},
+		},
+		{
+			Name: "create - output yaml",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch,
+				flags.OutputFlagName, printer.OutputFormatYaml, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  labels:
+    apps.tanzu.vmware.com/workload-type: web
+  name: my-workload
+  namespace: default
+  resourceVersion: "1"
+spec:
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://example.com/repo.git
+status:
+  supplyChainRef: {}
+`,
+		},
+		{
+			Name: "create - expand env",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch,
+				flags.EnvFlagName, "VERSION=${APPS_TEST_VERSION}-beta", flags.ExpandEnvFlagName, flags.OutputFlagName, printer.OutputFormatYaml, flags.YesFlagName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				t.Setenv("APPS_TEST_VERSION", "1.2.3")
+				return ctx, nil
+			},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Env: []corev1.EnvVar{
+							{Name: "VERSION", Value: "1.2.3-beta"},
+						},
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  labels:
+    apps.tanzu.vmware.com/workload-type: web
+  name: my-workload
+  namespace: default
+  resourceVersion: "1"
+spec:
+  env:
+  - name: VERSION
+    value: 1.2.3-beta
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://example.com/repo.git
+status:
+  supplyChainRef: {}
+`,
+		},
+		{
+			Name: "create - env kept literal without expand env",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch,
+				flags.EnvFlagName, "VERSION=${APPS_TEST_VERSION}-beta", flags.OutputFlagName, printer.OutputFormatYaml, flags.YesFlagName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				t.Setenv("APPS_TEST_VERSION", "1.2.3")
+				return ctx, nil
+			},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Env: []corev1.EnvVar{
+							{Name: "VERSION", Value: "${APPS_TEST_VERSION}-beta"},
+						},
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  labels:
+    apps.tanzu.vmware.com/workload-type: web
+  name: my-workload
+  namespace: default
+  resourceVersion: "1"
+spec:
+  env:
+  - name: VERSION
+    value: ${APPS_TEST_VERSION}-beta
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://example.com/repo.git
+status:
+  supplyChainRef: {}
+`,
+		},
+		{
+			Name:         "create - guaranteed copies the requests to the limits",
+			Args:         []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.RequestCPUFlagName, "500m", flags.RequestMemoryFlagName, "1Gi", flags.GuaranteedFlagName, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+						Resources: &corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("500m"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("500m"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  image: ubuntu:bionic
+     11 + |  resources:
+     12 + |    limits:
+     13 + |      cpu: 500m
+     14 + |      memory: 1Gi
+     15 + |    requests:
+     16 + |      cpu: 500m
+     17 + |      memory: 1Gi
+👍 Created workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name: "update - guaranteed copies a new limit to the request",
+			Args: []string{workloadName, flags.LimitMemoryFlagName, "2Gi", flags.GuaranteedFlagName, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Resources(&corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("1"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("500m"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+						})
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Resources(&corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("500m"),
+								corev1.ResourceMemory: resource.MustParse("2Gi"),
+							},
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("500m"),
+								corev1.ResourceMemory: resource.MustParse("2Gi"),
+							},
+						})
+					}),
+			},
+			ExpectOutput: `
+🔎 Update workload:
+...
+  9,  9   |spec:
+ 10, 10   |  image: ubuntu:bionic
+ 11, 11   |  resources:
+ 12, 12   |    limits:
+ 13     - |      cpu: "1"
+ 14     - |      memory: 1Gi
+     13 + |      cpu: 500m
+     14 + |      memory: 2Gi
+ 15, 15   |    requests:
+ 16, 16   |      cpu: 500m
+ 17     - |      memory: 1Gi
+     17 + |      memory: 2Gi
+👍 Updated workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name: "create - output yaml masks secret values",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch,
+				flags.EnvFlagName, "DB_PASSWORD=hunter2", flags.EnvFlagName, "PORT=8080", flags.ParamFlagName, "api-token=abc123",
+				flags.OutputFlagName, printer.OutputFormatYaml, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Params: []cartov1alpha1.Param{
+							{
+								Name:  "api-token",
+								Value: apiextensionsv1.JSON{Raw: []byte(`"abc123"`)},
+							},
+						},
+						Env: []corev1.EnvVar{
+							{Name: "DB_PASSWORD", Value: "hunter2"},
+							{Name: "PORT", Value: "8080"},
+						},
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  labels:
+    apps.tanzu.vmware.com/workload-type: web
+  name: my-workload
+  namespace: default
+  resourceVersion: "1"
+spec:
+  env:
+  - name: DB_PASSWORD
+    value: '*****'
+  - name: PORT
+    value: "8080"
+  params:
+  - name: api-token
+    value: '*****'
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://example.com/repo.git
+status:
+  supplyChainRef: {}
+`,
+		},
+		{
+			Name: "create - output yaml keeps the git secret name",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch,
+				flags.GitSecretFlagName, "foo", flags.OutputFlagName, printer.OutputFormatYaml, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Params: []cartov1alpha1.Param{
+							{
+								Name:  cartov1alpha1.WorkloadGitSecretParam,
+								Value: apiextensionsv1.JSON{Raw: []byte(`"foo"`)},
+							},
+						},
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  labels:
+    apps.tanzu.vmware.com/workload-type: web
+  name: my-workload
+  namespace: default
+  resourceVersion: "1"
+spec:
+  params:
+  - name: gitops_ssh_secret
+    value: foo
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://example.com/repo.git
+status:
+  supplyChainRef: {}
+`,
+		},
+		{
+			Name: "create - dry run keeps secret values",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch,
+				flags.EnvFlagName, "DB_PASSWORD=hunter2", flags.GitSecretFlagName, "foo", flags.DryRunFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  labels:
+    apps.tanzu.vmware.com/workload-type: web
+  name: my-workload
+  namespace: default
+spec:
+  env:
+  - name: DB_PASSWORD
+    value: hunter2
+  params:
+  - name: gitops_ssh_secret
+    value: foo
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://example.com/repo.git
+status:
+  supplyChainRef: {}
+`,
+		},
+		{
+			Name: "create - output yaml with show secrets",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch,
+				flags.EnvFlagName, "DB_PASSWORD=hunter2", flags.OutputFlagName, printer.OutputFormatYaml, flags.ShowSecretsFlagName, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Env: []corev1.EnvVar{
+							{Name: "DB_PASSWORD", Value: "hunter2"},
+						},
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  labels:
+    apps.tanzu.vmware.com/workload-type: web
+  name: my-workload
+  namespace: default
+  resourceVersion: "1"
+spec:
+  env:
+  - name: DB_PASSWORD
+    value: hunter2
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://example.com/repo.git
+status:
+  supplyChainRef: {}
+`,
+		},
+		{
+			Name: "create - output yaml with wait",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch,
+				flags.OutputFlagName, printer.OutputFormatYaml, flags.WaitFlagName, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				workload := &cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cartov1alpha1.WorkloadConditionReady,
+								Status: metav1.ConditionTrue,
+							},
+						},
+					},
+				}
+				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
+					{Type: watch.Modified, Object: workload},
+				})
+				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
+				return ctx, nil
+			},
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  labels:
+    apps.tanzu.vmware.com/workload-type: web
+  name: my-workload
+  namespace: default
+  resourceVersion: "1"
+spec:
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://example.com/repo.git
+status:
+  supplyChainRef: {}
+`,
+		},
+		{
+			Name: "create - output json",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch,
+				flags.OutputFlagName, printer.OutputFormatJson, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+{
+	"apiVersion": "carto.run/v1alpha1",
+	"kind": "Workload",
+	"metadata": {
+		"creationTimestamp": null,
+		"labels": {
+			"apps.tanzu.vmware.com/workload-type": "web"
+		},
+		"name": "my-workload",
+		"namespace": "default",
+		"resourceVersion": "1"
+	},
+	"spec": {
+		"source": {
+			"git": {
+				"ref": {
+					"branch": "main"
+				},
+				"url": "https://example.com/repo.git"
+			}
+		}
+	},
+	"status": {
+		"supplyChainRef": {}
+	}
+}
+`,
+		},
+		{
+			Name: "create - output patch",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch,
+				flags.OutputFlagName, "patch", flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+{
+	"apiVersion": "carto.run/v1alpha1",
+	"kind": "Workload",
+	"metadata": {
+		"labels": {
+			"apps.tanzu.vmware.com/workload-type": "web"
+		},
+		"name": "my-workload",
+		"namespace": "default"
+	},
+	"spec": {
+		"source": {
+			"git": {
+				"ref": {
+					"branch": "main"
+				},
+				"url": "https://example.com/repo.git"
+			}
+		}
+	}
+}
+`,
+		},
+		{
+			Name: "update - output patch",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:jammy", flags.EnvFlagName, "FOO=baz", flags.AnnotationFlagName, "my-annotation=my-value",
+				flags.OutputFlagName, "patch", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Env(
+							corev1.EnvVar{Name: "FOO", Value: "bar"},
+							corev1.EnvVar{Name: "API_TOKEN", Value: "my-token"},
+						)
+						d.Params(cartov1alpha1.Param{
+							Name:  "ports",
+							Value: apiextensionsv1.JSON{Raw: []byte(`[{"port":8080}]`)},
+						})
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:jammy",
+						Env: []corev1.EnvVar{
+							{Name: "FOO", Value: "baz"},
+							{Name: "API_TOKEN", Value: "my-token"},
+						},
+						Params: []cartov1alpha1.Param{
+							{Name: "ports", Value: apiextensionsv1.JSON{Raw: []byte(`[{"port":8080}]`)}},
+							{Name: "annotations", Value: apiextensionsv1.JSON{Raw: []byte(`{"my-annotation":"my-value"}`)}},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+{
+	"spec": {
+		"env": [
+			{
+				"name": "FOO",
+				"value": "baz"
+			},
+			{
+				"name": "API_TOKEN",
+				"value": "*****"
+			}
+		],
+		"image": "ubuntu:jammy",
+		"params": [
+			{
+				"name": "ports",
+				"value": [
+					{
+						"port": 8080
+					}
+				]
+			},
+			{
+				"name": "annotations",
+				"value": {
+					"my-annotation": "my-value"
+				}
+			}
+		]
+	}
+}
+`,
+		},
+		{
+			Name: "create - output helm values",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch,
+				flags.EnvFlagName, "SPRING_PROFILES_ACTIVE=cloud", flags.EnvFlagName, "API_TOKEN=my-token", flags.BuildEnvFlagName, "BP_JVM_VERSION=17",
+				flags.ParamFlagName, "port=8080", flags.LimitCPUFlagName, "500m", flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db",
+				flags.OutputFlagName, "helm-values"},
+			ExpectOutput: `
+workload:
+  buildEnv:
+    BP_JVM_VERSION: "17"
+  env:
+    API_TOKEN: '*****'
+    SPRING_PROFILES_ACTIVE: cloud
+  labels:
+    apps.tanzu.vmware.com/workload-type: web
+  name: my-workload
+  namespace: default
+  params:
+    port: "8080"
+  resources:
+    limits:
+      cpu: 500m
+  serviceClaims:
+  - name: database
+    ref:
+      apiVersion: services.tanzu.vmware.com/v1alpha1
+      kind: PostgreSQL
+      name: my-prod-db
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://example.com/repo.git
+`,
+		},
+		{
+			Name: "create - output helm values with invalid workload",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.LimitCPUFlagName, "500m", flags.RequestCPUFlagName, "1",
+				flags.OutputFlagName, "helm-values"},
+			ShouldError: true,
+		},
+		{
+			Name: "update - output patch dry run",
+			Args: []string{workloadName, flags.EnvFlagName, "API_TOKEN=my-new-token", flags.LabelFlagName, "app.kubernetes.io/part-of-",
+				flags.OutputFlagName, "patch", flags.DryRunFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.AddLabel("app.kubernetes.io/part-of", "my-app")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Env(
+							corev1.EnvVar{Name: "API_TOKEN", Value: "my-token"},
+						)
+					}),
+			},
+			ExpectOutput: `
+{
+	"metadata": {
+		"labels": {
+			"app.kubernetes.io/part-of": null
+		}
+	},
+	"spec": {
+		"env": [
+			{
+				"name": "API_TOKEN",
+				"value": "*****"
+			}
+		]
+	}
+}
+`,
+		},
+		{
+			Name: "update - output yaml",
+			Args: []string{workloadName, flags.ServiceRefFlagName,
+				"database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db",
+				flags.OutputFlagName, printer.OutputFormatYaml, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}).StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
+					d.Conditions(metav1.Condition{
+						Type:   cartov1alpha1.WorkloadConditionReady,
+						Status: metav1.ConditionTrue,
+					}, metav1.Condition{
+						Type:   "my-other-type",
+						Status: metav1.ConditionTrue,
+					})
+				}),
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
+							{
+								Name: "database",
+								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
+									APIVersion: "services.tanzu.vmware.com/v1alpha1",
+									Kind:       "PostgreSQL",
+									Name:       "my-prod-db",
+								},
+							},
+						},
+					},
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   "Ready",
+								Status: metav1.ConditionTrue,
+							},
+							{
+								Type:   "my-other-type",
+								Status: metav1.ConditionTrue,
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: "1970-01-01T00:00:01Z"
+  labels:
+    apps.tanzu.vmware.com/workload-type: web
+  name: my-workload
+  namespace: default
+  resourceVersion: "1000"
+spec:
+  image: ubuntu:bionic
+  serviceClaims:
+  - name: database
+    ref:
+      apiVersion: services.tanzu.vmware.com/v1alpha1
+      kind: PostgreSQL
+      name: my-prod-db
+status:
+  conditions:
+  - lastTransitionTime: null
+    message: ""
+    reason: ""
+    status: "True"
+    type: Ready
+  - lastTransitionTime: null
+    message: ""
+    reason: ""
+    status: "True"
+    type: my-other-type
+  supplyChainRef: {}
+`,
+		},
+		{
+			Name: "update - output json",
+			Args: []string{workloadName, flags.ServiceRefFlagName,
+				"database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db",
+				flags.OutputFlagName, printer.OutputFormatJson, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}).StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
+					d.Conditions(metav1.Condition{
+						Type:   "my-type",
+						Status: metav1.ConditionTrue,
+					}, metav1.Condition{
+						Type:   "my-other-type",
+						Status: metav1.ConditionTrue,
+					})
+				}),
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
+							{
+								Name: "database",
+								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
+									APIVersion: "services.tanzu.vmware.com/v1alpha1",
+									Kind:       "PostgreSQL",
+									Name:       "my-prod-db",
+								},
+							},
+						},
+					},
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   "my-type",
+								Status: metav1.ConditionTrue,
+							},
+							{
+								Type:   "my-other-type",
+								Status: metav1.ConditionTrue,
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+{
+	"apiVersion": "carto.run/v1alpha1",
+	"kind": "Workload",
+	"metadata": {
+		"creationTimestamp": "1970-01-01T00:00:01Z",
+		"labels": {
+			"apps.tanzu.vmware.com/workload-type": "web"
+		},
+		"name": "my-workload",
+		"namespace": "default",
+		"resourceVersion": "1000"
+	},
+	"spec": {
+		"image": "ubuntu:bionic",
+		"serviceClaims": [
+			{
+				"name": "database",
+				"ref": {
+					"apiVersion": "services.tanzu.vmware.com/v1alpha1",
+					"kind": "PostgreSQL",
+					"name": "my-prod-db"
+				}
+			}
+		]
+	},
+	"status": {
+		"conditions": [
+			{
+				"lastTransitionTime": null,
+				"message": "",
+				"reason": "",
+				"status": "True",
+				"type": "my-type"
+			},
+			{
+				"lastTransitionTime": null,
+				"message": "",
+				"reason": "",
+				"status": "True",
+				"type": "my-other-type"
+			}
+		],
+		"supplyChainRef": {}
+	}
+}
+`,
+		},
+		{
+			Name: "update - output json with removed fields",
+			Args: []string{workloadName, flags.EnvFlagName, "FOO=baz", flags.PruneEnvFlagName, flags.LabelFlagName, "app.kubernetes.io/part-of-",
+				flags.OutputFlagName, printer.OutputFormatJson, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.AddLabel("app.kubernetes.io/part-of", "my-app")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Env(
+							corev1.EnvVar{Name: "FOO", Value: "bar"},
+							corev1.EnvVar{Name: "UNMANAGED", Value: "value"},
+						)
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Env(
+							corev1.EnvVar{Name: "FOO", Value: "baz"},
+						)
+					}),
+			},
+			ExpectOutput: `
+{
+	"apiVersion": "carto.run/v1alpha1",
+	"kind": "Workload",
+	"metadata": {
+		"creationTimestamp": "1970-01-01T00:00:01Z",
+		"labels": {
+			"apps.tanzu.vmware.com/workload-type": "web"
+		},
+		"name": "my-workload",
+		"namespace": "default",
+		"resourceVersion": "1000"
+	},
+	"spec": {
+		"env": [
+			{
+				"name": "FOO",
+				"value": "baz"
+			}
+		],
+		"image": "ubuntu:bionic"
+	},
+	"status": {
+		"supplyChainRef": {}
+	}
+}
+{"removed":["metadata.labels[\"app.kubernetes.io/part-of\"]","spec.env[name=UNMANAGED]"]}
+`,
+		},
+		{
+			Name: "update - output json dry run with removed fields",
+			Args: []string{workloadName, flags.ParamFlagName, "port=8080", flags.PruneParamsFlagName, flags.BuildEnvFlagName, "BP_JVM_VERSION-",
+				flags.OutputFlagName, printer.OutputFormatJson, flags.DryRunFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Params(
+							cartov1alpha1.Param{Name: "port", Value: apiextensionsv1.JSON{Raw: []byte(`"9090"`)}},
+							cartov1alpha1.Param{Name: "unmanaged", Value: apiextensionsv1.JSON{Raw: []byte(`"value"`)}},
+						)
+						d.Build(&cartov1alpha1.WorkloadBuild{
+							Env: []corev1.EnvVar{
+								{Name: "BP_JVM_VERSION", Value: "17"},
+							},
+						})
+					}),
+			},
+			ExpectOutput: `
+{
+	"apiVersion": "carto.run/v1alpha1",
+	"kind": "Workload",
+	"metadata": {
+		"creationTimestamp": "1970-01-01T00:00:01Z",
+		"labels": {
+			"apps.tanzu.vmware.com/workload-type": "web"
+		},
+		"name": "my-workload",
+		"namespace": "default",
+		"resourceVersion": "999"
+	},
+	"spec": {
+		"image": "ubuntu:bionic",
+		"params": [
+			{
+				"name": "port",
+				"value": "8080"
+			}
+		]
+	},
+	"status": {
+		"supplyChainRef": {}
+	}
+}
+{"removed":["spec.build","spec.params[name=unmanaged]"]}
+`,
+		},
+		{
+			Name: "update - output json with tail",
+			Args: []string{workloadName, flags.ServiceRefFlagName,
+				"database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db",
+				flags.OutputFlagName, printer.OutputFormatJson, flags.TailFlagName, flags.YesFlagName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				workload := &cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cartov1alpha1.WorkloadConditionReady,
+								Status: metav1.ConditionTrue,
+								LastTransitionTime: metav1.Time{
+									Time: time.Date(2019, 6, 29, 01, 44, 12, 0, time.UTC),
+								},
+							},
+						},
+					},
+				}
+				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
+					{Type: watch.Modified, Object: workload},
+				})
+				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
+
+				tailer := &logs.FakeTailer{}
+				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
+				tailer.On("Tail", mock.Anything, "default", selector, logs.TailOptions{Containers: []string{}, Since: time.Minute, TailLines: -1, Follow: true}).Return(nil).Once()
+				ctx = logs.StashTailer(ctx, tailer)
+
+				return ctx, nil
+			},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}).StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
+					d.Conditions(metav1.Condition{
+						Type:   cartov1alpha1.WorkloadConditionReady,
+						Status: metav1.ConditionTrue,
+						LastTransitionTime: metav1.Time{
+							Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+						},
+					}, metav1.Condition{
+						Type:   "my-other-type",
+						Status: metav1.ConditionTrue,
+					})
+				}),
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
+							{
+								Name: "database",
+								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
+									APIVersion: "services.tanzu.vmware.com/v1alpha1",
+									Kind:       "PostgreSQL",
+									Name:       "my-prod-db",
+								},
+							},
+						},
+					},
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cartov1alpha1.WorkloadConditionReady,
+								Status: metav1.ConditionTrue,
+								LastTransitionTime: metav1.Time{
+									Time: time.Date(2019, 6, 29, 01, 44, 12, 0, time.UTC),
+								},
+							},
+							{
+								Type:   "my-other-type",
+								Status: metav1.ConditionTrue,
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+...tail output...
+{
+	"apiVersion": "carto.run/v1alpha1",
+	"kind": "Workload",
+	"metadata": {
+		"creationTimestamp": "1970-01-01T00:00:01Z",
+		"labels": {
+			"apps.tanzu.vmware.com/workload-type": "web"
+		},
+		"name": "my-workload",
+		"namespace": "default",
+		"resourceVersion": "1000"
+	},
+	"spec": {
+		"image": "ubuntu:bionic",
+		"serviceClaims": [
+			{
+				"name": "database",
+				"ref": {
+					"apiVersion": "services.tanzu.vmware.com/v1alpha1",
+					"kind": "PostgreSQL",
+					"name": "my-prod-db"
+				}
+			}
+		]
+	},
+	"status": {
+		"conditions": [
+			{
+				"lastTransitionTime": "2019-06-29T01:44:05Z",
+				"message": "",
+				"reason": "",
+				"status": "True",
+				"type": "Ready"
+			},
+			{
+				"lastTransitionTime": null,
+				"message": "",
+				"reason": "",
+				"status": "True",
+				"type": "my-other-type"
+			}
+		],
+		"supplyChainRef": {}
+	}
+}
+`,
+		},
+		{
+			Name: "create git source with invalid namespace",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.NamespaceFlagName, "foo", flags.YesFlagName},
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("get", "Namespace", clitesting.InduceFailureOpts{
+					Error: apierrs.NewNotFound(corev1.Resource("Namespace"), "foo"),
+				}),
+			},
+			ShouldError: true,
+			ExpectOutput: `
+Error: namespace "foo" not found, it may not exist or user does not have permissions to read it.
+`,
+		},
+		{
+			Name: "update ignores the namespace check when the workload exists",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:jammy", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("get", "Namespace", clitesting.InduceFailureOpts{
+					Error: apierrs.NewNotFound(corev1.Resource("Namespace"), defaultNamespace),
+				}),
+			},
+			ExpectUpdates: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:jammy")
+					}),
+			},
+			ExpectOutput: `
+🔎 Update workload:
+...
+  6,  6   |    apps.tanzu.vmware.com/workload-type: web
+  7,  7   |  name: my-workload
+  8,  8   |  namespace: default
+  9,  9   |spec:
+ 10     - |  image: ubuntu:bionic
+     10 + |  image: ubuntu:jammy
+👍 Updated workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name: "Update git source with subPath from file",
+			Args: []string{workloadName, flags.FilePathFlagName, "./testdata/workload-subPath.yaml", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Source(&cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						})
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+							Subpath: "./app",
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
+🔎 Update workload:
+...
+ 11, 11   |    git:
+ 12, 12   |      ref:
+ 13, 13   |        branch: main
+ 14, 14   |      url: https://github.com/spring-projects/spring-petclinic.git
+     15 + |    subPath: ./app
+👍 Updated workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name: "Update git source with subPath from file relative to context dir",
+			Args: []string{workloadName, flags.ContextDirFlagName, "testdata", flags.FilePathFlagName, "workload-subPath.yaml", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Source(&cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						})
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+							Subpath: "./app",
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
+🔎 Update workload:
+...
+ 11, 11   |    git:
+ 12, 12   |      ref:
+ 13, 13   |        branch: main
+ 14, 14   |      url: https://github.com/spring-projects/spring-petclinic.git
+     15 + |    subPath: ./app
+👍 Updated workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name: "Update git source with subPath from file with suppressed update strategy warning",
+			Args: []string{workloadName, flags.FilePathFlagName, "./testdata/workload-subPath.yaml", flags.SuppressWarningsFlagName, "update-strategy", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Source(&cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						})
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+							Subpath: "./app",
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+🔎 Update workload:
+...
+ 11, 11   |    git:
+ 12, 12   |      ref:
+ 13, 13   |        branch: main
+ 14, 14   |      url: https://github.com/spring-projects/spring-petclinic.git
+     15 + |    subPath: ./app
+👍 Updated workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name: "update - apply result",
+			Args: []string{workloadName, flags.FilePathFlagName, "./testdata/workload-subPath.yaml", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Source(&cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						})
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+							Subpath: "./app",
+						},
+					},
+				},
+			},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				return commands.StashWorkloadApplyResults(ctx, &commands.WorkloadApplyResults{}), nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				expected := []commands.WorkloadApplyResult{{
+					Namespace: defaultNamespace,
+					Name:      workloadName,
+					Action:    commands.WorkloadApplyUpdated,
+					Diff: `...
+ 11, 11   |    git:
+ 12, 12   |      ref:
+ 13, 13   |        branch: main
+ 14, 14   |      url: https://github.com/spring-projects/spring-petclinic.git
+     15 + |    subPath: ./app
+`,
+					Warnings: []string{`Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).`},
+				}}
+				if diff := cmp.Diff(expected, commands.RetrieveWorkloadApplyResults(ctx).Results()); diff != "" {
+					t.Errorf("Unexpected apply results (-expected, +actual): %s", diff)
+				}
+				return nil
+			},
+		},
+		{
+			Name:         "create - unchanged apply result when declined",
+			Args:         []string{workloadName, flags.ImageFlagName, "ubuntu:bionic"},
+			GivenObjects: givenNamespaceDefault,
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				return commands.StashWorkloadApplyResults(ctx, &commands.WorkloadApplyResults{}), nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				expected := []commands.WorkloadApplyResult{{
+					Namespace: defaultNamespace,
+					Name:      workloadName,
+					Action:    commands.WorkloadApplyUnchanged,
+				}}
+				if diff := cmp.Diff(expected, commands.RetrieveWorkloadApplyResults(ctx).Results()); diff != "" {
+					t.Errorf("Unexpected apply results (-expected, +actual): %s", diff)
+				}
+				return nil
+			},
+			ExpectOutput: `
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  image: ubuntu:bionic
+❓ Do you want to create this workload? [yN]: Skipping workload "my-workload"
+`,
+		},
+		{
+			Name: "update from file removes fields dropped since last applied configuration",
+			Args: []string{flags.FilePathFlagName, "./testdata/workload-save-config.yaml", flags.SaveConfigFlagName, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.AddAnnotation(apis.LastAppliedConfigurationAnnotationName, `{"apiVersion":"carto.run/v1alpha1","kind":"Workload","metadata":{"name":"my-workload"},"spec":{"env":[{"name":"FOO","value":"bar"},{"name":"BAR","value":"baz"}]}}`)
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Source(&cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						})
+						d.Env(
+							corev1.EnvVar{Name: "FOO", Value: "bar"},
+							corev1.EnvVar{Name: "BAR", Value: "baz"},
+							corev1.EnvVar{Name: "UNMANAGED", Value: "value"},
+						)
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+						Annotations: map[string]string{
+							apis.LastAppliedConfigurationAnnotationName: `{"apiVersion":"carto.run/v1alpha1","kind":"Workload","metadata":{"labels":{"apps.tanzu.vmware.com/workload-type":"web"},"name":"my-workload"},"spec":{"env":[{"name":"FOO","value":"bar"}],"source":{"git":{"ref":{"branch":"main"},"url":"https://example.com/repo.git"}}}}`,
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+						Env: []corev1.EnvVar{
+							{Name: "FOO", Value: "bar"},
+							{Name: "UNMANAGED", Value: "value"},
+						},
+					},
+				},
+			},
+			Verify: func(t *testing.T, output string, err error) {
+				if !strings.Contains(output, "- |  - name: BAR") {
+					t.Errorf("expected output to show env BAR removal, got %q", output)
+				}
+			},
+		},
+		{
+			Name: "update from file prunes only the fields of the prune allowlist",
+			Args: []string{flags.FilePathFlagName, "./testdata/workload-save-config.yaml", flags.SaveConfigFlagName, flags.PruneAllowlistFlagName, "labels", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.AddLabel("app.kubernetes.io/part-of", "my-app")
+						d.AddAnnotation(apis.LastAppliedConfigurationAnnotationName, `{"apiVersion":"carto.run/v1alpha1","kind":"Workload","metadata":{"labels":{"app.kubernetes.io/part-of":"my-app"},"name":"my-workload"},"spec":{"env":[{"name":"FOO","value":"bar"},{"name":"BAR","value":"baz"}]}}`)
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Source(&cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						})
+						d.Env(
+							corev1.EnvVar{Name: "FOO", Value: "bar"},
+							corev1.EnvVar{Name: "BAR", Value: "baz"},
+						)
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+						Annotations: map[string]string{
+							apis.LastAppliedConfigurationAnnotationName: `{"apiVersion":"carto.run/v1alpha1","kind":"Workload","metadata":{"labels":{"apps.tanzu.vmware.com/workload-type":"web"},"name":"my-workload"},"spec":{"env":[{"name":"FOO","value":"bar"}],"source":{"git":{"ref":{"branch":"main"},"url":"https://example.com/repo.git"}}}}`,
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+						Env: []corev1.EnvVar{
+							{Name: "FOO", Value: "bar"},
+							{Name: "BAR", Value: "baz"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "update prunes env after confirming the pruned entries",
+			Args: []string{workloadName, flags.EnvFlagName, "FOO=baz", flags.PruneEnvFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Env(
+							corev1.EnvVar{Name: "FOO", Value: "bar"},
+							corev1.EnvVar{Name: "UNMANAGED", Value: "value"},
+						)
+					}),
+			},
+			WithConsoleInteractions: func(t *testing.T, c *expect.Console) {
+				c.ExpectString(clitesting.ToInteractTerminal("? Really update the workload %q and prune 1 entries? [yN]: ", workloadName))
+				c.Send(clitesting.InteractInputLine("y"))
+				c.ExpectString(clitesting.ToInteractOutput(`👍 Updated workload %q`, workloadName))
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+						Env: []corev1.EnvVar{
+							{Name: "FOO", Value: "baz"},
+						},
+					},
+				},
+			},
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := "❗ The update prunes the entries not set through the file or flags:\n  - env UNMANAGED\n"; !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %q, got %q", expected, output)
+				}
+			},
+		},
+		{
+			Name: "update skips the prune when the pruned entries are not confirmed",
+			Args: []string{workloadName, flags.EnvFlagName, "FOO=baz", flags.PruneEnvFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Env(
+							corev1.EnvVar{Name: "FOO", Value: "bar"},
+							corev1.EnvVar{Name: "UNMANAGED", Value: "value"},
+						)
+					}),
+			},
+			WithConsoleInteractions: func(t *testing.T, c *expect.Console) {
+				c.ExpectString(clitesting.ToInteractTerminal("? Really update the workload %q and prune 1 entries? [yN]: ", workloadName))
+				c.Send(clitesting.InteractInputLine("n"))
+				c.ExpectString(clitesting.ToInteractOutput("Skipping workload %q", workloadName))
+			},
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := "❗ The update prunes the entries not set through the file or flags:\n  - env UNMANAGED\n"; !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %q, got %q", expected, output)
+				}
+			},
+		},
+		{
+			Name: "update prunes env not supplied through flags",
+			Args: []string{workloadName, flags.EnvFlagName, "FOO=baz", flags.PruneEnvFlagName, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Env(
+							corev1.EnvVar{Name: "FOO", Value: "bar"},
+							corev1.EnvVar{Name: "UNMANAGED", Value: "value"},
+						)
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+						Env: []corev1.EnvVar{
+							{Name: "FOO", Value: "baz"},
+						},
+					},
+				},
+			},
+			Verify: func(t *testing.T, output string, err error) {
+				if !strings.Contains(output, "- |  - name: UNMANAGED") {
+					t.Errorf("expected output to show env UNMANAGED removal, got %q", output)
+				}
+			},
+		},
+		{
+			Name: "update prunes params not supplied through flags",
+			Args: []string{workloadName, flags.ParamFlagName, "port=8080", flags.PruneParamsFlagName, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Params(
+							cartov1alpha1.Param{Name: "port", Value: apiextensionsv1.JSON{Raw: []byte(`"9090"`)}},
+							cartov1alpha1.Param{Name: "unmanaged", Value: apiextensionsv1.JSON{Raw: []byte(`"value"`)}},
+						)
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Params(
+							cartov1alpha1.Param{Name: "port", Value: apiextensionsv1.JSON{Raw: []byte(`"8080"`)}},
+						)
+					}),
+			},
+			Verify: func(t *testing.T, output string, err error) {
+				if !strings.Contains(output, "- |  - name: unmanaged") {
+					t.Errorf("expected output to show param unmanaged removal, got %q", output)
+				}
+			},
+		},
+		{
+			Name: "update prunes build env not supplied through flags",
+			Args: []string{workloadName, flags.BuildEnvFlagName, "BAR=baz", flags.PruneBuildEnvFlagName, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Env(
+							corev1.EnvVar{Name: "UNMANAGED", Value: "value"},
+						)
+						d.Build(&cartov1alpha1.WorkloadBuild{
+							Env: []corev1.EnvVar{
+								{Name: "UNMANAGED", Value: "value"},
+							},
+						})
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+						Env: []corev1.EnvVar{
+							{Name: "UNMANAGED", Value: "value"},
+						},
+						Build: &cartov1alpha1.WorkloadBuild{
+							Env: []corev1.EnvVar{
+								{Name: "BAR", Value: "baz"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:         "Create git source with subPath from file",
+			Args:         []string{workloadName, flags.FilePathFlagName, "./testdata/workload-subPath.yaml", flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+							Subpath: "./app",
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  source:
+     11 + |    git:
+     12 + |      ref:
+     13 + |        branch: main
+     14 + |      url: https://github.com/spring-projects/spring-petclinic.git
+     15 + |    subPath: ./app
+👍 Created workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name:        "subPath with no source",
+			Args:        []string{workloadName, flags.SubPathFlagName, "./app", flags.YesFlagName},
+			ShouldError: true,
+		},
+		{
+			Name: "wait with timeout error",
+			Skip: runtm.GOOS == "windows",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName, flags.WaitFlagName, flags.WaitTimeoutFlagName, "1ns"},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				workload := &cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cartov1alpha1.WorkloadConditionReady,
+								Status: metav1.ConditionTrue,
+							},
+						},
+					},
+				}
+				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
+					{Type: watch.Modified, Object: workload},
+				})
+				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
+				return ctx, nil
+			},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
+			},
+			ShouldError: true,
+			ExpectOutput: `
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  source:
+     11 + |    git:
+     12 + |      ref:
+     13 + |        branch: main
+     14 + |      url: https://example.com/repo.git
+👍 Created workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+Waiting for workload "my-workload" to become ready...
+Error waiting for ready condition: timeout after 1ns waiting for "my-workload" to become ready
+`,
+		},
+		{
+			Name: "wait with events",
+			Skip: runtm.GOOS == "windows",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName, flags.WaitFlagName, flags.WaitTimeoutFlagName, "500ms", flags.EventsFlagName},
+			Now:  time.Date(2021, time.September, 10, 15, 0, 0, 0, time.UTC),
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				event := &corev1.Event{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName + "-build-1.1234",
+					},
+					InvolvedObject: corev1.ObjectReference{
+						APIVersion: "v1",
+						Kind:       "Pod",
+						Name:       workloadName + "-build-1-build-pod",
+					},
+					Type:          corev1.EventTypeWarning,
+					Reason:        "FailedScheduling",
+					Message:       "0/1 nodes are available",
+					LastTimestamp: metav1.NewTime(tc.Now),
+				}
+				otherEvent := event.DeepCopy()
+				otherEvent.InvolvedObject.Name = "other-workload-build-1-build-pod"
+				otherEvent.Reason = "Scheduled"
+				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
+					{Type: watch.Added, Object: otherEvent},
+					{Type: watch.Added, Object: event},
+				})
+				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
+				return ctx, nil
+			},
+			GivenObjects: []client.Object{
+				givenNamespaceDefault[0],
+				diecorev1.PodBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Namespace(defaultNamespace)
+						d.Name(workloadName + "-build-1-build-pod")
+						d.AddLabel(cartov1alpha1.WorkloadLabelName, workloadName)
+					}),
+				diecorev1.PodBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Namespace(defaultNamespace)
+						d.Name("other-workload-build-1-build-pod")
+						d.AddLabel(cartov1alpha1.WorkloadLabelName, "other-workload")
+					}),
+			},
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
+			},
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := "📨 Warning FailedScheduling Pod/my-workload-build-1-build-pod: 0/1 nodes are available\n"; !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %q, got %q", expected, output)
+				}
+				if strings.Contains(output, "Scheduled Pod/other-workload") {
+					t.Errorf("expected output to not contain events of other workloads, got %q", output)
+				}
+			},
+		},
+		{
+			Name: "wait with events of the owned resources only",
+			Skip: runtm.GOOS == "windows",
+			Args: []string{"app", flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName, flags.WaitFlagName, flags.WaitTimeoutFlagName, "500ms", flags.EventsFlagName},
+			Now:  time.Date(2021, time.September, 10, 15, 0, 0, 0, time.UTC),
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				event := &corev1.Event{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "app-build-1.1234",
+					},
+					InvolvedObject: corev1.ObjectReference{
+						APIVersion: "v1",
+						Kind:       "Pod",
+						Name:       "app-build-1-build-pod",
+					},
+					Type:          corev1.EventTypeNormal,
+					Reason:        "Pulling",
+					Message:       "Pulling image",
+					LastTimestamp: metav1.NewTime(tc.Now),
+				}
+				prefixedEvent := event.DeepCopy()
+				prefixedEvent.InvolvedObject.Name = "app-db-build-1-build-pod"
+				prefixedEvent.Reason = "Scheduled"
+				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
+					{Type: watch.Added, Object: prefixedEvent},
+					{Type: watch.Added, Object: event},
+				})
+				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
+				return ctx, nil
+			},
+			GivenObjects: []client.Object{
+				givenNamespaceDefault[0],
+				diecorev1.PodBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Namespace(defaultNamespace)
+						d.Name("app-build-1-build-pod")
+						d.ControlledBy(diecartov1alpha1.WorkloadBlank.
+							MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+								d.Namespace(defaultNamespace)
+								d.Name("app")
+							}), scheme)
+					}),
+				diecorev1.PodBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Namespace(defaultNamespace)
+						d.Name("app-db-build-1-build-pod")
+						d.AddLabel(cartov1alpha1.WorkloadLabelName, "app-db")
+					}),
+			},
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "app",
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
+			},
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := "📨 Normal Pulling Pod/app-build-1-build-pod: Pulling image\n"; !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %q, got %q", expected, output)
+				}
+				if strings.Contains(output, "Pod/app-db-build-1-build-pod") {
+					t.Errorf("expected output to not contain events of the app-db workload, got %q", output)
+				}
+			},
+		},
+		{
+			Name: "wait with timeout error and rollback timeout action",
+			Skip: runtm.GOOS == "windows",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName, flags.WaitFlagName, flags.WaitTimeoutFlagName, "1ns", flags.TimeoutActionFlagName, "rollback"},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				workload := &cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cartov1alpha1.WorkloadConditionReady,
+								Status: metav1.ConditionTrue,
+							},
+						},
+					},
+				}
+				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
+					{Type: watch.Modified, Object: workload},
+				})
+				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
+				return ctx, nil
+			},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
+			},
+			ShouldError: true,
+			ExpectOutput: `
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  source:
+     11 + |    git:
+     12 + |      ref:
+     13 + |        branch: main
+     14 + |      url: https://example.com/repo.git
+👍 Created workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+Waiting for workload "my-workload" to become ready...
+Error waiting for ready condition: timeout after 1ns waiting for "my-workload" to become ready
+Deleted workload "my-workload" created by this command
+`,
+			ExpectDeletes: []rtesting.DeleteRef{{
+				Group:     "carto.run",
+				Kind:      "Workload",
+				Namespace: defaultNamespace,
+				Name:      workloadName,
+			}},
+		},
+		{
+			Name: "wait with timeout error and ignore timeout action",
+			Skip: runtm.GOOS == "windows",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName, flags.WaitFlagName, flags.WaitTimeoutFlagName, "1ns", flags.TimeoutActionFlagName, "ignore"},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				workload := &cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cartov1alpha1.WorkloadConditionReady,
+								Status: metav1.ConditionTrue,
+							},
+						},
+					},
+				}
+				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
+					{Type: watch.Modified, Object: workload},
+				})
+				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
+				return ctx, nil
+			},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  source:
+     11 + |    git:
+     12 + |      ref:
+     13 + |        branch: main
+     14 + |      url: https://example.com/repo.git
+👍 Created workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+Waiting for workload "my-workload" to become ready...
+Error waiting for ready condition: timeout after 1ns waiting for "my-workload" to become ready
+Workload "my-workload" was applied but is not ready yet, to check its status: "tanzu apps workload get my-workload --namespace default"
+`,
+		},
+		{
+			Name: "create - successful wait for ready cond",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName, flags.WaitFlagName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				workload := &cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cartov1alpha1.WorkloadConditionReady,
+								Status: metav1.ConditionTrue,
+							},
+						},
+					},
+				}
+				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
+					{Type: watch.Modified, Object: workload},
+				})
+				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
+				return ctx, nil
+			},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  source:
+     11 + |    git:
+     12 + |      ref:
+     13 + |        branch: main
+     14 + |      url: https://example.com/repo.git
+👍 Created workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+Waiting for workload "my-workload" to become ready...
+Workload "my-workload" is ready
+
+`,
+		},
+		{
+			Name: "create - tail while waiting for ready cond",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName, flags.TailFlagName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				workload := &cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cartov1alpha1.WorkloadConditionReady,
+								Status: metav1.ConditionTrue,
+							},
+						},
+					},
+				}
+				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
+					{Type: watch.Modified, Object: workload},
+				})
+				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
+
+				tailer := &logs.FakeTailer{}
+				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
+				tailer.On("Tail", mock.Anything, "default", selector, logs.TailOptions{Containers: []string{}, Since: time.Minute, TailLines: -1, Follow: true}).Return(nil).Once()
+				ctx = logs.StashTailer(ctx, tailer)
+
+				return ctx, nil
+			},
+			GivenObjects: givenNamespaceDefault,
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				tailer := logs.RetrieveTailer(ctx).(*logs.FakeTailer)
+				tailer.AssertExpectations(t)
+				// log lines flushed after the tail stopped must not follow the ready message
+				fmt.Fprint(tailer.Stdout, "...late tail output...\n")
+				if output := config.Stdout.(*bytes.Buffer).String(); strings.Contains(output, "late tail output") {
+					t.Errorf("expected tail output to be discarded once the tail stopped, got %q", output)
+				}
+				return nil
+			},
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  source:
+     11 + |    git:
+     12 + |      ref:
+     13 + |        branch: main
+     14 + |      url: https://example.com/repo.git
+👍 Created workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+Waiting for workload "my-workload" to become ready...
+...tail output...
+Workload "my-workload" is ready
+
+`,
+		},
+		{
+			Name: "error during create",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName},
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("create", "Workload"),
+			},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "create - watcher error",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName, flags.WaitFlagName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				fakewatch := watchfakes.NewFakeWithWatch(true, config.Client, []watch.Event{})
+				ctx = watchhelper.WithWatcher(ctx, fakewatch)
+				return ctx, nil
+			},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "create - wait error for false condition",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.LabelFlagName, "apps.tanzu.vmware.com/workload-type=web", flags.LabelFlagName, "apps.tanzu.vmware.com/workload-type-", flags.YesFlagName, flags.WaitFlagName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				workload := &cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:    cartov1alpha1.WorkloadConditionReady,
+								Status:  metav1.ConditionFalse,
+								Reason:  "OopsieDoodle",
+								Message: "a hopefully informative message about what went wrong",
+							},
+						},
+					},
+				}
+				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
+					{Type: watch.Modified, Object: workload},
+				})
+				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
+				return ctx, nil
+			},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
+			},
+			ShouldError: true,
+			ExpectOutput: `
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  source:
+     11 + |    git:
+     12 + |      ref:
+     13 + |        branch: main
+     14 + |      url: https://example.com/repo.git
+👍 Created workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+Waiting for workload "my-workload" to become ready...
+Error waiting for ready condition: Failed to become ready: a hopefully informative message about what went wrong
+`,
+		},
+		{
+			Name: "create - wait error for unknown condition with a blocking reason",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.LabelFlagName, "apps.tanzu.vmware.com/workload-type=web", flags.LabelFlagName, "apps.tanzu.vmware.com/workload-type-", flags.YesFlagName, flags.WaitFlagName, flags.FailOnUnknownReasonFlagName, "MissingValueAtPath"},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				workload := &cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:    cartov1alpha1.WorkloadConditionReady,
+								Status:  metav1.ConditionUnknown,
+								Reason:  "MissingValueAtPath",
+								Message: "waiting to read value [.status.latestImage] from resource [image.kpack.io/my-workload] in namespace [default]",
+							},
+						},
+					},
+				}
+				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
+					{Type: watch.Modified, Object: workload},
+				})
+				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
+				return ctx, nil
+			},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
+			},
+			ShouldError: true,
+			ExpectOutput: `
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  source:
+     11 + |    git:
+     12 + |      ref:
+     13 + |        branch: main
+     14 + |      url: https://example.com/repo.git
+👍 Created workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+Waiting for workload "my-workload" to become ready...
+Error waiting for ready condition: Failed to become ready: MissingValueAtPath: waiting to read value [.status.latestImage] from resource [image.kpack.io/my-workload] in namespace [default]
+`,
+		},
+		{
+			Name: "create - wait error for false condition with rollback on error",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.LabelFlagName, "apps.tanzu.vmware.com/workload-type=web", flags.LabelFlagName, "apps.tanzu.vmware.com/workload-type-", flags.YesFlagName, flags.WaitFlagName, flags.RollbackOnErrorFlagName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				workload := &cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:    cartov1alpha1.WorkloadConditionReady,
+								Status:  metav1.ConditionFalse,
+								Reason:  "OopsieDoodle",
+								Message: "a hopefully informative message about what went wrong",
+							},
+						},
+					},
+				}
+				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
+					{Type: watch.Modified, Object: workload},
+				})
+				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
+				return ctx, nil
+			},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
+			},
+			ShouldError: true,
+			ExpectOutput: `
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  source:
+     11 + |    git:
+     12 + |      ref:
+     13 + |        branch: main
+     14 + |      url: https://example.com/repo.git
+👍 Created workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+Waiting for workload "my-workload" to become ready...
+Error waiting for ready condition: Failed to become ready: a hopefully informative message about what went wrong
+Rolled back workload "my-workload" created by this command
+`,
+			ExpectDeletes: []rtesting.DeleteRef{{
+				Group:     "carto.run",
+				Kind:      "Workload",
+				Namespace: defaultNamespace,
+				Name:      workloadName,
+			}},
+		},
+		{
+			Name:         "filepath from url",
+			Args:         []string{flags.FilePathFlagName, fileFromUrl, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "spring-petclinic",
+						Labels: map[string]string{
+							apis.AppPartOfLabelName:               "spring-petclinic",
+							"apps.tanzu.vmware.com/workload-type": "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "main",
+								},
+							},
+						},
+						Env: []corev1.EnvVar{
+							{
+								Name:  "SPRING_PROFILES_ACTIVE",
+								Value: "mysql",
+							},
+						},
+						Resources: &corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("500m"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("100m"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    app.kubernetes.io/part-of: spring-petclinic
+      7 + |    apps.tanzu.vmware.com/workload-type: web
+      8 + |  name: spring-petclinic
+      9 + |  namespace: default
+     10 + |spec:
+     11 + |  env:
+     12 + |  - name: SPRING_PROFILES_ACTIVE
+     13 + |    value: mysql
+     14 + |  resources:
+     15 + |    limits:
+     16 + |      cpu: 500m
+     17 + |      memory: 1Gi
+     18 + |    requests:
+     19 + |      cpu: 100m
+     20 + |      memory: 1Gi
+     21 + |  source:
+     22 + |    git:
+     23 + |      ref:
+     24 + |        branch: main
+     25 + |      url: https://github.com/spring-projects/spring-petclinic.git
+👍 Created workload "spring-petclinic"
+
+To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
+To get status: "tanzu apps workload get spring-petclinic"
+
+`,
+		},
+		{
+			Name:        "invalid url",
+			Args:        []string{flags.FilePathFlagName, "https://github.com/vmware-tanzu/apps-cli-plugin/blob/main/pkg/commands/testdata/workload.yaml", flags.YesFlagName},
+			ShouldError: true,
+		},
+		{
+			Name:        "not http/https url",
+			Args:        []string{flags.FilePathFlagName, "ftp://raw.githubusercontent.com/vmware-tanzu/apps-cli-plugin/main/pkg/commands/testdata/workload.yaml", flags.YesFlagName},
+			ShouldError: true,
+		},
+		{
+			Name:         "filepath",
+			Args:         []string{flags.FilePathFlagName, file, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "spring-petclinic",
+						Labels: map[string]string{
+							apis.AppPartOfLabelName:               "spring-petclinic",
+							"apps.tanzu.vmware.com/workload-type": "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "main",
+								},
+							},
+						},
+						Env: []corev1.EnvVar{
+							{
+								Name:  "SPRING_PROFILES_ACTIVE",
+								Value: "mysql",
+							},
+						},
+						Resources: &corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("500m"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("100m"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    app.kubernetes.io/part-of: spring-petclinic
+      7 + |    apps.tanzu.vmware.com/workload-type: web
+      8 + |  name: spring-petclinic
+      9 + |  namespace: default
+     10 + |spec:
+     11 + |  env:
+     12 + |  - name: SPRING_PROFILES_ACTIVE
+     13 + |    value: mysql
+     14 + |  resources:
+     15 + |    limits:
+     16 + |      cpu: 500m
+     17 + |      memory: 1Gi
+     18 + |    requests:
+     19 + |      cpu: 100m
+     20 + |      memory: 1Gi
+     21 + |  source:
+     22 + |    git:
+     23 + |      ref:
+     24 + |        branch: main
+     25 + |      url: https://github.com/spring-projects/spring-petclinic.git
+👍 Created workload "spring-petclinic"
+
+To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
+To get status: "tanzu apps workload get spring-petclinic"
+
+`,
+		},
+		{
+			Name: "create - invalid label key in yaml file",
+			Args: []string{flags.FilePathFlagName, "-", flags.YesFlagName},
+			Stdin: []byte(`
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: spring-petclinic
+  labels:
+    Invalid Key: spring-petclinic
+spec:
+  image: ubuntu:bionic
+`),
+			GivenObjects: givenNamespaceDefault,
+			ShouldError:  true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := `metadata.labels: Invalid value: "Invalid Key"`; err == nil || !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected error to contain %q, got %v", expected, err)
+				}
+			},
+		},
+		{
+			Name: "create - invalid label key in yaml file with validation disabled",
+			Args: []string{flags.FilePathFlagName, "-", flags.ValidateFlagName + "=false", flags.YesFlagName},
+			Stdin: []byte(`
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: spring-petclinic
+  labels:
+    Invalid Key: spring-petclinic
+spec:
+  image: ubuntu:bionic
+`),
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "spring-petclinic",
+						Labels: map[string]string{
+							"Invalid Key":              "spring-petclinic",
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+					},
+				},
+			},
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := "WARNING: client validation is disabled (--validate=false), the workload is only validated by the cluster"; !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %q, got %q", expected, output)
+				}
+			},
+		},
+		{
+			Name:         "create - validation disabled still rejects values that cannot be parsed",
+			Args:         []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.EnvFlagName, "FOO", flags.ValidateFlagName + "=false", flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ShouldError:  true,
+		},
+		{
+			Name:         "create - validation disabled still rejects a malformed configmap reference",
+			Args:         []string{flags.FilePathFlagName, "configmap://default/my-config", flags.ValidateFlagName + "=false", flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ShouldError:  true,
+		},
+		{
+			Name: "create - validation disabled does not overwrite an existing diff file",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.DiffFileFlagName, filepath.Join(contextDir, "existing-diff.txt"), flags.ValidateFlagName + "=false"},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				return ctx, os.WriteFile(filepath.Join(contextDir, "existing-diff.txt"), []byte("previous diff"), 0644)
+			},
+			GivenObjects: givenNamespaceDefault,
+			ShouldError:  true,
+			Verify: func(t *testing.T, output string, err error) {
+				if content, _ := os.ReadFile(filepath.Join(contextDir, "existing-diff.txt")); string(content) != "previous diff" {
+					t.Errorf("expected the diff file to be kept, got %q", content)
+				}
+			},
+		},
+		{
+			Name:         "create - request above limit with validation disabled",
+			Args:         []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.LimitCPUFlagName, "500m", flags.RequestCPUFlagName, "1", flags.ValidateFlagName + "=false", flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+						Resources: &corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("500m"),
+							},
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("1"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:         "create - validate schema",
+			Args:         []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.EnvFlagName, "FOO=bar", flags.ValidateSchemaFlagName, flags.YesFlagName},
+			GivenObjects: append(givenNamespaceDefault, workloadCRD),
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+						Env: []corev1.EnvVar{
+							{Name: "FOO", Value: "bar"},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  env:
+     11 + |  - name: FOO
+     12 + |    value: bar
+     13 + |  image: ubuntu:bionic
+👍 Created workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name:         "create - validate schema with an invalid field",
+			Args:         []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.ServiceAccountFlagName, "my-very-long-service-account", flags.ValidateSchemaFlagName, flags.YesFlagName},
+			GivenObjects: append(givenNamespaceDefault, workloadCRD),
+			ShouldError:  true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := "spec.serviceAccountName: Too long"; err == nil || !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected error to contain %q, got %v", expected, err)
+				}
+			},
+		},
+		{
+			Name:         "create - validate schema with a field unknown to the cluster",
+			Args:         []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.BuildEnvFlagName, "BP_JVM_VERSION=17", flags.ValidateSchemaFlagName, flags.YesFlagName},
+			GivenObjects: append(givenNamespaceDefault, workloadCRD),
+			ShouldError:  true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := `spec.build: Unsupported value`; err == nil || !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected error to contain %q, got %v", expected, err)
+				}
+			},
+		},
+		{
+			Name:         "create - validate schema without the workload crd",
+			Args:         []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.ValidateSchemaFlagName, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+					},
+				},
+			},
+			ExpectOutput: `
+❗ WARNING: unable to validate the workload against the Workload schema: customresourcedefinitions.apiextensions.k8s.io "workloads.carto.run" not found
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  image: ubuntu:bionic
+👍 Created workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name: "create - accept yaml file through stdin - using --yes flag",
+			Args: []string{flags.FilePathFlagName, "-", flags.YesFlagName},
+			Stdin: []byte(`
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: spring-petclinic
+  labels:
+    app.kubernetes.io/part-of: spring-petclinic
+    apps.tanzu.vmware.com/workload-type: web
+spec:
+  env:
+  - name: SPRING_PROFILES_ACTIVE
+    value: mysql
+  resources:
+    requests:
+      memory: 1Gi
+      cpu: 100m
+    limits:
+      memory: 1Gi
+      cpu: 500m
+  source:
+    git:
+      url: https://github.com/spring-projects/spring-petclinic.git
+      ref:
+        branch: main
+`),
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "spring-petclinic",
+						Labels: map[string]string{
+							apis.AppPartOfLabelName:               "spring-petclinic",
+							"apps.tanzu.vmware.com/workload-type": "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "main",
+								},
+							},
+						},
+						Env: []corev1.EnvVar{
+							{
+								Name:  "SPRING_PROFILES_ACTIVE",
+								Value: "mysql",
+							},
+						},
+						Resources: &corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("500m"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("100m"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    app.kubernetes.io/part-of: spring-petclinic
+      7 + |    apps.tanzu.vmware.com/workload-type: web
+      8 + |  name: spring-petclinic
+      9 + |  namespace: default
+     10 + |spec:
+     11 + |  env:
+     12 + |  - name: SPRING_PROFILES_ACTIVE
+     13 + |    value: mysql
+     14 + |  resources:
+     15 + |    limits:
+     16 + |      cpu: 500m
+     17 + |      memory: 1Gi
+     18 + |    requests:
+     19 + |      cpu: 100m
+     20 + |      memory: 1Gi
+     21 + |  source:
+     22 + |    git:
+     23 + |      ref:
+     24 + |        branch: main
+     25 + |      url: https://github.com/spring-projects/spring-petclinic.git
+👍 Created workload "spring-petclinic"
+
+To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
+To get status: "tanzu apps workload get spring-petclinic"
+
+`,
+		},
+		{
+			Name: "update - accept yaml file through stdin - using --yes flag",
+			Args: []string{flags.FilePathFlagName, "-", flags.YesFlagName},
+			Stdin: []byte(`
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: spring-petclinic
+  labels:
+    app.kubernetes.io/part-of: spring-petclinic
+    apps.tanzu.vmware.com/workload-type: web
+spec:
+  env:
+  - name: SPRING_PROFILES_ACTIVE
+    value: mysql
+  resources:
+    requests:
+      memory: 1Gi
+      cpu: 100m
+    limits:
+      memory: 1Gi
+      cpu: 500m
+  source:
+    git:
+      url: https://github.com/spring-projects/spring-petclinic.git
+      ref:
+        branch: main
+`),
+			GivenObjects: []client.Object{
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("spring-petclinic")
+						d.Namespace(defaultNamespace)
+						d.AddLabel("preserve-me", "should-exist")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Env(
+							corev1.EnvVar{
+								Name:  "OVERRIDE_VAR",
+								Value: "doesnt matter",
+							},
+						)
+					}),
+				givenNamespaceDefault[0],
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "spring-petclinic",
+						Labels: map[string]string{
+							"preserve-me":                         "should-exist",
+							"app.kubernetes.io/part-of":           "spring-petclinic",
+							"apps.tanzu.vmware.com/workload-type": "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "main",
+								},
+							},
+						},
+						Env: []corev1.EnvVar{
+							{
+								Name:  "OVERRIDE_VAR",
+								Value: "doesnt matter",
+							},
+							{
+								Name:  "SPRING_PROFILES_ACTIVE",
+								Value: "mysql",
+							},
+						},
+						Resources: &corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("500m"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("100m"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
+🔎 Update workload:
+...
+  2,  2   |apiVersion: carto.run/v1alpha1
+  3,  3   |kind: Workload
+  4,  4   |metadata:
+  5,  5   |  labels:
+      6 + |    app.kubernetes.io/part-of: spring-petclinic
+      7 + |    apps.tanzu.vmware.com/workload-type: web
+  6,  8   |    preserve-me: should-exist
+  7,  9   |  name: spring-petclinic
+  8, 10   |  namespace: default
+  9, 11   |spec:
+ 10, 12   |  env:
+ 11, 13   |  - name: OVERRIDE_VAR
+ 12, 14   |    value: doesnt matter
+ 13     - |  image: ubuntu:bionic
+     15 + |  - name: SPRING_PROFILES_ACTIVE
+     16 + |    value: mysql
+     17 + |  resources:
+     18 + |    limits:
+     19 + |      cpu: 500m
+     20 + |      memory: 1Gi
+     21 + |    requests:
+     22 + |      cpu: 100m
+     23 + |      memory: 1Gi
+     24 + |  source:
+     25 + |    git:
+     26 + |      ref:
+     27 + |        branch: main
+     28 + |      url: https://github.com/spring-projects/spring-petclinic.git
+👍 Updated workload "spring-petclinic"
+
+To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
+To get status: "tanzu apps workload get spring-petclinic"
+
+`,
+		},
+		{
+			Name: "update - accept yaml file through stdin - using --dry-run flag",
+			Args: []string{flags.FilePathFlagName, "-", flags.DryRunFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			Stdin: []byte(`
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  name: my-workload
+  namespace: default
+  resourceVersion: "999"
+spec:
+  image: ubuntu:bionic
+status:
+  supplyChainRef: {}
+`),
+		},
+		{
+			Name:         "create - accept yaml file through stdin - using --dry-run flag",
+			Args:         []string{flags.FilePathFlagName, "-", flags.DryRunFlagName},
+			GivenObjects: givenNamespaceDefault,
+			Stdin: []byte(`
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  name: my-workload
+  namespace: default
+spec:
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://example.com/repo.git
+status:
+  supplyChainRef: {}
+`),
+		},
+		{
+			Name:         "filepath - service account build-env",
+			Args:         []string{flags.FilePathFlagName, "testdata/workload-build-env.yaml", flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "spring-petclinic",
+						Labels: map[string]string{
+							apis.AppPartOfLabelName:               "spring-petclinic",
+							"apps.tanzu.vmware.com/workload-type": "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Build: &cartov1alpha1.WorkloadBuild{Env: []corev1.EnvVar{
+							{Name: "BP_MAVEN_POM_FILE", Value: "skip-pom.xml"},
+						}},
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "main",
+								},
+							},
+						},
+						Env: []corev1.EnvVar{
+							{
+								Name:  "SPRING_PROFILES_ACTIVE",
+								Value: "mysql",
+							},
+						},
+						Resources: &corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("500m"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("100m"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    app.kubernetes.io/part-of: spring-petclinic
+      7 + |    apps.tanzu.vmware.com/workload-type: web
+      8 + |  name: spring-petclinic
+      9 + |  namespace: default
+     10 + |spec:
+     11 + |  build:
+     12 + |    env:
+     13 + |    - name: BP_MAVEN_POM_FILE
+     14 + |      value: skip-pom.xml
+     15 + |  env:
+     16 + |  - name: SPRING_PROFILES_ACTIVE
+     17 + |    value: mysql
+     18 + |  resources:
+     19 + |    limits:
+     20 + |      cpu: 500m
+     21 + |      memory: 1Gi
+     22 + |    requests:
+     23 + |      cpu: 100m
+     24 + |      memory: 1Gi
+     25 + |  source:
+     26 + |    git:
+     27 + |      ref:
+     28 + |        branch: main
+     29 + |      url: https://github.com/spring-projects/spring-petclinic.git
+👍 Created workload "spring-petclinic"
+
+To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
+To get status: "tanzu apps workload get spring-petclinic"
+
+`,
+		},
+		{
+			Name:        "fail to accept yaml file through stdin missing --yes flag",
+			Args:        []string{flags.FilePathFlagName, "-"},
+			ShouldError: true,
+		},
+		{
+			Name:        "filepath - missing",
+			Args:        []string{workloadName, flags.FilePathFlagName, "testdata/missing.yaml", flags.YesFlagName},
+			ShouldError: true,
+		},
+		{
+			Name: "create - env from configmap",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.EnvFromConfigMapFlagName, "app-config", flags.EnvFlagName, "LOG_LEVEL=debug", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				givenNamespaceDefault[0],
+				diecorev1.ConfigMapBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Namespace(defaultNamespace)
+						d.Name("app-config")
+					}).
+					AddData("LOG_LEVEL", "info").
+					AddData("DB_HOST", "db.example.com"),
+			},
+			ExpectCreates: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.AddLabel(apis.WorkloadTypeLabelName, "web")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Env(
+							corev1.EnvVar{Name: "LOG_LEVEL", Value: "debug"},
+							corev1.EnvVar{
+								Name: "DB_HOST",
+								ValueFrom: &corev1.EnvVarSource{
+									ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+										LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"},
+										Key:                  "DB_HOST",
+									},
+								},
+							},
+						)
+					}),
+			},
+			ExpectOutput: `
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  env:
+     11 + |  - name: LOG_LEVEL
+     12 + |    value: debug
+     13 + |  - name: DB_HOST
+     14 + |    valueFrom:
+     15 + |      configMapKeyRef:
+     16 + |        key: DB_HOST
+     17 + |        name: app-config
+     18 + |  image: ubuntu:bionic
+👍 Created workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name:         "create - env from missing configmap",
+			Args:         []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.EnvFromConfigMapFlagName, "app-config", flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ShouldError:  true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := `configmap "app-config" not found in namespace "default"`; err == nil || err.Error() != expected {
+					t.Errorf("expected error %q, got %v", expected, err)
+				}
+			},
+		},
+		{
+			Name:        "filepath - directory",
+			Args:        []string{workloadName, flags.FilePathFlagName, "testdata", flags.YesFlagName},
+			ShouldError: true,
+		},
+		{
+			Name: "filepath - recursive directory",
+			Args: []string{flags.FilePathFlagName, "testdata/recursive", flags.RecursiveFlagName, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				givenNamespaceDefault[0],
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Namespace(defaultNamespace)
+						d.Name("web")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ExpectCreates: []client.Object{
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Namespace(defaultNamespace)
+						d.Name("api")
+						d.AddLabel(apis.WorkloadTypeLabelName, "web")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Namespace(defaultNamespace)
+						d.Name("web")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:jammy")
+					}),
+			},
+			ShouldError: true,
+			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
+[1/3] applying testdata/recursive/api.yaml
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: api
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  image: ubuntu:bionic
+👍 Created workload "api"
+
+To see logs:   "tanzu apps workload tail api --timestamp --since 1h"
+To get status: "tanzu apps workload get api"
+
+[2/3] applying testdata/recursive/web/invalid.yaml
+Failed to apply workload file: testdata/recursive/web/invalid.yaml: unable to load file "testdata/recursive/web/invalid.yaml": file must contain resource with API Version "carto.run/v1alpha1" and Kind "Workload"
+[3/3] applying testdata/recursive/web/web.yml
+🔎 Update workload:
+...
+  4,  4   |metadata:
+  5,  5   |  name: web
+  6,  6   |  namespace: default
+  7,  7   |spec:
+  8     - |  image: ubuntu:bionic
+      8 + |  image: ubuntu:jammy
+👍 Updated workload "web"
+
+To see logs:   "tanzu apps workload tail web --timestamp --since 1h"
+To get status: "tanzu apps workload get web"
+
+   FILE                                  WORKLOAD    RESULT
+   testdata/recursive/api.yaml           api         created
+   testdata/recursive/web/invalid.yaml   <unknown>   failed
+   testdata/recursive/web/web.yml        web         updated
+
+Applied 3 workload files: 1 created, 1 updated, 0 unchanged, 1 failed
+`,
+		},
+		{
+			Name: "filepath - recursive directory no progress",
+			Args: []string{flags.FilePathFlagName, "testdata/recursive", flags.RecursiveFlagName, flags.NoProgressFlagName, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				givenNamespaceDefault[0],
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Namespace(defaultNamespace)
+						d.Name("web")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ExpectCreates: []client.Object{
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Namespace(defaultNamespace)
+						d.Name("api")
+						d.AddLabel(apis.WorkloadTypeLabelName, "web")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Namespace(defaultNamespace)
+						d.Name("web")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:jammy")
+					}),
+			},
+			ShouldError: true,
+			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: api
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  image: ubuntu:bionic
+👍 Created workload "api"
+
+To see logs:   "tanzu apps workload tail api --timestamp --since 1h"
+To get status: "tanzu apps workload get api"
+
+Failed to apply workload file: testdata/recursive/web/invalid.yaml: unable to load file "testdata/recursive/web/invalid.yaml": file must contain resource with API Version "carto.run/v1alpha1" and Kind "Workload"
+🔎 Update workload:
+...
+  4,  4   |metadata:
+  5,  5   |  name: web
+  6,  6   |  namespace: default
+  7,  7   |spec:
+  8     - |  image: ubuntu:bionic
+      8 + |  image: ubuntu:jammy
+👍 Updated workload "web"
+
+To see logs:   "tanzu apps workload tail web --timestamp --since 1h"
+To get status: "tanzu apps workload get web"
+
+   FILE                                  WORKLOAD    RESULT
+   testdata/recursive/api.yaml           api         created
+   testdata/recursive/web/invalid.yaml   <unknown>   failed
+   testdata/recursive/web/web.yml        web         updated
+
+Applied 3 workload files: 1 created, 1 updated, 0 unchanged, 1 failed
+`,
+		},
+		{
+			Name: "filepath - recursive directory quiet",
+			Args: []string{flags.FilePathFlagName, "testdata/recursive", flags.RecursiveFlagName, flags.QuietFlagName, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				givenNamespaceDefault[0],
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Namespace(defaultNamespace)
+						d.Name("web")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ExpectCreates: []client.Object{
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Namespace(defaultNamespace)
+						d.Name("api")
+						d.AddLabel(apis.WorkloadTypeLabelName, "web")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Namespace(defaultNamespace)
+						d.Name("web")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:jammy")
+					}),
+			},
+			ShouldError: true,
+			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
+Failed to apply workload file: testdata/recursive/web/invalid.yaml: unable to load file "testdata/recursive/web/invalid.yaml": file must contain resource with API Version "carto.run/v1alpha1" and Kind "Workload"
+
+   FILE                                  WORKLOAD    RESULT
+   testdata/recursive/api.yaml           api         created
+   testdata/recursive/web/invalid.yaml   <unknown>   failed
+   testdata/recursive/web/web.yml        web         updated
+
+Applied 3 workload files: 1 created, 1 updated, 0 unchanged, 1 failed
+`,
+		},
+		{
+			Name: "filepath - recursive directory concurrency",
+			Args: []string{flags.FilePathFlagName, "testdata/recursive", flags.RecursiveFlagName, flags.ConcurrencyFlagName, "2", flags.NoProgressFlagName, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				givenNamespaceDefault[0],
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Namespace(defaultNamespace)
+						d.Name("web")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ExpectCreates: []client.Object{
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Namespace(defaultNamespace)
+						d.Name("api")
+						d.AddLabel(apis.WorkloadTypeLabelName, "web")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Namespace(defaultNamespace)
+						d.Name("web")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:jammy")
+					}),
+			},
+			ShouldError: true,
+			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: api
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  image: ubuntu:bionic
+👍 Created workload "api"
+
+To see logs:   "tanzu apps workload tail api --timestamp --since 1h"
+To get status: "tanzu apps workload get api"
+
+Failed to apply workload file: testdata/recursive/web/invalid.yaml: unable to load file "testdata/recursive/web/invalid.yaml": file must contain resource with API Version "carto.run/v1alpha1" and Kind "Workload"
+🔎 Update workload:
+...
+  4,  4   |metadata:
+  5,  5   |  name: web
+  6,  6   |  namespace: default
+  7,  7   |spec:
+  8     - |  image: ubuntu:bionic
+      8 + |  image: ubuntu:jammy
+👍 Updated workload "web"
+
+To see logs:   "tanzu apps workload tail web --timestamp --since 1h"
+To get status: "tanzu apps workload get web"
+
+   FILE                                  WORKLOAD    RESULT
+   testdata/recursive/api.yaml           api         created
+   testdata/recursive/web/invalid.yaml   <unknown>   failed
+   testdata/recursive/web/web.yml        web         updated
+
+Applied 3 workload files: 1 created, 1 updated, 0 unchanged, 1 failed
+`,
+		},
+		{
+			Name: "filepath - recursive directory concurrency dry run",
+			Args: []string{flags.FilePathFlagName, "testdata/recursive", flags.RecursiveFlagName, flags.ConcurrencyFlagName, "2", flags.NoProgressFlagName, flags.DryRunFlagName, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				givenNamespaceDefault[0],
+			},
+			ShouldError: true,
+			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  labels:
+    apps.tanzu.vmware.com/workload-type: web
+  name: api
+  namespace: default
+spec:
+  image: ubuntu:bionic
+status:
+  supplyChainRef: {}
+Failed to apply workload file: testdata/recursive/web/invalid.yaml: unable to load file "testdata/recursive/web/invalid.yaml": file must contain resource with API Version "carto.run/v1alpha1" and Kind "Workload"
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  labels:
+    apps.tanzu.vmware.com/workload-type: web
+  name: web
+  namespace: default
+spec:
+  image: ubuntu:jammy
+status:
+  supplyChainRef: {}
+   FILE                                  WORKLOAD    RESULT
+   testdata/recursive/api.yaml           api         unchanged
+   testdata/recursive/web/invalid.yaml   <unknown>   failed
+   testdata/recursive/web/web.yml        web         unchanged
+
+Applied 3 workload files: 0 created, 0 updated, 2 unchanged, 1 failed
+`,
+		},
+		{
+			Name: "filepath - recursive directory counts the warnings of every file",
+			Args: []string{flags.FilePathFlagName, "testdata/recursive", flags.RecursiveFlagName, flags.ServiceAccountFlagName, "my-sa", flags.NoProgressFlagName, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				givenNamespaceDefault[0],
+			},
+			ExpectCreates: []client.Object{
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Namespace(defaultNamespace)
+						d.Name("api")
+						d.AddLabel(apis.WorkloadTypeLabelName, "web")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.ServiceAccountName(pointer.String("my-sa"))
+					}),
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Namespace(defaultNamespace)
+						d.Name("web")
+						d.AddLabel(apis.WorkloadTypeLabelName, "web")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:jammy")
+						d.ServiceAccountName(pointer.String("my-sa"))
+					}),
+			},
+			ShouldError: true,
+			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
+❗ WARNING: service account "my-sa" not found in namespace "default", the workload may fail to run (use --no-sa-check to skip this check)
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: api
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  image: ubuntu:bionic
+     11 + |  serviceAccountName: my-sa
+👍 Created workload "api"
+
+To see logs:   "tanzu apps workload tail api --timestamp --since 1h"
+To get status: "tanzu apps workload get api"
+
+Failed to apply workload file: testdata/recursive/web/invalid.yaml: unable to load file "testdata/recursive/web/invalid.yaml": file must contain resource with API Version "carto.run/v1alpha1" and Kind "Workload"
+❗ WARNING: service account "my-sa" not found in namespace "default", the workload may fail to run (use --no-sa-check to skip this check)
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: web
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  image: ubuntu:jammy
+     11 + |  serviceAccountName: my-sa
+👍 Created workload "web"
+
+To see logs:   "tanzu apps workload tail web --timestamp --since 1h"
+To get status: "tanzu apps workload get web"
+
+   FILE                                  WORKLOAD    RESULT
+   testdata/recursive/api.yaml           api         created
+   testdata/recursive/web/invalid.yaml   <unknown>   failed
+   testdata/recursive/web/web.yml        web         created
+
+Applied 3 workload files: 2 created, 0 updated, 0 unchanged, 1 failed
+2 warning(s) printed while applying the workload files
+`,
+		},
+		{
+			Name: "filepath - recursive directory concurrency conflict",
+			Args: []string{flags.FilePathFlagName, "testdata/recursive-conflict", flags.RecursiveFlagName, flags.ConcurrencyFlagName, "2", flags.NoProgressFlagName, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				givenNamespaceDefault[0],
+			},
+			ExpectCreates: []client.Object{
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Namespace(defaultNamespace)
+						d.Name("api")
+						d.AddLabel(apis.WorkloadTypeLabelName, "web")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ShouldError: true,
+			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: api
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  image: ubuntu:bionic
+👍 Created workload "api"
+
+To see logs:   "tanzu apps workload tail api --timestamp --since 1h"
+To get status: "tanzu apps workload get api"
+
+Failed to apply workload file: testdata/recursive-conflict/copy/api.yaml: workload "api" in namespace "default" is also in file "testdata/recursive-conflict/api.yaml", they can't be applied concurrently
+
+   FILE                                        WORKLOAD   RESULT
+   testdata/recursive-conflict/api.yaml        api        created
+   testdata/recursive-conflict/copy/api.yaml   api        failed
+
+Applied 2 workload files: 1 created, 0 updated, 0 unchanged, 1 failed
+`,
+		},
+		{
+			Name: "noop",
+			Args: []string{workloadName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ExpectOutput: `
+Workload is unchanged, skipping update
+`,
+		},
+		{
+			Name: "noop - always show",
+			Args: []string{workloadName, flags.AlwaysShowFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ExpectOutput: `
+🔎 Current workload:
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
 metadata:
-  creationTimestamp: null
   labels:
     apps.tanzu.vmware.com/workload-type: web
   name: my-workload
   namespace: default
-  resourceVersion: "1"
 spec:
-  source:
-    git:
-      ref:
-        branch: main
-      url: https://example.com/repo.git
-status:
-  supplyChainRef: {}
+  image: ubuntu:bionic
+Workload is unchanged, skipping update
+`,
+		},
+		{
+			Name: "set image tag",
+			Args: []string{workloadName, flags.SetImageTagFlagName, "jammy", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("registry.example.com:5000/ubuntu@sha256:978be33a7f0cbe89bf48fbb438846047a28e1298d6d10d0de2d64bdc102a9e69")
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("registry.example.com:5000/ubuntu:jammy")
+					}),
+			},
+			ExpectOutput: `
+🔎 Update workload:
+...
+  6,  6   |    apps.tanzu.vmware.com/workload-type: web
+  7,  7   |  name: my-workload
+  8,  8   |  namespace: default
+  9,  9   |spec:
+ 10     - |  image: registry.example.com:5000/ubuntu@sha256:978be33a7f0cbe89bf48fbb438846047a28e1298d6d10d0de2d64bdc102a9e69
+     10 + |  image: registry.example.com:5000/ubuntu:jammy
+👍 Updated workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name: "set image tag without image",
+			Args: []string{workloadName, flags.SetImageTagFlagName, "jammy", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Source(&cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://example.com/repo.git",
+								Ref: cartov1alpha1.GitRef{Branch: "main"},
+							},
+						})
+					}),
+			},
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := validation.ErrInvalidValueWithDetail("jammy", flags.SetImageTagFlagName, `workload "my-workload" has no image to set the tag of`).ToAggregate().Error(); err == nil || err.Error() != expected {
+					t.Errorf("expected error %q, got %v", expected, err)
+				}
+			},
+		},
+		{
+			Name: "noop - same flags in a different order than the workload",
+			Args: []string{workloadName, flags.EnvFlagName, "A=a", flags.EnvFlagName, "B=b",
+				flags.ParamFlagName, "x=1", flags.ParamFlagName, "y=2",
+				flags.ServiceRefFlagName, "cache=v1:Secret:cache", flags.ServiceRefFlagName, "database=v1:Secret:database"},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Env(
+							corev1.EnvVar{Name: "B", Value: "b"},
+							corev1.EnvVar{Name: "A", Value: "a"},
+						)
+						d.Params(
+							cartov1alpha1.Param{Name: "y", Value: apiextensionsv1.JSON{Raw: []byte(`"2"`)}},
+							cartov1alpha1.Param{Name: "x", Value: apiextensionsv1.JSON{Raw: []byte(`"1"`)}},
+						)
+						d.ServiceClaims(
+							cartov1alpha1.NewServiceClaim("database", corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Name: "database"}),
+							cartov1alpha1.NewServiceClaim("cache", corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Name: "cache"}),
+						)
+					}),
+			},
+			ExpectOutput: `
+Workload is unchanged, skipping update
+`,
+		},
+		{
+			Name: "update - long value truncated in the diff",
+			Args: []string{workloadName, flags.EnvFlagName, "CERT=" + strings.Repeat("a", 30), flags.MaxValueWidthFlagName, "20", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Env(corev1.EnvVar{Name: "CERT", Value: strings.Repeat("a", 30)})
+					}),
+			},
+			ExpectOutput: `
+🔎 Update workload:
+...
+  6,  6   |    apps.tanzu.vmware.com/workload-type: web
+  7,  7   |  name: my-workload
+  8,  8   |  namespace: default
+  9,  9   |spec:
+     10 + |  env:
+     11 + |  - name: CERT
+     12 + |    value: "aaaaaaaaaaaaaaaaaaaa...(truncated 10 bytes)"
+ 10, 13   |  image: ubuntu:bionic
+👍 Updated workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name: "update - change past the truncated part of a long value",
+			Args: []string{workloadName, flags.EnvFlagName, "CERT=" + strings.Repeat("a", 30) + "port=8081", flags.MaxValueWidthFlagName, "20", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Env(corev1.EnvVar{Name: "CERT", Value: strings.Repeat("a", 30) + "port=8080"})
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Env(corev1.EnvVar{Name: "CERT", Value: strings.Repeat("a", 30) + "port=8081"})
+					}),
+			},
+			ExpectOutput: `
+🔎 Update workload:
+...
+  8,  8   |  namespace: default
+  9,  9   |spec:
+ 10, 10   |  env:
+ 11, 11   |  - name: CERT
+ 12     - |    value: aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaport=8080
+     12 + |    value: aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaport=8081
+ 13, 13   |  image: ubuntu:bionic
+👍 Updated workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name: "update - unified diff format",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:jammy", flags.DiffFormatFlagName, "unified", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:jammy")
+					}),
+			},
+			ExpectOutput: `
+🔎 Update workload:
+--- a/my-workload.yaml
++++ b/my-workload.yaml
+@@ -7,4 +7,4 @@
+   name: my-workload
+   namespace: default
+ spec:
+-  image: ubuntu:bionic
++  image: ubuntu:jammy
+👍 Updated workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name: "update - word diff",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:jammy", flags.WordDiffFlagName, flags.YesFlagName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				// a terminal that supports colors, where the changed part of the lines is highlighted
+				color.NoColor = false
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				color.NoColor = true
+				return nil
+			},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:jammy")
+					}),
+			},
+			Verify: func(t *testing.T, output string, err error) {
+				for _, expected := range []string{
+					"\x1b[31m 10     - |  image: ubuntu:\x1b[0m\x1b[31;1;4mbionic\x1b[0m",
+					"\x1b[32m     10 + |  image: ubuntu:\x1b[0m\x1b[32;1;4mjammy\x1b[0m",
+				} {
+					if !strings.Contains(output, expected) {
+						t.Errorf("expected output to contain %q, got %q", expected, output)
+					}
+				}
+			},
+		},
+		{
+			Name: "update - collapse unchanged",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:jammy", flags.CollapseUnchangedFlagName, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Env(
+							corev1.EnvVar{Name: "FOO", Value: "bar"},
+							corev1.EnvVar{Name: "BAR", Value: "baz"},
+						)
+						d.Resources(&corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("500m"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+						})
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:jammy")
+						d.Env(
+							corev1.EnvVar{Name: "FOO", Value: "bar"},
+							corev1.EnvVar{Name: "BAR", Value: "baz"},
+						)
+						d.Resources(&corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("500m"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+						})
+					}),
+			},
+			ExpectOutput: `
+🔎 Update workload:
+...
+ 10, 10   |  env: (unchanged)
+ 15     - |  image: ubuntu:bionic
+     15 + |  image: ubuntu:jammy
+ 16, 16   |  resources: (unchanged)
+👍 Updated workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
 `,
 		},
 		{
-			Name: "create - output yaml with wait",
-			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch,
-				flags.OutputFlagName, printer.OutputFormatYaml, flags.WaitFlagName, flags.YesFlagName},
+			Name: "update - diff file",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:jammy", flags.DiffFormatFlagName, "unified", flags.DiffFileFlagName, diffFile, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:jammy")
+					}),
+			},
+			Verify: func(t *testing.T, output string, err error) {
+				content, err := os.ReadFile(diffFile)
+				if err != nil {
+					t.Fatalf("unable to read diff file: %v", err)
+				}
+				expected := `--- a/my-workload.yaml
++++ b/my-workload.yaml
+@@ -7,4 +7,4 @@
+   name: my-workload
+   namespace: default
+ spec:
+-  image: ubuntu:bionic
++  image: ubuntu:jammy
+`
+				if diff := cmp.Diff(expected, string(content)); diff != "" {
+					t.Errorf("Unexpected diff file content (-expected, +actual): %s", diff)
+				}
+				if !strings.Contains(output, "+  image: ubuntu:jammy") {
+					t.Errorf("expected the diff to still be shown in the terminal, got %q", output)
+				}
+			},
+		},
+		{
+			Name: "update - diff file relative to context dir",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:jammy", flags.ContextDirFlagName, contextDir, flags.DiffFileFlagName, "diff.txt", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:jammy")
+					}),
+			},
+			Verify: func(t *testing.T, output string, err error) {
+				if _, err := os.Stat(filepath.Join(contextDir, "diff.txt")); err != nil {
+					t.Errorf("expected the diff file in the context dir: %v", err)
+				}
+				if _, err := os.Stat("diff.txt"); err == nil {
+					t.Errorf("expected no diff file in the current dir")
+				}
+			},
+		},
+		{
+			Name:         "local path relative to context dir",
+			Args:         []string{workloadName, flags.ContextDirFlagName, "testdata", flags.LocalPathFlagName, "missing-source", flags.SourceImageFlagName, "my-registry.io/my-source", flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ShouldError:  true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := filepath.Join("testdata", "missing-source"); err == nil || !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected error to reference %q, got %v", expected, err)
+				}
+			},
+		},
+		{
+			Name:         "registry ca cert relative to context dir",
+			Args:         []string{workloadName, flags.ContextDirFlagName, "testdata", flags.LocalPathFlagName, ".", flags.SourceImageFlagName, "my-registry.io/my-source", flags.RegistryCertFlagName, "missing.crt", flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ShouldError:  true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := filepath.Join("testdata", "missing.crt"); err == nil || !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected error to reference %q, got %v", expected, err)
+				}
+			},
+		},
+		{
+			Name:         "ignore file relative to context dir",
+			Args:         []string{workloadName, flags.ContextDirFlagName, "testdata", flags.LocalPathFlagName, "local-source-exclude-files", flags.SourceImageFlagName, "my-registry.io/my-source", flags.RegistryCertFlagName, "missing.crt", flags.YesFlagName},
 			GivenObjects: givenNamespaceDefault,
-			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
-				workload := &cartov1alpha1.Workload{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: defaultNamespace,
-						Name:      workloadName,
-						Labels: map[string]string{
-							apis.WorkloadTypeLabelName: "web",
-						},
-					},
-					Status: cartov1alpha1.WorkloadStatus{
-						Conditions: []metav1.Condition{
-							{
-								Type:   cartov1alpha1.WorkloadConditionReady,
-								Status: metav1.ConditionTrue,
-							},
-						},
-					},
+			ShouldError:  true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := "The files and/or directories listed in the .tanzuignore file are being excluded from the uploaded source code."; !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %q, got %q", expected, output)
 				}
-				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
-					{Type: watch.Modified, Object: workload},
-				})
-				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
-				return ctx, nil
 			},
-			ExpectCreates: []client.Object{
-				&cartov1alpha1.Workload{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: defaultNamespace,
-						Name:      workloadName,
-						Labels: map[string]string{
-							apis.WorkloadTypeLabelName: "web",
-						},
-					},
-					Spec: cartov1alpha1.WorkloadSpec{
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: gitRepo,
-								Ref: cartov1alpha1.GitRef{
-									Branch: gitBranch,
-								},
-							},
-						},
-					},
-				},
+		},
+		{
+			Name: "no source resource",
+			Args: []string{workloadName},
+			GivenObjects: []client.Object{
+				parent,
+			},
+		},
+		{
+			Name: "get failed",
+			Args: []string{workloadName},
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("get", "Workload"),
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "update - dry run",
+			Args: []string{workloadName, flags.DebugFlagName, flags.DryRunFlagName, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
 			},
 			ExpectOutput: `
 ---
 apiVersion: carto.run/v1alpha1
 kind: Workload
 metadata:
-  creationTimestamp: null
+  creationTimestamp: "1970-01-01T00:00:01Z"
   labels:
     apps.tanzu.vmware.com/workload-type: web
   name: my-workload
   namespace: default
-  resourceVersion: "1"
+  resourceVersion: "999"
 spec:
-  source:
-    git:
-      ref:
-        branch: main
-      url: https://example.com/repo.git
+  image: ubuntu:bionic
+  params:
+  - name: debug
+    value: "true"
 status:
   supplyChainRef: {}
 `,
 		},
 		{
-			Name: "create - output json",
-			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch,
-				flags.OutputFlagName, printer.OutputFormatJson, flags.YesFlagName},
-			GivenObjects: givenNamespaceDefault,
-			ExpectCreates: []client.Object{
+			Name: "error during update",
+			Args: []string{workloadName, flags.DebugFlagName, flags.YesFlagName},
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("update", "Workload"),
+			},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
@@ -433,64 +5631,31 @@ status:
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: gitRepo,
-								Ref: cartov1alpha1.GitRef{
-									Branch: gitBranch,
-								},
+						Image: "ubuntu:bionic",
+						Params: []cartov1alpha1.Param{
+							{
+								Name:  "debug",
+								Value: apiextensionsv1.JSON{Raw: []byte(`"true"`)},
 							},
 						},
 					},
 				},
 			},
-			ExpectOutput: `
-{
-	"apiVersion": "carto.run/v1alpha1",
-	"kind": "Workload",
-	"metadata": {
-		"creationTimestamp": null,
-		"labels": {
-			"apps.tanzu.vmware.com/workload-type": "web"
-		},
-		"name": "my-workload",
-		"namespace": "default",
-		"resourceVersion": "1"
-	},
-	"spec": {
-		"source": {
-			"git": {
-				"ref": {
-					"branch": "main"
-				},
-				"url": "https://example.com/repo.git"
-			}
-		}
-	},
-	"status": {
-		"supplyChainRef": {}
-	}
-}
-`,
+			ShouldError: true,
 		},
 		{
-			Name: "update - output yaml",
-			Args: []string{workloadName, flags.ServiceRefFlagName,
-				"database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db",
-				flags.OutputFlagName, printer.OutputFormatYaml, flags.YesFlagName},
+			Name: "conflict during update",
+			Args: []string{workloadName, flags.DebugFlagName, flags.YesFlagName},
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("update", "Workload", clitesting.InduceFailureOpts{
+					Error: apierrs.NewConflict(schema.GroupResource{Group: "carto.run", Resource: "workloads"}, workloadName, fmt.Errorf("induced conflict")),
+				}),
+			},
 			GivenObjects: []client.Object{
 				parent.
 					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
 						d.Image("ubuntu:bionic")
-					}).StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
-					d.Conditions(metav1.Condition{
-						Type:   cartov1alpha1.WorkloadConditionReady,
-						Status: metav1.ConditionTrue,
-					}, metav1.Condition{
-						Type:   "my-other-type",
-						Status: metav1.ConditionTrue,
-					})
-				}),
+					}),
 			},
 			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
@@ -503,84 +5668,79 @@ status:
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
 						Image: "ubuntu:bionic",
-						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
-							{
-								Name: "database",
-								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
-									APIVersion: "services.tanzu.vmware.com/v1alpha1",
-									Kind:       "PostgreSQL",
-									Name:       "my-prod-db",
-								},
-							},
-						},
-					},
-					Status: cartov1alpha1.WorkloadStatus{
-						Conditions: []metav1.Condition{
-							{
-								Type:   "Ready",
-								Status: metav1.ConditionTrue,
-							},
+						Params: []cartov1alpha1.Param{
 							{
-								Type:   "my-other-type",
-								Status: metav1.ConditionTrue,
+								Name:  "debug",
+								Value: apiextensionsv1.JSON{Raw: []byte(`"true"`)},
 							},
 						},
 					},
 				},
 			},
+			ShouldError: true,
 			ExpectOutput: `
----
-apiVersion: carto.run/v1alpha1
-kind: Workload
-metadata:
-  creationTimestamp: "1970-01-01T00:00:01Z"
-  labels:
-    apps.tanzu.vmware.com/workload-type: web
-  name: my-workload
-  namespace: default
-  resourceVersion: "1000"
-spec:
-  image: ubuntu:bionic
-  serviceClaims:
-  - name: database
-    ref:
-      apiVersion: services.tanzu.vmware.com/v1alpha1
-      kind: PostgreSQL
-      name: my-prod-db
-status:
-  conditions:
-  - lastTransitionTime: null
-    message: ""
-    reason: ""
-    status: "True"
-    type: Ready
-  - lastTransitionTime: null
-    message: ""
-    reason: ""
-    status: "True"
-    type: my-other-type
-  supplyChainRef: {}
+🔎 Update workload:
+...
+  7,  7   |  name: my-workload
+  8,  8   |  namespace: default
+  9,  9   |spec:
+ 10, 10   |  image: ubuntu:bionic
+     11 + |  params:
+     12 + |  - name: debug
+     13 + |    value: "true"
+Error: conflict updating workload, the object was modified by another user; please run the update command again
 `,
 		},
 		{
-			Name: "update - output json",
-			Args: []string{workloadName, flags.ServiceRefFlagName,
-				"database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db",
-				flags.OutputFlagName, printer.OutputFormatJson, flags.YesFlagName},
+			Name: "update - wait for ready condition - error with timeout",
+			Skip: runtm.GOOS == "windows",
+			Args: []string{workloadName, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db", flags.WaitFlagName, flags.YesFlagName, flags.WaitTimeoutFlagName, "1ns"},
 			GivenObjects: []client.Object{
 				parent.
 					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
 						d.Image("ubuntu:bionic")
 					}).StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
 					d.Conditions(metav1.Condition{
-						Type:   "my-type",
+						Type:   cartov1alpha1.WorkloadConditionReady,
 						Status: metav1.ConditionTrue,
+						LastTransitionTime: metav1.Time{
+							Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+						},
 					}, metav1.Condition{
 						Type:   "my-other-type",
 						Status: metav1.ConditionTrue,
 					})
 				}),
 			},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				workload := &cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cartov1alpha1.WorkloadConditionReady,
+								Status: metav1.ConditionTrue,
+								LastTransitionTime: metav1.Time{
+									Time: time.Date(2019, 6, 29, 01, 44, 05, 01, time.UTC),
+								},
+							},
+						},
+					},
+				}
+
+				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
+					{Type: watch.Modified, Object: workload},
+				})
+				ctx = context.WithValue(ctx, commands.WorkloadTimeoutStashKey{}, "1s")
+				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
+				return ctx, nil
+			},
 			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
@@ -606,8 +5766,11 @@ status:
 					Status: cartov1alpha1.WorkloadStatus{
 						Conditions: []metav1.Condition{
 							{
-								Type:   "my-type",
+								Type:   "Ready",
 								Status: metav1.ConditionTrue,
+								LastTransitionTime: metav1.Time{
+									Time: time.Date(2019, 6, 29, 01, 44, 05, 01, time.UTC),
+								},
 							},
 							{
 								Type:   "my-other-type",
@@ -617,59 +5780,50 @@ status:
 					},
 				},
 			},
+			ShouldError: true,
 			ExpectOutput: `
-{
-	"apiVersion": "carto.run/v1alpha1",
-	"kind": "Workload",
-	"metadata": {
-		"creationTimestamp": "1970-01-01T00:00:01Z",
-		"labels": {
-			"apps.tanzu.vmware.com/workload-type": "web"
-		},
-		"name": "my-workload",
-		"namespace": "default",
-		"resourceVersion": "1000"
-	},
-	"spec": {
-		"image": "ubuntu:bionic",
-		"serviceClaims": [
-			{
-				"name": "database",
-				"ref": {
-					"apiVersion": "services.tanzu.vmware.com/v1alpha1",
-					"kind": "PostgreSQL",
-					"name": "my-prod-db"
-				}
-			}
-		]
-	},
-	"status": {
-		"conditions": [
-			{
-				"lastTransitionTime": null,
-				"message": "",
-				"reason": "",
-				"status": "True",
-				"type": "my-type"
-			},
-			{
-				"lastTransitionTime": null,
-				"message": "",
-				"reason": "",
-				"status": "True",
-				"type": "my-other-type"
-			}
-		],
-		"supplyChainRef": {}
-	}
-}
+🔎 Update workload:
+...
+  7,  7   |  name: my-workload
+  8,  8   |  namespace: default
+  9,  9   |spec:
+ 10, 10   |  image: ubuntu:bionic
+     11 + |  serviceClaims:
+     12 + |  - name: database
+     13 + |    ref:
+     14 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
+     15 + |      kind: PostgreSQL
+     16 + |      name: my-prod-db
+👍 Updated workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+Waiting for workload "my-workload" to become ready...
+Error waiting for ready condition: timeout after 1ns waiting for "my-workload" to become ready
 `,
 		},
 		{
-			Name: "update - output json with tail",
-			Args: []string{workloadName, flags.ServiceRefFlagName,
-				"database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db",
-				flags.OutputFlagName, printer.OutputFormatJson, flags.TailFlagName, flags.YesFlagName},
+			Name: "update - wait timeout with rollback timeout action keeps existing workload",
+			Skip: runtm.GOOS == "windows",
+			Args: []string{workloadName, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db", flags.WaitFlagName, flags.YesFlagName, flags.WaitTimeoutFlagName, "1ns", flags.TimeoutActionFlagName, "rollback"},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}).StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
+					d.Conditions(metav1.Condition{
+						Type:   cartov1alpha1.WorkloadConditionReady,
+						Status: metav1.ConditionTrue,
+						LastTransitionTime: metav1.Time{
+							Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+						},
+					}, metav1.Condition{
+						Type:   "my-other-type",
+						Status: metav1.ConditionTrue,
+					})
+				}),
+			},
 			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
 				workload := &cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
@@ -685,41 +5839,20 @@ status:
 								Type:   cartov1alpha1.WorkloadConditionReady,
 								Status: metav1.ConditionTrue,
 								LastTransitionTime: metav1.Time{
-									Time: time.Date(2019, 6, 29, 01, 44, 12, 0, time.UTC),
+									Time: time.Date(2019, 6, 29, 01, 44, 05, 01, time.UTC),
 								},
 							},
 						},
 					},
 				}
+
 				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
 					{Type: watch.Modified, Object: workload},
 				})
+				ctx = context.WithValue(ctx, commands.WorkloadTimeoutStashKey{}, "1s")
 				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
-
-				tailer := &logs.FakeTailer{}
-				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Minute, false).Return(nil).Once()
-				ctx = logs.StashTailer(ctx, tailer)
-
 				return ctx, nil
 			},
-			GivenObjects: []client.Object{
-				parent.
-					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-						d.Image("ubuntu:bionic")
-					}).StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
-					d.Conditions(metav1.Condition{
-						Type:   cartov1alpha1.WorkloadConditionReady,
-						Status: metav1.ConditionTrue,
-						LastTransitionTime: metav1.Time{
-							Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
-						},
-					}, metav1.Condition{
-						Type:   "my-other-type",
-						Status: metav1.ConditionTrue,
-					})
-				}),
-			},
 			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
@@ -745,10 +5878,10 @@ status:
 					Status: cartov1alpha1.WorkloadStatus{
 						Conditions: []metav1.Condition{
 							{
-								Type:   cartov1alpha1.WorkloadConditionReady,
+								Type:   "Ready",
 								Status: metav1.ConditionTrue,
 								LastTransitionTime: metav1.Time{
-									Time: time.Date(2019, 6, 29, 01, 44, 12, 0, time.UTC),
+									Time: time.Date(2019, 6, 29, 01, 44, 05, 01, time.UTC),
 								},
 							},
 							{
@@ -759,83 +5892,74 @@ status:
 					},
 				},
 			},
-			ExpectOutput: `
-...tail output...
-{
-	"apiVersion": "carto.run/v1alpha1",
-	"kind": "Workload",
-	"metadata": {
-		"creationTimestamp": "1970-01-01T00:00:01Z",
-		"labels": {
-			"apps.tanzu.vmware.com/workload-type": "web"
-		},
-		"name": "my-workload",
-		"namespace": "default",
-		"resourceVersion": "1000"
-	},
-	"spec": {
-		"image": "ubuntu:bionic",
-		"serviceClaims": [
-			{
-				"name": "database",
-				"ref": {
-					"apiVersion": "services.tanzu.vmware.com/v1alpha1",
-					"kind": "PostgreSQL",
-					"name": "my-prod-db"
-				}
-			}
-		]
-	},
-	"status": {
-		"conditions": [
-			{
-				"lastTransitionTime": "2019-06-29T01:44:05Z",
-				"message": "",
-				"reason": "",
-				"status": "True",
-				"type": "Ready"
-			},
-			{
-				"lastTransitionTime": null,
-				"message": "",
-				"reason": "",
-				"status": "True",
-				"type": "my-other-type"
-			}
-		],
-		"supplyChainRef": {}
-	}
-}
-`,
-		},
-		{
-			Name: "create git source with invalid namespace",
-			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.NamespaceFlagName, "foo", flags.YesFlagName},
-			WithReactors: []clitesting.ReactionFunc{
-				clitesting.InduceFailure("get", "Namespace", clitesting.InduceFailureOpts{
-					Error: apierrs.NewNotFound(corev1.Resource("Namespace"), "foo"),
-				}),
-			},
 			ShouldError: true,
 			ExpectOutput: `
-Error: namespace "foo" not found, it may not exist or user does not have permissions to read it.
+🔎 Update workload:
+...
+  7,  7   |  name: my-workload
+  8,  8   |  namespace: default
+  9,  9   |spec:
+ 10, 10   |  image: ubuntu:bionic
+     11 + |  serviceClaims:
+     12 + |  - name: database
+     13 + |    ref:
+     14 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
+     15 + |      kind: PostgreSQL
+     16 + |      name: my-prod-db
+👍 Updated workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+Waiting for workload "my-workload" to become ready...
+Error waiting for ready condition: timeout after 1ns waiting for "my-workload" to become ready
+Workload "my-workload" existed before this command, it is not rolled back
 `,
 		},
 		{
-			Name: "Update git source with subPath from file",
-			Args: []string{workloadName, flags.FilePathFlagName, "./testdata/workload-subPath.yaml", flags.YesFlagName},
+			Name: "update - wait timeout when there is no transition time",
+			Skip: runtm.GOOS == "windows",
+			Args: []string{workloadName, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db", flags.WaitFlagName, flags.YesFlagName, flags.WaitTimeoutFlagName, "1ns"},
 			GivenObjects: []client.Object{
 				parent.
 					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-						d.Source(&cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: "https://github.com/spring-projects/spring-petclinic.git",
-								Ref: cartov1alpha1.GitRef{
-									Branch: gitBranch,
-								},
+						d.Image("ubuntu:bionic")
+					}).StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
+					d.Conditions(metav1.Condition{
+						Type:   cartov1alpha1.WorkloadConditionReady,
+						Status: metav1.ConditionTrue,
+						LastTransitionTime: metav1.Time{
+							Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+						},
+					}, metav1.Condition{
+						Type:   "my-other-type",
+						Status: metav1.ConditionTrue,
+					})
+				}),
+			},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				workload := &cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cartov1alpha1.WorkloadConditionReady,
+								Status: metav1.ConditionTrue,
 							},
-						})
-					}),
+						},
+					},
+				}
+				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
+					{Type: watch.Modified, Object: workload},
+				})
+				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
+				return ctx, nil
 			},
 			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
@@ -847,40 +5971,93 @@ Error: namespace "foo" not found, it may not exist or user does not have permiss
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: "https://github.com/spring-projects/spring-petclinic.git",
-								Ref: cartov1alpha1.GitRef{
-									Branch: gitBranch,
+						Image: "ubuntu:bionic",
+						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
+							{
+								Name: "database",
+								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
+									APIVersion: "services.tanzu.vmware.com/v1alpha1",
+									Kind:       "PostgreSQL",
+									Name:       "my-prod-db",
 								},
 							},
-							Subpath: "./app",
+						},
+					},
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   "Ready",
+								Status: metav1.ConditionTrue,
+								LastTransitionTime: metav1.Time{
+									Time: time.Date(2019, 6, 29, 01, 44, 05, 01, time.UTC),
+								},
+							},
+							{
+								Type:   "my-other-type",
+								Status: metav1.ConditionTrue,
+							},
 						},
 					},
 				},
 			},
+			ShouldError: true,
 			ExpectOutput: `
-❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
-
 🔎 Update workload:
 ...
- 11, 11   |    git:
- 12, 12   |      ref:
- 13, 13   |        branch: main
- 14, 14   |      url: https://github.com/spring-projects/spring-petclinic.git
-     15 + |    subPath: ./app
+  7,  7   |  name: my-workload
+  8,  8   |  namespace: default
+  9,  9   |spec:
+ 10, 10   |  image: ubuntu:bionic
+     11 + |  serviceClaims:
+     12 + |  - name: database
+     13 + |    ref:
+     14 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
+     15 + |      kind: PostgreSQL
+     16 + |      name: my-prod-db
 👍 Updated workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
 To get status: "tanzu apps workload get my-workload"
 
+Waiting for workload "my-workload" to become ready...
+Error waiting for status change: timeout after 1ns waiting for "my-workload" to become ready
 `,
 		},
 		{
-			Name:         "Create git source with subPath from file",
-			Args:         []string{workloadName, flags.FilePathFlagName, "./testdata/workload-subPath.yaml", flags.YesFlagName},
-			GivenObjects: givenNamespaceDefault,
-			ExpectCreates: []client.Object{
+			Name: "update - wait timeout when there is no ready cond",
+			Skip: runtm.GOOS == "windows",
+			Args: []string{workloadName, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db", flags.WaitFlagName, flags.YesFlagName, flags.WaitTimeoutFlagName, "1ns"},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}).StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
+					d.Conditions(metav1.Condition{
+						Type:   "my-type",
+						Status: metav1.ConditionTrue,
+					}, metav1.Condition{
+						Type:   "my-other-type",
+						Status: metav1.ConditionTrue,
+					})
+				}),
+			},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				workload := &cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+				}
+				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
+					{Type: watch.Modified, Object: workload},
+				})
+				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
+				return ctx, nil
+			},
+			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
@@ -890,53 +6067,76 @@ To get status: "tanzu apps workload get my-workload"
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: "https://github.com/spring-projects/spring-petclinic.git",
-								Ref: cartov1alpha1.GitRef{
-									Branch: gitBranch,
+						Image: "ubuntu:bionic",
+						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
+							{
+								Name: "database",
+								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
+									APIVersion: "services.tanzu.vmware.com/v1alpha1",
+									Kind:       "PostgreSQL",
+									Name:       "my-prod-db",
 								},
 							},
-							Subpath: "./app",
+						},
+					},
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   "my-type",
+								Status: metav1.ConditionTrue,
+							},
+							{
+								Type:   "my-other-type",
+								Status: metav1.ConditionTrue,
+							},
 						},
 					},
 				},
 			},
+			ShouldError: true,
 			ExpectOutput: `
-❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
-
-🔎 Create workload:
-      1 + |---
-      2 + |apiVersion: carto.run/v1alpha1
-      3 + |kind: Workload
-      4 + |metadata:
-      5 + |  labels:
-      6 + |    apps.tanzu.vmware.com/workload-type: web
-      7 + |  name: my-workload
-      8 + |  namespace: default
-      9 + |spec:
-     10 + |  source:
-     11 + |    git:
-     12 + |      ref:
-     13 + |        branch: main
-     14 + |      url: https://github.com/spring-projects/spring-petclinic.git
-     15 + |    subPath: ./app
-👍 Created workload "my-workload"
+🔎 Update workload:
+...
+  7,  7   |  name: my-workload
+  8,  8   |  namespace: default
+  9,  9   |spec:
+ 10, 10   |  image: ubuntu:bionic
+     11 + |  serviceClaims:
+     12 + |  - name: database
+     13 + |    ref:
+     14 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
+     15 + |      kind: PostgreSQL
+     16 + |      name: my-prod-db
+👍 Updated workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
 To get status: "tanzu apps workload get my-workload"
 
+Waiting for workload "my-workload" to become ready...
+Error waiting for status change: timeout after 1ns waiting for "my-workload" to become ready
 `,
 		},
 		{
-			Name:        "subPath with no source",
-			Args:        []string{workloadName, flags.SubPathFlagName, "./app", flags.YesFlagName},
-			ShouldError: true,
-		},
-		{
-			Name: "wait with timeout error",
+			Name: "update - wait for timestamp change error with timeout",
 			Skip: runtm.GOOS == "windows",
-			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName, flags.WaitFlagName, flags.WaitTimeoutFlagName, "1ns"},
+			Args: []string{workloadName, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db", flags.WaitFlagName, flags.YesFlagName, flags.WaitTimeoutFlagName, "1ns"},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}).StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
+					d.Conditions(metav1.Condition{
+						Type:   cartov1alpha1.WorkloadConditionReady,
+						Status: metav1.ConditionTrue,
+						LastTransitionTime: metav1.Time{
+							Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+						},
+					}, metav1.Condition{
+						Type:   "my-other-type",
+						Status: metav1.ConditionTrue,
+					})
+				}),
+			},
 			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
 				workload := &cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
@@ -951,18 +6151,21 @@ To get status: "tanzu apps workload get my-workload"
 							{
 								Type:   cartov1alpha1.WorkloadConditionReady,
 								Status: metav1.ConditionTrue,
+								LastTransitionTime: metav1.Time{
+									Time: time.Date(2019, 6, 29, 01, 44, 05, 01, time.UTC),
+								},
 							},
 						},
 					},
 				}
+
 				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
 					{Type: watch.Modified, Object: workload},
 				})
 				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
 				return ctx, nil
 			},
-			GivenObjects: givenNamespaceDefault,
-			ExpectCreates: []client.Object{
+			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
@@ -972,46 +6175,78 @@ To get status: "tanzu apps workload get my-workload"
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: gitRepo,
-								Ref: cartov1alpha1.GitRef{
-									Branch: gitBranch,
+						Image: "ubuntu:bionic",
+						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
+							{
+								Name: "database",
+								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
+									APIVersion: "services.tanzu.vmware.com/v1alpha1",
+									Kind:       "PostgreSQL",
+									Name:       "my-prod-db",
+								},
+							},
+						},
+					},
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   "Ready",
+								Status: metav1.ConditionTrue,
+								LastTransitionTime: metav1.Time{
+									Time: time.Date(2019, 6, 29, 01, 44, 05, 01, time.UTC),
 								},
 							},
+							{
+								Type:   "my-other-type",
+								Status: metav1.ConditionTrue,
+							},
 						},
 					},
 				},
 			},
 			ShouldError: true,
 			ExpectOutput: `
-🔎 Create workload:
-      1 + |---
-      2 + |apiVersion: carto.run/v1alpha1
-      3 + |kind: Workload
-      4 + |metadata:
-      5 + |  labels:
-      6 + |    apps.tanzu.vmware.com/workload-type: web
-      7 + |  name: my-workload
-      8 + |  namespace: default
-      9 + |spec:
-     10 + |  source:
-     11 + |    git:
-     12 + |      ref:
-     13 + |        branch: main
-     14 + |      url: https://example.com/repo.git
-👍 Created workload "my-workload"
+🔎 Update workload:
+...
+  7,  7   |  name: my-workload
+  8,  8   |  namespace: default
+  9,  9   |spec:
+ 10, 10   |  image: ubuntu:bionic
+     11 + |  serviceClaims:
+     12 + |  - name: database
+     13 + |    ref:
+     14 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
+     15 + |      kind: PostgreSQL
+     16 + |      name: my-prod-db
+👍 Updated workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
 To get status: "tanzu apps workload get my-workload"
 
 Waiting for workload "my-workload" to become ready...
-Error waiting for ready condition: timeout after 1ns waiting for "my-workload" to become ready
+Error waiting for status change: timeout after 1ns waiting for "my-workload" to become ready
 `,
 		},
 		{
-			Name: "create - successful wait for ready cond",
-			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName, flags.WaitFlagName},
+			Name: "update - wait error for false condition",
+			Args: []string{workloadName, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db", flags.WaitFlagName, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}).StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
+					d.Conditions(metav1.Condition{
+						Type:   cartov1alpha1.WorkloadConditionReady,
+						Status: metav1.ConditionTrue,
+						LastTransitionTime: metav1.Time{
+							Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+						},
+					}, metav1.Condition{
+						Type:   "my-other-type",
+						Status: metav1.ConditionTrue,
+					})
+				}),
+			},
 			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
 				workload := &cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
@@ -1024,8 +6259,13 @@ Error waiting for ready condition: timeout after 1ns waiting for "my-workload" t
 					Status: cartov1alpha1.WorkloadStatus{
 						Conditions: []metav1.Condition{
 							{
-								Type:   cartov1alpha1.WorkloadConditionReady,
-								Status: metav1.ConditionTrue,
+								Type:    cartov1alpha1.WorkloadConditionReady,
+								Status:  metav1.ConditionFalse,
+								Reason:  "OopsieDoodle",
+								Message: "a hopefully informative message about what went wrong",
+								LastTransitionTime: metav1.Time{
+									Time: time.Date(2019, 6, 29, 01, 44, 06, 0, time.UTC),
+								},
 							},
 						},
 					},
@@ -1036,8 +6276,7 @@ Error waiting for ready condition: timeout after 1ns waiting for "my-workload" t
 				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
 				return ctx, nil
 			},
-			GivenObjects: givenNamespaceDefault,
-			ExpectCreates: []client.Object{
+			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
@@ -1047,46 +6286,78 @@ Error waiting for ready condition: timeout after 1ns waiting for "my-workload" t
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: gitRepo,
-								Ref: cartov1alpha1.GitRef{
-									Branch: gitBranch,
+						Image: "ubuntu:bionic",
+						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
+							{
+								Name: "database",
+								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
+									APIVersion: "services.tanzu.vmware.com/v1alpha1",
+									Kind:       "PostgreSQL",
+									Name:       "my-prod-db",
+								},
+							},
+						},
+					},
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   "Ready",
+								Status: metav1.ConditionTrue,
+								LastTransitionTime: metav1.Time{
+									Time: time.Date(2019, 6, 29, 01, 44, 06, 0, time.UTC),
 								},
 							},
+							{
+								Type:   "my-other-type",
+								Status: metav1.ConditionTrue,
+							},
 						},
 					},
 				},
 			},
+			ShouldError: true,
 			ExpectOutput: `
-🔎 Create workload:
-      1 + |---
-      2 + |apiVersion: carto.run/v1alpha1
-      3 + |kind: Workload
-      4 + |metadata:
-      5 + |  labels:
-      6 + |    apps.tanzu.vmware.com/workload-type: web
-      7 + |  name: my-workload
-      8 + |  namespace: default
-      9 + |spec:
-     10 + |  source:
-     11 + |    git:
-     12 + |      ref:
-     13 + |        branch: main
-     14 + |      url: https://example.com/repo.git
-👍 Created workload "my-workload"
+🔎 Update workload:
+...
+  7,  7   |  name: my-workload
+  8,  8   |  namespace: default
+  9,  9   |spec:
+ 10, 10   |  image: ubuntu:bionic
+     11 + |  serviceClaims:
+     12 + |  - name: database
+     13 + |    ref:
+     14 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
+     15 + |      kind: PostgreSQL
+     16 + |      name: my-prod-db
+👍 Updated workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
 To get status: "tanzu apps workload get my-workload"
 
 Waiting for workload "my-workload" to become ready...
-Workload "my-workload" is ready
-
+Error waiting for ready condition: Failed to become ready: a hopefully informative message about what went wrong
 `,
 		},
 		{
-			Name: "create - tail while waiting for ready cond",
-			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName, flags.TailFlagName},
+			Name: "update - wait error for false condition with rollback on error keeps existing workload",
+			Args: []string{workloadName, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db", flags.WaitFlagName, flags.YesFlagName, flags.RollbackOnErrorFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}).StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
+					d.Conditions(metav1.Condition{
+						Type:   cartov1alpha1.WorkloadConditionReady,
+						Status: metav1.ConditionTrue,
+						LastTransitionTime: metav1.Time{
+							Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+						},
+					}, metav1.Condition{
+						Type:   "my-other-type",
+						Status: metav1.ConditionTrue,
+					})
+				}),
+			},
 			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
 				workload := &cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
@@ -1099,8 +6370,13 @@ Workload "my-workload" is ready
 					Status: cartov1alpha1.WorkloadStatus{
 						Conditions: []metav1.Condition{
 							{
-								Type:   cartov1alpha1.WorkloadConditionReady,
-								Status: metav1.ConditionTrue,
+								Type:    cartov1alpha1.WorkloadConditionReady,
+								Status:  metav1.ConditionFalse,
+								Reason:  "OopsieDoodle",
+								Message: "a hopefully informative message about what went wrong",
+								LastTransitionTime: metav1.Time{
+									Time: time.Date(2019, 6, 29, 01, 44, 06, 0, time.UTC),
+								},
 							},
 						},
 					},
@@ -1109,21 +6385,9 @@ Workload "my-workload" is ready
 					{Type: watch.Modified, Object: workload},
 				})
 				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
-
-				tailer := &logs.FakeTailer{}
-				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Minute, false).Return(nil).Once()
-				ctx = logs.StashTailer(ctx, tailer)
-
 				return ctx, nil
 			},
-			GivenObjects: givenNamespaceDefault,
-			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
-				tailer := logs.RetrieveTailer(ctx).(*logs.FakeTailer)
-				tailer.AssertExpectations(t)
-				return nil
-			},
-			ExpectCreates: []client.Object{
+			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
@@ -1133,53 +6397,80 @@ Workload "my-workload" is ready
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: gitRepo,
-								Ref: cartov1alpha1.GitRef{
-									Branch: gitBranch,
+						Image: "ubuntu:bionic",
+						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
+							{
+								Name: "database",
+								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
+									APIVersion: "services.tanzu.vmware.com/v1alpha1",
+									Kind:       "PostgreSQL",
+									Name:       "my-prod-db",
+								},
+							},
+						},
+					},
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   "Ready",
+								Status: metav1.ConditionTrue,
+								LastTransitionTime: metav1.Time{
+									Time: time.Date(2019, 6, 29, 01, 44, 06, 0, time.UTC),
 								},
 							},
+							{
+								Type:   "my-other-type",
+								Status: metav1.ConditionTrue,
+							},
 						},
 					},
 				},
 			},
+			ShouldError: true,
 			ExpectOutput: `
-🔎 Create workload:
-      1 + |---
-      2 + |apiVersion: carto.run/v1alpha1
-      3 + |kind: Workload
-      4 + |metadata:
-      5 + |  labels:
-      6 + |    apps.tanzu.vmware.com/workload-type: web
-      7 + |  name: my-workload
-      8 + |  namespace: default
-      9 + |spec:
-     10 + |  source:
-     11 + |    git:
-     12 + |      ref:
-     13 + |        branch: main
-     14 + |      url: https://example.com/repo.git
-👍 Created workload "my-workload"
+🔎 Update workload:
+...
+  7,  7   |  name: my-workload
+  8,  8   |  namespace: default
+  9,  9   |spec:
+ 10, 10   |  image: ubuntu:bionic
+     11 + |  serviceClaims:
+     12 + |  - name: database
+     13 + |    ref:
+     14 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
+     15 + |      kind: PostgreSQL
+     16 + |      name: my-prod-db
+👍 Updated workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
 To get status: "tanzu apps workload get my-workload"
 
 Waiting for workload "my-workload" to become ready...
-...tail output...
-Workload "my-workload" is ready
-
+Error waiting for ready condition: Failed to become ready: a hopefully informative message about what went wrong
 `,
 		},
 		{
-			Name: "error during create",
-			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName},
-			WithReactors: []clitesting.ReactionFunc{
-				clitesting.InduceFailure("create", "Workload"),
+			Name: "update - successful wait for ready condition",
+			Args: []string{workloadName, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db", flags.WaitFlagName, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}).StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
+					d.Conditions(metav1.Condition{
+						Type:   cartov1alpha1.WorkloadConditionReady,
+						Status: metav1.ConditionTrue,
+						LastTransitionTime: metav1.Time{
+							Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+						},
+					}, metav1.Condition{
+						Type:   "my-other-type",
+						Status: metav1.ConditionTrue,
+					})
+				}),
 			},
-			GivenObjects: givenNamespaceDefault,
-			ExpectCreates: []client.Object{
-				&cartov1alpha1.Workload{
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				workload := &cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
 						Name:      workloadName,
@@ -1187,30 +6478,25 @@ Workload "my-workload" is ready
 							apis.WorkloadTypeLabelName: "web",
 						},
 					},
-					Spec: cartov1alpha1.WorkloadSpec{
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: gitRepo,
-								Ref: cartov1alpha1.GitRef{
-									Branch: gitBranch,
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cartov1alpha1.WorkloadConditionReady,
+								Status: metav1.ConditionTrue,
+								LastTransitionTime: metav1.Time{
+									Time: time.Date(2019, 6, 29, 01, 44, 06, 0, time.UTC),
 								},
 							},
 						},
 					},
-				},
-			},
-			ShouldError: true,
-		},
-		{
-			Name: "create - watcher error",
-			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName, flags.WaitFlagName},
-			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
-				fakewatch := watchfakes.NewFakeWithWatch(true, config.Client, []watch.Event{})
-				ctx = watchhelper.WithWatcher(ctx, fakewatch)
+				}
+				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
+					{Type: watch.Modified, Object: workload},
+				})
+				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
 				return ctx, nil
 			},
-			GivenObjects: givenNamespaceDefault,
-			ExpectCreates: []client.Object{
+			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
@@ -1220,22 +6506,61 @@ Workload "my-workload" is ready
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: gitRepo,
-								Ref: cartov1alpha1.GitRef{
-									Branch: gitBranch,
+						Image: "ubuntu:bionic",
+						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
+							{
+								Name: "database",
+								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
+									APIVersion: "services.tanzu.vmware.com/v1alpha1",
+									Kind:       "PostgreSQL",
+									Name:       "my-prod-db",
+								},
+							},
+						},
+					},
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   "Ready",
+								Status: metav1.ConditionTrue,
+								LastTransitionTime: metav1.Time{
+									Time: time.Date(2019, 6, 29, 01, 44, 06, 0, time.UTC),
 								},
 							},
+							{
+								Type:   "my-other-type",
+								Status: metav1.ConditionTrue,
+							},
 						},
 					},
 				},
 			},
-			ShouldError: true,
+			ExpectOutput: `
+🔎 Update workload:
+...
+  7,  7   |  name: my-workload
+  8,  8   |  namespace: default
+  9,  9   |spec:
+ 10, 10   |  image: ubuntu:bionic
+     11 + |  serviceClaims:
+     12 + |  - name: database
+     13 + |    ref:
+     14 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
+     15 + |      kind: PostgreSQL
+     16 + |      name: my-prod-db
+👍 Updated workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+Waiting for workload "my-workload" to become ready...
+Workload "my-workload" is ready
+
+`,
 		},
 		{
-			Name: "create - wait error for false condition",
-			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.LabelFlagName, "apps.tanzu.vmware.com/workload-type=web", flags.LabelFlagName, "apps.tanzu.vmware.com/workload-type-", flags.YesFlagName, flags.WaitFlagName},
+			Name: "update - tail with timestamp while waiting for ready condition",
+			Args: []string{workloadName, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db", flags.YesFlagName, flags.TailTimestampFlagName},
 			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
 				workload := &cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
@@ -1248,10 +6573,11 @@ Workload "my-workload" is ready
 					Status: cartov1alpha1.WorkloadStatus{
 						Conditions: []metav1.Condition{
 							{
-								Type:    cartov1alpha1.WorkloadConditionReady,
-								Status:  metav1.ConditionFalse,
-								Reason:  "OopsieDoodle",
-								Message: "a hopefully informative message about what went wrong",
+								Type:   cartov1alpha1.WorkloadConditionReady,
+								Status: metav1.ConditionTrue,
+								LastTransitionTime: metav1.Time{
+									Time: time.Date(2019, 6, 29, 01, 44, 06, 0, time.UTC),
+								},
 							},
 						},
 					},
@@ -1260,10 +6586,37 @@ Workload "my-workload" is ready
 					{Type: watch.Modified, Object: workload},
 				})
 				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
+
+				tailer := &logs.FakeTailer{}
+				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
+				tailer.On("Tail", mock.Anything, "default", selector, logs.TailOptions{Containers: []string{}, Since: time.Minute, TailLines: -1, Timestamps: true, Follow: true}).Return(nil).Once()
+				ctx = logs.StashTailer(ctx, tailer)
+
 				return ctx, nil
 			},
-			GivenObjects: givenNamespaceDefault,
-			ExpectCreates: []client.Object{
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				tailer := logs.RetrieveTailer(ctx).(*logs.FakeTailer)
+				tailer.AssertExpectations(t)
+				return nil
+			},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}).StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
+					d.Conditions(metav1.Condition{
+						Type:   cartov1alpha1.WorkloadConditionReady,
+						Status: metav1.ConditionTrue,
+						LastTransitionTime: metav1.Time{
+							Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+						},
+					}, metav1.Condition{
+						Type:   "my-other-type",
+						Status: metav1.ConditionTrue,
+					})
+				}),
+			},
+			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
@@ -1273,54 +6626,86 @@ Workload "my-workload" is ready
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: gitRepo,
-								Ref: cartov1alpha1.GitRef{
-									Branch: gitBranch,
+						Image: "ubuntu:bionic",
+						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
+							{
+								Name: "database",
+								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
+									APIVersion: "services.tanzu.vmware.com/v1alpha1",
+									Kind:       "PostgreSQL",
+									Name:       "my-prod-db",
+								},
+							},
+						},
+					},
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   "Ready",
+								Status: metav1.ConditionTrue,
+								LastTransitionTime: metav1.Time{
+									Time: time.Date(2019, 6, 29, 01, 44, 06, 0, time.UTC),
 								},
 							},
+							{
+								Type:   "my-other-type",
+								Status: metav1.ConditionTrue,
+							},
 						},
 					},
 				},
 			},
-			ShouldError: true,
 			ExpectOutput: `
-🔎 Create workload:
-      1 + |---
-      2 + |apiVersion: carto.run/v1alpha1
-      3 + |kind: Workload
-      4 + |metadata:
-      5 + |  labels:
-      6 + |    apps.tanzu.vmware.com/workload-type: web
-      7 + |  name: my-workload
-      8 + |  namespace: default
-      9 + |spec:
-     10 + |  source:
-     11 + |    git:
-     12 + |      ref:
-     13 + |        branch: main
-     14 + |      url: https://example.com/repo.git
-👍 Created workload "my-workload"
+🔎 Update workload:
+...
+  7,  7   |  name: my-workload
+  8,  8   |  namespace: default
+  9,  9   |spec:
+ 10, 10   |  image: ubuntu:bionic
+     11 + |  serviceClaims:
+     12 + |  - name: database
+     13 + |    ref:
+     14 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
+     15 + |      kind: PostgreSQL
+     16 + |      name: my-prod-db
+👍 Updated workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
 To get status: "tanzu apps workload get my-workload"
 
 Waiting for workload "my-workload" to become ready...
-Error waiting for ready condition: Failed to become ready: a hopefully informative message about what went wrong
+...tail output...
+Workload "my-workload" is ready
+
 `,
 		},
 		{
-			Name:         "filepath from url",
-			Args:         []string{flags.FilePathFlagName, fileFromUrl, flags.YesFlagName},
-			GivenObjects: givenNamespaceDefault,
-			ExpectCreates: []client.Object{
+			Name: "update - filepath",
+			Args: []string{flags.FilePathFlagName, file, flags.SubPathFlagName, "./cmd", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("spring-petclinic")
+						d.AddLabel("preserve-me", "should-exist")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Env(
+							corev1.EnvVar{
+								Name:  "OVERRIDE_VAR",
+								Value: "doesnt matter",
+							},
+						)
+					}),
+			},
+			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
 						Name:      "spring-petclinic",
 						Labels: map[string]string{
-							apis.AppPartOfLabelName:               "spring-petclinic",
+							"preserve-me":                         "should-exist",
+							"app.kubernetes.io/part-of":           "spring-petclinic",
 							"apps.tanzu.vmware.com/workload-type": "web",
 						},
 					},
@@ -1332,8 +6717,13 @@ Error waiting for ready condition: Failed to become ready: a hopefully informati
 									Branch: "main",
 								},
 							},
+							Subpath: "./cmd",
 						},
 						Env: []corev1.EnvVar{
+							{
+								Name:  "OVERRIDE_VAR",
+								Value: "doesnt matter",
+							},
 							{
 								Name:  "SPRING_PROFILES_ACTIVE",
 								Value: "mysql",
@@ -1355,33 +6745,38 @@ Error waiting for ready condition: Failed to become ready: a hopefully informati
 			ExpectOutput: `
 ❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
 
-🔎 Create workload:
-      1 + |---
-      2 + |apiVersion: carto.run/v1alpha1
-      3 + |kind: Workload
-      4 + |metadata:
-      5 + |  labels:
+🔎 Update workload:
+...
+  2,  2   |apiVersion: carto.run/v1alpha1
+  3,  3   |kind: Workload
+  4,  4   |metadata:
+  5,  5   |  labels:
       6 + |    app.kubernetes.io/part-of: spring-petclinic
-      7 + |    apps.tanzu.vmware.com/workload-type: web
-      8 + |  name: spring-petclinic
-      9 + |  namespace: default
-     10 + |spec:
-     11 + |  env:
-     12 + |  - name: SPRING_PROFILES_ACTIVE
-     13 + |    value: mysql
-     14 + |  resources:
-     15 + |    limits:
-     16 + |      cpu: 500m
-     17 + |      memory: 1Gi
-     18 + |    requests:
-     19 + |      cpu: 100m
+  6,  7   |    apps.tanzu.vmware.com/workload-type: web
+  7,  8   |    preserve-me: should-exist
+  8,  9   |  name: spring-petclinic
+  9, 10   |  namespace: default
+ 10, 11   |spec:
+ 11, 12   |  env:
+ 12, 13   |  - name: OVERRIDE_VAR
+ 13, 14   |    value: doesnt matter
+ 14     - |  image: ubuntu:bionic
+     15 + |  - name: SPRING_PROFILES_ACTIVE
+     16 + |    value: mysql
+     17 + |  resources:
+     18 + |    limits:
+     19 + |      cpu: 500m
      20 + |      memory: 1Gi
-     21 + |  source:
-     22 + |    git:
-     23 + |      ref:
-     24 + |        branch: main
-     25 + |      url: https://github.com/spring-projects/spring-petclinic.git
-👍 Created workload "spring-petclinic"
+     21 + |    requests:
+     22 + |      cpu: 100m
+     23 + |      memory: 1Gi
+     24 + |  source:
+     25 + |    git:
+     26 + |      ref:
+     27 + |        branch: main
+     28 + |      url: https://github.com/spring-projects/spring-petclinic.git
+     29 + |    subPath: ./cmd
+👍 Updated workload "spring-petclinic"
 
 To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
 To get status: "tanzu apps workload get spring-petclinic"
@@ -1389,26 +6784,32 @@ To get status: "tanzu apps workload get spring-petclinic"
 `,
 		},
 		{
-			Name:        "invalid url",
-			Args:        []string{flags.FilePathFlagName, "https://github.com/vmware-tanzu/apps-cli-plugin/blob/main/pkg/commands/testdata/workload.yaml", flags.YesFlagName},
-			ShouldError: true,
-		},
-		{
-			Name:        "not http/https url",
-			Args:        []string{flags.FilePathFlagName, "ftp://raw.githubusercontent.com/vmware-tanzu/apps-cli-plugin/main/pkg/commands/testdata/workload.yaml", flags.YesFlagName},
-			ShouldError: true,
-		},
-		{
-			Name:         "filepath",
-			Args:         []string{flags.FilePathFlagName, file, flags.YesFlagName},
-			GivenObjects: givenNamespaceDefault,
-			ExpectCreates: []client.Object{
+			Name: "update - filepath from url",
+			Args: []string{flags.FilePathFlagName, fileFromUrl, flags.SubPathFlagName, "./cmd", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("spring-petclinic")
+						d.AddLabel("preserve-me", "should-exist")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Env(
+							corev1.EnvVar{
+								Name:  "OVERRIDE_VAR",
+								Value: "doesnt matter",
+							},
+						)
+					}),
+			},
+			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
 						Name:      "spring-petclinic",
 						Labels: map[string]string{
-							apis.AppPartOfLabelName:               "spring-petclinic",
+							"preserve-me":                         "should-exist",
+							"app.kubernetes.io/part-of":           "spring-petclinic",
 							"apps.tanzu.vmware.com/workload-type": "web",
 						},
 					},
@@ -1420,8 +6821,13 @@ To get status: "tanzu apps workload get spring-petclinic"
 									Branch: "main",
 								},
 							},
+							Subpath: "./cmd",
 						},
 						Env: []corev1.EnvVar{
+							{
+								Name:  "OVERRIDE_VAR",
+								Value: "doesnt matter",
+							},
 							{
 								Name:  "SPRING_PROFILES_ACTIVE",
 								Value: "mysql",
@@ -1443,33 +6849,38 @@ To get status: "tanzu apps workload get spring-petclinic"
 			ExpectOutput: `
 ❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
 
-🔎 Create workload:
-      1 + |---
-      2 + |apiVersion: carto.run/v1alpha1
-      3 + |kind: Workload
-      4 + |metadata:
-      5 + |  labels:
+🔎 Update workload:
+...
+  2,  2   |apiVersion: carto.run/v1alpha1
+  3,  3   |kind: Workload
+  4,  4   |metadata:
+  5,  5   |  labels:
       6 + |    app.kubernetes.io/part-of: spring-petclinic
-      7 + |    apps.tanzu.vmware.com/workload-type: web
-      8 + |  name: spring-petclinic
-      9 + |  namespace: default
-     10 + |spec:
-     11 + |  env:
-     12 + |  - name: SPRING_PROFILES_ACTIVE
-     13 + |    value: mysql
-     14 + |  resources:
-     15 + |    limits:
-     16 + |      cpu: 500m
-     17 + |      memory: 1Gi
-     18 + |    requests:
-     19 + |      cpu: 100m
+  6,  7   |    apps.tanzu.vmware.com/workload-type: web
+  7,  8   |    preserve-me: should-exist
+  8,  9   |  name: spring-petclinic
+  9, 10   |  namespace: default
+ 10, 11   |spec:
+ 11, 12   |  env:
+ 12, 13   |  - name: OVERRIDE_VAR
+ 13, 14   |    value: doesnt matter
+ 14     - |  image: ubuntu:bionic
+     15 + |  - name: SPRING_PROFILES_ACTIVE
+     16 + |    value: mysql
+     17 + |  resources:
+     18 + |    limits:
+     19 + |      cpu: 500m
      20 + |      memory: 1Gi
-     21 + |  source:
-     22 + |    git:
-     23 + |      ref:
-     24 + |        branch: main
-     25 + |      url: https://github.com/spring-projects/spring-petclinic.git
-👍 Created workload "spring-petclinic"
+     21 + |    requests:
+     22 + |      cpu: 100m
+     23 + |      memory: 1Gi
+     24 + |  source:
+     25 + |    git:
+     26 + |      ref:
+     27 + |        branch: main
+     28 + |      url: https://github.com/spring-projects/spring-petclinic.git
+     29 + |    subPath: ./cmd
+👍 Updated workload "spring-petclinic"
 
 To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
 To get status: "tanzu apps workload get spring-petclinic"
@@ -1477,41 +6888,32 @@ To get status: "tanzu apps workload get spring-petclinic"
 `,
 		},
 		{
-			Name: "create - accept yaml file through stdin - using --yes flag",
-			Args: []string{flags.FilePathFlagName, "-", flags.YesFlagName},
-			Stdin: []byte(`
-apiVersion: carto.run/v1alpha1
-kind: Workload
-metadata:
-  name: spring-petclinic
-  labels:
-    app.kubernetes.io/part-of: spring-petclinic
-    apps.tanzu.vmware.com/workload-type: web
-spec:
-  env:
-  - name: SPRING_PROFILES_ACTIVE
-    value: mysql
-  resources:
-    requests:
-      memory: 1Gi
-      cpu: 100m
-    limits:
-      memory: 1Gi
-      cpu: 500m
-  source:
-    git:
-      url: https://github.com/spring-projects/spring-petclinic.git
-      ref:
-        branch: main
-`),
-			GivenObjects: givenNamespaceDefault,
-			ExpectCreates: []client.Object{
+			Name: "update - filepath - custom namespace and name",
+			Args: []string{workloadName, flags.NamespaceFlagName, "test-namespace", flags.FilePathFlagName, file, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Namespace("test-namespace")
+						d.AddLabel("preserve-me", "should-exist")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Env(
+							corev1.EnvVar{
+								Name:  "OVERRIDE_VAR",
+								Value: "doesnt matter",
+							},
+						)
+					}),
+			},
+			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
-						Namespace: defaultNamespace,
-						Name:      "spring-petclinic",
+						Namespace: "test-namespace",
+						Name:      workloadName,
 						Labels: map[string]string{
-							apis.AppPartOfLabelName:               "spring-petclinic",
+							"preserve-me":                         "should-exist",
+							"app.kubernetes.io/part-of":           "spring-petclinic",
 							"apps.tanzu.vmware.com/workload-type": "web",
 						},
 					},
@@ -1525,6 +6927,10 @@ spec:
 							},
 						},
 						Env: []corev1.EnvVar{
+							{
+								Name:  "OVERRIDE_VAR",
+								Value: "doesnt matter",
+							},
 							{
 								Name:  "SPRING_PROFILES_ACTIVE",
 								Value: "mysql",
@@ -1544,256 +6950,407 @@ spec:
 				},
 			},
 			ExpectOutput: `
-❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
-
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
+🔎 Update workload:
+...
+  2,  2   |apiVersion: carto.run/v1alpha1
+  3,  3   |kind: Workload
+  4,  4   |metadata:
+  5,  5   |  labels:
+      6 + |    app.kubernetes.io/part-of: spring-petclinic
+  6,  7   |    apps.tanzu.vmware.com/workload-type: web
+  7,  8   |    preserve-me: should-exist
+  8,  9   |  name: my-workload
+  9, 10   |  namespace: test-namespace
+ 10, 11   |spec:
+ 11, 12   |  env:
+ 12, 13   |  - name: OVERRIDE_VAR
+ 13, 14   |    value: doesnt matter
+ 14     - |  image: ubuntu:bionic
+     15 + |  - name: SPRING_PROFILES_ACTIVE
+     16 + |    value: mysql
+     17 + |  resources:
+     18 + |    limits:
+     19 + |      cpu: 500m
+     20 + |      memory: 1Gi
+     21 + |    requests:
+     22 + |      cpu: 100m
+     23 + |      memory: 1Gi
+     24 + |  source:
+     25 + |    git:
+     26 + |      ref:
+     27 + |        branch: main
+     28 + |      url: https://github.com/spring-projects/spring-petclinic.git
+👍 Updated workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --namespace test-namespace --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload --namespace test-namespace"
+
+`,
+		},
+		{
+			Name:        "filepath invalid name",
+			Args:        []string{flags.FilePathFlagName, "testdata/workload-invalid-name.yaml", flags.YesFlagName},
+			ShouldError: true,
+		},
+		{
+			Name: "update - serviceclaim with deprecation warning",
+			Args: []string{workloadName, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-ns:my-prod-db", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+						Annotations: map[string]string{
+							apis.ServiceClaimAnnotationName: `{"kind":"ServiceClaimsExtension","apiVersion":"supplychain.apps.x-tanzu.vmware.com/v1alpha1","spec":{"serviceClaims":{"database":{"namespace":"my-prod-ns"}}}}`,
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
+							{
+								Name: "database",
+								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
+									APIVersion: "services.tanzu.vmware.com/v1alpha1",
+									Kind:       "PostgreSQL",
+									Name:       "my-prod-db",
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+❗ WARNING: Cross namespace service claims are deprecated. Please use ` + "`tanzu service claim create`" + ` instead.
+🔎 Update workload:
+  1,  1   |---
+  2,  2   |apiVersion: carto.run/v1alpha1
+  3,  3   |kind: Workload
+  4,  4   |metadata:
+      5 + |  annotations:
+      6 + |    serviceclaims.supplychain.apps.x-tanzu.vmware.com/extensions: '{"kind":"ServiceClaimsExtension","apiVersion":"supplychain.apps.x-tanzu.vmware.com/v1alpha1","spec":{"serviceClaims":{"database":{"namespace":"my-prod-ns"}}}}'
+  5,  7   |  labels:
+  6,  8   |    apps.tanzu.vmware.com/workload-type: web
+  7,  9   |  name: my-workload
+  8, 10   |  namespace: default
+  9, 11   |spec:
+ 10, 12   |  image: ubuntu:bionic
+     13 + |  serviceClaims:
+     14 + |  - name: database
+     15 + |    ref:
+     16 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
+     17 + |      kind: PostgreSQL
+     18 + |      name: my-prod-db
+👍 Updated workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name:         "create - serviceclaim with deprecation warning",
+			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-ns:my-prod-db", flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+						Annotations: map[string]string{
+							apis.ServiceClaimAnnotationName: `{"kind":"ServiceClaimsExtension","apiVersion":"supplychain.apps.x-tanzu.vmware.com/v1alpha1","spec":{"serviceClaims":{"database":{"namespace":"my-prod-ns"}}}}`,
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{URL: "https://example.com/repo.git", Ref: cartov1alpha1.GitRef{Branch: "main"}},
+						},
+						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
+							{
+								Name: "database",
+								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
+									APIVersion: "services.tanzu.vmware.com/v1alpha1",
+									Kind:       "PostgreSQL",
+									Name:       "my-prod-db",
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+❗ WARNING: Cross namespace service claims are deprecated. Please use ` + "`tanzu service claim create`" + ` instead.
 🔎 Create workload:
       1 + |---
       2 + |apiVersion: carto.run/v1alpha1
       3 + |kind: Workload
       4 + |metadata:
-      5 + |  labels:
-      6 + |    app.kubernetes.io/part-of: spring-petclinic
-      7 + |    apps.tanzu.vmware.com/workload-type: web
-      8 + |  name: spring-petclinic
-      9 + |  namespace: default
-     10 + |spec:
-     11 + |  env:
-     12 + |  - name: SPRING_PROFILES_ACTIVE
-     13 + |    value: mysql
-     14 + |  resources:
-     15 + |    limits:
-     16 + |      cpu: 500m
-     17 + |      memory: 1Gi
-     18 + |    requests:
-     19 + |      cpu: 100m
-     20 + |      memory: 1Gi
-     21 + |  source:
-     22 + |    git:
-     23 + |      ref:
-     24 + |        branch: main
-     25 + |      url: https://github.com/spring-projects/spring-petclinic.git
-👍 Created workload "spring-petclinic"
+      5 + |  annotations:
+      6 + |    serviceclaims.supplychain.apps.x-tanzu.vmware.com/extensions: '{"kind":"ServiceClaimsExtension","apiVersion":"supplychain.apps.x-tanzu.vmware.com/v1alpha1","spec":{"serviceClaims":{"database":{"namespace":"my-prod-ns"}}}}'
+      7 + |  labels:
+      8 + |    apps.tanzu.vmware.com/workload-type: web
+      9 + |  name: my-workload
+     10 + |  namespace: default
+     11 + |spec:
+     12 + |  serviceClaims:
+     13 + |  - name: database
+     14 + |    ref:
+     15 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
+     16 + |      kind: PostgreSQL
+     17 + |      name: my-prod-db
+     18 + |  source:
+     19 + |    git:
+     20 + |      ref:
+     21 + |        branch: main
+     22 + |      url: https://example.com/repo.git
+👍 Created workload "my-workload"
 
-To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
-To get status: "tanzu apps workload get spring-petclinic"
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
 
 `,
 		},
 		{
-			Name: "update - accept yaml file through stdin - using --yes flag",
-			Args: []string{flags.FilePathFlagName, "-", flags.YesFlagName},
-			Stdin: []byte(`
-apiVersion: carto.run/v1alpha1
-kind: Workload
-metadata:
-  name: spring-petclinic
-  labels:
-    app.kubernetes.io/part-of: spring-petclinic
-    apps.tanzu.vmware.com/workload-type: web
-spec:
-  env:
-  - name: SPRING_PROFILES_ACTIVE
-    value: mysql
-  resources:
-    requests:
-      memory: 1Gi
-      cpu: 100m
-    limits:
-      memory: 1Gi
-      cpu: 500m
-  source:
-    git:
-      url: https://github.com/spring-projects/spring-petclinic.git
-      ref:
-        branch: main
-`),
+			Name: "update - suppressed deprecation warning",
+			Args: []string{workloadName, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-ns:my-prod-db", flags.SuppressWarningsFlagName, "cross-namespace-service-claims", flags.WarningsAsErrorsFlagName, flags.YesFlagName},
 			GivenObjects: []client.Object{
-				diecartov1alpha1.WorkloadBlank.
-					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
-						d.Name("spring-petclinic")
-						d.Namespace(defaultNamespace)
-						d.AddLabel("preserve-me", "should-exist")
-					}).
+				parent.
 					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
 						d.Image("ubuntu:bionic")
-						d.Env(
-							corev1.EnvVar{
-								Name:  "OVERRIDE_VAR",
-								Value: "doesnt matter",
-							},
-						)
 					}),
-				givenNamespaceDefault[0],
 			},
 			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
-						Name:      "spring-petclinic",
+						Name:      workloadName,
 						Labels: map[string]string{
-							"preserve-me":                         "should-exist",
-							"app.kubernetes.io/part-of":           "spring-petclinic",
-							"apps.tanzu.vmware.com/workload-type": "web",
+							apis.WorkloadTypeLabelName: "web",
+						},
+						Annotations: map[string]string{
+							apis.ServiceClaimAnnotationName: `{"kind":"ServiceClaimsExtension","apiVersion":"supplychain.apps.x-tanzu.vmware.com/v1alpha1","spec":{"serviceClaims":{"database":{"namespace":"my-prod-ns"}}}}`,
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: "https://github.com/spring-projects/spring-petclinic.git",
-								Ref: cartov1alpha1.GitRef{
-									Branch: "main",
+						Image: "ubuntu:bionic",
+						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
+							{
+								Name: "database",
+								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
+									APIVersion: "services.tanzu.vmware.com/v1alpha1",
+									Kind:       "PostgreSQL",
+									Name:       "my-prod-db",
 								},
 							},
 						},
-						Env: []corev1.EnvVar{
-							{
-								Name:  "OVERRIDE_VAR",
-								Value: "doesnt matter",
-							},
-							{
-								Name:  "SPRING_PROFILES_ACTIVE",
-								Value: "mysql",
-							},
+					},
+				},
+			},
+			ExpectOutput: `
+🔎 Update workload:
+  1,  1   |---
+  2,  2   |apiVersion: carto.run/v1alpha1
+  3,  3   |kind: Workload
+  4,  4   |metadata:
+      5 + |  annotations:
+      6 + |    serviceclaims.supplychain.apps.x-tanzu.vmware.com/extensions: '{"kind":"ServiceClaimsExtension","apiVersion":"supplychain.apps.x-tanzu.vmware.com/v1alpha1","spec":{"serviceClaims":{"database":{"namespace":"my-prod-ns"}}}}'
+  5,  7   |  labels:
+  6,  8   |    apps.tanzu.vmware.com/workload-type: web
+  7,  9   |  name: my-workload
+  8, 10   |  namespace: default
+  9, 11   |spec:
+ 10, 12   |  image: ubuntu:bionic
+     13 + |  serviceClaims:
+     14 + |  - name: database
+     15 + |    ref:
+     16 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
+     17 + |      kind: PostgreSQL
+     18 + |      name: my-prod-db
+👍 Updated workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name: "update - suppressed deprecation warning from env var",
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				os.Setenv("TANZU_APPS_SUPPRESS_WARNINGS", "unknown-warning,cross-namespace-service-claims")
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				os.Unsetenv("TANZU_APPS_SUPPRESS_WARNINGS")
+				return nil
+			},
+			Args: []string{workloadName, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-ns:my-prod-db", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
 						},
-						Resources: &corev1.ResourceRequirements{
-							Limits: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse("500m"),
-								corev1.ResourceMemory: resource.MustParse("1Gi"),
-							},
-							Requests: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse("100m"),
-								corev1.ResourceMemory: resource.MustParse("1Gi"),
+						Annotations: map[string]string{
+							apis.ServiceClaimAnnotationName: `{"kind":"ServiceClaimsExtension","apiVersion":"supplychain.apps.x-tanzu.vmware.com/v1alpha1","spec":{"serviceClaims":{"database":{"namespace":"my-prod-ns"}}}}`,
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
+							{
+								Name: "database",
+								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
+									APIVersion: "services.tanzu.vmware.com/v1alpha1",
+									Kind:       "PostgreSQL",
+									Name:       "my-prod-db",
+								},
 							},
 						},
 					},
 				},
 			},
 			ExpectOutput: `
-❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
-
 🔎 Update workload:
-...
+  1,  1   |---
   2,  2   |apiVersion: carto.run/v1alpha1
   3,  3   |kind: Workload
   4,  4   |metadata:
-  5,  5   |  labels:
-      6 + |    app.kubernetes.io/part-of: spring-petclinic
-      7 + |    apps.tanzu.vmware.com/workload-type: web
-  6,  8   |    preserve-me: should-exist
-  7,  9   |  name: spring-petclinic
+      5 + |  annotations:
+      6 + |    serviceclaims.supplychain.apps.x-tanzu.vmware.com/extensions: '{"kind":"ServiceClaimsExtension","apiVersion":"supplychain.apps.x-tanzu.vmware.com/v1alpha1","spec":{"serviceClaims":{"database":{"namespace":"my-prod-ns"}}}}'
+  5,  7   |  labels:
+  6,  8   |    apps.tanzu.vmware.com/workload-type: web
+  7,  9   |  name: my-workload
   8, 10   |  namespace: default
   9, 11   |spec:
- 10, 12   |  env:
- 11, 13   |  - name: OVERRIDE_VAR
- 12, 14   |    value: doesnt matter
- 13     - |  image: ubuntu:bionic
-     15 + |  - name: SPRING_PROFILES_ACTIVE
-     16 + |    value: mysql
-     17 + |  resources:
-     18 + |    limits:
-     19 + |      cpu: 500m
-     20 + |      memory: 1Gi
-     21 + |    requests:
-     22 + |      cpu: 100m
-     23 + |      memory: 1Gi
-     24 + |  source:
-     25 + |    git:
-     26 + |      ref:
-     27 + |        branch: main
-     28 + |      url: https://github.com/spring-projects/spring-petclinic.git
-👍 Updated workload "spring-petclinic"
+ 10, 12   |  image: ubuntu:bionic
+     13 + |  serviceClaims:
+     14 + |  - name: database
+     15 + |    ref:
+     16 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
+     17 + |      kind: PostgreSQL
+     18 + |      name: my-prod-db
+👍 Updated workload "my-workload"
 
-To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
-To get status: "tanzu apps workload get spring-petclinic"
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
 
 `,
 		},
 		{
-			Name: "update - accept yaml file through stdin - using --dry-run flag",
-			Args: []string{flags.FilePathFlagName, "-", flags.DryRunFlagName},
+			Name: "update - warnings as errors",
+			Args: []string{workloadName, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-ns:my-prod-db", flags.WarningsAsErrorsFlagName, flags.YesFlagName},
 			GivenObjects: []client.Object{
 				parent.
 					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
 						d.Image("ubuntu:bionic")
 					}),
 			},
-			Stdin: []byte(`
----
-apiVersion: carto.run/v1alpha1
-kind: Workload
-metadata:
-  creationTimestamp: null
-  name: my-workload
-  namespace: default
-  resourceVersion: "999"
-spec:
-  image: ubuntu:bionic
-status:
-  supplyChainRef: {}
-`),
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if !errors.Is(err, cli.SilentError) {
+					t.Errorf("expected error to be silenced, got %v", err)
+				}
+			},
+			ExpectOutput: `
+❗ WARNING: Cross namespace service claims are deprecated. Please use ` + "`tanzu service claim create`" + ` instead.
+🔎 Update workload:
+  1,  1   |---
+  2,  2   |apiVersion: carto.run/v1alpha1
+  3,  3   |kind: Workload
+  4,  4   |metadata:
+      5 + |  annotations:
+      6 + |    serviceclaims.supplychain.apps.x-tanzu.vmware.com/extensions: '{"kind":"ServiceClaimsExtension","apiVersion":"supplychain.apps.x-tanzu.vmware.com/v1alpha1","spec":{"serviceClaims":{"database":{"namespace":"my-prod-ns"}}}}'
+  5,  7   |  labels:
+  6,  8   |    apps.tanzu.vmware.com/workload-type: web
+  7,  9   |  name: my-workload
+  8, 10   |  namespace: default
+  9, 11   |spec:
+ 10, 12   |  image: ubuntu:bionic
+     13 + |  serviceClaims:
+     14 + |  - name: database
+     15 + |    ref:
+     16 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
+     17 + |      kind: PostgreSQL
+     18 + |      name: my-prod-db
+Error: 1 warning(s) treated as errors (--warnings-as-errors), the workload was not applied
+`,
 		},
 		{
-			Name:         "create - accept yaml file through stdin - using --dry-run flag",
-			Args:         []string{flags.FilePathFlagName, "-", flags.DryRunFlagName},
+			Name:         "create - warnings as errors",
+			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-ns:my-prod-db", flags.WarningsAsErrorsFlagName, flags.YesFlagName},
 			GivenObjects: givenNamespaceDefault,
-			Stdin: []byte(`
-apiVersion: carto.run/v1alpha1
-kind: Workload
-metadata:
-  creationTimestamp: null
-  name: my-workload
-  namespace: default
-spec:
-  source:
-    git:
-      ref:
-        branch: main
-      url: https://example.com/repo.git
-status:
-  supplyChainRef: {}
-`),
+			ShouldError:  true,
+			ExpectOutput: `
+❗ WARNING: Cross namespace service claims are deprecated. Please use ` + "`tanzu service claim create`" + ` instead.
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  annotations:
+      6 + |    serviceclaims.supplychain.apps.x-tanzu.vmware.com/extensions: '{"kind":"ServiceClaimsExtension","apiVersion":"supplychain.apps.x-tanzu.vmware.com/v1alpha1","spec":{"serviceClaims":{"database":{"namespace":"my-prod-ns"}}}}'
+      7 + |  labels:
+      8 + |    apps.tanzu.vmware.com/workload-type: web
+      9 + |  name: my-workload
+     10 + |  namespace: default
+     11 + |spec:
+     12 + |  serviceClaims:
+     13 + |  - name: database
+     14 + |    ref:
+     15 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
+     16 + |      kind: PostgreSQL
+     17 + |      name: my-prod-db
+     18 + |  source:
+     19 + |    git:
+     20 + |      ref:
+     21 + |        branch: main
+     22 + |      url: https://example.com/repo.git
+Error: 1 warning(s) treated as errors (--warnings-as-errors), the workload was not applied
+`,
 		},
 		{
-			Name:         "filepath - service account build-env",
-			Args:         []string{flags.FilePathFlagName, "testdata/workload-build-env.yaml", flags.YesFlagName},
+			Name:         "create - warnings as errors from file",
+			Args:         []string{workloadName, flags.FilePathFlagName, "./testdata/workload-subPath.yaml", flags.WarningsAsErrorsFlagName, flags.YesFlagName},
 			GivenObjects: givenNamespaceDefault,
 			ExpectCreates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
-						Name:      "spring-petclinic",
+						Name:      workloadName,
 						Labels: map[string]string{
-							apis.AppPartOfLabelName:               "spring-petclinic",
-							"apps.tanzu.vmware.com/workload-type": "web",
+							apis.WorkloadTypeLabelName: "web",
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Build: &cartov1alpha1.WorkloadBuild{Env: []corev1.EnvVar{
-							{Name: "BP_MAVEN_POM_FILE", Value: "skip-pom.xml"},
-						}},
 						Source: &cartov1alpha1.Source{
 							Git: &cartov1alpha1.GitSource{
 								URL: "https://github.com/spring-projects/spring-petclinic.git",
 								Ref: cartov1alpha1.GitRef{
-									Branch: "main",
+									Branch: gitBranch,
 								},
 							},
-						},
-						Env: []corev1.EnvVar{
-							{
-								Name:  "SPRING_PROFILES_ACTIVE",
-								Value: "mysql",
-							},
-						},
-						Resources: &corev1.ResourceRequirements{
-							Limits: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse("500m"),
-								corev1.ResourceMemory: resource.MustParse("1Gi"),
-							},
-							Requests: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse("100m"),
-								corev1.ResourceMemory: resource.MustParse("1Gi"),
-							},
+							Subpath: "./app",
 						},
 					},
 				},
@@ -1807,342 +7364,338 @@ status:
       3 + |kind: Workload
       4 + |metadata:
       5 + |  labels:
-      6 + |    app.kubernetes.io/part-of: spring-petclinic
-      7 + |    apps.tanzu.vmware.com/workload-type: web
-      8 + |  name: spring-petclinic
-      9 + |  namespace: default
-     10 + |spec:
-     11 + |  build:
-     12 + |    env:
-     13 + |    - name: BP_MAVEN_POM_FILE
-     14 + |      value: skip-pom.xml
-     15 + |  env:
-     16 + |  - name: SPRING_PROFILES_ACTIVE
-     17 + |    value: mysql
-     18 + |  resources:
-     19 + |    limits:
-     20 + |      cpu: 500m
-     21 + |      memory: 1Gi
-     22 + |    requests:
-     23 + |      cpu: 100m
-     24 + |      memory: 1Gi
-     25 + |  source:
-     26 + |    git:
-     27 + |      ref:
-     28 + |        branch: main
-     29 + |      url: https://github.com/spring-projects/spring-petclinic.git
-👍 Created workload "spring-petclinic"
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  source:
+     11 + |    git:
+     12 + |      ref:
+     13 + |        branch: main
+     14 + |      url: https://github.com/spring-projects/spring-petclinic.git
+     15 + |    subPath: ./app
+👍 Created workload "my-workload"
 
-To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
-To get status: "tanzu apps workload get spring-petclinic"
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
 
 `,
 		},
 		{
-			Name:        "fail to accept yaml file through stdin missing --yes flag",
-			Args:        []string{flags.FilePathFlagName, "-"},
-			ShouldError: true,
-		},
-		{
-			Name:        "filepath - missing",
-			Args:        []string{workloadName, flags.FilePathFlagName, "testdata/missing.yaml", flags.YesFlagName},
-			ShouldError: true,
-		},
-		{
-			Name: "noop",
-			Args: []string{workloadName},
-			GivenObjects: []client.Object{
-				parent.
-					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-						d.Image("ubuntu:bionic")
-					}),
-			},
-			ExpectOutput: `
-Workload is unchanged, skipping update
-`,
-		},
-		{
-			Name: "no source resource",
-			Args: []string{workloadName},
-			GivenObjects: []client.Object{
-				parent,
-			},
-		},
-		{
-			Name: "get failed",
-			Args: []string{workloadName},
-			WithReactors: []clitesting.ReactionFunc{
-				clitesting.InduceFailure("get", "Workload"),
-			},
-			ShouldError: true,
-		},
-		{
-			Name: "update - dry run",
-			Args: []string{workloadName, flags.DebugFlagName, flags.DryRunFlagName, flags.YesFlagName},
-			GivenObjects: []client.Object{
-				parent.
-					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-						d.Image("ubuntu:bionic")
-					}),
+			Name:         "create - warnings as errors without warnings",
+			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.WarningsAsErrorsFlagName, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{URL: "https://example.com/repo.git", Ref: cartov1alpha1.GitRef{Branch: "main"}},
+						},
+					},
+				},
 			},
 			ExpectOutput: `
----
-apiVersion: carto.run/v1alpha1
-kind: Workload
-metadata:
-  creationTimestamp: "1970-01-01T00:00:01Z"
-  labels:
-    apps.tanzu.vmware.com/workload-type: web
-  name: my-workload
-  namespace: default
-  resourceVersion: "999"
-spec:
-  image: ubuntu:bionic
-  params:
-  - name: debug
-    value: "true"
-status:
-  supplyChainRef: {}
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  source:
+     11 + |    git:
+     12 + |      ref:
+     13 + |        branch: main
+     14 + |      url: https://example.com/repo.git
+👍 Created workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
 `,
 		},
-		{
-			Name: "error during update",
-			Args: []string{workloadName, flags.DebugFlagName, flags.YesFlagName},
-			WithReactors: []clitesting.ReactionFunc{
-				clitesting.InduceFailure("update", "Workload"),
-			},
-			GivenObjects: []client.Object{
+		{
+			Name: "update serviceAccountName via file",
+			Args: []string{flags.FilePathFlagName, "testdata/service-account-name.yaml", flags.YesFlagName},
+			GivenObjects: append([]client.Object{
 				parent.
-					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-						d.Image("ubuntu:bionic")
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("spring-petclinic")
+						d.AddLabel("preserve-me", "should-exist")
 					}),
-			},
+			}, givenServiceAccounts...),
 			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
-						Name:      workloadName,
+						Name:      "spring-petclinic",
 						Labels: map[string]string{
-							apis.WorkloadTypeLabelName: "web",
+							"preserve-me":                         "should-exist",
+							"app.kubernetes.io/part-of":           "spring-petclinic",
+							"apps.tanzu.vmware.com/workload-type": "web",
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Image: "ubuntu:bionic",
-						Params: []cartov1alpha1.Param{
-							{
-								Name:  "debug",
-								Value: apiextensionsv1.JSON{Raw: []byte(`"true"`)},
+						ServiceAccountName: &serviceAccountName,
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								Ref: cartov1alpha1.GitRef{
+									Tag: "tap-1.1",
+								},
 							},
 						},
 					},
 				},
 			},
-			ShouldError: true,
+			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
+🔎 Update workload:
+...
+  2,  2   |apiVersion: carto.run/v1alpha1
+  3,  3   |kind: Workload
+  4,  4   |metadata:
+  5,  5   |  labels:
+      6 + |    app.kubernetes.io/part-of: spring-petclinic
+  6,  7   |    apps.tanzu.vmware.com/workload-type: web
+  7,  8   |    preserve-me: should-exist
+  8,  9   |  name: spring-petclinic
+  9, 10   |  namespace: default
+ 10     - |spec: {}
+     11 + |spec:
+     12 + |  serviceAccountName: my-service-account
+     13 + |  source:
+     14 + |    git:
+     15 + |      ref:
+     16 + |        tag: tap-1.1
+     17 + |      url: https://github.com/sample-accelerators/spring-petclinic
+👍 Updated workload "spring-petclinic"
+
+To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
+To get status: "tanzu apps workload get spring-petclinic"
+
+`,
 		},
 		{
-			Name: "conflict during update",
-			Args: []string{workloadName, flags.DebugFlagName, flags.YesFlagName},
-			WithReactors: []clitesting.ReactionFunc{
-				clitesting.InduceFailure("update", "Workload", clitesting.InduceFailureOpts{
-					Error: apierrs.NewConflict(schema.GroupResource{Group: "carto.run", Resource: "workloads"}, workloadName, fmt.Errorf("induced conflict")),
-				}),
-			},
+			Name: "delete serviceAccountName by setting to empty in file",
+			Args: []string{flags.FilePathFlagName, "testdata/no-service-account-name.yaml", flags.YesFlagName},
 			GivenObjects: []client.Object{
 				parent.
-					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-						d.Image("ubuntu:bionic")
-					}),
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("spring-petclinic")
+						d.AddLabel("preserve-me", "should-exist")
+					}).SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+					d.ServiceAccountName(&serviceAccountName)
+				}),
 			},
 			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
-						Name:      workloadName,
+						Name:      "spring-petclinic",
 						Labels: map[string]string{
-							apis.WorkloadTypeLabelName: "web",
+							"preserve-me":                         "should-exist",
+							"app.kubernetes.io/part-of":           "spring-petclinic",
+							"apps.tanzu.vmware.com/workload-type": "web",
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Image: "ubuntu:bionic",
-						Params: []cartov1alpha1.Param{
-							{
-								Name:  "debug",
-								Value: apiextensionsv1.JSON{Raw: []byte(`"true"`)},
+						ServiceAccountName: nil,
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								Ref: cartov1alpha1.GitRef{
+									Tag: "tap-1.1",
+								},
 							},
 						},
 					},
 				},
 			},
-			ShouldError: true,
 			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
 🔎 Update workload:
 ...
-  7,  7   |  name: my-workload
-  8,  8   |  namespace: default
-  9,  9   |spec:
- 10, 10   |  image: ubuntu:bionic
-     11 + |  params:
-     12 + |  - name: debug
-     13 + |    value: "true"
-Error: conflict updating workload, the object was modified by another user; please run the update command again
+  2,  2   |apiVersion: carto.run/v1alpha1
+  3,  3   |kind: Workload
+  4,  4   |metadata:
+  5,  5   |  labels:
+      6 + |    app.kubernetes.io/part-of: spring-petclinic
+  6,  7   |    apps.tanzu.vmware.com/workload-type: web
+  7,  8   |    preserve-me: should-exist
+  8,  9   |  name: spring-petclinic
+  9, 10   |  namespace: default
+ 10, 11   |spec:
+ 11     - |  serviceAccountName: my-service-account
+     12 + |  source:
+     13 + |    git:
+     14 + |      ref:
+     15 + |        tag: tap-1.1
+     16 + |      url: https://github.com/sample-accelerators/spring-petclinic
+👍 Updated workload "spring-petclinic"
+
+To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
+To get status: "tanzu apps workload get spring-petclinic"
+
 `,
 		},
 		{
-			Name: "update - wait for ready condition - error with timeout",
-			Skip: runtm.GOOS == "windows",
-			Args: []string{workloadName, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db", flags.WaitFlagName, flags.YesFlagName, flags.WaitTimeoutFlagName, "1ns"},
-			GivenObjects: []client.Object{
+			Name: "updated serviceAccountName taking priority from flag",
+			Args: []string{flags.FilePathFlagName, "testdata/no-service-account-name.yaml", flags.ServiceAccountFlagName, serviceAccountNameUpdated, flags.YesFlagName},
+			GivenObjects: append([]client.Object{
 				parent.
-					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-						d.Image("ubuntu:bionic")
-					}).StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
-					d.Conditions(metav1.Condition{
-						Type:   cartov1alpha1.WorkloadConditionReady,
-						Status: metav1.ConditionTrue,
-						LastTransitionTime: metav1.Time{
-							Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
-						},
-					}, metav1.Condition{
-						Type:   "my-other-type",
-						Status: metav1.ConditionTrue,
-					})
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("spring-petclinic")
+						d.AddLabel("preserve-me", "should-exist")
+					}).SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+					d.ServiceAccountName(&serviceAccountName)
 				}),
-			},
-			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
-				workload := &cartov1alpha1.Workload{
+			}, givenServiceAccounts...),
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
-						Name:      workloadName,
+						Name:      "spring-petclinic",
 						Labels: map[string]string{
-							apis.WorkloadTypeLabelName: "web",
+							"preserve-me":                         "should-exist",
+							"app.kubernetes.io/part-of":           "spring-petclinic",
+							"apps.tanzu.vmware.com/workload-type": "web",
 						},
 					},
-					Status: cartov1alpha1.WorkloadStatus{
-						Conditions: []metav1.Condition{
-							{
-								Type:   cartov1alpha1.WorkloadConditionReady,
-								Status: metav1.ConditionTrue,
-								LastTransitionTime: metav1.Time{
-									Time: time.Date(2019, 6, 29, 01, 44, 05, 01, time.UTC),
+					Spec: cartov1alpha1.WorkloadSpec{
+						ServiceAccountName: &serviceAccountNameUpdated,
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								Ref: cartov1alpha1.GitRef{
+									Tag: "tap-1.1",
 								},
 							},
 						},
 					},
-				}
+				},
+			},
+			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
 
-				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
-					{Type: watch.Modified, Object: workload},
-				})
-				ctx = context.WithValue(ctx, commands.WorkloadTimeoutStashKey{}, "1s")
-				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
-				return ctx, nil
+🔎 Update workload:
+...
+  2,  2   |apiVersion: carto.run/v1alpha1
+  3,  3   |kind: Workload
+  4,  4   |metadata:
+  5,  5   |  labels:
+      6 + |    app.kubernetes.io/part-of: spring-petclinic
+  6,  7   |    apps.tanzu.vmware.com/workload-type: web
+  7,  8   |    preserve-me: should-exist
+  8,  9   |  name: spring-petclinic
+  9, 10   |  namespace: default
+ 10, 11   |spec:
+ 11     - |  serviceAccountName: my-service-account
+     12 + |  serviceAccountName: my-service-account-updated
+     13 + |  source:
+     14 + |    git:
+     15 + |      ref:
+     16 + |        tag: tap-1.1
+     17 + |      url: https://github.com/sample-accelerators/spring-petclinic
+👍 Updated workload "spring-petclinic"
+
+To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
+To get status: "tanzu apps workload get spring-petclinic"
+
+`,
+		},
+		{
+			Name: "delete serviceAccountName field",
+			Args: []string{flags.FilePathFlagName, "testdata/no-service-account-name.yaml", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("spring-petclinic")
+						d.AddLabel("preserve-me", "should-exist")
+					}).SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+					d.ServiceAccountName(&serviceAccountName)
+				}),
 			},
 			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
-						Name:      workloadName,
+						Name:      "spring-petclinic",
 						Labels: map[string]string{
-							apis.WorkloadTypeLabelName: "web",
+							"preserve-me":                         "should-exist",
+							"app.kubernetes.io/part-of":           "spring-petclinic",
+							"apps.tanzu.vmware.com/workload-type": "web",
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Image: "ubuntu:bionic",
-						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
-							{
-								Name: "database",
-								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
-									APIVersion: "services.tanzu.vmware.com/v1alpha1",
-									Kind:       "PostgreSQL",
-									Name:       "my-prod-db",
-								},
-							},
-						},
-					},
-					Status: cartov1alpha1.WorkloadStatus{
-						Conditions: []metav1.Condition{
-							{
-								Type:   "Ready",
-								Status: metav1.ConditionTrue,
-								LastTransitionTime: metav1.Time{
-									Time: time.Date(2019, 6, 29, 01, 44, 05, 01, time.UTC),
-								},
-							},
-							{
-								Type:   "my-other-type",
-								Status: metav1.ConditionTrue,
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								Ref: cartov1alpha1.GitRef{
+									Tag: "tap-1.1",
+								},
 							},
 						},
 					},
 				},
 			},
-			ShouldError: true,
 			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
 🔎 Update workload:
 ...
-  7,  7   |  name: my-workload
-  8,  8   |  namespace: default
-  9,  9   |spec:
- 10, 10   |  image: ubuntu:bionic
-     11 + |  serviceClaims:
-     12 + |  - name: database
-     13 + |    ref:
-     14 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
-     15 + |      kind: PostgreSQL
-     16 + |      name: my-prod-db
-👍 Updated workload "my-workload"
+  2,  2   |apiVersion: carto.run/v1alpha1
+  3,  3   |kind: Workload
+  4,  4   |metadata:
+  5,  5   |  labels:
+      6 + |    app.kubernetes.io/part-of: spring-petclinic
+  6,  7   |    apps.tanzu.vmware.com/workload-type: web
+  7,  8   |    preserve-me: should-exist
+  8,  9   |  name: spring-petclinic
+  9, 10   |  namespace: default
+ 10, 11   |spec:
+ 11     - |  serviceAccountName: my-service-account
+     12 + |  source:
+     13 + |    git:
+     14 + |      ref:
+     15 + |        tag: tap-1.1
+     16 + |      url: https://github.com/sample-accelerators/spring-petclinic
+👍 Updated workload "spring-petclinic"
 
-To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
-To get status: "tanzu apps workload get my-workload"
+To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
+To get status: "tanzu apps workload get spring-petclinic"
 
-Waiting for workload "my-workload" to become ready...
-Error waiting for ready condition: timeout after 1ns waiting for "my-workload" to become ready
 `,
 		},
 		{
-			Name: "update - wait timeout when there is no transition time",
-			Skip: runtm.GOOS == "windows",
-			Args: []string{workloadName, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db", flags.WaitFlagName, flags.YesFlagName, flags.WaitTimeoutFlagName, "1ns"},
+			Name: "do not delete serviceAccountName when updating another field",
+			Args: []string{workloadName, flags.GitTagFlagName, "tap-1.1", flags.YesFlagName},
 			GivenObjects: []client.Object{
 				parent.
-					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-						d.Image("ubuntu:bionic")
-					}).StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
-					d.Conditions(metav1.Condition{
-						Type:   cartov1alpha1.WorkloadConditionReady,
-						Status: metav1.ConditionTrue,
-						LastTransitionTime: metav1.Time{
-							Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
-						},
-					}, metav1.Condition{
-						Type:   "my-other-type",
-						Status: metav1.ConditionTrue,
-					})
-				}),
-			},
-			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
-				workload := &cartov1alpha1.Workload{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: defaultNamespace,
-						Name:      workloadName,
-						Labels: map[string]string{
-							apis.WorkloadTypeLabelName: "web",
-						},
-					},
-					Status: cartov1alpha1.WorkloadStatus{
-						Conditions: []metav1.Condition{
-							{
-								Type:   cartov1alpha1.WorkloadConditionReady,
-								Status: metav1.ConditionTrue,
-							},
-						},
-					},
-				}
-				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
-					{Type: watch.Modified, Object: workload},
-				})
-				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
-				return ctx, nil
+					SpecDie(
+						func(d *diecartov1alpha1.WorkloadSpecDie) {
+							d.ServiceAccountName(&serviceAccountName)
+							d.Source(&cartov1alpha1.Source{
+								Git: &cartov1alpha1.GitSource{
+									URL: "https://github.com/sample-accelerators/spring-petclinic",
+									Ref: cartov1alpha1.GitRef{
+										Branch: "main",
+									},
+								},
+							})
+						}),
 			},
 			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
@@ -2154,91 +7707,52 @@ Error waiting for ready condition: timeout after 1ns waiting for "my-workload" t
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Image: "ubuntu:bionic",
-						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
-							{
-								Name: "database",
-								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
-									APIVersion: "services.tanzu.vmware.com/v1alpha1",
-									Kind:       "PostgreSQL",
-									Name:       "my-prod-db",
-								},
-							},
-						},
-					},
-					Status: cartov1alpha1.WorkloadStatus{
-						Conditions: []metav1.Condition{
-							{
-								Type:   "Ready",
-								Status: metav1.ConditionTrue,
-								LastTransitionTime: metav1.Time{
-									Time: time.Date(2019, 6, 29, 01, 44, 05, 01, time.UTC),
+						ServiceAccountName: &serviceAccountName,
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "main",
+									Tag:    "tap-1.1",
 								},
 							},
-							{
-								Type:   "my-other-type",
-								Status: metav1.ConditionTrue,
-							},
 						},
 					},
 				},
 			},
-			ShouldError: true,
 			ExpectOutput: `
 🔎 Update workload:
 ...
-  7,  7   |  name: my-workload
-  8,  8   |  namespace: default
-  9,  9   |spec:
- 10, 10   |  image: ubuntu:bionic
-     11 + |  serviceClaims:
-     12 + |  - name: database
-     13 + |    ref:
-     14 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
-     15 + |      kind: PostgreSQL
-     16 + |      name: my-prod-db
+ 11, 11   |  source:
+ 12, 12   |    git:
+ 13, 13   |      ref:
+ 14, 14   |        branch: main
+     15 + |        tag: tap-1.1
+ 15, 16   |      url: https://github.com/sample-accelerators/spring-petclinic
 👍 Updated workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
 To get status: "tanzu apps workload get my-workload"
 
-Waiting for workload "my-workload" to become ready...
-Error waiting for status change: timeout after 1ns waiting for "my-workload" to become ready
 `,
 		},
 		{
-			Name: "update - wait timeout when there is no ready cond",
-			Skip: runtm.GOOS == "windows",
-			Args: []string{workloadName, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db", flags.WaitFlagName, flags.YesFlagName, flags.WaitTimeoutFlagName, "1ns"},
+			Name: "update - git tag with git ref exclusive clears the other refs",
+			Args: []string{workloadName, flags.GitTagFlagName, "tap-1.1", flags.GitRefExclusiveFlagName, flags.YesFlagName},
 			GivenObjects: []client.Object{
 				parent.
-					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-						d.Image("ubuntu:bionic")
-					}).StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
-					d.Conditions(metav1.Condition{
-						Type:   "my-type",
-						Status: metav1.ConditionTrue,
-					}, metav1.Condition{
-						Type:   "my-other-type",
-						Status: metav1.ConditionTrue,
-					})
-				}),
-			},
-			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
-				workload := &cartov1alpha1.Workload{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: defaultNamespace,
-						Name:      workloadName,
-						Labels: map[string]string{
-							apis.WorkloadTypeLabelName: "web",
-						},
-					},
-				}
-				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
-					{Type: watch.Modified, Object: workload},
-				})
-				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
-				return ctx, nil
+					SpecDie(
+						func(d *diecartov1alpha1.WorkloadSpecDie) {
+							d.Source(&cartov1alpha1.Source{
+								Git: &cartov1alpha1.GitSource{
+									URL: "https://github.com/sample-accelerators/spring-petclinic",
+									Ref: cartov1alpha1.GitRef{
+										Branch: "main",
+										Commit: "abcd1234",
+									},
+								},
+							})
+						}),
 			},
 			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
@@ -2250,78 +7764,55 @@ Error waiting for status change: timeout after 1ns waiting for "my-workload" to
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Image: "ubuntu:bionic",
-						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
-							{
-								Name: "database",
-								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
-									APIVersion: "services.tanzu.vmware.com/v1alpha1",
-									Kind:       "PostgreSQL",
-									Name:       "my-prod-db",
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								Ref: cartov1alpha1.GitRef{
+									Tag: "tap-1.1",
 								},
 							},
 						},
 					},
-					Status: cartov1alpha1.WorkloadStatus{
-						Conditions: []metav1.Condition{
-							{
-								Type:   "my-type",
-								Status: metav1.ConditionTrue,
-							},
-							{
-								Type:   "my-other-type",
-								Status: metav1.ConditionTrue,
-							},
-						},
-					},
 				},
 			},
-			ShouldError: true,
 			ExpectOutput: `
 🔎 Update workload:
 ...
-  7,  7   |  name: my-workload
-  8,  8   |  namespace: default
   9,  9   |spec:
- 10, 10   |  image: ubuntu:bionic
-     11 + |  serviceClaims:
-     12 + |  - name: database
-     13 + |    ref:
-     14 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
-     15 + |      kind: PostgreSQL
-     16 + |      name: my-prod-db
+ 10, 10   |  source:
+ 11, 11   |    git:
+ 12, 12   |      ref:
+ 13     - |        branch: main
+ 14     - |        commit: abcd1234
+     13 + |        tag: tap-1.1
+ 15, 14   |      url: https://github.com/sample-accelerators/spring-petclinic
 👍 Updated workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
 To get status: "tanzu apps workload get my-workload"
 
-Waiting for workload "my-workload" to become ready...
-Error waiting for status change: timeout after 1ns waiting for "my-workload" to become ready
 `,
 		},
 		{
-			Name: "update - wait for timestamp change error with timeout",
-			Skip: runtm.GOOS == "windows",
-			Args: []string{workloadName, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db", flags.WaitFlagName, flags.YesFlagName, flags.WaitTimeoutFlagName, "1ns"},
+			Name: "update - git branch and commit with git ref exclusive keep both",
+			Args: []string{workloadName, flags.GitBranchFlagName, "release", flags.GitCommitFlagName, "ef567890", flags.GitRefExclusiveFlagName, flags.YesFlagName},
 			GivenObjects: []client.Object{
 				parent.
-					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-						d.Image("ubuntu:bionic")
-					}).StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
-					d.Conditions(metav1.Condition{
-						Type:   cartov1alpha1.WorkloadConditionReady,
-						Status: metav1.ConditionTrue,
-						LastTransitionTime: metav1.Time{
-							Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
-						},
-					}, metav1.Condition{
-						Type:   "my-other-type",
-						Status: metav1.ConditionTrue,
-					})
-				}),
+					SpecDie(
+						func(d *diecartov1alpha1.WorkloadSpecDie) {
+							d.Source(&cartov1alpha1.Source{
+								Git: &cartov1alpha1.GitSource{
+									URL: "https://github.com/sample-accelerators/spring-petclinic",
+									Ref: cartov1alpha1.GitRef{
+										Branch: "main",
+										Tag:    "tap-1.1",
+									},
+								},
+							})
+						}),
 			},
-			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
-				workload := &cartov1alpha1.Workload{
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
 						Name:      workloadName,
@@ -2329,25 +7820,56 @@ Error waiting for status change: timeout after 1ns waiting for "my-workload" to
 							apis.WorkloadTypeLabelName: "web",
 						},
 					},
-					Status: cartov1alpha1.WorkloadStatus{
-						Conditions: []metav1.Condition{
-							{
-								Type:   cartov1alpha1.WorkloadConditionReady,
-								Status: metav1.ConditionTrue,
-								LastTransitionTime: metav1.Time{
-									Time: time.Date(2019, 6, 29, 01, 44, 05, 01, time.UTC),
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "release",
+									Commit: "ef567890",
 								},
 							},
 						},
 					},
-				}
-
-				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
-					{Type: watch.Modified, Object: workload},
-				})
-				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
-				return ctx, nil
+				},
 			},
+			ExpectOutput: `
+🔎 Update workload:
+...
+  9,  9   |spec:
+ 10, 10   |  source:
+ 11, 11   |    git:
+ 12, 12   |      ref:
+ 13     - |        branch: main
+ 14     - |        tag: tap-1.1
+     13 + |        branch: release
+     14 + |        commit: ef567890
+ 15, 15   |      url: https://github.com/sample-accelerators/spring-petclinic
+👍 Updated workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name: "update serviceAccountName field via flag",
+			Args: []string{workloadName, flags.ServiceAccountFlagName, serviceAccountNameUpdated, flags.YesFlagName},
+			GivenObjects: append([]client.Object{
+				parent.
+					SpecDie(
+						func(d *diecartov1alpha1.WorkloadSpecDie) {
+							d.ServiceAccountName(&serviceAccountName)
+							d.Source(&cartov1alpha1.Source{
+								Git: &cartov1alpha1.GitSource{
+									URL: "https://github.com/sample-accelerators/spring-petclinic",
+									Ref: cartov1alpha1.GitRef{
+										Branch: "main",
+									},
+								},
+							})
+						}),
+			}, givenServiceAccounts...),
 			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
@@ -2358,106 +7880,56 @@ Error waiting for status change: timeout after 1ns waiting for "my-workload" to
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Image: "ubuntu:bionic",
-						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
-							{
-								Name: "database",
-								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
-									APIVersion: "services.tanzu.vmware.com/v1alpha1",
-									Kind:       "PostgreSQL",
-									Name:       "my-prod-db",
-								},
-							},
-						},
-					},
-					Status: cartov1alpha1.WorkloadStatus{
-						Conditions: []metav1.Condition{
-							{
-								Type:   "Ready",
-								Status: metav1.ConditionTrue,
-								LastTransitionTime: metav1.Time{
-									Time: time.Date(2019, 6, 29, 01, 44, 05, 01, time.UTC),
+						ServiceAccountName: &serviceAccountNameUpdated,
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "main",
 								},
 							},
-							{
-								Type:   "my-other-type",
-								Status: metav1.ConditionTrue,
-							},
 						},
 					},
 				},
 			},
-			ShouldError: true,
 			ExpectOutput: `
 🔎 Update workload:
 ...
+  6,  6   |    apps.tanzu.vmware.com/workload-type: web
   7,  7   |  name: my-workload
   8,  8   |  namespace: default
   9,  9   |spec:
- 10, 10   |  image: ubuntu:bionic
-     11 + |  serviceClaims:
-     12 + |  - name: database
-     13 + |    ref:
-     14 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
-     15 + |      kind: PostgreSQL
-     16 + |      name: my-prod-db
+ 10     - |  serviceAccountName: my-service-account
+     10 + |  serviceAccountName: my-service-account-updated
+ 11, 11   |  source:
+ 12, 12   |    git:
+ 13, 13   |      ref:
+ 14, 14   |        branch: main
+...
 👍 Updated workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
 To get status: "tanzu apps workload get my-workload"
 
-Waiting for workload "my-workload" to become ready...
-Error waiting for status change: timeout after 1ns waiting for "my-workload" to become ready
 `,
 		},
 		{
-			Name: "update - wait error for false condition",
-			Args: []string{workloadName, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db", flags.WaitFlagName, flags.YesFlagName},
+			Name: "delete serviceAccountName via flag",
+			Args: []string{workloadName, flags.ServiceAccountFlagName, "", flags.YesFlagName},
 			GivenObjects: []client.Object{
 				parent.
-					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-						d.Image("ubuntu:bionic")
-					}).StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
-					d.Conditions(metav1.Condition{
-						Type:   cartov1alpha1.WorkloadConditionReady,
-						Status: metav1.ConditionTrue,
-						LastTransitionTime: metav1.Time{
-							Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
-						},
-					}, metav1.Condition{
-						Type:   "my-other-type",
-						Status: metav1.ConditionTrue,
-					})
-				}),
-			},
-			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
-				workload := &cartov1alpha1.Workload{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: defaultNamespace,
-						Name:      workloadName,
-						Labels: map[string]string{
-							apis.WorkloadTypeLabelName: "web",
-						},
-					},
-					Status: cartov1alpha1.WorkloadStatus{
-						Conditions: []metav1.Condition{
-							{
-								Type:    cartov1alpha1.WorkloadConditionReady,
-								Status:  metav1.ConditionFalse,
-								Reason:  "OopsieDoodle",
-								Message: "a hopefully informative message about what went wrong",
-								LastTransitionTime: metav1.Time{
-									Time: time.Date(2019, 6, 29, 01, 44, 06, 0, time.UTC),
+					SpecDie(
+						func(d *diecartov1alpha1.WorkloadSpecDie) {
+							d.ServiceAccountName(&serviceAccountName)
+							d.Source(&cartov1alpha1.Source{
+								Git: &cartov1alpha1.GitSource{
+									URL: "https://github.com/sample-accelerators/spring-petclinic",
+									Ref: cartov1alpha1.GitRef{
+										Branch: "main",
+									},
 								},
-							},
-						},
-					},
-				}
-				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
-					{Type: watch.Modified, Object: workload},
-				})
-				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
-				return ctx, nil
+							})
+						}),
 			},
 			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
@@ -2469,106 +7941,97 @@ Error waiting for status change: timeout after 1ns waiting for "my-workload" to
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Image: "ubuntu:bionic",
-						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
-							{
-								Name: "database",
-								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
-									APIVersion: "services.tanzu.vmware.com/v1alpha1",
-									Kind:       "PostgreSQL",
-									Name:       "my-prod-db",
-								},
-							},
-						},
-					},
-					Status: cartov1alpha1.WorkloadStatus{
-						Conditions: []metav1.Condition{
-							{
-								Type:   "Ready",
-								Status: metav1.ConditionTrue,
-								LastTransitionTime: metav1.Time{
-									Time: time.Date(2019, 6, 29, 01, 44, 06, 0, time.UTC),
+						ServiceAccountName: nil,
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "main",
 								},
 							},
-							{
-								Type:   "my-other-type",
-								Status: metav1.ConditionTrue,
-							},
 						},
 					},
 				},
 			},
-			ShouldError: true,
 			ExpectOutput: `
 🔎 Update workload:
 ...
+  6,  6   |    apps.tanzu.vmware.com/workload-type: web
   7,  7   |  name: my-workload
   8,  8   |  namespace: default
   9,  9   |spec:
- 10, 10   |  image: ubuntu:bionic
-     11 + |  serviceClaims:
-     12 + |  - name: database
-     13 + |    ref:
-     14 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
-     15 + |      kind: PostgreSQL
-     16 + |      name: my-prod-db
+ 10     - |  serviceAccountName: my-service-account
+ 11, 10   |  source:
+ 12, 11   |    git:
+ 13, 12   |      ref:
+ 14, 13   |        branch: main
+...
 👍 Updated workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
 To get status: "tanzu apps workload get my-workload"
 
-Waiting for workload "my-workload" to become ready...
-Error waiting for ready condition: Failed to become ready: a hopefully informative message about what went wrong
 `,
 		},
 		{
-			Name: "update - successful wait for ready condition",
-			Args: []string{workloadName, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db", flags.WaitFlagName, flags.YesFlagName},
-			GivenObjects: []client.Object{
-				parent.
-					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-						d.Image("ubuntu:bionic")
-					}).StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
-					d.Conditions(metav1.Condition{
-						Type:   cartov1alpha1.WorkloadConditionReady,
-						Status: metav1.ConditionTrue,
-						LastTransitionTime: metav1.Time{
-							Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
-						},
-					}, metav1.Condition{
-						Type:   "my-other-type",
-						Status: metav1.ConditionTrue,
-					})
-				}),
-			},
-			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
-				workload := &cartov1alpha1.Workload{
+			Name:         "create with serviceAccountName",
+			Args:         []string{flags.FilePathFlagName, "testdata/service-account-name.yaml", flags.YesFlagName},
+			GivenObjects: append(givenNamespaceDefault, givenServiceAccounts...),
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
-						Name:      workloadName,
+						Name:      "spring-petclinic",
 						Labels: map[string]string{
-							apis.WorkloadTypeLabelName: "web",
+							apis.AppPartOfLabelName:               "spring-petclinic",
+							"apps.tanzu.vmware.com/workload-type": "web",
 						},
 					},
-					Status: cartov1alpha1.WorkloadStatus{
-						Conditions: []metav1.Condition{
-							{
-								Type:   cartov1alpha1.WorkloadConditionReady,
-								Status: metav1.ConditionTrue,
-								LastTransitionTime: metav1.Time{
-									Time: time.Date(2019, 6, 29, 01, 44, 06, 0, time.UTC),
+					Spec: cartov1alpha1.WorkloadSpec{
+						ServiceAccountName: &serviceAccountName,
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								Ref: cartov1alpha1.GitRef{
+									Tag: "tap-1.1",
 								},
 							},
 						},
 					},
-				}
-				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
-					{Type: watch.Modified, Object: workload},
-				})
-				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
-				return ctx, nil
+				},
 			},
-			ExpectUpdates: []client.Object{
+			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    app.kubernetes.io/part-of: spring-petclinic
+      7 + |    apps.tanzu.vmware.com/workload-type: web
+      8 + |  name: spring-petclinic
+      9 + |  namespace: default
+     10 + |spec:
+     11 + |  serviceAccountName: my-service-account
+     12 + |  source:
+     13 + |    git:
+     14 + |      ref:
+     15 + |        tag: tap-1.1
+     16 + |      url: https://github.com/sample-accelerators/spring-petclinic
+👍 Created workload "spring-petclinic"
+
+To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
+To get status: "tanzu apps workload get spring-petclinic"
+
+`,
+		},
+		{
+			Name:         "create with serviceAccountName via flag",
+			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.ServiceAccountFlagName, serviceAccountName, flags.YesFlagName},
+			GivenObjects: append(givenNamespaceDefault, givenServiceAccounts...),
+			ExpectCreates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
@@ -2578,63 +8041,48 @@ Error waiting for ready condition: Failed to become ready: a hopefully informati
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Image: "ubuntu:bionic",
-						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
-							{
-								Name: "database",
-								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
-									APIVersion: "services.tanzu.vmware.com/v1alpha1",
-									Kind:       "PostgreSQL",
-									Name:       "my-prod-db",
-								},
-							},
-						},
-					},
-					Status: cartov1alpha1.WorkloadStatus{
-						Conditions: []metav1.Condition{
-							{
-								Type:   "Ready",
-								Status: metav1.ConditionTrue,
-								LastTransitionTime: metav1.Time{
-									Time: time.Date(2019, 6, 29, 01, 44, 06, 0, time.UTC),
+						ServiceAccountName: &serviceAccountName,
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
 								},
 							},
-							{
-								Type:   "my-other-type",
-								Status: metav1.ConditionTrue,
-							},
 						},
 					},
 				},
 			},
 			ExpectOutput: `
-🔎 Update workload:
-...
-  7,  7   |  name: my-workload
-  8,  8   |  namespace: default
-  9,  9   |spec:
- 10, 10   |  image: ubuntu:bionic
-     11 + |  serviceClaims:
-     12 + |  - name: database
-     13 + |    ref:
-     14 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
-     15 + |      kind: PostgreSQL
-     16 + |      name: my-prod-db
-👍 Updated workload "my-workload"
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  serviceAccountName: my-service-account
+     11 + |  source:
+     12 + |    git:
+     13 + |      ref:
+     14 + |        branch: main
+     15 + |      url: https://example.com/repo.git
+👍 Created workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
 To get status: "tanzu apps workload get my-workload"
 
-Waiting for workload "my-workload" to become ready...
-Workload "my-workload" is ready
-
 `,
 		},
 		{
-			Name: "update - tail with timestamp while waiting for ready condition",
-			Args: []string{workloadName, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db", flags.YesFlagName, flags.TailTimestampFlagName},
-			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
-				workload := &cartov1alpha1.Workload{
+			Name:         "create with serviceAccountName not found",
+			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.ServiceAccountFlagName, serviceAccountName, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
 						Name:      workloadName,
@@ -2642,53 +8090,54 @@ Workload "my-workload" is ready
 							apis.WorkloadTypeLabelName: "web",
 						},
 					},
-					Status: cartov1alpha1.WorkloadStatus{
-						Conditions: []metav1.Condition{
-							{
-								Type:   cartov1alpha1.WorkloadConditionReady,
-								Status: metav1.ConditionTrue,
-								LastTransitionTime: metav1.Time{
-									Time: time.Date(2019, 6, 29, 01, 44, 06, 0, time.UTC),
+					Spec: cartov1alpha1.WorkloadSpec{
+						ServiceAccountName: &serviceAccountName,
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
 								},
 							},
 						},
 					},
-				}
-				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
-					{Type: watch.Modified, Object: workload},
-				})
-				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
+				},
+			},
+			ExpectOutput: `
+❗ WARNING: service account "my-service-account" not found in namespace "default", the workload may fail to run (use --no-sa-check to skip this check)
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  serviceAccountName: my-service-account
+     11 + |  source:
+     12 + |    git:
+     13 + |      ref:
+     14 + |        branch: main
+     15 + |      url: https://example.com/repo.git
+👍 Created workload "my-workload"
 
-				tailer := &logs.FakeTailer{}
-				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Minute, true).Return(nil).Once()
-				ctx = logs.StashTailer(ctx, tailer)
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
 
-				return ctx, nil
-			},
-			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
-				tailer := logs.RetrieveTailer(ctx).(*logs.FakeTailer)
-				tailer.AssertExpectations(t)
-				return nil
-			},
-			GivenObjects: []client.Object{
-				parent.
-					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-						d.Image("ubuntu:bionic")
-					}).StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
-					d.Conditions(metav1.Condition{
-						Type:   cartov1alpha1.WorkloadConditionReady,
-						Status: metav1.ConditionTrue,
-						LastTransitionTime: metav1.Time{
-							Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
-						},
-					}, metav1.Condition{
-						Type:   "my-other-type",
-						Status: metav1.ConditionTrue,
-					})
+`,
+		},
+		{
+			Name:         "create with serviceAccountName forbidden",
+			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.ServiceAccountFlagName, serviceAccountName, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("get", "ServiceAccount", clitesting.InduceFailureOpts{
+					Error: apierrs.NewForbidden(corev1.Resource("ServiceAccount"), serviceAccountName, fmt.Errorf("forbidden")),
 				}),
 			},
-			ExpectUpdates: []client.Object{
+			ExpectCreates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
@@ -2698,222 +8147,125 @@ Workload "my-workload" is ready
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Image: "ubuntu:bionic",
-						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
-							{
-								Name: "database",
-								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
-									APIVersion: "services.tanzu.vmware.com/v1alpha1",
-									Kind:       "PostgreSQL",
-									Name:       "my-prod-db",
-								},
-							},
-						},
-					},
-					Status: cartov1alpha1.WorkloadStatus{
-						Conditions: []metav1.Condition{
-							{
-								Type:   "Ready",
-								Status: metav1.ConditionTrue,
-								LastTransitionTime: metav1.Time{
-									Time: time.Date(2019, 6, 29, 01, 44, 06, 0, time.UTC),
+						ServiceAccountName: &serviceAccountName,
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
 								},
 							},
-							{
-								Type:   "my-other-type",
-								Status: metav1.ConditionTrue,
-							},
 						},
 					},
 				},
 			},
 			ExpectOutput: `
-🔎 Update workload:
-...
-  7,  7   |  name: my-workload
-  8,  8   |  namespace: default
-  9,  9   |spec:
- 10, 10   |  image: ubuntu:bionic
-     11 + |  serviceClaims:
-     12 + |  - name: database
-     13 + |    ref:
-     14 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
-     15 + |      kind: PostgreSQL
-     16 + |      name: my-prod-db
-👍 Updated workload "my-workload"
-
-To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
-To get status: "tanzu apps workload get my-workload"
-
-Waiting for workload "my-workload" to become ready...
-...tail output...
-Workload "my-workload" is ready
-
-`,
-		},
-		{
-			Name: "update - filepath",
-			Args: []string{flags.FilePathFlagName, file, flags.SubPathFlagName, "./cmd", flags.YesFlagName},
-			GivenObjects: []client.Object{
-				parent.
-					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
-						d.Name("spring-petclinic")
-						d.AddLabel("preserve-me", "should-exist")
-					}).
-					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-						d.Image("ubuntu:bionic")
-						d.Env(
-							corev1.EnvVar{
-								Name:  "OVERRIDE_VAR",
-								Value: "doesnt matter",
-							},
-						)
-					}),
-			},
-			ExpectUpdates: []client.Object{
+❗ WARNING: unable to check service account "my-service-account" exists in namespace "default", user does not have permissions to read it
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  serviceAccountName: my-service-account
+     11 + |  source:
+     12 + |    git:
+     13 + |      ref:
+     14 + |        branch: main
+     15 + |      url: https://example.com/repo.git
+👍 Created workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name:         "create with serviceAccountName not found without the check",
+			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.ServiceAccountFlagName, serviceAccountName, flags.NoSACheckFlagName, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
-						Name:      "spring-petclinic",
+						Name:      workloadName,
 						Labels: map[string]string{
-							"preserve-me":                         "should-exist",
-							"app.kubernetes.io/part-of":           "spring-petclinic",
-							"apps.tanzu.vmware.com/workload-type": "web",
+							apis.WorkloadTypeLabelName: "web",
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
+						ServiceAccountName: &serviceAccountName,
 						Source: &cartov1alpha1.Source{
 							Git: &cartov1alpha1.GitSource{
-								URL: "https://github.com/spring-projects/spring-petclinic.git",
+								URL: gitRepo,
 								Ref: cartov1alpha1.GitRef{
-									Branch: "main",
+									Branch: gitBranch,
 								},
 							},
-							Subpath: "./cmd",
-						},
-						Env: []corev1.EnvVar{
-							{
-								Name:  "OVERRIDE_VAR",
-								Value: "doesnt matter",
-							},
-							{
-								Name:  "SPRING_PROFILES_ACTIVE",
-								Value: "mysql",
-							},
-						},
-						Resources: &corev1.ResourceRequirements{
-							Limits: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse("500m"),
-								corev1.ResourceMemory: resource.MustParse("1Gi"),
-							},
-							Requests: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse("100m"),
-								corev1.ResourceMemory: resource.MustParse("1Gi"),
-							},
 						},
 					},
 				},
 			},
 			ExpectOutput: `
-❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
-
-🔎 Update workload:
-...
-  2,  2   |apiVersion: carto.run/v1alpha1
-  3,  3   |kind: Workload
-  4,  4   |metadata:
-  5,  5   |  labels:
-      6 + |    app.kubernetes.io/part-of: spring-petclinic
-  6,  7   |    apps.tanzu.vmware.com/workload-type: web
-  7,  8   |    preserve-me: should-exist
-  8,  9   |  name: spring-petclinic
-  9, 10   |  namespace: default
- 10, 11   |spec:
- 11, 12   |  env:
- 12, 13   |  - name: OVERRIDE_VAR
- 13, 14   |    value: doesnt matter
- 14     - |  image: ubuntu:bionic
-     15 + |  - name: SPRING_PROFILES_ACTIVE
-     16 + |    value: mysql
-     17 + |  resources:
-     18 + |    limits:
-     19 + |      cpu: 500m
-     20 + |      memory: 1Gi
-     21 + |    requests:
-     22 + |      cpu: 100m
-     23 + |      memory: 1Gi
-     24 + |  source:
-     25 + |    git:
-     26 + |      ref:
-     27 + |        branch: main
-     28 + |      url: https://github.com/spring-projects/spring-petclinic.git
-     29 + |    subPath: ./cmd
-👍 Updated workload "spring-petclinic"
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  serviceAccountName: my-service-account
+     11 + |  source:
+     12 + |    git:
+     13 + |      ref:
+     14 + |        branch: main
+     15 + |      url: https://example.com/repo.git
+👍 Created workload "my-workload"
 
-To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
-To get status: "tanzu apps workload get spring-petclinic"
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
 
 `,
 		},
 		{
-			Name: "update - filepath from url",
-			Args: []string{flags.FilePathFlagName, fileFromUrl, flags.SubPathFlagName, "./cmd", flags.YesFlagName},
-			GivenObjects: []client.Object{
-				parent.
-					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
-						d.Name("spring-petclinic")
-						d.AddLabel("preserve-me", "should-exist")
-					}).
-					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-						d.Image("ubuntu:bionic")
-						d.Env(
-							corev1.EnvVar{
-								Name:  "OVERRIDE_VAR",
-								Value: "doesnt matter",
-							},
-						)
-					}),
+			Name:         "create with serviceAccountName get error",
+			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.ServiceAccountFlagName, serviceAccountName, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("get", "ServiceAccount"),
 			},
-			ExpectUpdates: []client.Object{
+			ShouldError: true,
+		},
+		{
+			Name:         "create with serviceAccountName from file and flag",
+			Args:         []string{flags.FilePathFlagName, "testdata/service-account-name.yaml", flags.ServiceAccountFlagName, serviceAccountNameUpdated, flags.YesFlagName},
+			GivenObjects: append(givenNamespaceDefault, givenServiceAccounts...),
+			ExpectCreates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
 						Name:      "spring-petclinic",
 						Labels: map[string]string{
-							"preserve-me":                         "should-exist",
-							"app.kubernetes.io/part-of":           "spring-petclinic",
+							apis.AppPartOfLabelName:               "spring-petclinic",
 							"apps.tanzu.vmware.com/workload-type": "web",
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
+						ServiceAccountName: &serviceAccountNameUpdated,
 						Source: &cartov1alpha1.Source{
 							Git: &cartov1alpha1.GitSource{
-								URL: "https://github.com/spring-projects/spring-petclinic.git",
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
 								Ref: cartov1alpha1.GitRef{
-									Branch: "main",
+									Tag: "tap-1.1",
 								},
 							},
-							Subpath: "./cmd",
-						},
-						Env: []corev1.EnvVar{
-							{
-								Name:  "OVERRIDE_VAR",
-								Value: "doesnt matter",
-							},
-							{
-								Name:  "SPRING_PROFILES_ACTIVE",
-								Value: "mysql",
-							},
-						},
-						Resources: &corev1.ResourceRequirements{
-							Limits: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse("500m"),
-								corev1.ResourceMemory: resource.MustParse("1Gi"),
-							},
-							Requests: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse("100m"),
-								corev1.ResourceMemory: resource.MustParse("1Gi"),
-							},
 						},
 					},
 				},
@@ -2921,38 +8273,24 @@ To get status: "tanzu apps workload get spring-petclinic"
 			ExpectOutput: `
 ❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
 
-🔎 Update workload:
-...
-  2,  2   |apiVersion: carto.run/v1alpha1
-  3,  3   |kind: Workload
-  4,  4   |metadata:
-  5,  5   |  labels:
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
       6 + |    app.kubernetes.io/part-of: spring-petclinic
-  6,  7   |    apps.tanzu.vmware.com/workload-type: web
-  7,  8   |    preserve-me: should-exist
-  8,  9   |  name: spring-petclinic
-  9, 10   |  namespace: default
- 10, 11   |spec:
- 11, 12   |  env:
- 12, 13   |  - name: OVERRIDE_VAR
- 13, 14   |    value: doesnt matter
- 14     - |  image: ubuntu:bionic
-     15 + |  - name: SPRING_PROFILES_ACTIVE
-     16 + |    value: mysql
-     17 + |  resources:
-     18 + |    limits:
-     19 + |      cpu: 500m
-     20 + |      memory: 1Gi
-     21 + |    requests:
-     22 + |      cpu: 100m
-     23 + |      memory: 1Gi
-     24 + |  source:
-     25 + |    git:
-     26 + |      ref:
-     27 + |        branch: main
-     28 + |      url: https://github.com/spring-projects/spring-petclinic.git
-     29 + |    subPath: ./cmd
-👍 Updated workload "spring-petclinic"
+      7 + |    apps.tanzu.vmware.com/workload-type: web
+      8 + |  name: spring-petclinic
+      9 + |  namespace: default
+     10 + |spec:
+     11 + |  serviceAccountName: my-service-account-updated
+     12 + |  source:
+     13 + |    git:
+     14 + |      ref:
+     15 + |        tag: tap-1.1
+     16 + |      url: https://github.com/sample-accelerators/spring-petclinic
+👍 Created workload "spring-petclinic"
 
 To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
 To get status: "tanzu apps workload get spring-petclinic"
@@ -2960,62 +8298,31 @@ To get status: "tanzu apps workload get spring-petclinic"
 `,
 		},
 		{
-			Name: "update - filepath - custom namespace and name",
-			Args: []string{workloadName, flags.NamespaceFlagName, "test-namespace", flags.FilePathFlagName, file, flags.YesFlagName},
-			GivenObjects: []client.Object{
-				parent.
-					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
-						d.Namespace("test-namespace")
-						d.AddLabel("preserve-me", "should-exist")
-					}).
-					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-						d.Image("ubuntu:bionic")
-						d.Env(
-							corev1.EnvVar{
-								Name:  "OVERRIDE_VAR",
-								Value: "doesnt matter",
-							},
-						)
-					}),
-			},
-			ExpectUpdates: []client.Object{
+			Name: "create with multiple param-yaml using valid json and yaml",
+			Args: []string{flags.FilePathFlagName, "testdata/param-yaml.yaml",
+				flags.ParamYamlFlagName, `ports_json={"name": "smtp", "port": 1026}`,
+				flags.ParamYamlFlagName, "ports_nesting_yaml=- deployment:\n    name: smtp\n    port: 1026",
+				flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "test-namespace",
-						Name:      workloadName,
+						Namespace: defaultNamespace,
+						Name:      "spring-petclinic",
 						Labels: map[string]string{
-							"preserve-me":                         "should-exist",
-							"app.kubernetes.io/part-of":           "spring-petclinic",
+							apis.AppPartOfLabelName:               "spring-petclinic",
 							"apps.tanzu.vmware.com/workload-type": "web",
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: "https://github.com/spring-projects/spring-petclinic.git",
-								Ref: cartov1alpha1.GitRef{
-									Branch: "main",
-								},
-							},
-						},
-						Env: []corev1.EnvVar{
-							{
-								Name:  "OVERRIDE_VAR",
-								Value: "doesnt matter",
-							},
+						Image: "my-reponame/my-image:my-tag",
+						Params: []cartov1alpha1.Param{
 							{
-								Name:  "SPRING_PROFILES_ACTIVE",
-								Value: "mysql",
-							},
-						},
-						Resources: &corev1.ResourceRequirements{
-							Limits: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse("500m"),
-								corev1.ResourceMemory: resource.MustParse("1Gi"),
-							},
-							Requests: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse("100m"),
-								corev1.ResourceMemory: resource.MustParse("1Gi"),
+								Name:  "ports_json",
+								Value: apiextensionsv1.JSON{Raw: []byte(`{"name":"smtp","port":1026}`)},
+							}, {
+								Name:  "ports_nesting_yaml",
+								Value: apiextensionsv1.JSON{Raw: []byte(`[{"deployment":{"name":"smtp","port":1026}}]`)},
 							},
 						},
 					},
@@ -3024,58 +8331,43 @@ To get status: "tanzu apps workload get spring-petclinic"
 			ExpectOutput: `
 ❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
 
-🔎 Update workload:
-...
-  2,  2   |apiVersion: carto.run/v1alpha1
-  3,  3   |kind: Workload
-  4,  4   |metadata:
-  5,  5   |  labels:
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
       6 + |    app.kubernetes.io/part-of: spring-petclinic
-  6,  7   |    apps.tanzu.vmware.com/workload-type: web
-  7,  8   |    preserve-me: should-exist
-  8,  9   |  name: my-workload
-  9, 10   |  namespace: test-namespace
- 10, 11   |spec:
- 11, 12   |  env:
- 12, 13   |  - name: OVERRIDE_VAR
- 13, 14   |    value: doesnt matter
- 14     - |  image: ubuntu:bionic
-     15 + |  - name: SPRING_PROFILES_ACTIVE
-     16 + |    value: mysql
-     17 + |  resources:
-     18 + |    limits:
-     19 + |      cpu: 500m
-     20 + |      memory: 1Gi
-     21 + |    requests:
-     22 + |      cpu: 100m
-     23 + |      memory: 1Gi
-     24 + |  source:
-     25 + |    git:
-     26 + |      ref:
-     27 + |        branch: main
-     28 + |      url: https://github.com/spring-projects/spring-petclinic.git
-👍 Updated workload "my-workload"
+      7 + |    apps.tanzu.vmware.com/workload-type: web
+      8 + |  name: spring-petclinic
+      9 + |  namespace: default
+     10 + |spec:
+     11 + |  image: my-reponame/my-image:my-tag
+     12 + |  params:
+     13 + |  - name: ports_json
+     14 + |    value:
+     15 + |      name: smtp
+     16 + |      port: 1026
+     17 + |  - name: ports_nesting_yaml
+     18 + |    value:
+     19 + |    - deployment:
+     20 + |        name: smtp
+     21 + |        port: 1026
+👍 Created workload "spring-petclinic"
 
-To see logs:   "tanzu apps workload tail my-workload --namespace test-namespace --timestamp --since 1h"
-To get status: "tanzu apps workload get my-workload --namespace test-namespace"
+To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
+To get status: "tanzu apps workload get spring-petclinic"
 
 `,
 		},
 		{
-			Name:        "filepath invalid name",
-			Args:        []string{flags.FilePathFlagName, "testdata/workload-invalid-name.yaml", flags.YesFlagName},
-			ShouldError: true,
-		},
-		{
-			Name: "update - serviceclaim with deprecation warning",
-			Args: []string{workloadName, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-ns:my-prod-db", flags.YesFlagName},
-			GivenObjects: []client.Object{
-				parent.
-					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-						d.Image("ubuntu:bionic")
-					}),
-			},
-			ExpectUpdates: []client.Object{
+			Name: "create with param-yaml in flow style",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:bionic",
+				flags.ParamYamlFlagName, `ports_json={"name": "smtp", "port": 1026}`,
+				flags.ParamYamlFlagName, "ports_nesting_yaml=- deployment:\n    name: smtp\n    port: 1026",
+				flags.YamlFlowParamsFlagName, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
@@ -3083,47 +8375,39 @@ To get status: "tanzu apps workload get my-workload --namespace test-namespace"
 						Labels: map[string]string{
 							apis.WorkloadTypeLabelName: "web",
 						},
-						Annotations: map[string]string{
-							apis.ServiceClaimAnnotationName: `{"kind":"ServiceClaimsExtension","apiVersion":"supplychain.apps.x-tanzu.vmware.com/v1alpha1","spec":{"serviceClaims":{"database":{"namespace":"my-prod-ns"}}}}`,
-						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
 						Image: "ubuntu:bionic",
-						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
+						Params: []cartov1alpha1.Param{
 							{
-								Name: "database",
-								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
-									APIVersion: "services.tanzu.vmware.com/v1alpha1",
-									Kind:       "PostgreSQL",
-									Name:       "my-prod-db",
-								},
+								Name:  "ports_json",
+								Value: apiextensionsv1.JSON{Raw: []byte(`{"name":"smtp","port":1026}`)},
+							}, {
+								Name:  "ports_nesting_yaml",
+								Value: apiextensionsv1.JSON{Raw: []byte(`[{"deployment":{"name":"smtp","port":1026}}]`)},
 							},
 						},
 					},
 				},
 			},
 			ExpectOutput: `
-❗ WARNING: Cross namespace service claims are deprecated. Please use ` + "`tanzu service claim create`" + ` instead.
-🔎 Update workload:
-  1,  1   |---
-  2,  2   |apiVersion: carto.run/v1alpha1
-  3,  3   |kind: Workload
-  4,  4   |metadata:
-      5 + |  annotations:
-      6 + |    serviceclaims.supplychain.apps.x-tanzu.vmware.com/extensions: '{"kind":"ServiceClaimsExtension","apiVersion":"supplychain.apps.x-tanzu.vmware.com/v1alpha1","spec":{"serviceClaims":{"database":{"namespace":"my-prod-ns"}}}}'
-  5,  7   |  labels:
-  6,  8   |    apps.tanzu.vmware.com/workload-type: web
-  7,  9   |  name: my-workload
-  8, 10   |  namespace: default
-  9, 11   |spec:
- 10, 12   |  image: ubuntu:bionic
-     13 + |  serviceClaims:
-     14 + |  - name: database
-     15 + |    ref:
-     16 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
-     17 + |      kind: PostgreSQL
-     18 + |      name: my-prod-db
-👍 Updated workload "my-workload"
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  image: ubuntu:bionic
+     11 + |  params:
+     12 + |  - name: ports_json
+     13 + |    value: {name: smtp, port: 1026}
+     14 + |  - name: ports_nesting_yaml
+     15 + |    value: [{deployment: {name: smtp, port: 1026}}]
+👍 Created workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
 To get status: "tanzu apps workload get my-workload"
@@ -3131,8 +8415,69 @@ To get status: "tanzu apps workload get my-workload"
 `,
 		},
 		{
-			Name:         "create - serviceclaim with deprecation warning",
-			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-ns:my-prod-db", flags.YesFlagName},
+			Name: "dry run with param-yaml in flow style",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:bionic",
+				flags.ParamYamlFlagName, `ports_json={"name": "smtp", "port": 1026}`,
+				flags.YamlFlowParamsFlagName, flags.DryRunFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  labels:
+    apps.tanzu.vmware.com/workload-type: web
+  name: my-workload
+  namespace: default
+spec:
+  image: ubuntu:bionic
+  params:
+  - name: ports_json
+    value: {name: smtp, port: 1026}
+status:
+  supplyChainRef: {}
+`,
+		},
+		{
+			Name:         "dry run bare",
+			Args:         []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.BareFlagName, flags.DryRunFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: my-workload
+  namespace: default
+spec:
+  image: ubuntu:bionic
+`,
+		},
+		{
+			Name:         "dry run bare with type and app",
+			Args:         []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.TypeFlagName, "web", flags.AppFlagName, "my-app", flags.BareFlagName, flags.DryRunFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  labels:
+    app.kubernetes.io/part-of: my-app
+    apps.tanzu.vmware.com/workload-type: web
+  name: my-workload
+  namespace: default
+spec:
+  image: ubuntu:bionic
+`,
+		},
+		{
+			Name: "param-yaml after param wins",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:bionic",
+				flags.ParamFlagName, "enabled=true",
+				flags.ParamYamlFlagName, "enabled=true",
+				flags.YesFlagName},
 			GivenObjects: givenNamespaceDefault,
 			ExpectCreates: []client.Object{
 				&cartov1alpha1.Workload{
@@ -3142,52 +8487,82 @@ To get status: "tanzu apps workload get my-workload"
 						Labels: map[string]string{
 							apis.WorkloadTypeLabelName: "web",
 						},
-						Annotations: map[string]string{
-							apis.ServiceClaimAnnotationName: `{"kind":"ServiceClaimsExtension","apiVersion":"supplychain.apps.x-tanzu.vmware.com/v1alpha1","spec":{"serviceClaims":{"database":{"namespace":"my-prod-ns"}}}}`,
-						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{URL: "https://example.com/repo.git", Ref: cartov1alpha1.GitRef{Branch: "main"}},
-						},
-						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
+						Image: "ubuntu:bionic",
+						Params: []cartov1alpha1.Param{
 							{
-								Name: "database",
-								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
-									APIVersion: "services.tanzu.vmware.com/v1alpha1",
-									Kind:       "PostgreSQL",
-									Name:       "my-prod-db",
-								},
+								Name:  "enabled",
+								Value: apiextensionsv1.JSON{Raw: []byte(`true`)},
 							},
 						},
 					},
 				},
 			},
 			ExpectOutput: `
-❗ WARNING: Cross namespace service claims are deprecated. Please use ` + "`tanzu service claim create`" + ` instead.
 🔎 Create workload:
       1 + |---
       2 + |apiVersion: carto.run/v1alpha1
       3 + |kind: Workload
       4 + |metadata:
-      5 + |  annotations:
-      6 + |    serviceclaims.supplychain.apps.x-tanzu.vmware.com/extensions: '{"kind":"ServiceClaimsExtension","apiVersion":"supplychain.apps.x-tanzu.vmware.com/v1alpha1","spec":{"serviceClaims":{"database":{"namespace":"my-prod-ns"}}}}'
-      7 + |  labels:
-      8 + |    apps.tanzu.vmware.com/workload-type: web
-      9 + |  name: my-workload
-     10 + |  namespace: default
-     11 + |spec:
-     12 + |  serviceClaims:
-     13 + |  - name: database
-     14 + |    ref:
-     15 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
-     16 + |      kind: PostgreSQL
-     17 + |      name: my-prod-db
-     18 + |  source:
-     19 + |    git:
-     20 + |      ref:
-     21 + |        branch: main
-     22 + |      url: https://example.com/repo.git
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  image: ubuntu:bionic
+     11 + |  params:
+     12 + |  - name: enabled
+     13 + |    value: true
+👍 Created workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name: "param after param-yaml wins",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:bionic",
+				flags.ParamYamlFlagName, "enabled=true",
+				flags.ParamFlagName, "enabled=true",
+				flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+						Params: []cartov1alpha1.Param{
+							{
+								Name:  "enabled",
+								Value: apiextensionsv1.JSON{Raw: []byte(`"true"`)},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  image: ubuntu:bionic
+     11 + |  params:
+     12 + |  - name: enabled
+     13 + |    value: "true"
 👍 Created workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
@@ -3196,277 +8571,207 @@ To get status: "tanzu apps workload get my-workload"
 `,
 		},
 		{
-			Name: "update serviceAccountName via file",
-			Args: []string{flags.FilePathFlagName, "testdata/service-account-name.yaml", flags.YesFlagName},
-			GivenObjects: []client.Object{
-				parent.
-					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
-						d.Name("spring-petclinic")
-						d.AddLabel("preserve-me", "should-exist")
-					}),
-			},
-			ExpectUpdates: []client.Object{
+			Name:         "create from maven artifact using paramyaml",
+			Args:         []string{workloadName, flags.ParamYamlFlagName, `maven={"artifactId": "spring-petclinic", "version": "2.6.0", "groupId": "org.springframework.samples"}`, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
-						Name:      "spring-petclinic",
+						Name:      workloadName,
 						Labels: map[string]string{
-							"preserve-me":                         "should-exist",
-							"app.kubernetes.io/part-of":           "spring-petclinic",
-							"apps.tanzu.vmware.com/workload-type": "web",
+							apis.WorkloadTypeLabelName: "web",
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						ServiceAccountName: &serviceAccountName,
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: "https://github.com/sample-accelerators/spring-petclinic",
-								Ref: cartov1alpha1.GitRef{
-									Tag: "tap-1.1",
-								},
+						Params: []cartov1alpha1.Param{
+							{
+								Name:  "maven",
+								Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"spring-petclinic","groupId":"org.springframework.samples","version":"2.6.0"}`)},
 							},
 						},
 					},
 				},
 			},
 			ExpectOutput: `
-❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
-
-🔎 Update workload:
-...
-  2,  2   |apiVersion: carto.run/v1alpha1
-  3,  3   |kind: Workload
-  4,  4   |metadata:
-  5,  5   |  labels:
-      6 + |    app.kubernetes.io/part-of: spring-petclinic
-  6,  7   |    apps.tanzu.vmware.com/workload-type: web
-  7,  8   |    preserve-me: should-exist
-  8,  9   |  name: spring-petclinic
-  9, 10   |  namespace: default
- 10     - |spec: {}
-     11 + |spec:
-     12 + |  serviceAccountName: my-service-account
-     13 + |  source:
-     14 + |    git:
-     15 + |      ref:
-     16 + |        tag: tap-1.1
-     17 + |      url: https://github.com/sample-accelerators/spring-petclinic
-👍 Updated workload "spring-petclinic"
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  params:
+     11 + |  - name: maven
+     12 + |    value:
+     13 + |      artifactId: spring-petclinic
+     14 + |      groupId: org.springframework.samples
+     15 + |      version: 2.6.0
+👍 Created workload "my-workload"
 
-To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
-To get status: "tanzu apps workload get spring-petclinic"
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
 
 `,
 		},
 		{
-			Name: "delete serviceAccountName by setting to empty in file",
-			Args: []string{flags.FilePathFlagName, "testdata/no-service-account-name.yaml", flags.YesFlagName},
-			GivenObjects: []client.Object{
-				parent.
-					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
-						d.Name("spring-petclinic")
-						d.AddLabel("preserve-me", "should-exist")
-					}).SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-					d.ServiceAccountName(&serviceAccountName)
-				}),
-			},
-			ExpectUpdates: []client.Object{
+			Name:         "create from maven artifact using flags",
+			Args:         []string{workloadName, flags.MavenArtifactFlagName, "spring-petclinic", flags.MavenVersionFlagName, "2.6.0", flags.MavenGroupFlagName, "org.springframework.samples", flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
-						Name:      "spring-petclinic",
+						Name:      workloadName,
 						Labels: map[string]string{
-							"preserve-me":                         "should-exist",
-							"app.kubernetes.io/part-of":           "spring-petclinic",
-							"apps.tanzu.vmware.com/workload-type": "web",
+							apis.WorkloadTypeLabelName: "web",
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						ServiceAccountName: nil,
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: "https://github.com/sample-accelerators/spring-petclinic",
-								Ref: cartov1alpha1.GitRef{
-									Tag: "tap-1.1",
-								},
+						Params: []cartov1alpha1.Param{
+							{
+								Name:  "maven",
+								Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"spring-petclinic","groupId":"org.springframework.samples","version":"2.6.0"}`)},
 							},
 						},
 					},
 				},
 			},
 			ExpectOutput: `
-❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
-
-🔎 Update workload:
-...
-  2,  2   |apiVersion: carto.run/v1alpha1
-  3,  3   |kind: Workload
-  4,  4   |metadata:
-  5,  5   |  labels:
-      6 + |    app.kubernetes.io/part-of: spring-petclinic
-  6,  7   |    apps.tanzu.vmware.com/workload-type: web
-  7,  8   |    preserve-me: should-exist
-  8,  9   |  name: spring-petclinic
-  9, 10   |  namespace: default
- 10, 11   |spec:
- 11     - |  serviceAccountName: my-service-account
-     12 + |  source:
-     13 + |    git:
-     14 + |      ref:
-     15 + |        tag: tap-1.1
-     16 + |      url: https://github.com/sample-accelerators/spring-petclinic
-👍 Updated workload "spring-petclinic"
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  params:
+     11 + |  - name: maven
+     12 + |    value:
+     13 + |      artifactId: spring-petclinic
+     14 + |      groupId: org.springframework.samples
+     15 + |      version: 2.6.0
+👍 Created workload "my-workload"
 
-To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
-To get status: "tanzu apps workload get spring-petclinic"
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
 
 `,
 		},
 		{
-			Name: "updated serviceAccountName taking priority from flag",
-			Args: []string{flags.FilePathFlagName, "testdata/no-service-account-name.yaml", flags.ServiceAccountFlagName, serviceAccountNameUpdated, flags.YesFlagName},
-			GivenObjects: []client.Object{
-				parent.
-					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
-						d.Name("spring-petclinic")
-						d.AddLabel("preserve-me", "should-exist")
-					}).SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-					d.ServiceAccountName(&serviceAccountName)
-				}),
-			},
-			ExpectUpdates: []client.Object{
+			Name: "create from maven artifact taking priority from flags",
+			Args: []string{workloadName, flags.ParamYamlFlagName, `maven={"artifactId": "spring-petclinic-test", "version": "2.6.1", "groupId": "org.springframework.samples.test"}`,
+				flags.MavenArtifactFlagName, "spring-petclinic", flags.MavenVersionFlagName, "2.6.0", flags.MavenGroupFlagName, "org.springframework.samples", flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
-						Name:      "spring-petclinic",
+						Name:      workloadName,
 						Labels: map[string]string{
-							"preserve-me":                         "should-exist",
-							"app.kubernetes.io/part-of":           "spring-petclinic",
-							"apps.tanzu.vmware.com/workload-type": "web",
+							apis.WorkloadTypeLabelName: "web",
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						ServiceAccountName: &serviceAccountNameUpdated,
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: "https://github.com/sample-accelerators/spring-petclinic",
-								Ref: cartov1alpha1.GitRef{
-									Tag: "tap-1.1",
-								},
+						Params: []cartov1alpha1.Param{
+							{
+								Name:  "maven",
+								Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"spring-petclinic","groupId":"org.springframework.samples","version":"2.6.0"}`)},
 							},
 						},
 					},
 				},
 			},
 			ExpectOutput: `
-❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
-
-🔎 Update workload:
-...
-  2,  2   |apiVersion: carto.run/v1alpha1
-  3,  3   |kind: Workload
-  4,  4   |metadata:
-  5,  5   |  labels:
-      6 + |    app.kubernetes.io/part-of: spring-petclinic
-  6,  7   |    apps.tanzu.vmware.com/workload-type: web
-  7,  8   |    preserve-me: should-exist
-  8,  9   |  name: spring-petclinic
-  9, 10   |  namespace: default
- 10, 11   |spec:
- 11     - |  serviceAccountName: my-service-account
-     12 + |  serviceAccountName: my-service-account-updated
-     13 + |  source:
-     14 + |    git:
-     15 + |      ref:
-     16 + |        tag: tap-1.1
-     17 + |      url: https://github.com/sample-accelerators/spring-petclinic
-👍 Updated workload "spring-petclinic"
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  params:
+     11 + |  - name: maven
+     12 + |    value:
+     13 + |      artifactId: spring-petclinic
+     14 + |      groupId: org.springframework.samples
+     15 + |      version: 2.6.0
+❗ NOTICE: Maven configuration flags have overwritten values provided by "--params-yaml".
+👍 Created workload "my-workload"
 
-To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
-To get status: "tanzu apps workload get spring-petclinic"
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
 
 `,
 		},
 		{
-			Name: "delete serviceAccountName field",
-			Args: []string{flags.FilePathFlagName, "testdata/no-service-account-name.yaml", flags.YesFlagName},
-			GivenObjects: []client.Object{
-				parent.
-					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
-						d.Name("spring-petclinic")
-						d.AddLabel("preserve-me", "should-exist")
-					}).SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-					d.ServiceAccountName(&serviceAccountName)
-				}),
+			Name: "update from maven artifact taking priority from flags",
+			Args: []string{workloadName, flags.ParamYamlFlagName, `maven={"artifactId": "foo", "version": "1.0.0", "groupId": "bar"}`,
+				flags.MavenArtifactFlagName, "spring-petclinic-test", flags.MavenVersionFlagName, "2.6.1", flags.MavenGroupFlagName, "org.springframework.samples.test", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Params(cartov1alpha1.Param{
+							Name:  "maven",
+							Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"spring-petclinic","groupId":"org.springframework.samples","version":"2.6.0"}`)},
+						})
+					}),
 			},
 			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
-						Name:      "spring-petclinic",
+						Name:      workloadName,
 						Labels: map[string]string{
-							"preserve-me":                         "should-exist",
-							"app.kubernetes.io/part-of":           "spring-petclinic",
-							"apps.tanzu.vmware.com/workload-type": "web",
+							apis.WorkloadTypeLabelName: "web",
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: "https://github.com/sample-accelerators/spring-petclinic",
-								Ref: cartov1alpha1.GitRef{
-									Tag: "tap-1.1",
-								},
+						Params: []cartov1alpha1.Param{
+							{
+								Name:  "maven",
+								Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"spring-petclinic-test","groupId":"org.springframework.samples.test","version":"2.6.1"}`)},
 							},
 						},
 					},
 				},
 			},
 			ExpectOutput: `
-❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
-
 🔎 Update workload:
 ...
-  2,  2   |apiVersion: carto.run/v1alpha1
-  3,  3   |kind: Workload
-  4,  4   |metadata:
-  5,  5   |  labels:
-      6 + |    app.kubernetes.io/part-of: spring-petclinic
-  6,  7   |    apps.tanzu.vmware.com/workload-type: web
-  7,  8   |    preserve-me: should-exist
-  8,  9   |  name: spring-petclinic
-  9, 10   |  namespace: default
- 10, 11   |spec:
- 11     - |  serviceAccountName: my-service-account
-     12 + |  source:
-     13 + |    git:
-     14 + |      ref:
-     15 + |        tag: tap-1.1
-     16 + |      url: https://github.com/sample-accelerators/spring-petclinic
-👍 Updated workload "spring-petclinic"
+  9,  9   |spec:
+ 10, 10   |  params:
+ 11, 11   |  - name: maven
+ 12, 12   |    value:
+ 13     - |      artifactId: spring-petclinic
+ 14     - |      groupId: org.springframework.samples
+ 15     - |      version: 2.6.0
+     13 + |      artifactId: spring-petclinic-test
+     14 + |      groupId: org.springframework.samples.test
+     15 + |      version: 2.6.1
+❗ NOTICE: Maven configuration flags have overwritten values provided by "--params-yaml".
+👍 Updated workload "my-workload"
 
-To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
-To get status: "tanzu apps workload get spring-petclinic"
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
 
 `,
 		},
 		{
-			Name: "do not delete serviceAccountName when updating another field",
-			Args: []string{workloadName, flags.GitTagFlagName, "tap-1.1", flags.YesFlagName},
+			Name: "update workload to add maven param",
+			Args: []string{workloadName, flags.ParamYamlFlagName, `maven={"artifactId": "spring-petclinic", "version": "2.6.0", "groupId": "org.springframework.samples"}`, flags.YesFlagName},
 			GivenObjects: []client.Object{
 				parent.
-					SpecDie(
-						func(d *diecartov1alpha1.WorkloadSpecDie) {
-							d.ServiceAccountName(&serviceAccountName)
-							d.Source(&cartov1alpha1.Source{
-								Git: &cartov1alpha1.GitSource{
-									URL: "https://github.com/sample-accelerators/spring-petclinic",
-									Ref: cartov1alpha1.GitRef{
-										Branch: "main",
-									},
-								},
-							})
-						}),
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {}),
 			},
 			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
@@ -3478,14 +8783,10 @@ To get status: "tanzu apps workload get spring-petclinic"
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						ServiceAccountName: &serviceAccountName,
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: "https://github.com/sample-accelerators/spring-petclinic",
-								Ref: cartov1alpha1.GitRef{
-									Branch: "main",
-									Tag:    "tap-1.1",
-								},
+						Params: []cartov1alpha1.Param{
+							{
+								Name:  "maven",
+								Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"spring-petclinic","groupId":"org.springframework.samples","version":"2.6.0"}`)},
 							},
 						},
 					},
@@ -3494,12 +8795,18 @@ To get status: "tanzu apps workload get spring-petclinic"
 			ExpectOutput: `
 🔎 Update workload:
 ...
- 11, 11   |  source:
- 12, 12   |    git:
- 13, 13   |      ref:
- 14, 14   |        branch: main
-     15 + |        tag: tap-1.1
- 15, 16   |      url: https://github.com/sample-accelerators/spring-petclinic
+  5,  5   |  labels:
+  6,  6   |    apps.tanzu.vmware.com/workload-type: web
+  7,  7   |  name: my-workload
+  8,  8   |  namespace: default
+  9     - |spec: {}
+      9 + |spec:
+     10 + |  params:
+     11 + |  - name: maven
+     12 + |    value:
+     13 + |      artifactId: spring-petclinic
+     14 + |      groupId: org.springframework.samples
+     15 + |      version: 2.6.0
 👍 Updated workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
@@ -3508,22 +8815,11 @@ To get status: "tanzu apps workload get my-workload"
 `,
 		},
 		{
-			Name: "update serviceAccountName field via flag",
-			Args: []string{workloadName, flags.ServiceAccountFlagName, serviceAccountNameUpdated, flags.YesFlagName},
+			Name: "update workload to add maven through flags",
+			Args: []string{workloadName, flags.MavenArtifactFlagName, "spring-petclinic", flags.MavenVersionFlagName, "2.6.0", flags.MavenGroupFlagName, "org.springframework.samples", flags.MavenTypeFlagName, "jar", flags.YesFlagName},
 			GivenObjects: []client.Object{
 				parent.
-					SpecDie(
-						func(d *diecartov1alpha1.WorkloadSpecDie) {
-							d.ServiceAccountName(&serviceAccountName)
-							d.Source(&cartov1alpha1.Source{
-								Git: &cartov1alpha1.GitSource{
-									URL: "https://github.com/sample-accelerators/spring-petclinic",
-									Ref: cartov1alpha1.GitRef{
-										Branch: "main",
-									},
-								},
-							})
-						}),
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {}),
 			},
 			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
@@ -3535,13 +8831,10 @@ To get status: "tanzu apps workload get my-workload"
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						ServiceAccountName: &serviceAccountNameUpdated,
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: "https://github.com/sample-accelerators/spring-petclinic",
-								Ref: cartov1alpha1.GitRef{
-									Branch: "main",
-								},
+						Params: []cartov1alpha1.Param{
+							{
+								Name:  "maven",
+								Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"spring-petclinic","groupId":"org.springframework.samples","version":"2.6.0","type":"jar"}`)},
 							},
 						},
 					},
@@ -3550,17 +8843,19 @@ To get status: "tanzu apps workload get my-workload"
 			ExpectOutput: `
 🔎 Update workload:
 ...
+  5,  5   |  labels:
   6,  6   |    apps.tanzu.vmware.com/workload-type: web
   7,  7   |  name: my-workload
   8,  8   |  namespace: default
-  9,  9   |spec:
- 10     - |  serviceAccountName: my-service-account
-     10 + |  serviceAccountName: my-service-account-updated
- 11, 11   |  source:
- 12, 12   |    git:
- 13, 13   |      ref:
- 14, 14   |        branch: main
-...
+  9     - |spec: {}
+      9 + |spec:
+     10 + |  params:
+     11 + |  - name: maven
+     12 + |    value:
+     13 + |      artifactId: spring-petclinic
+     14 + |      groupId: org.springframework.samples
+     15 + |      type: jar
+     16 + |      version: 2.6.0
 👍 Updated workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
@@ -3569,22 +8864,16 @@ To get status: "tanzu apps workload get my-workload"
 `,
 		},
 		{
-			Name: "delete serviceAccountName via flag",
-			Args: []string{workloadName, flags.ServiceAccountFlagName, "", flags.YesFlagName},
+			Name: "update workload to change maven info through flags",
+			Args: []string{workloadName, flags.MavenVersionFlagName, "2.6.1", flags.MavenTypeFlagName, "jar", flags.YesFlagName},
 			GivenObjects: []client.Object{
 				parent.
-					SpecDie(
-						func(d *diecartov1alpha1.WorkloadSpecDie) {
-							d.ServiceAccountName(&serviceAccountName)
-							d.Source(&cartov1alpha1.Source{
-								Git: &cartov1alpha1.GitSource{
-									URL: "https://github.com/sample-accelerators/spring-petclinic",
-									Ref: cartov1alpha1.GitRef{
-										Branch: "main",
-									},
-								},
-							})
-						}),
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Params(cartov1alpha1.Param{
+							Name:  "maven",
+							Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"spring-petclinic","groupId":"org.springframework.samples","version":"2.6.0"}`)},
+						})
+					}),
 			},
 			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
@@ -3596,13 +8885,10 @@ To get status: "tanzu apps workload get my-workload"
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						ServiceAccountName: nil,
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: "https://github.com/sample-accelerators/spring-petclinic",
-								Ref: cartov1alpha1.GitRef{
-									Branch: "main",
-								},
+						Params: []cartov1alpha1.Param{
+							{
+								Name:  "maven",
+								Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"spring-petclinic","groupId":"org.springframework.samples","version":"2.6.1","type":"jar"}`)},
 							},
 						},
 					},
@@ -3611,16 +8897,13 @@ To get status: "tanzu apps workload get my-workload"
 			ExpectOutput: `
 🔎 Update workload:
 ...
-  6,  6   |    apps.tanzu.vmware.com/workload-type: web
-  7,  7   |  name: my-workload
-  8,  8   |  namespace: default
-  9,  9   |spec:
- 10     - |  serviceAccountName: my-service-account
- 11, 10   |  source:
- 12, 11   |    git:
- 13, 12   |      ref:
- 14, 13   |        branch: main
-...
+ 11, 11   |  - name: maven
+ 12, 12   |    value:
+ 13, 13   |      artifactId: spring-petclinic
+ 14, 14   |      groupId: org.springframework.samples
+ 15     - |      version: 2.6.0
+     15 + |      type: jar
+     16 + |      version: 2.6.1
 👍 Updated workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
@@ -3629,62 +8912,60 @@ To get status: "tanzu apps workload get my-workload"
 `,
 		},
 		{
-			Name:         "create with serviceAccountName",
-			Args:         []string{flags.FilePathFlagName, "testdata/service-account-name.yaml", flags.YesFlagName},
-			GivenObjects: givenNamespaceDefault,
-			ExpectCreates: []client.Object{
+			Name: "update workload to override maven info through params yaml",
+			Args: []string{workloadName, flags.ParamYamlFlagName, `maven={"artifactId": "spring-petclinic", "version": "2.6.0", "groupId": "org.springframework.samples"}`, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Params(cartov1alpha1.Param{
+							Name:  "maven",
+							Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"foo","groupId":"bar","version":"1.0.0","type":"baz"}`)},
+						})
+					}),
+			},
+			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
-						Name:      "spring-petclinic",
+						Name:      workloadName,
 						Labels: map[string]string{
-							apis.AppPartOfLabelName:               "spring-petclinic",
-							"apps.tanzu.vmware.com/workload-type": "web",
+							apis.WorkloadTypeLabelName: "web",
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						ServiceAccountName: &serviceAccountName,
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: "https://github.com/sample-accelerators/spring-petclinic",
-								Ref: cartov1alpha1.GitRef{
-									Tag: "tap-1.1",
-								},
+						Params: []cartov1alpha1.Param{
+							{
+								Name:  "maven",
+								Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"spring-petclinic","groupId":"org.springframework.samples","version":"2.6.0"}`)},
 							},
 						},
 					},
 				},
 			},
-			ExpectOutput: `
-❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
-
-🔎 Create workload:
-      1 + |---
-      2 + |apiVersion: carto.run/v1alpha1
-      3 + |kind: Workload
-      4 + |metadata:
-      5 + |  labels:
-      6 + |    app.kubernetes.io/part-of: spring-petclinic
-      7 + |    apps.tanzu.vmware.com/workload-type: web
-      8 + |  name: spring-petclinic
-      9 + |  namespace: default
-     10 + |spec:
-     11 + |  serviceAccountName: my-service-account
-     12 + |  source:
-     13 + |    git:
-     14 + |      ref:
-     15 + |        tag: tap-1.1
-     16 + |      url: https://github.com/sample-accelerators/spring-petclinic
-👍 Created workload "spring-petclinic"
+			ExpectOutput: `
+🔎 Update workload:
+...
+  9,  9   |spec:
+ 10, 10   |  params:
+ 11, 11   |  - name: maven
+ 12, 12   |    value:
+ 13     - |      artifactId: foo
+ 14     - |      groupId: bar
+ 15     - |      type: baz
+ 16     - |      version: 1.0.0
+     13 + |      artifactId: spring-petclinic
+     14 + |      groupId: org.springframework.samples
+     15 + |      version: 2.6.0
+👍 Updated workload "my-workload"
 
-To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
-To get status: "tanzu apps workload get spring-petclinic"
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
 
 `,
 		},
 		{
-			Name:         "create with serviceAccountName via flag",
-			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.ServiceAccountFlagName, serviceAccountName, flags.YesFlagName},
+			Name:         "create from maven artifact using paramyaml with type",
+			Args:         []string{workloadName, flags.ParamYamlFlagName, `maven={"artifactId": "spring-petclinic", "version": "2.6.0", "groupId": "org.springframework.samples", "type": "jar"}`, flags.YesFlagName},
 			GivenObjects: givenNamespaceDefault,
 			ExpectCreates: []client.Object{
 				&cartov1alpha1.Workload{
@@ -3696,13 +8977,10 @@ To get status: "tanzu apps workload get spring-petclinic"
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						ServiceAccountName: &serviceAccountName,
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: gitRepo,
-								Ref: cartov1alpha1.GitRef{
-									Branch: gitBranch,
-								},
+						Params: []cartov1alpha1.Param{
+							{
+								Name:  "maven",
+								Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"spring-petclinic","groupId":"org.springframework.samples","type":"jar","version":"2.6.0"}`)},
 							},
 						},
 					},
@@ -3719,12 +8997,13 @@ To get status: "tanzu apps workload get spring-petclinic"
       7 + |  name: my-workload
       8 + |  namespace: default
       9 + |spec:
-     10 + |  serviceAccountName: my-service-account
-     11 + |  source:
-     12 + |    git:
-     13 + |      ref:
-     14 + |        branch: main
-     15 + |      url: https://example.com/repo.git
+     10 + |  params:
+     11 + |  - name: maven
+     12 + |    value:
+     13 + |      artifactId: spring-petclinic
+     14 + |      groupId: org.springframework.samples
+     15 + |      type: jar
+     16 + |      version: 2.6.0
 👍 Created workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
@@ -3733,8 +9012,18 @@ To get status: "tanzu apps workload get my-workload"
 `,
 		},
 		{
-			Name:         "create with serviceAccountName from file and flag",
-			Args:         []string{flags.FilePathFlagName, "testdata/service-account-name.yaml", flags.ServiceAccountFlagName, serviceAccountNameUpdated, flags.YesFlagName},
+			ShouldError: true,
+			Name:        "fails create with multiple param-yaml using invalid json",
+			Args: []string{flags.FilePathFlagName, "testdata/param-yaml.yaml",
+				flags.ParamYamlFlagName, `ports_json={"name": "smtp", "port": 1026`,
+				flags.ParamYamlFlagName, `ports_nesting_yaml=- deployment:\n    name: smtp\n    port: 1026`,
+				flags.YesFlagName},
+			ExpectOutput: "",
+		},
+		{
+			Name: "create with multiple param-yaml using valid json and yaml from file",
+			Args: []string{flags.FilePathFlagName, "testdata/workload-param-yaml.yaml",
+				flags.YesFlagName},
 			GivenObjects: givenNamespaceDefault,
 			ExpectCreates: []client.Object{
 				&cartov1alpha1.Workload{
@@ -3747,15 +9036,23 @@ To get status: "tanzu apps workload get my-workload"
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						ServiceAccountName: &serviceAccountNameUpdated,
 						Source: &cartov1alpha1.Source{
 							Git: &cartov1alpha1.GitSource{
-								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								URL: "https://github.com/spring-projects/spring-petclinic.git",
 								Ref: cartov1alpha1.GitRef{
-									Tag: "tap-1.1",
+									Branch: "main",
 								},
 							},
 						},
+						Params: []cartov1alpha1.Param{
+							{
+								Name:  "ports",
+								Value: apiextensionsv1.JSON{Raw: []byte(`{"ports":[{"name":"http","port":8080,"protocol":"TCP","targetPort":8080},{"name":"https","port":8443,"protocol":"TCP","targetPort":8443}]}`)},
+							}, {
+								Name:  "services",
+								Value: apiextensionsv1.JSON{Raw: []byte(`[{"image":"mysql:5.7","name":"mysql"},{"image":"postgres:9.6","name":"postgres"}]`)},
+							},
+						},
 					},
 				},
 			},
@@ -3773,12 +9070,29 @@ To get status: "tanzu apps workload get my-workload"
       8 + |  name: spring-petclinic
       9 + |  namespace: default
      10 + |spec:
-     11 + |  serviceAccountName: my-service-account-updated
-     12 + |  source:
-     13 + |    git:
-     14 + |      ref:
-     15 + |        tag: tap-1.1
-     16 + |      url: https://github.com/sample-accelerators/spring-petclinic
+     11 + |  params:
+     12 + |  - name: ports
+     13 + |    value:
+     14 + |      ports:
+     15 + |      - name: http
+     16 + |        port: 8080
+     17 + |        protocol: TCP
+     18 + |        targetPort: 8080
+     19 + |      - name: https
+     20 + |        port: 8443
+     21 + |        protocol: TCP
+     22 + |        targetPort: 8443
+     23 + |  - name: services
+     24 + |    value:
+     25 + |    - image: mysql:5.7
+     26 + |      name: mysql
+     27 + |    - image: postgres:9.6
+     28 + |      name: postgres
+     29 + |  source:
+     30 + |    git:
+     31 + |      ref:
+     32 + |        branch: main
+     33 + |      url: https://github.com/spring-projects/spring-petclinic.git
 👍 Created workload "spring-petclinic"
 
 To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
@@ -3787,109 +9101,127 @@ To get status: "tanzu apps workload get spring-petclinic"
 `,
 		},
 		{
-			Name: "create with multiple param-yaml using valid json and yaml",
-			Args: []string{flags.FilePathFlagName, "testdata/param-yaml.yaml",
-				flags.ParamYamlFlagName, `ports_json={"name": "smtp", "port": 1026}`,
-				flags.ParamYamlFlagName, "ports_nesting_yaml=- deployment:\n    name: smtp\n    port: 1026",
-				flags.YesFlagName},
-			GivenObjects: givenNamespaceDefault,
-			ExpectCreates: []client.Object{
+			Name: "update - do not modify type in existing workload",
+			Args: []string{workloadName, flags.GitBranchFlagName, "accelerator", flags.GitCommitFlagName, "abcd1234", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name(workloadName)
+						d.Namespace(defaultNamespace)
+						d.Labels(map[string]string{
+							apis.WorkloadTypeLabelName: "my-type",
+						})
+					}).
+					SpecDie(
+						func(d *diecartov1alpha1.WorkloadSpecDie) {
+							d.Source(&cartov1alpha1.Source{
+								Git: &cartov1alpha1.GitSource{
+									URL: "https://github.com/sample-accelerators/spring-petclinic",
+									Ref: cartov1alpha1.GitRef{
+										Branch: "main",
+										Tag:    "tap-1.1",
+									},
+								},
+							})
+						}),
+			},
+			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
-						Name:      "spring-petclinic",
+						Name:      workloadName,
 						Labels: map[string]string{
-							apis.AppPartOfLabelName:               "spring-petclinic",
-							"apps.tanzu.vmware.com/workload-type": "web",
+							apis.WorkloadTypeLabelName: "my-type",
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Image: "my-reponame/my-image:my-tag",
-						Params: []cartov1alpha1.Param{
-							{
-								Name:  "ports_json",
-								Value: apiextensionsv1.JSON{Raw: []byte(`{"name":"smtp","port":1026}`)},
-							}, {
-								Name:  "ports_nesting_yaml",
-								Value: apiextensionsv1.JSON{Raw: []byte(`[{"deployment":{"name":"smtp","port":1026}}]`)},
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "accelerator",
+									Tag:    "tap-1.1",
+									Commit: "abcd1234",
+								},
 							},
 						},
 					},
 				},
 			},
 			ExpectOutput: `
-❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
-
-🔎 Create workload:
-      1 + |---
-      2 + |apiVersion: carto.run/v1alpha1
-      3 + |kind: Workload
-      4 + |metadata:
-      5 + |  labels:
-      6 + |    app.kubernetes.io/part-of: spring-petclinic
-      7 + |    apps.tanzu.vmware.com/workload-type: web
-      8 + |  name: spring-petclinic
-      9 + |  namespace: default
-     10 + |spec:
-     11 + |  image: my-reponame/my-image:my-tag
-     12 + |  params:
-     13 + |  - name: ports_json
-     14 + |    value:
-     15 + |      name: smtp
-     16 + |      port: 1026
-     17 + |  - name: ports_nesting_yaml
-     18 + |    value:
-     19 + |    - deployment:
-     20 + |        name: smtp
-     21 + |        port: 1026
-👍 Created workload "spring-petclinic"
+🔎 Update workload:
+...
+  9,  9   |spec:
+ 10, 10   |  source:
+ 11, 11   |    git:
+ 12, 12   |      ref:
+ 13     - |        branch: main
+     13 + |        branch: accelerator
+     14 + |        commit: abcd1234
+ 14, 15   |        tag: tap-1.1
+ 15, 16   |      url: https://github.com/sample-accelerators/spring-petclinic
+👍 Updated workload "my-workload"
 
-To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
-To get status: "tanzu apps workload get spring-petclinic"
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
 
 `,
 		},
 		{
-			Name:         "create from maven artifact using paramyaml",
-			Args:         []string{workloadName, flags.ParamYamlFlagName, `maven={"artifactId": "spring-petclinic", "version": "2.6.0", "groupId": "org.springframework.samples"}`, flags.YesFlagName},
-			GivenObjects: givenNamespaceDefault,
-			ExpectCreates: []client.Object{
+			Name: "update - do not default type in existing workload",
+			Args: []string{workloadName, flags.GitBranchFlagName, "accelerator", flags.GitCommitFlagName, "abcd1234", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name(workloadName)
+						d.Namespace(defaultNamespace)
+					}).
+					SpecDie(
+						func(d *diecartov1alpha1.WorkloadSpecDie) {
+							d.Source(&cartov1alpha1.Source{
+								Git: &cartov1alpha1.GitSource{
+									URL: "https://github.com/sample-accelerators/spring-petclinic",
+									Ref: cartov1alpha1.GitRef{
+										Branch: "main",
+										Tag:    "tap-1.1",
+									},
+								},
+							})
+						}),
+			},
+			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
 						Name:      workloadName,
-						Labels: map[string]string{
-							apis.WorkloadTypeLabelName: "web",
-						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Params: []cartov1alpha1.Param{
-							{
-								Name:  "maven",
-								Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"spring-petclinic","groupId":"org.springframework.samples","version":"2.6.0"}`)},
-							},
-						},
-					},
-				},
-			},
-			ExpectOutput: `
-🔎 Create workload:
-      1 + |---
-      2 + |apiVersion: carto.run/v1alpha1
-      3 + |kind: Workload
-      4 + |metadata:
-      5 + |  labels:
-      6 + |    apps.tanzu.vmware.com/workload-type: web
-      7 + |  name: my-workload
-      8 + |  namespace: default
-      9 + |spec:
-     10 + |  params:
-     11 + |  - name: maven
-     12 + |    value:
-     13 + |      artifactId: spring-petclinic
-     14 + |      groupId: org.springframework.samples
-     15 + |      version: 2.6.0
-👍 Created workload "my-workload"
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "accelerator",
+									Tag:    "tap-1.1",
+									Commit: "abcd1234",
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+🔎 Update workload:
+...
+  7,  7   |spec:
+  8,  8   |  source:
+  9,  9   |    git:
+ 10, 10   |      ref:
+ 11     - |        branch: main
+     11 + |        branch: accelerator
+     12 + |        commit: abcd1234
+ 12, 13   |        tag: tap-1.1
+ 13, 14   |      url: https://github.com/sample-accelerators/spring-petclinic
+👍 Updated workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
 To get status: "tanzu apps workload get my-workload"
@@ -3897,10 +9229,24 @@ To get status: "tanzu apps workload get my-workload"
 `,
 		},
 		{
-			Name:         "create from maven artifact using flags",
-			Args:         []string{workloadName, flags.MavenArtifactFlagName, "spring-petclinic", flags.MavenVersionFlagName, "2.6.0", flags.MavenGroupFlagName, "org.springframework.samples", flags.YesFlagName},
-			GivenObjects: givenNamespaceDefault,
-			ExpectCreates: []client.Object{
+			Name: "update git fields",
+			Args: []string{workloadName, flags.GitBranchFlagName, "accelerator", flags.GitCommitFlagName, "abcd1234", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(
+						func(d *diecartov1alpha1.WorkloadSpecDie) {
+							d.Source(&cartov1alpha1.Source{
+								Git: &cartov1alpha1.GitSource{
+									URL: "https://github.com/sample-accelerators/spring-petclinic",
+									Ref: cartov1alpha1.GitRef{
+										Branch: "main",
+										Tag:    "tap-1.1",
+									},
+								},
+							})
+						}),
+			},
+			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
@@ -3910,33 +9256,32 @@ To get status: "tanzu apps workload get my-workload"
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Params: []cartov1alpha1.Param{
-							{
-								Name:  "maven",
-								Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"spring-petclinic","groupId":"org.springframework.samples","version":"2.6.0"}`)},
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "accelerator",
+									Tag:    "tap-1.1",
+									Commit: "abcd1234",
+								},
 							},
 						},
 					},
 				},
 			},
 			ExpectOutput: `
-🔎 Create workload:
-      1 + |---
-      2 + |apiVersion: carto.run/v1alpha1
-      3 + |kind: Workload
-      4 + |metadata:
-      5 + |  labels:
-      6 + |    apps.tanzu.vmware.com/workload-type: web
-      7 + |  name: my-workload
-      8 + |  namespace: default
-      9 + |spec:
-     10 + |  params:
-     11 + |  - name: maven
-     12 + |    value:
-     13 + |      artifactId: spring-petclinic
-     14 + |      groupId: org.springframework.samples
-     15 + |      version: 2.6.0
-👍 Created workload "my-workload"
+🔎 Update workload:
+...
+  9,  9   |spec:
+ 10, 10   |  source:
+ 11, 11   |    git:
+ 12, 12   |      ref:
+ 13     - |        branch: main
+     13 + |        branch: accelerator
+     14 + |        commit: abcd1234
+ 14, 15   |        tag: tap-1.1
+ 15, 16   |      url: https://github.com/sample-accelerators/spring-petclinic
+👍 Updated workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
 To get status: "tanzu apps workload get my-workload"
@@ -3944,24 +9289,38 @@ To get status: "tanzu apps workload get my-workload"
 `,
 		},
 		{
-			Name: "create from maven artifact taking priority from flags",
-			Args: []string{workloadName, flags.ParamYamlFlagName, `maven={"artifactId": "spring-petclinic-test", "version": "2.6.1", "groupId": "org.springframework.samples.test"}`,
-				flags.MavenArtifactFlagName, "spring-petclinic", flags.MavenVersionFlagName, "2.6.0", flags.MavenGroupFlagName, "org.springframework.samples", flags.YesFlagName},
-			GivenObjects: givenNamespaceDefault,
+			Name: "git source with namespace from env var",
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				os.Setenv("TANZU_APPS_NAMESPACE", "my-custom-namespace")
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				os.Unsetenv("TANZU_APPS_NAMESPACE")
+				return nil
+			},
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				diecorev1.NamespaceBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("my-custom-namespace")
+					}),
+			},
 			ExpectCreates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
-						Namespace: defaultNamespace,
+						Namespace: "my-custom-namespace",
 						Name:      workloadName,
 						Labels: map[string]string{
 							apis.WorkloadTypeLabelName: "web",
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Params: []cartov1alpha1.Param{
-							{
-								Name:  "maven",
-								Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"spring-petclinic","groupId":"org.springframework.samples","version":"2.6.0"}`)},
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
 							},
 						},
 					},
@@ -3976,83 +9335,114 @@ To get status: "tanzu apps workload get my-workload"
       5 + |  labels:
       6 + |    apps.tanzu.vmware.com/workload-type: web
       7 + |  name: my-workload
-      8 + |  namespace: default
+      8 + |  namespace: my-custom-namespace
       9 + |spec:
-     10 + |  params:
-     11 + |  - name: maven
-     12 + |    value:
-     13 + |      artifactId: spring-petclinic
-     14 + |      groupId: org.springframework.samples
-     15 + |      version: 2.6.0
-❗ NOTICE: Maven configuration flags have overwritten values provided by "--params-yaml".
+     10 + |  source:
+     11 + |    git:
+     12 + |      ref:
+     13 + |        branch: main
+     14 + |      url: https://example.com/repo.git
 👍 Created workload "my-workload"
 
-To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
-To get status: "tanzu apps workload get my-workload"
+To see logs:   "tanzu apps workload tail my-workload --namespace my-custom-namespace --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload --namespace my-custom-namespace"
 
 `,
-		},
-		{
-			Name: "update from maven artifact taking priority from flags",
-			Args: []string{workloadName, flags.ParamYamlFlagName, `maven={"artifactId": "foo", "version": "1.0.0", "groupId": "bar"}`,
-				flags.MavenArtifactFlagName, "spring-petclinic-test", flags.MavenVersionFlagName, "2.6.1", flags.MavenGroupFlagName, "org.springframework.samples.test", flags.YesFlagName},
-			GivenObjects: []client.Object{
-				parent.
-					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-						d.Params(cartov1alpha1.Param{
-							Name:  "maven",
-							Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"spring-petclinic","groupId":"org.springframework.samples","version":"2.6.0"}`)},
-						})
-					}),
-			},
-			ExpectUpdates: []client.Object{
+		}, {
+			Name: "git source with profile",
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				home := t.TempDir()
+				t.Setenv("HOME", home)
+				dir := filepath.Join(home, ".config", "tanzu", "apps")
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					return ctx, err
+				}
+				profiles := `
+java-web:
+  type: web-java
+  service-account: java-sa
+  build-env:
+  - BP_JVM_VERSION=17
+  - BP_MAVEN_BUILD_ARGUMENTS=-DskipTests
+`
+				return ctx, os.WriteFile(filepath.Join(dir, "profiles.yaml"), []byte(profiles), 0644)
+			},
+			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.ProfileFlagName, "java-web", flags.ServiceAccountFlagName, serviceAccountName, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
 						Name:      workloadName,
 						Labels: map[string]string{
-							apis.WorkloadTypeLabelName: "web",
+							apis.WorkloadTypeLabelName: "web-java",
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Params: []cartov1alpha1.Param{
-							{
-								Name:  "maven",
-								Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"spring-petclinic-test","groupId":"org.springframework.samples.test","version":"2.6.1"}`)},
+						ServiceAccountName: &serviceAccountName,
+						Build: &cartov1alpha1.WorkloadBuild{
+							Env: []corev1.EnvVar{
+								{Name: "BP_JVM_VERSION", Value: "17"},
+								{Name: "BP_MAVEN_BUILD_ARGUMENTS", Value: "-DskipTests"},
+							},
+						},
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
 							},
 						},
 					},
 				},
 			},
-			ExpectOutput: `
-🔎 Update workload:
-...
-  9,  9   |spec:
- 10, 10   |  params:
- 11, 11   |  - name: maven
- 12, 12   |    value:
- 13     - |      artifactId: spring-petclinic
- 14     - |      groupId: org.springframework.samples
- 15     - |      version: 2.6.0
-     13 + |      artifactId: spring-petclinic-test
-     14 + |      groupId: org.springframework.samples.test
-     15 + |      version: 2.6.1
-❗ NOTICE: Maven configuration flags have overwritten values provided by "--params-yaml".
-👍 Updated workload "my-workload"
-
-To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
-To get status: "tanzu apps workload get my-workload"
-
-`,
-		},
-		{
-			Name: "update workload to add maven param",
-			Args: []string{workloadName, flags.ParamYamlFlagName, `maven={"artifactId": "spring-petclinic", "version": "2.6.0", "groupId": "org.springframework.samples"}`, flags.YesFlagName},
-			GivenObjects: []client.Object{
-				parent.
-					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {}),
+		}, {
+			Name: "profile not found",
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				home := t.TempDir()
+				t.Setenv("HOME", home)
+				dir := filepath.Join(home, ".config", "tanzu", "apps")
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					return ctx, err
+				}
+				return ctx, os.WriteFile(filepath.Join(dir, "profiles.yaml"), []byte("java-web:\n  type: web-java\n"), 0644)
 			},
-			ExpectUpdates: []client.Object{
+			Args:        []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.ProfileFlagName, "python", flags.YesFlagName},
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := "profile not found in"; err == nil || !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected error to contain %q, got %v", expected, err)
+				}
+			},
+		}, {
+			Name: "profile with unknown flag",
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				home := t.TempDir()
+				t.Setenv("HOME", home)
+				dir := filepath.Join(home, ".config", "tanzu", "apps")
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					return ctx, err
+				}
+				return ctx, os.WriteFile(filepath.Join(dir, "profiles.yaml"), []byte("java-web:\n  jvm: \"17\"\n"), 0644)
+			},
+			Args:        []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.ProfileFlagName, "java-web", flags.YesFlagName},
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := `profile "java-web" sets an unknown flag`; err == nil || !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected error to contain %q, got %v", expected, err)
+				}
+			},
+		}, {
+			Name: "git repo shorthand",
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				home := t.TempDir()
+				t.Setenv("HOME", home)
+				return ctx, nil
+			},
+			Args:         []string{workloadName, flags.GitRepoFlagName, "gh:my-org/my-repo", flags.GitBranchFlagName, gitBranch, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
@@ -4062,45 +9452,31 @@ To get status: "tanzu apps workload get my-workload"
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Params: []cartov1alpha1.Param{
-							{
-								Name:  "maven",
-								Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"spring-petclinic","groupId":"org.springframework.samples","version":"2.6.0"}`)},
-							},
-						},
-					},
-				},
-			},
-			ExpectOutput: `
-🔎 Update workload:
-...
-  5,  5   |  labels:
-  6,  6   |    apps.tanzu.vmware.com/workload-type: web
-  7,  7   |  name: my-workload
-  8,  8   |  namespace: default
-  9     - |spec: {}
-      9 + |spec:
-     10 + |  params:
-     11 + |  - name: maven
-     12 + |    value:
-     13 + |      artifactId: spring-petclinic
-     14 + |      groupId: org.springframework.samples
-     15 + |      version: 2.6.0
-👍 Updated workload "my-workload"
-
-To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
-To get status: "tanzu apps workload get my-workload"
-
-`,
-		},
-		{
-			Name: "update workload to add maven through flags",
-			Args: []string{workloadName, flags.MavenArtifactFlagName, "spring-petclinic", flags.MavenVersionFlagName, "2.6.0", flags.MavenGroupFlagName, "org.springframework.samples", flags.MavenTypeFlagName, "jar", flags.YesFlagName},
-			GivenObjects: []client.Object{
-				parent.
-					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {}),
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/my-org/my-repo.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
 			},
-			ExpectUpdates: []client.Object{
+		}, {
+			Name: "git repo shorthand from git hosts file",
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				home := t.TempDir()
+				t.Setenv("HOME", home)
+				dir := filepath.Join(home, ".config", "tanzu", "apps")
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					return ctx, err
+				}
+				return ctx, os.WriteFile(filepath.Join(dir, "git-hosts.yaml"), []byte("ghe: https://github.example.com\n"), 0644)
+			},
+			Args:         []string{workloadName, flags.GitRepoFlagName, "ghe:my-org/my-repo", flags.GitBranchFlagName, gitBranch, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
@@ -4110,51 +9486,43 @@ To get status: "tanzu apps workload get my-workload"
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Params: []cartov1alpha1.Param{
-							{
-								Name:  "maven",
-								Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"spring-petclinic","groupId":"org.springframework.samples","version":"2.6.0","type":"jar"}`)},
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.example.com/my-org/my-repo.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
 							},
 						},
 					},
 				},
 			},
-			ExpectOutput: `
-🔎 Update workload:
-...
-  5,  5   |  labels:
-  6,  6   |    apps.tanzu.vmware.com/workload-type: web
-  7,  7   |  name: my-workload
-  8,  8   |  namespace: default
-  9     - |spec: {}
-      9 + |spec:
-     10 + |  params:
-     11 + |  - name: maven
-     12 + |    value:
-     13 + |      artifactId: spring-petclinic
-     14 + |      groupId: org.springframework.samples
-     15 + |      type: jar
-     16 + |      version: 2.6.0
-👍 Updated workload "my-workload"
-
-To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
-To get status: "tanzu apps workload get my-workload"
-
-`,
-		},
-		{
-			Name: "update workload to change maven info through flags",
-			Args: []string{workloadName, flags.MavenVersionFlagName, "2.6.1", flags.MavenTypeFlagName, "jar", flags.YesFlagName},
-			GivenObjects: []client.Object{
-				parent.
-					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-						d.Params(cartov1alpha1.Param{
-							Name:  "maven",
-							Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"spring-petclinic","groupId":"org.springframework.samples","version":"2.6.0"}`)},
-						})
-					}),
+		}, {
+			Name: "invalid git repo shorthand",
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				t.Setenv("HOME", t.TempDir())
+				return ctx, nil
 			},
-			ExpectUpdates: []client.Object{
+			Args:        []string{workloadName, flags.GitRepoFlagName, "gl:my-repo", flags.GitBranchFlagName, gitBranch, flags.YesFlagName},
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := "expected gl:<org>/<repo>"; err == nil || !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected error to contain %q, got %v", expected, err)
+				}
+			},
+		}, {
+			Name: "git source with default namespace from env var",
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				os.Setenv("TANZU_APPS_NAMESPACE", defaultNamespace)
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				os.Unsetenv("TANZU_APPS_NAMESPACE")
+				return nil
+			},
+			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
@@ -4164,26 +9532,34 @@ To get status: "tanzu apps workload get my-workload"
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Params: []cartov1alpha1.Param{
-							{
-								Name:  "maven",
-								Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"spring-petclinic","groupId":"org.springframework.samples","version":"2.6.1","type":"jar"}`)},
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
 							},
 						},
 					},
 				},
 			},
 			ExpectOutput: `
-🔎 Update workload:
-...
- 11, 11   |  - name: maven
- 12, 12   |    value:
- 13, 13   |      artifactId: spring-petclinic
- 14, 14   |      groupId: org.springframework.samples
- 15     - |      version: 2.6.0
-     15 + |      type: jar
-     16 + |      version: 2.6.1
-👍 Updated workload "my-workload"
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  source:
+     11 + |    git:
+     12 + |      ref:
+     13 + |        branch: main
+     14 + |      url: https://example.com/repo.git
+👍 Created workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
 To get status: "tanzu apps workload get my-workload"
@@ -4191,18 +9567,18 @@ To get status: "tanzu apps workload get my-workload"
 `,
 		},
 		{
-			Name: "update workload to override maven info through params yaml",
-			Args: []string{workloadName, flags.ParamYamlFlagName, `maven={"artifactId": "spring-petclinic", "version": "2.6.0", "groupId": "org.springframework.samples"}`, flags.YesFlagName},
-			GivenObjects: []client.Object{
-				parent.
-					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-						d.Params(cartov1alpha1.Param{
-							Name:  "maven",
-							Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"foo","groupId":"bar","version":"1.0.0","type":"baz"}`)},
-						})
-					}),
+			Name: "git source with non-allowed env var",
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				os.Setenv("TANZU_APPS_LABEL", "foo=var")
+				return ctx, nil
 			},
-			ExpectUpdates: []client.Object{
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				os.Unsetenv("TANZU_APPS_LABEL")
+				return nil
+			},
+			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
@@ -4212,39 +9588,52 @@ To get status: "tanzu apps workload get my-workload"
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Params: []cartov1alpha1.Param{
-							{
-								Name:  "maven",
-								Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"spring-petclinic","groupId":"org.springframework.samples","version":"2.6.0"}`)},
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
 							},
 						},
 					},
 				},
 			},
 			ExpectOutput: `
-🔎 Update workload:
-...
-  9,  9   |spec:
- 10, 10   |  params:
- 11, 11   |  - name: maven
- 12, 12   |    value:
- 13     - |      artifactId: foo
- 14     - |      groupId: bar
- 15     - |      type: baz
- 16     - |      version: 1.0.0
-     13 + |      artifactId: spring-petclinic
-     14 + |      groupId: org.springframework.samples
-     15 + |      version: 2.6.0
-👍 Updated workload "my-workload"
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  source:
+     11 + |    git:
+     12 + |      ref:
+     13 + |        branch: main
+     14 + |      url: https://example.com/repo.git
+👍 Created workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
 To get status: "tanzu apps workload get my-workload"
 
 `,
-		},
-		{
-			Name:         "create from maven artifact using paramyaml with type",
-			Args:         []string{workloadName, flags.ParamYamlFlagName, `maven={"artifactId": "spring-petclinic", "version": "2.6.0", "groupId": "org.springframework.samples", "type": "jar"}`, flags.YesFlagName},
+		}, {
+			Name: "git source with allowed and non-allowed env var",
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				os.Setenv("TANZU_APPS_LABEL", "foo=var")
+				os.Setenv("TANZU_APPS_TYPE", "web")
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				os.Unsetenv("TANZU_APPS_LABEL")
+				os.Unsetenv("TANZU_APPS_TYPE")
+				return nil
+			},
+			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName},
 			GivenObjects: givenNamespaceDefault,
 			ExpectCreates: []client.Object{
 				&cartov1alpha1.Workload{
@@ -4252,14 +9641,16 @@ To get status: "tanzu apps workload get my-workload"
 						Namespace: defaultNamespace,
 						Name:      workloadName,
 						Labels: map[string]string{
-							apis.WorkloadTypeLabelName: "web",
+							"apps.tanzu.vmware.com/workload-type": "web",
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Params: []cartov1alpha1.Param{
-							{
-								Name:  "maven",
-								Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"spring-petclinic","groupId":"org.springframework.samples","type":"jar","version":"2.6.0"}`)},
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
 							},
 						},
 					},
@@ -4276,120 +9667,87 @@ To get status: "tanzu apps workload get my-workload"
       7 + |  name: my-workload
       8 + |  namespace: default
       9 + |spec:
-     10 + |  params:
-     11 + |  - name: maven
-     12 + |    value:
-     13 + |      artifactId: spring-petclinic
-     14 + |      groupId: org.springframework.samples
-     15 + |      type: jar
-     16 + |      version: 2.6.0
+     10 + |  source:
+     11 + |    git:
+     12 + |      ref:
+     13 + |        branch: main
+     14 + |      url: https://example.com/repo.git
 👍 Created workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
 To get status: "tanzu apps workload get my-workload"
 
 `,
-		},
-		{
-			ShouldError: true,
-			Name:        "fails create with multiple param-yaml using invalid json",
-			Args: []string{flags.FilePathFlagName, "testdata/param-yaml.yaml",
-				flags.ParamYamlFlagName, `ports_json={"name": "smtp", "port": 1026`,
-				flags.ParamYamlFlagName, `ports_nesting_yaml=- deployment:\n    name: smtp\n    port: 1026`,
-				flags.YesFlagName},
-			ExpectOutput: "",
-		},
-		{
-			Name: "create with multiple param-yaml using valid json and yaml from file",
-			Args: []string{flags.FilePathFlagName, "testdata/workload-param-yaml.yaml",
-				flags.YesFlagName},
+		}, {
+			Name: "git source with allowed env var overwritten",
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				os.Setenv("TANZU_APPS_TYPE", "jar")
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				os.Unsetenv("TANZU_APPS_TYPE")
+				return nil
+			},
+			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.TypeFlagName, "web", flags.YesFlagName},
 			GivenObjects: givenNamespaceDefault,
 			ExpectCreates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
-						Name:      "spring-petclinic",
+						Name:      workloadName,
 						Labels: map[string]string{
-							apis.AppPartOfLabelName:               "spring-petclinic",
 							"apps.tanzu.vmware.com/workload-type": "web",
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
 						Source: &cartov1alpha1.Source{
 							Git: &cartov1alpha1.GitSource{
-								URL: "https://github.com/spring-projects/spring-petclinic.git",
-								Ref: cartov1alpha1.GitRef{
-									Branch: "main",
-								},
-							},
-						},
-						Params: []cartov1alpha1.Param{
-							{
-								Name:  "ports",
-								Value: apiextensionsv1.JSON{Raw: []byte(`{"ports":[{"name":"http","port":8080,"protocol":"TCP","targetPort":8080},{"name":"https","port":8443,"protocol":"TCP","targetPort":8443}]}`)},
-							}, {
-								Name:  "services",
-								Value: apiextensionsv1.JSON{Raw: []byte(`[{"image":"mysql:5.7","name":"mysql"},{"image":"postgres:9.6","name":"postgres"}]`)},
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
 							},
 						},
 					},
 				},
 			},
 			ExpectOutput: `
-❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
-
 🔎 Create workload:
       1 + |---
       2 + |apiVersion: carto.run/v1alpha1
       3 + |kind: Workload
       4 + |metadata:
       5 + |  labels:
-      6 + |    app.kubernetes.io/part-of: spring-petclinic
-      7 + |    apps.tanzu.vmware.com/workload-type: web
-      8 + |  name: spring-petclinic
-      9 + |  namespace: default
-     10 + |spec:
-     11 + |  params:
-     12 + |  - name: ports
-     13 + |    value:
-     14 + |      ports:
-     15 + |      - name: http
-     16 + |        port: 8080
-     17 + |        protocol: TCP
-     18 + |        targetPort: 8080
-     19 + |      - name: https
-     20 + |        port: 8443
-     21 + |        protocol: TCP
-     22 + |        targetPort: 8443
-     23 + |  - name: services
-     24 + |    value:
-     25 + |    - image: mysql:5.7
-     26 + |      name: mysql
-     27 + |    - image: postgres:9.6
-     28 + |      name: postgres
-     29 + |  source:
-     30 + |    git:
-     31 + |      ref:
-     32 + |        branch: main
-     33 + |      url: https://github.com/spring-projects/spring-petclinic.git
-👍 Created workload "spring-petclinic"
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  source:
+     11 + |    git:
+     12 + |      ref:
+     13 + |        branch: main
+     14 + |      url: https://example.com/repo.git
+👍 Created workload "my-workload"
 
-To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
-To get status: "tanzu apps workload get spring-petclinic"
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
 
 `,
-		},
-		{
-			Name: "update - do not modify type in existing workload",
-			Args: []string{workloadName, flags.GitBranchFlagName, "accelerator", flags.GitCommitFlagName, "abcd1234", flags.YesFlagName},
+		}, {
+			Name: "update type via allowed env var",
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				os.Setenv("TANZU_APPS_TYPE", "web")
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				os.Unsetenv("TANZU_APPS_TYPE")
+				return nil
+			},
+			Args: []string{workloadName, flags.YesFlagName},
 			GivenObjects: []client.Object{
-				diecartov1alpha1.WorkloadBlank.
+				parent.
 					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
-						d.Name(workloadName)
-						d.Namespace(defaultNamespace)
-						d.Labels(map[string]string{
-							apis.WorkloadTypeLabelName: "my-type",
-						})
+						d.AddLabel("apps.tanzu.vmware.com/workload-type", "jar")
 					}).
 					SpecDie(
 						func(d *diecartov1alpha1.WorkloadSpecDie) {
@@ -4398,7 +9756,6 @@ To get status: "tanzu apps workload get spring-petclinic"
 									URL: "https://github.com/sample-accelerators/spring-petclinic",
 									Ref: cartov1alpha1.GitRef{
 										Branch: "main",
-										Tag:    "tap-1.1",
 									},
 								},
 							})
@@ -4410,7 +9767,7 @@ To get status: "tanzu apps workload get spring-petclinic"
 						Namespace: defaultNamespace,
 						Name:      workloadName,
 						Labels: map[string]string{
-							apis.WorkloadTypeLabelName: "my-type",
+							"apps.tanzu.vmware.com/workload-type": "web",
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
@@ -4418,9 +9775,7 @@ To get status: "tanzu apps workload get spring-petclinic"
 							Git: &cartov1alpha1.GitSource{
 								URL: "https://github.com/sample-accelerators/spring-petclinic",
 								Ref: cartov1alpha1.GitRef{
-									Branch: "accelerator",
-									Tag:    "tap-1.1",
-									Commit: "abcd1234",
+									Branch: "main",
 								},
 							},
 						},
@@ -4430,15 +9785,17 @@ To get status: "tanzu apps workload get spring-petclinic"
 			ExpectOutput: `
 🔎 Update workload:
 ...
+  2,  2   |apiVersion: carto.run/v1alpha1
+  3,  3   |kind: Workload
+  4,  4   |metadata:
+  5,  5   |  labels:
+  6     - |    apps.tanzu.vmware.com/workload-type: jar
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+  7,  7   |  name: my-workload
+  8,  8   |  namespace: default
   9,  9   |spec:
  10, 10   |  source:
- 11, 11   |    git:
- 12, 12   |      ref:
- 13     - |        branch: main
-     13 + |        branch: accelerator
-     14 + |        commit: abcd1234
- 14, 15   |        tag: tap-1.1
- 15, 16   |      url: https://github.com/sample-accelerators/spring-petclinic
+...
 👍 Updated workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
@@ -4447,14 +9804,10 @@ To get status: "tanzu apps workload get my-workload"
 `,
 		},
 		{
-			Name: "update - do not default type in existing workload",
-			Args: []string{workloadName, flags.GitBranchFlagName, "accelerator", flags.GitCommitFlagName, "abcd1234", flags.YesFlagName},
+			Name: "update - remove git tag by setting to empty in flags",
+			Args: []string{workloadName, flags.GitTagFlagName, "", flags.YesFlagName},
 			GivenObjects: []client.Object{
-				diecartov1alpha1.WorkloadBlank.
-					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
-						d.Name(workloadName)
-						d.Namespace(defaultNamespace)
-					}).
+				parent.
 					SpecDie(
 						func(d *diecartov1alpha1.WorkloadSpecDie) {
 							d.Source(&cartov1alpha1.Source{
@@ -4462,7 +9815,7 @@ To get status: "tanzu apps workload get my-workload"
 									URL: "https://github.com/sample-accelerators/spring-petclinic",
 									Ref: cartov1alpha1.GitRef{
 										Branch: "main",
-										Tag:    "tap-1.1",
+										Tag:    "v0.1.0",
 									},
 								},
 							})
@@ -4473,15 +9826,16 @@ To get status: "tanzu apps workload get my-workload"
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
 						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
 						Source: &cartov1alpha1.Source{
 							Git: &cartov1alpha1.GitSource{
 								URL: "https://github.com/sample-accelerators/spring-petclinic",
 								Ref: cartov1alpha1.GitRef{
-									Branch: "accelerator",
-									Tag:    "tap-1.1",
-									Commit: "abcd1234",
+									Branch: "main",
 								},
 							},
 						},
@@ -4491,15 +9845,12 @@ To get status: "tanzu apps workload get my-workload"
 			ExpectOutput: `
 🔎 Update workload:
 ...
-  7,  7   |spec:
-  8,  8   |  source:
-  9,  9   |    git:
- 10, 10   |      ref:
- 11     - |        branch: main
-     11 + |        branch: accelerator
-     12 + |        commit: abcd1234
- 12, 13   |        tag: tap-1.1
- 13, 14   |      url: https://github.com/sample-accelerators/spring-petclinic
+ 10, 10   |  source:
+ 11, 11   |    git:
+ 12, 12   |      ref:
+ 13, 13   |        branch: main
+ 14     - |        tag: v0.1.0
+ 15, 14   |      url: https://github.com/sample-accelerators/spring-petclinic
 👍 Updated workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
@@ -4508,8 +9859,119 @@ To get status: "tanzu apps workload get my-workload"
 `,
 		},
 		{
-			Name: "update git fields",
-			Args: []string{workloadName, flags.GitBranchFlagName, "accelerator", flags.GitCommitFlagName, "abcd1234", flags.YesFlagName},
+			Name: "update - git secret",
+			Args: []string{workloadName, flags.GitSecretFlagName, "git-credentials", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Source(&cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "main",
+								},
+							},
+						})
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Source(&cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "main",
+								},
+							},
+						})
+						d.Params(cartov1alpha1.Param{
+							Name:  cartov1alpha1.WorkloadGitSecretParam,
+							Value: apiextensionsv1.JSON{Raw: []byte(`"git-credentials"`)},
+						})
+					}),
+			},
+			ExpectOutput: `
+🔎 Update workload:
+...
+  6,  6   |    apps.tanzu.vmware.com/workload-type: web
+  7,  7   |  name: my-workload
+  8,  8   |  namespace: default
+  9,  9   |spec:
+     10 + |  params:
+     11 + |  - name: gitops_ssh_secret
+     12 + |    value: git-credentials
+ 10, 13   |  source:
+ 11, 14   |    git:
+ 12, 15   |      ref:
+ 13, 16   |        branch: main
+...
+👍 Updated workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name: "update - remove git secret by setting to empty in flags",
+			Args: []string{workloadName, flags.GitSecretFlagName, "", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Source(&cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "main",
+								},
+							},
+						})
+						d.Params(cartov1alpha1.Param{
+							Name:  cartov1alpha1.WorkloadGitSecretParam,
+							Value: apiextensionsv1.JSON{Raw: []byte(`"git-credentials"`)},
+						})
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Source(&cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "main",
+								},
+							},
+						})
+						d.Params()
+					}),
+			},
+			ExpectOutput: `
+🔎 Update workload:
+...
+  6,  6   |    apps.tanzu.vmware.com/workload-type: web
+  7,  7   |  name: my-workload
+  8,  8   |  namespace: default
+  9,  9   |spec:
+ 10     - |  params:
+ 11     - |  - name: gitops_ssh_secret
+ 12     - |    value: git-credentials
+ 13, 10   |  source:
+ 14, 11   |    git:
+ 15, 12   |      ref:
+ 16, 13   |        branch: main
+...
+👍 Updated workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name: "update - remove git branch by setting to empty in flags",
+			Args: []string{workloadName, flags.GitBranchFlagName, "", flags.YesFlagName},
 			GivenObjects: []client.Object{
 				parent.
 					SpecDie(
@@ -4519,7 +9981,7 @@ To get status: "tanzu apps workload get my-workload"
 									URL: "https://github.com/sample-accelerators/spring-petclinic",
 									Ref: cartov1alpha1.GitRef{
 										Branch: "main",
-										Tag:    "tap-1.1",
+										Tag:    "v0.1.0",
 									},
 								},
 							})
@@ -4539,9 +10001,7 @@ To get status: "tanzu apps workload get my-workload"
 							Git: &cartov1alpha1.GitSource{
 								URL: "https://github.com/sample-accelerators/spring-petclinic",
 								Ref: cartov1alpha1.GitRef{
-									Branch: "accelerator",
-									Tag:    "tap-1.1",
-									Commit: "abcd1234",
+									Tag: "v0.1.0",
 								},
 							},
 						},
@@ -4556,10 +10016,8 @@ To get status: "tanzu apps workload get my-workload"
  11, 11   |    git:
  12, 12   |      ref:
  13     - |        branch: main
-     13 + |        branch: accelerator
-     14 + |        commit: abcd1234
- 14, 15   |        tag: tap-1.1
- 15, 16   |      url: https://github.com/sample-accelerators/spring-petclinic
+ 14, 13   |        tag: v0.1.0
+ 15, 14   |      url: https://github.com/sample-accelerators/spring-petclinic
 👍 Updated workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
@@ -4568,18 +10026,24 @@ To get status: "tanzu apps workload get my-workload"
 `,
 		},
 		{
-			Name: "git source with non-allowed env var",
-			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
-				os.Setenv("TANZU_APPS_LABEL", "foo=var")
-				return ctx, nil
-			},
-			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
-				os.Unsetenv("TANZU_APPS_LABEL")
-				return nil
+			Name: "update - remove git commit by setting to empty in flags",
+			Args: []string{workloadName, flags.GitCommitFlagName, "", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(
+						func(d *diecartov1alpha1.WorkloadSpecDie) {
+							d.Source(&cartov1alpha1.Source{
+								Git: &cartov1alpha1.GitSource{
+									URL: "https://github.com/sample-accelerators/spring-petclinic",
+									Ref: cartov1alpha1.GitRef{
+										Branch: "main",
+										Commit: "abc1234",
+									},
+								},
+							})
+						}),
 			},
-			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName},
-			GivenObjects: givenNamespaceDefault,
-			ExpectCreates: []client.Object{
+			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
@@ -4591,9 +10055,9 @@ To get status: "tanzu apps workload get my-workload"
 					Spec: cartov1alpha1.WorkloadSpec{
 						Source: &cartov1alpha1.Source{
 							Git: &cartov1alpha1.GitSource{
-								URL: gitRepo,
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
 								Ref: cartov1alpha1.GitRef{
-									Branch: gitBranch,
+									Branch: "main",
 								},
 							},
 						},
@@ -4601,56 +10065,46 @@ To get status: "tanzu apps workload get my-workload"
 				},
 			},
 			ExpectOutput: `
-🔎 Create workload:
-      1 + |---
-      2 + |apiVersion: carto.run/v1alpha1
-      3 + |kind: Workload
-      4 + |metadata:
-      5 + |  labels:
-      6 + |    apps.tanzu.vmware.com/workload-type: web
-      7 + |  name: my-workload
-      8 + |  namespace: default
-      9 + |spec:
-     10 + |  source:
-     11 + |    git:
-     12 + |      ref:
-     13 + |        branch: main
-     14 + |      url: https://example.com/repo.git
-👍 Created workload "my-workload"
+🔎 Update workload:
+...
+ 10, 10   |  source:
+ 11, 11   |    git:
+ 12, 12   |      ref:
+ 13, 13   |        branch: main
+ 14     - |        commit: abc1234
+ 15, 14   |      url: https://github.com/sample-accelerators/spring-petclinic
+👍 Updated workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
 To get status: "tanzu apps workload get my-workload"
 
 `,
-		}, {
-			Name: "git source with allowed and non-allowed env var",
-			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
-				os.Setenv("TANZU_APPS_LABEL", "foo=var")
-				os.Setenv("TANZU_APPS_TYPE", "web")
-				return ctx, nil
-			},
-			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
-				os.Unsetenv("TANZU_APPS_LABEL")
-				os.Unsetenv("TANZU_APPS_TYPE")
-				return nil
+		},
+		{
+			Name: "update - change from image to git source",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(
+						func(d *diecartov1alpha1.WorkloadSpecDie) {
+							d.Image("private.repo.domain.com/spring-pet-clinic")
+						}),
 			},
-			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName},
-			GivenObjects: givenNamespaceDefault,
-			ExpectCreates: []client.Object{
+			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
 						Name:      workloadName,
 						Labels: map[string]string{
-							"apps.tanzu.vmware.com/workload-type": "web",
+							apis.WorkloadTypeLabelName: "web",
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
 						Source: &cartov1alpha1.Source{
 							Git: &cartov1alpha1.GitSource{
-								URL: gitRepo,
+								URL: "https://example.com/repo.git",
 								Ref: cartov1alpha1.GitRef{
-									Branch: gitBranch,
+									Branch: "main",
 								},
 							},
 						},
@@ -4658,46 +10112,88 @@ To get status: "tanzu apps workload get my-workload"
 				},
 			},
 			ExpectOutput: `
-🔎 Create workload:
-      1 + |---
-      2 + |apiVersion: carto.run/v1alpha1
-      3 + |kind: Workload
-      4 + |metadata:
-      5 + |  labels:
-      6 + |    apps.tanzu.vmware.com/workload-type: web
-      7 + |  name: my-workload
-      8 + |  namespace: default
-      9 + |spec:
+🔎 Update workload:
+...
+  6,  6   |    apps.tanzu.vmware.com/workload-type: web
+  7,  7   |  name: my-workload
+  8,  8   |  namespace: default
+  9,  9   |spec:
+ 10     - |  image: private.repo.domain.com/spring-pet-clinic
      10 + |  source:
      11 + |    git:
      12 + |      ref:
      13 + |        branch: main
      14 + |      url: https://example.com/repo.git
-👍 Created workload "my-workload"
+👍 Updated workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
 To get status: "tanzu apps workload get my-workload"
 
 `,
-		}, {
-			Name: "git source with allowed env var overwritten",
-			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
-				os.Setenv("TANZU_APPS_TYPE", "jar")
-				return ctx, nil
+		},
+		{
+			Name: "update - change from image to git source with error",
+			Args: []string{workloadName, flags.GitBranchFlagName, gitBranch, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(
+						func(d *diecartov1alpha1.WorkloadSpecDie) {
+							d.Image("private.repo.domain.com/spring-pet-clinic")
+						}),
 			},
-			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
-				os.Unsetenv("TANZU_APPS_TYPE")
-				return nil
+			ShouldError: true,
+		},
+		{
+			Name: "update - change from image to git source without ref",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(
+						func(d *diecartov1alpha1.WorkloadSpecDie) {
+							d.Image("private.repo.domain.com/spring-pet-clinic")
+						}),
 			},
-			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.TypeFlagName, "web", flags.YesFlagName},
-			GivenObjects: givenNamespaceDefault,
-			ExpectCreates: []client.Object{
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := "required to switch the workload source from image to git"; err == nil || !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected error to contain %q, got %v", expected, err)
+				}
+			},
+		},
+		{
+			Name: "update - git repository host not allowed",
+			Args: []string{workloadName, flags.GitRepoFlagName, "git@github.com:example/repo.git", flags.GitBranchFlagName, gitBranch, flags.AllowedHostsFlagName, "example.com", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(
+						func(d *diecartov1alpha1.WorkloadSpecDie) {
+							d.Image("private.repo.domain.com/spring-pet-clinic")
+						}),
+			},
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := `host "github.com" is not in the allowed hosts (example.com)`; err == nil || !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected error to contain %q, got %v", expected, err)
+				}
+			},
+		},
+		{
+			Name: "update - git repository host allowed",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.AllowedHostsFlagName, "github.com,example.com", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(
+						func(d *diecartov1alpha1.WorkloadSpecDie) {
+							d.Image("private.repo.domain.com/spring-pet-clinic")
+						}),
+			},
+			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: defaultNamespace,
 						Name:      workloadName,
 						Labels: map[string]string{
-							"apps.tanzu.vmware.com/workload-type": "web",
+							apis.WorkloadTypeLabelName: "web",
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
@@ -4712,51 +10208,19 @@ To get status: "tanzu apps workload get my-workload"
 					},
 				},
 			},
-			ExpectOutput: `
-🔎 Create workload:
-      1 + |---
-      2 + |apiVersion: carto.run/v1alpha1
-      3 + |kind: Workload
-      4 + |metadata:
-      5 + |  labels:
-      6 + |    apps.tanzu.vmware.com/workload-type: web
-      7 + |  name: my-workload
-      8 + |  namespace: default
-      9 + |spec:
-     10 + |  source:
-     11 + |    git:
-     12 + |      ref:
-     13 + |        branch: main
-     14 + |      url: https://example.com/repo.git
-👍 Created workload "my-workload"
-
-To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
-To get status: "tanzu apps workload get my-workload"
-
-`,
-		}, {
-			Name: "update type via allowed env var",
-			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
-				os.Setenv("TANZU_APPS_TYPE", "web")
-				return ctx, nil
-			},
-			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
-				os.Unsetenv("TANZU_APPS_TYPE")
-				return nil
-			},
-			Args: []string{workloadName, flags.YesFlagName},
+		},
+		{
+			Name: "update - existing git repository host is not checked",
+			Args: []string{workloadName, flags.EnvFlagName, "FOO=bar", flags.AllowedHostsFlagName, "github.com", flags.YesFlagName},
 			GivenObjects: []client.Object{
 				parent.
-					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
-						d.AddLabel("apps.tanzu.vmware.com/workload-type", "jar")
-					}).
 					SpecDie(
 						func(d *diecartov1alpha1.WorkloadSpecDie) {
 							d.Source(&cartov1alpha1.Source{
 								Git: &cartov1alpha1.GitSource{
-									URL: "https://github.com/sample-accelerators/spring-petclinic",
+									URL: gitRepo,
 									Ref: cartov1alpha1.GitRef{
-										Branch: "main",
+										Branch: gitBranch,
 									},
 								},
 							})
@@ -4768,57 +10232,130 @@ To get status: "tanzu apps workload get my-workload"
 						Namespace: defaultNamespace,
 						Name:      workloadName,
 						Labels: map[string]string{
-							"apps.tanzu.vmware.com/workload-type": "web",
+							apis.WorkloadTypeLabelName: "web",
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
 						Source: &cartov1alpha1.Source{
 							Git: &cartov1alpha1.GitSource{
-								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								URL: gitRepo,
 								Ref: cartov1alpha1.GitRef{
-									Branch: "main",
+									Branch: gitBranch,
 								},
 							},
 						},
+						Env: []corev1.EnvVar{
+							{Name: "FOO", Value: "bar"},
+						},
+					},
+				},
+			},
+		},
+		func() clitesting.CommandTestCase {
+			failed := false
+			updated := &cartov1alpha1.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: defaultNamespace,
+					Name:      workloadName,
+					Labels: map[string]string{
+						apis.WorkloadTypeLabelName: "web",
+					},
+				},
+				Spec: cartov1alpha1.WorkloadSpec{
+					Image: "ubuntu:bionic",
+					Env: []corev1.EnvVar{
+						{Name: "FOO", Value: "bar"},
+					},
+				},
+			}
+			return clitesting.CommandTestCase{
+				Name: "update - retried after too many requests",
+				Args: []string{workloadName, flags.EnvFlagName, "FOO=bar", flags.RetryBackoffFlagName, "0s", flags.YesFlagName},
+				GivenObjects: []client.Object{
+					parent.
+						SpecDie(
+							func(d *diecartov1alpha1.WorkloadSpecDie) {
+								d.Image("ubuntu:bionic")
+							}),
+				},
+				WithReactors: []clitesting.ReactionFunc{
+					func(action clitesting.Action) (bool, runtime.Object, error) {
+						if failed || !action.Matches("update", "Workload") {
+							return false, nil, nil
+						}
+						failed = true
+						return true, nil, apierrs.NewTooManyRequests("the server is busy", 0)
+					},
+				},
+				// the failed attempt and its retry
+				ExpectUpdates: []client.Object{updated, updated},
+			}
+		}(),
+		{
+			Name: "update - not retried after a server timeout",
+			Args: []string{workloadName, flags.EnvFlagName, "FOO=bar", flags.RetryBackoffFlagName, "0s", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(
+						func(d *diecartov1alpha1.WorkloadSpecDie) {
+							d.Image("ubuntu:bionic")
+						}),
+			},
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("update", "Workload", clitesting.InduceFailureOpts{
+					Error: apierrs.NewServerTimeout(schema.GroupResource{Group: "carto.run", Resource: "workloads"}, "update", 0),
+				}),
+			},
+			// the update may have been applied, it is not sent again
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+						Env: []corev1.EnvVar{
+							{Name: "FOO", Value: "bar"},
+						},
+					},
+				},
+			},
+			ShouldError: true,
+		},
+		{
+			Name:         "create - pre-pushed source image",
+			Args:         []string{workloadName, flags.SourceImageFlagName, "registry.example.com/source@sha256:978be33a7f0cbe89bf48fbb438846047a28e1298d6d10d0de2d64bdc102a9e69", flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Image: "registry.example.com/source@sha256:978be33a7f0cbe89bf48fbb438846047a28e1298d6d10d0de2d64bdc102a9e69",
+						},
 					},
 				},
 			},
-			ExpectOutput: `
-🔎 Update workload:
-...
-  2,  2   |apiVersion: carto.run/v1alpha1
-  3,  3   |kind: Workload
-  4,  4   |metadata:
-  5,  5   |  labels:
-  6     - |    apps.tanzu.vmware.com/workload-type: jar
-      6 + |    apps.tanzu.vmware.com/workload-type: web
-  7,  7   |  name: my-workload
-  8,  8   |  namespace: default
-  9,  9   |spec:
- 10, 10   |  source:
-...
-👍 Updated workload "my-workload"
-
-To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
-To get status: "tanzu apps workload get my-workload"
-
-`,
 		},
 		{
-			Name: "update - remove git tag by setting to empty in flags",
-			Args: []string{workloadName, flags.GitTagFlagName, "", flags.YesFlagName},
+			Name: "update - source image repository with workload name appended",
+			Args: []string{workloadName, flags.SourceImageFlagName, "registry.example.com/apps/", flags.YesFlagName},
 			GivenObjects: []client.Object{
 				parent.
 					SpecDie(
 						func(d *diecartov1alpha1.WorkloadSpecDie) {
 							d.Source(&cartov1alpha1.Source{
-								Git: &cartov1alpha1.GitSource{
-									URL: "https://github.com/sample-accelerators/spring-petclinic",
-									Ref: cartov1alpha1.GitRef{
-										Branch: "main",
-										Tag:    "v0.1.0",
-									},
-								},
+								Image: "registry.example.com/old-image",
 							})
 						}),
 			},
@@ -4833,47 +10370,46 @@ To get status: "tanzu apps workload get my-workload"
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
 						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: "https://github.com/sample-accelerators/spring-petclinic",
-								Ref: cartov1alpha1.GitRef{
-									Branch: "main",
-								},
-							},
+							Image: "registry.example.com/apps/my-workload-source",
 						},
 					},
 				},
 			},
-			ExpectOutput: `
-🔎 Update workload:
-...
- 10, 10   |  source:
- 11, 11   |    git:
- 12, 12   |      ref:
- 13, 13   |        branch: main
- 14     - |        tag: v0.1.0
- 15, 14   |      url: https://github.com/sample-accelerators/spring-petclinic
-👍 Updated workload "my-workload"
-
-To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
-To get status: "tanzu apps workload get my-workload"
-
-`,
 		},
 		{
-			Name: "update - remove git branch by setting to empty in flags",
-			Args: []string{workloadName, flags.GitBranchFlagName, "", flags.YesFlagName},
+			Name: "update - invalid source image repository",
+			Args: []string{workloadName, flags.SourceImageFlagName, "registry.example.com/Apps/", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(
+						func(d *diecartov1alpha1.WorkloadSpecDie) {
+							d.Source(&cartov1alpha1.Source{
+								Image: "registry.example.com/old-image",
+							})
+						}),
+			},
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := `"registry.example.com/Apps/my-workload-source" is not a valid image reference`; err == nil || !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected error to contain %q, got %v", expected, err)
+				}
+			},
+		},
+		{
+			Name: "update - change from git to image clears git source",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.YesFlagName},
 			GivenObjects: []client.Object{
 				parent.
 					SpecDie(
 						func(d *diecartov1alpha1.WorkloadSpecDie) {
 							d.Source(&cartov1alpha1.Source{
 								Git: &cartov1alpha1.GitSource{
-									URL: "https://github.com/sample-accelerators/spring-petclinic",
+									URL: gitRepo,
 									Ref: cartov1alpha1.GitRef{
-										Branch: "main",
-										Tag:    "v0.1.0",
+										Branch: gitBranch,
 									},
 								},
+								Subpath: "./app",
 							})
 						}),
 			},
@@ -4887,49 +10423,27 @@ To get status: "tanzu apps workload get my-workload"
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: "https://github.com/sample-accelerators/spring-petclinic",
-								Ref: cartov1alpha1.GitRef{
-									Tag: "v0.1.0",
-								},
-							},
-						},
+						Image: "ubuntu:bionic",
 					},
 				},
 			},
-			ExpectOutput: `
-🔎 Update workload:
-...
-  9,  9   |spec:
- 10, 10   |  source:
- 11, 11   |    git:
- 12, 12   |      ref:
- 13     - |        branch: main
- 14, 13   |        tag: v0.1.0
- 15, 14   |      url: https://github.com/sample-accelerators/spring-petclinic
-👍 Updated workload "my-workload"
-
-To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
-To get status: "tanzu apps workload get my-workload"
-
-`,
 		},
 		{
-			Name: "update - remove git commit by setting to empty in flags",
-			Args: []string{workloadName, flags.GitCommitFlagName, "", flags.YesFlagName},
+			Name: "update - force replace git source",
+			Args: []string{workloadName, flags.GitRepoFlagName, "https://example.com/repo.git", flags.GitTagFlagName, "v1.0.0", flags.ForceReplaceSourceFlagName, flags.YesFlagName},
 			GivenObjects: []client.Object{
 				parent.
 					SpecDie(
 						func(d *diecartov1alpha1.WorkloadSpecDie) {
 							d.Source(&cartov1alpha1.Source{
 								Git: &cartov1alpha1.GitSource{
-									URL: "https://github.com/sample-accelerators/spring-petclinic",
+									URL: gitRepo,
 									Ref: cartov1alpha1.GitRef{
-										Branch: "main",
+										Branch: gitBranch,
 										Commit: "abc1234",
 									},
 								},
+								Subpath: "./app",
 							})
 						}),
 			},
@@ -4945,39 +10459,27 @@ To get status: "tanzu apps workload get my-workload"
 					Spec: cartov1alpha1.WorkloadSpec{
 						Source: &cartov1alpha1.Source{
 							Git: &cartov1alpha1.GitSource{
-								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								URL: "https://example.com/repo.git",
 								Ref: cartov1alpha1.GitRef{
-									Branch: "main",
+									Tag: "v1.0.0",
 								},
 							},
 						},
 					},
 				},
 			},
-			ExpectOutput: `
-🔎 Update workload:
-...
- 10, 10   |  source:
- 11, 11   |    git:
- 12, 12   |      ref:
- 13, 13   |        branch: main
- 14     - |        commit: abc1234
- 15, 14   |      url: https://github.com/sample-accelerators/spring-petclinic
-👍 Updated workload "my-workload"
-
-To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
-To get status: "tanzu apps workload get my-workload"
-
-`,
 		},
 		{
-			Name: "update - change from image to git source",
-			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName},
+			Name: "update - force replace maven source with image",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.ForceReplaceSourceFlagName, flags.YesFlagName},
 			GivenObjects: []client.Object{
 				parent.
 					SpecDie(
 						func(d *diecartov1alpha1.WorkloadSpecDie) {
-							d.Image("private.repo.domain.com/spring-pet-clinic")
+							d.Params(cartov1alpha1.Param{
+								Name:  "maven",
+								Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"hello-world","groupId":"carto.run","version":"2.1.0"}`)},
+							})
 						}),
 			},
 			ExpectUpdates: []client.Object{
@@ -4990,30 +10492,163 @@ To get status: "tanzu apps workload get my-workload"
 						},
 					},
 					Spec: cartov1alpha1.WorkloadSpec{
-						Source: &cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: "https://example.com/repo.git",
-								Ref: cartov1alpha1.GitRef{
-									Branch: "main",
-								},
-							},
-						},
+						Image: "ubuntu:bionic",
 					},
 				},
 			},
+		},
+		{
+			Name: "update - diff with exit code and drift",
+			Args: []string{workloadName, flags.EnvFlagName, "FOO=bar", flags.DiffFlagName, flags.ExitCodeFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(
+						func(d *diecartov1alpha1.WorkloadSpecDie) {
+							d.Source(&cartov1alpha1.Source{
+								Git: &cartov1alpha1.GitSource{
+									URL: gitRepo,
+									Ref: cartov1alpha1.GitRef{
+										Branch: gitBranch,
+									},
+								},
+							})
+						}),
+			},
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected, actual := 2, cli.ExitCode(err); expected != actual {
+					t.Errorf("expected exit code %d, got %d", expected, actual)
+				}
+			},
 			ExpectOutput: `
-🔎 Update workload:
+🔎 Workload drift:
 ...
   6,  6   |    apps.tanzu.vmware.com/workload-type: web
   7,  7   |  name: my-workload
   8,  8   |  namespace: default
   9,  9   |spec:
- 10     - |  image: private.repo.domain.com/spring-pet-clinic
-     10 + |  source:
-     11 + |    git:
-     12 + |      ref:
-     13 + |        branch: main
-     14 + |      url: https://example.com/repo.git
+     10 + |  env:
+     11 + |  - name: FOO
+     12 + |    value: bar
+ 10, 13   |  source:
+ 11, 14   |    git:
+ 12, 15   |      ref:
+ 13, 16   |        branch: main
+...
+Changes: +3 -0 lines across env
+Drift detected for workload "my-workload": spec.env
+`,
+		},
+		{
+			Name:         "create - diff summary of a missing workload",
+			Args:         []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.LabelFlagName, "app=my-app", flags.DiffFlagName, flags.DiffFormatFlagName, "unified"},
+			GivenObjects: givenNamespaceDefault,
+			ExpectOutput: `
+🔎 Workload drift:
+--- /dev/null
++++ b/my-workload.yaml
+@@ -0,0 +1,11 @@
++---
++apiVersion: carto.run/v1alpha1
++kind: Workload
++metadata:
++  labels:
++    app: my-app
++    apps.tanzu.vmware.com/workload-type: web
++  name: my-workload
++  namespace: default
++spec:
++  image: ubuntu:bionic
+Changes: +11 -0 lines across labels, image
+Drift detected for workload "my-workload": the workload does not exist
+`,
+		},
+		{
+			Name: "update - message appended to the change log",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:jammy", flags.MessageFlagName, "bump the base image", flags.YesFlagName},
+			Now:  time.Date(2021, time.September, 10, 15, 0, 0, 0, time.UTC),
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.AddAnnotation(apis.ChangeLogAnnotationName, "2021-09-01T10:00:00Z other-user: first deployment")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.AddAnnotation(apis.ChangeLogAnnotationName, "2021-09-01T10:00:00Z other-user: first deployment\n2021-09-10T15:00:00Z default-user: bump the base image")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:jammy")
+					}),
+			},
+			ExpectOutput: `
+🔎 Update workload:
+...
+  2,  2   |apiVersion: carto.run/v1alpha1
+  3,  3   |kind: Workload
+  4,  4   |metadata:
+  5,  5   |  annotations:
+  6     - |    apps.tanzu.vmware.com/change-log: '2021-09-01T10:00:00Z other-user: first deployment'
+      6 + |    apps.tanzu.vmware.com/change-log: |-
+      7 + |      2021-09-01T10:00:00Z other-user: first deployment
+      8 + |      2021-09-10T15:00:00Z default-user: bump the base image
+  7,  9   |  labels:
+  8, 10   |    apps.tanzu.vmware.com/workload-type: web
+  9, 11   |  name: my-workload
+ 10, 12   |  namespace: default
+ 11, 13   |spec:
+ 12     - |  image: ubuntu:bionic
+     14 + |  image: ubuntu:jammy
+👍 Updated workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name: "update - message replaces the change log",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:jammy", flags.MessageFlagName, "bump the base image", flags.MessageModeFlagName, "replace", flags.YesFlagName},
+			Now:  time.Date(2021, time.September, 10, 15, 0, 0, 0, time.UTC),
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.AddAnnotation(apis.ChangeLogAnnotationName, "2021-09-01T10:00:00Z other-user: first deployment")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.AddAnnotation(apis.ChangeLogAnnotationName, "2021-09-10T15:00:00Z default-user: bump the base image")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:jammy")
+					}),
+			},
+			ExpectOutput: `
+🔎 Update workload:
+...
+  2,  2   |apiVersion: carto.run/v1alpha1
+  3,  3   |kind: Workload
+  4,  4   |metadata:
+  5,  5   |  annotations:
+  6     - |    apps.tanzu.vmware.com/change-log: '2021-09-01T10:00:00Z other-user: first deployment'
+      6 + |    apps.tanzu.vmware.com/change-log: '2021-09-10T15:00:00Z default-user: bump the
+      7 + |      base image'
+  7,  8   |  labels:
+  8,  9   |    apps.tanzu.vmware.com/workload-type: web
+  9, 10   |  name: my-workload
+ 10, 11   |  namespace: default
+ 11, 12   |spec:
+ 12     - |  image: ubuntu:bionic
+     13 + |  image: ubuntu:jammy
 👍 Updated workload "my-workload"
 
 To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
@@ -5022,16 +10657,111 @@ To get status: "tanzu apps workload get my-workload"
 `,
 		},
 		{
-			Name: "update - change from image to git source with error",
-			Args: []string{workloadName, flags.GitBranchFlagName, gitBranch, flags.YesFlagName},
+			Name:         "create - message",
+			Args:         []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.MessageFlagName, "first deployment", flags.YesFlagName},
+			Now:          time.Date(2021, time.September, 10, 15, 0, 0, 0, time.UTC),
+			GivenObjects: givenNamespaceDefault,
+			ExpectCreates: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.AddAnnotation(apis.ChangeLogAnnotationName, "2021-09-10T15:00:00Z default-user: first deployment")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ExpectOutput: `
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  annotations:
+      6 + |    apps.tanzu.vmware.com/change-log: '2021-09-10T15:00:00Z default-user: first deployment'
+      7 + |  labels:
+      8 + |    apps.tanzu.vmware.com/workload-type: web
+      9 + |  name: my-workload
+     10 + |  namespace: default
+     11 + |spec:
+     12 + |  image: ubuntu:bionic
+👍 Created workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name: "update - diff summary with removed lines",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:jammy", flags.EnvFlagName, "FOO-", flags.DiffFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Env(corev1.EnvVar{Name: "FOO", Value: "bar"})
+					}),
+			},
+			ExpectOutput: `
+🔎 Workload drift:
+...
+  6,  6   |    apps.tanzu.vmware.com/workload-type: web
+  7,  7   |  name: my-workload
+  8,  8   |  namespace: default
+  9,  9   |spec:
+ 10     - |  env:
+ 11     - |  - name: FOO
+ 12     - |    value: bar
+ 13     - |  image: ubuntu:bionic
+     10 + |  image: ubuntu:jammy
+Changes: +1 -4 lines across env, image
+Drift detected for workload "my-workload": spec.env, spec.image
+`,
+		},
+		{
+			Name: "update - diff with multi-line env value",
+			Args: []string{workloadName, flags.EnvFlagName, "CERT=-----BEGIN CERTIFICATE-----\r\nMIIDdzCCAl+gAwIBAgIEAgAAuTANBgkqhkiG9w0BAQUFADBaMQswCQYDVQQGEwJJ\r\n-----END CERTIFICATE-----\r\n", flags.DiffFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Env(corev1.EnvVar{Name: "CERT", Value: "-----BEGIN CERTIFICATE-----\r\nMIIDdzCCAl+gAwIBAgIEAgAAuTANBgkqhkiG9w0BAQUFADBaMQswCQYDVQQGEwJI\r\n-----END CERTIFICATE-----\r\n"})
+					}),
+			},
+			ExpectOutput: `
+🔎 Workload drift:
+...
+  9,  9   |spec:
+ 10, 10   |  env:
+ 11, 11   |  - name: CERT
+ 12, 12   |    value: "-----BEGIN CERTIFICATE-----\r\n\
+ 13     - |      MIIDdzCCAl+gAwIBAgIEAgAAuTANBgkqhkiG9w0BAQUFADBaMQswCQYDVQQGEwJI\r\n\
+     13 + |      MIIDdzCCAl+gAwIBAgIEAgAAuTANBgkqhkiG9w0BAQUFADBaMQswCQYDVQQGEwJJ\r\n\
+ 14, 14   |      -----END CERTIFICATE-----\r\n"
+ 15, 15   |  image: ubuntu:bionic
+Changes: +1 -1 lines across env
+Drift detected for workload "my-workload": spec.env
+`,
+		},
+		{
+			Name: "update - diff with exit code and no drift",
+			Args: []string{workloadName, flags.GitBranchFlagName, gitBranch, flags.DiffFlagName, flags.ExitCodeFlagName},
 			GivenObjects: []client.Object{
 				parent.
 					SpecDie(
 						func(d *diecartov1alpha1.WorkloadSpecDie) {
-							d.Image("private.repo.domain.com/spring-pet-clinic")
+							d.Source(&cartov1alpha1.Source{
+								Git: &cartov1alpha1.GitSource{
+									URL: gitRepo,
+									Ref: cartov1alpha1.GitRef{
+										Branch: gitBranch,
+									},
+								},
+							})
 						}),
 			},
-			ShouldError: true,
+			ExpectOutput: `
+No drift detected for workload "my-workload"
+`,
 		},
 		{
 			Name: "update - set all git.ref fields to empty",
@@ -5847,7 +11577,7 @@ To get status: "tanzu apps workload get %s"`, workloadName, workloadName, worklo
 
 				tailer := &logs.FakeTailer{}
 				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Minute, false).Return(nil).Once()
+				tailer.On("Tail", mock.Anything, "default", selector, logs.TailOptions{Containers: []string{}, Since: time.Minute, TailLines: -1, Follow: true}).Return(nil).Once()
 				ctx = logs.StashTailer(ctx, tailer)
 
 				return ctx, nil
@@ -6337,7 +12067,7 @@ Skipping workload %q`,
 			Args: []string{flags.FilePathFlagName, "testdata/replace-update-strategy/all-fields-workload.yaml",
 				flags.TypeFlagName, "my-type",
 				flags.UpdateStrategyFlagName, "replace", flags.YesFlagName},
-			GivenObjects: []client.Object{
+			GivenObjects: append([]client.Object{
 				parent.
 					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
 						d.Name("spring-petclinic")
@@ -6351,7 +12081,7 @@ Skipping workload %q`,
 							},
 						)
 					}),
-			},
+			}, givenServiceAccounts...),
 			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
@@ -6422,6 +12152,9 @@ Skipping workload %q`,
 			ExpectOutput: `
 ❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
 
+❗ The "replace" update strategy removes these fields from the workload:
+  - spec.image
+
 🔎 Update workload:
   1,  1   |---
   2,  2   |apiVersion: carto.run/v1alpha1
@@ -6531,6 +12264,9 @@ To get status: "tanzu apps workload get spring-petclinic"
 			ExpectOutput: `
 ❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
 
+❗ The "replace" update strategy removes these fields from the workload:
+  - metadata.annotations.dont-preserve-me
+
 🔎 Update workload:
 ...
   2,  2   |apiVersion: carto.run/v1alpha1
@@ -6604,6 +12340,9 @@ To get status: "tanzu apps workload get spring-petclinic"
 			ExpectOutput: `
 ❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
 
+❗ The "replace" update strategy removes these fields from the workload:
+  - metadata.labels.dont-preserve-me
+
 🔎 Update workload:
 ...
   2,  2   |apiVersion: carto.run/v1alpha1
@@ -6679,6 +12418,9 @@ To get status: "tanzu apps workload get spring-petclinic"
 			ExpectOutput: `
 WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
 
+The "replace" update strategy removes these fields from the workload:
+  - metadata.labels.dont-preserve-me
+
 Update workload:
 ...
   2,  2   |apiVersion: carto.run/v1alpha1
@@ -6702,10 +12444,44 @@ To get status: "tanzu apps workload get spring-petclinic"
 
 `,
 		},
+		{
+			Name:   "update - no escape sequences in the diff with no color",
+			Args:   []string{workloadName, flags.ImageFlagName, "ubuntu:focal", flags.YesFlagName},
+			Config: &cli.Config{NoColor: true, Scheme: scheme},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				// a terminal that supports colors, where the diff lines are colored
+				color.NoColor = false
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				color.NoColor = true
+				return nil
+			},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:focal")
+					}),
+			},
+			Verify: func(t *testing.T, output string, err error) {
+				if strings.Contains(output, "\x1b") {
+					t.Errorf("expected output without escape sequences, got %q", output)
+				}
+				if expected := "     10 + |  image: ubuntu:focal\n"; !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %q, got %q", expected, output)
+				}
+			},
+		},
 		{
 			Name: "update/replace - add serviceAccountName",
 			Args: []string{flags.FilePathFlagName, "testdata/replace-update-strategy/replace-service-account-name.yaml", flags.UpdateStrategyFlagName, "replace", flags.YesFlagName},
-			GivenObjects: []client.Object{
+			GivenObjects: append([]client.Object{
 				parent.
 					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
 						d.Name("spring-petclinic")
@@ -6722,7 +12498,7 @@ To get status: "tanzu apps workload get spring-petclinic"
 							},
 						})
 					}),
-			},
+			}, givenServiceAccounts...),
 			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
@@ -6816,6 +12592,9 @@ To get status: "tanzu apps workload get spring-petclinic"
 			ExpectOutput: `
 ❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
 
+❗ The "replace" update strategy removes these fields from the workload:
+  - spec.serviceAccountName
+
 🔎 Update workload:
 ...
   7,  7   |    apps.tanzu.vmware.com/workload-type: web
@@ -6838,7 +12617,7 @@ To get status: "tanzu apps workload get spring-petclinic"
 		{
 			Name: "update/replace - change serviceAccountName",
 			Args: []string{flags.FilePathFlagName, "testdata/replace-update-strategy/replace-service-account-name.yaml", flags.UpdateStrategyFlagName, "replace", flags.YesFlagName},
-			GivenObjects: []client.Object{
+			GivenObjects: append([]client.Object{
 				parent.
 					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
 						d.Name("spring-petclinic")
@@ -6856,7 +12635,7 @@ To get status: "tanzu apps workload get spring-petclinic"
 							},
 						})
 					}),
-			},
+			}, givenServiceAccounts...),
 			ExpectUpdates: []client.Object{
 				&cartov1alpha1.Workload{
 					ObjectMeta: metav1.ObjectMeta{
@@ -6989,23 +12768,24 @@ To get status: "tanzu apps workload get spring-petclinic"
 
 🔎 Update workload:
 ...
-  9,  9   |  namespace: default
  10, 10   |spec:
  11, 11   |  build:
  12, 12   |    env:
-     13 + |    - name: my-new-build-env
-     14 + |      value: my-new-value
-     15 + |    - name: BP_MAVEN_POM_FILE
-     16 + |      value: skip-pom.xml
- 13, 17   |    - name: my-build-env
+ 13, 13   |    - name: my-build-env
  14     - |      value: my-build-env-value
  15     - |    - name: preserve-me
  16     - |      value: should-not-exist
-     18 + |      value: my-build-env-updated-value
- 17, 19   |    - name: do-preserve-me
- 18, 20   |      value: should-exist
+     14 + |      value: my-build-env-updated-value
+ 17, 15   |    - name: do-preserve-me
+ 18, 16   |      value: should-exist
+     17 + |    - name: my-new-build-env
+     18 + |      value: my-new-value
+     19 + |    - name: BP_MAVEN_POM_FILE
+     20 + |      value: skip-pom.xml
  19, 21   |  source:
  20, 22   |    git:
+ 21, 23   |      ref:
+ 22, 24   |        tag: tap-1.1
 ...
 👍 Updated workload "spring-petclinic"
 
@@ -7096,23 +12876,24 @@ To get status: "tanzu apps workload get spring-petclinic"
 
 🔎 Update workload:
 ...
-  8,  8   |  name: spring-petclinic
   9,  9   |  namespace: default
  10, 10   |spec:
  11, 11   |  env:
-     12 + |  - name: SPRING_PROFILES_ACTIVE
-     13 + |    value: mysql
- 12, 14   |  - name: my-envvar
+ 12, 12   |  - name: my-envvar
  13     - |    value: my-envvar-value
  14     - |  - name: dont-preserve-me
  15     - |    value: should-not-exist
-     15 + |    value: my-envvar-updated-value
-     16 + |  - name: my-new-envvar
-     17 + |    value: my-new-value
- 16, 18   |  - name: preserve-me
- 17, 19   |    value: should-exist
+     13 + |    value: my-envvar-updated-value
+ 16, 14   |  - name: preserve-me
+ 17, 15   |    value: should-exist
+     16 + |  - name: SPRING_PROFILES_ACTIVE
+     17 + |    value: mysql
+     18 + |  - name: my-new-envvar
+     19 + |    value: my-new-value
  18, 20   |  source:
  19, 21   |    git:
+ 20, 22   |      ref:
+ 21, 23   |        tag: tap-1.1
 ...
 👍 Updated workload "spring-petclinic"
 
@@ -7187,6 +12968,9 @@ To get status: "tanzu apps workload get spring-petclinic"
 			ExpectOutput: `
 ❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
 
+❗ The "replace" update strategy removes these fields from the workload:
+  - spec.resources.requests.cpu
+
 🔎 Update workload:
 ...
   9,  9   |  namespace: default
@@ -7210,6 +12994,212 @@ To get status: "tanzu apps workload get spring-petclinic"
 To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
 To get status: "tanzu apps workload get spring-petclinic"
 
+`,
+		},
+		{
+			Name: "update - reordered service claims",
+			Args: []string{flags.FilePathFlagName, "testdata/reordered-service-claims.yaml", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("spring-petclinic")
+						d.AddLabel("apps.tanzu.vmware.com/workload-type", "web")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.ServiceClaims(cartov1alpha1.WorkloadServiceClaim{
+							Name: "database",
+							Ref: &cartov1alpha1.WorkloadServiceClaimReference{
+								APIVersion: "services.tanzu.vmware.com/v1alpha1",
+								Kind:       "PostgreSQL",
+								Name:       "my-prod-db",
+							},
+						}, cartov1alpha1.WorkloadServiceClaim{
+							Name: "cache",
+							Ref: &cartov1alpha1.WorkloadServiceClaimReference{
+								APIVersion: "services.tanzu.vmware.com/v1alpha1",
+								Kind:       "Redis",
+								Name:       "my-cache",
+							},
+						})
+						d.Source(&cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								Ref: cartov1alpha1.GitRef{
+									Tag: "tap-1.1",
+								},
+							},
+						})
+					}),
+			},
+			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
+Workload is unchanged, skipping update
+`,
+		},
+		{
+			Name: "update - replace reordered service claims",
+			Args: []string{flags.FilePathFlagName, "testdata/reordered-service-claims.yaml",
+				flags.UpdateStrategyFlagName, "replace", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("spring-petclinic")
+						d.AddLabel("apps.tanzu.vmware.com/workload-type", "web")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.ServiceClaims(cartov1alpha1.WorkloadServiceClaim{
+							Name: "database",
+							Ref: &cartov1alpha1.WorkloadServiceClaimReference{
+								APIVersion: "services.tanzu.vmware.com/v1alpha1",
+								Kind:       "PostgreSQL",
+								Name:       "my-prod-db",
+							},
+						}, cartov1alpha1.WorkloadServiceClaim{
+							Name: "cache",
+							Ref: &cartov1alpha1.WorkloadServiceClaimReference{
+								APIVersion: "services.tanzu.vmware.com/v1alpha1",
+								Kind:       "Redis",
+								Name:       "my-cache",
+							},
+						})
+						d.Source(&cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								Ref: cartov1alpha1.GitRef{
+									Tag: "tap-1.1",
+								},
+							},
+						})
+					}),
+			},
+			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
+Workload is unchanged, skipping update
+`,
+		},
+		{
+			Name: "update - replace reordered env",
+			Args: []string{flags.FilePathFlagName, "testdata/reordered-env.yaml",
+				flags.UpdateStrategyFlagName, "replace", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("spring-petclinic")
+						d.AddLabel("apps.tanzu.vmware.com/workload-type", "web")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Env(
+							corev1.EnvVar{Name: "FOO", Value: "bar"},
+							corev1.EnvVar{Name: "BAR", Value: "baz"},
+						)
+						d.Build(&cartov1alpha1.WorkloadBuild{
+							Env: []corev1.EnvVar{
+								{Name: "BP_MAVEN_BUILD_ARGUMENTS", Value: "package"},
+								{Name: "BP_JVM_VERSION", Value: "17"},
+							},
+						})
+						d.Source(&cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								Ref: cartov1alpha1.GitRef{
+									Tag: "tap-1.1",
+								},
+							},
+						})
+					}),
+			},
+			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
+Workload is unchanged, skipping update
+`,
+		},
+		{
+			Name: "update - replace reordered and changed env",
+			Args: []string{flags.FilePathFlagName, "testdata/reordered-env-changed.yaml",
+				flags.UpdateStrategyFlagName, "replace", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("spring-petclinic")
+						d.AddLabel("apps.tanzu.vmware.com/workload-type", "web")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Env(
+							corev1.EnvVar{Name: "FOO", Value: "bar"},
+							corev1.EnvVar{Name: "BAR", Value: "baz"},
+						)
+						d.Build(&cartov1alpha1.WorkloadBuild{
+							Env: []corev1.EnvVar{
+								{Name: "BP_MAVEN_BUILD_ARGUMENTS", Value: "package"},
+								{Name: "BP_JVM_VERSION", Value: "17"},
+							},
+						})
+						d.Source(&cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								Ref: cartov1alpha1.GitRef{
+									Tag: "tap-1.1",
+								},
+							},
+						})
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "spring-petclinic",
+						Labels: map[string]string{
+							"apps.tanzu.vmware.com/workload-type": "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						// the file order is applied
+						Env: []corev1.EnvVar{
+							{Name: "BAR", Value: "qux"},
+							{Name: "FOO", Value: "bar"},
+						},
+						Build: &cartov1alpha1.WorkloadBuild{
+							Env: []corev1.EnvVar{
+								{Name: "BP_JVM_VERSION", Value: "17"},
+								{Name: "BP_MAVEN_BUILD_ARGUMENTS", Value: "package"},
+							},
+						},
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/sample-accelerators/spring-petclinic",
+								Ref: cartov1alpha1.GitRef{
+									Tag: "tap-1.1",
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
+
+🔎 Update workload:
+...
+ 16, 16   |  env:
+ 17, 17   |  - name: FOO
+ 18, 18   |    value: bar
+ 19, 19   |  - name: BAR
+ 20     - |    value: baz
+     20 + |    value: qux
+ 21, 21   |  source:
+ 22, 22   |    git:
+ 23, 23   |      ref:
+ 24, 24   |        tag: tap-1.1
+...
+👍 Updated workload "spring-petclinic"
+
+To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
+To get status: "tanzu apps workload get spring-petclinic"
+
 `,
 		},
 		{
@@ -7270,13 +13260,6 @@ To get status: "tanzu apps workload get spring-petclinic"
 					Spec: cartov1alpha1.WorkloadSpec{
 						ServiceClaims: []cartov1alpha1.WorkloadServiceClaim{
 							{
-								Name: "database",
-								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
-									APIVersion: "services.tanzu.vmware.com/v1alpha1",
-									Kind:       "Secret",
-									Name:       "stub-db",
-								},
-							}, {
 								Name: "my-service-claim",
 								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
 									APIVersion: "services.tanzu.vmware.com/v1alpha1",
@@ -7290,6 +13273,13 @@ To get status: "tanzu apps workload get spring-petclinic"
 									Kind:       "mysql",
 									Name:       "my-sql-db",
 								},
+							}, {
+								Name: "database",
+								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
+									APIVersion: "services.tanzu.vmware.com/v1alpha1",
+									Kind:       "Secret",
+									Name:       "stub-db",
+								},
 							}, {
 								Name: "my-new-svc-claim",
 								Ref: &cartov1alpha1.WorkloadServiceClaimReference{
@@ -7315,27 +13305,23 @@ To get status: "tanzu apps workload get spring-petclinic"
 
 🔎 Update workload:
 ...
-  8,  8   |  name: spring-petclinic
-  9,  9   |  namespace: default
- 10, 10   |spec:
- 11, 11   |  serviceClaims:
-     12 + |  - name: database
-     13 + |    ref:
-     14 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
-     15 + |      kind: Secret
-     16 + |      name: stub-db
- 12, 17   |  - name: my-service-claim
- 13, 18   |    ref:
- 14, 19   |      apiVersion: services.tanzu.vmware.com/v1alpha1
- 15, 20   |      kind: PostgreSQL
+ 12, 12   |  - name: my-service-claim
+ 13, 13   |    ref:
+ 14, 14   |      apiVersion: services.tanzu.vmware.com/v1alpha1
+ 15, 15   |      kind: PostgreSQL
  16     - |      name: my-prod-db
-     21 + |      name: my-prod-db-updated
- 17, 22   |  - name: my-second-service-claim
- 18, 23   |    ref:
- 19, 24   |      apiVersion: services.tanzu.vmware.com/v1alpha1
- 20, 25   |      kind: mysql
- 21, 26   |      name: my-sql-db
+     16 + |      name: my-prod-db-updated
+ 17, 17   |  - name: my-second-service-claim
+ 18, 18   |    ref:
+ 19, 19   |      apiVersion: services.tanzu.vmware.com/v1alpha1
+ 20, 20   |      kind: mysql
+ 21, 21   |      name: my-sql-db
  22     - |  - name: should-delete
+     22 + |  - name: database
+     23 + |    ref:
+     24 + |      apiVersion: services.tanzu.vmware.com/v1alpha1
+     25 + |      kind: Secret
+     26 + |      name: stub-db
      27 + |  - name: my-new-svc-claim
  23, 28   |    ref:
  24     - |      apiVersion: services.tanzu.vmware.com/v1
@@ -7464,6 +13450,9 @@ To get status: "tanzu apps workload get spring-petclinic"
 			ExpectOutput: `
 ❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
 
+❗ The "replace" update strategy removes these fields from the workload:
+  - spec.source.subPath
+
 🔎 Update workload:
 ...
  12, 12   |    git:
@@ -7590,6 +13579,9 @@ To get status: "tanzu apps workload get spring-petclinic"
 			ExpectOutput: `
 ❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
 
+❗ The "replace" update strategy removes these fields from the workload:
+  - spec.source.git.ref.branch
+
 🔎 Update workload:
 ...
  10, 10   |spec:
@@ -7804,6 +13796,10 @@ To get status: "tanzu apps workload get spring-petclinic"
 			ExpectOutput: `
 ❗ WARNING: Configuration file update strategy is changing. By default, provided configuration files will replace rather than merge existing configuration. The change will take place in the January 2024 TAP release (use "--update-strategy" to control strategy explicitly).
 
+❗ The "replace" update strategy removes these fields from the workload:
+  - metadata.labels["app.kubernetes.io/part-of"]
+  - metadata.labels["apps.tanzu.vmware.com/workload-type"]
+
 🔎 Update workload:
   1,  1   |---
   2,  2   |apiVersion: carto.run/v1alpha1
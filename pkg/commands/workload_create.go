@@ -20,12 +20,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/apis"
 	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
 	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/validation"
@@ -36,6 +38,7 @@ import (
 
 type WorkloadCreateOptions struct {
 	WorkloadOptions
+	OutputFile string
 }
 
 var (
@@ -45,15 +48,43 @@ var (
 )
 
 func (opts *WorkloadCreateOptions) Validate(ctx context.Context) validation.FieldErrors {
-	return opts.WorkloadOptions.Validate(ctx)
+	errs := opts.WorkloadOptions.Validate(ctx)
+
+	// the workload written to a file is assembled without reaching the cluster
+	if opts.OutputFile != "" {
+		if opts.DryRun {
+			errs = errs.Also(validation.ErrMultipleOneOf(flags.OutputFileFlagName, flags.DryRunFlagName))
+		}
+		if opts.LocalPath != "" {
+			errs = errs.Also(validation.ErrMultipleOneOf(flags.OutputFileFlagName, flags.LocalPathFlagName))
+		}
+		if len(opts.EnvConfigMaps) != 0 {
+			errs = errs.Also(validation.ErrMultipleOneOf(flags.OutputFileFlagName, flags.EnvFromConfigMapFlagName))
+		}
+		if opts.Wait {
+			errs = errs.Also(validation.ErrMultipleOneOf(flags.OutputFileFlagName, flags.WaitFlagName))
+		}
+		if opts.Tail || opts.TailTimestamps {
+			errs = errs.Also(validation.ErrMultipleOneOf(flags.OutputFileFlagName, flags.TailFlagName))
+		}
+	}
+	if opts.Bare && !opts.DryRun && opts.OutputFile == "" {
+		errs = errs.Also(validation.ErrMissingOneOfWithDetail("required by "+flags.BareFlagName, flags.DryRunFlagName, flags.OutputFileFlagName))
+	}
+	if opts.Output == patchOutputFormat || opts.Output == helmValuesOutputFormat {
+		errs = errs.Also(validation.ErrInvalidValueWithDetail(opts.Output, flags.OutputFlagName, "only supported by workload apply"))
+	}
+
+	return errs
 }
 
 func (opts *WorkloadCreateOptions) Exec(ctx context.Context, c *cli.Config) error {
+	opts.logUnknownSuppressedWarnings(c)
 	workload := &cartov1alpha1.Workload{}
 	fileWorkload := &cartov1alpha1.Workload{}
 
 	if opts.FilePath != "" {
-		if err := opts.WorkloadOptions.LoadInputWorkload(c.Stdin, fileWorkload); err != nil {
+		if err := opts.WorkloadOptions.LoadInputWorkload(ctx, c, fileWorkload); err != nil {
 			return err
 		}
 
@@ -66,29 +97,20 @@ func (opts *WorkloadCreateOptions) Exec(ctx context.Context, c *cli.Config) erro
 	if workload.Namespace == "" || cli.CommandFromContext(ctx).Flags().Changed(cli.StripDash(flags.NamespaceFlagName)) {
 		workload.Namespace = opts.Namespace
 	}
-
-	existingWorkload := &cartov1alpha1.Workload{}
-
-	if err := c.Get(ctx, client.ObjectKey{Namespace: workload.Namespace, Name: workload.Name}, existingWorkload); err != nil {
-		// return err, except when not found
-		if !apierrs.IsNotFound(err) {
-			return err
-		} else if apierrs.IsNotFound(err) {
-			if nsErr := validateNamespace(ctx, c, opts.Namespace); nsErr != nil {
-				return err
-			}
-		}
+	if err := opts.expandSourceImage(workload.Name); err != nil {
+		return err
 	}
 
-	// check if the workload exists
-	if existingWorkload != nil {
-		if existingWorkload.Name == workload.Name && existingWorkload.Namespace == workload.Namespace {
-			c.Printf("%s workload %q already exists\n", printer.Serrorf("Error:"), fmt.Sprintf("%s/%s", workload.Namespace, workload.Name))
-			return cli.SilenceError(errors.New(""))
+	if opts.OutputFile == "" {
+		if err := opts.checkWorkloadNotExists(ctx, c, workload); err != nil {
+			return err
 		}
 	}
 
 	ctx = opts.ApplyOptionsToWorkload(ctx, nil, workload)
+	if err := opts.ApplyEnvFromConfigMaps(ctx, c, workload); err != nil {
+		return err
+	}
 
 	// validate complex flag interactions with existing state
 	errs := workload.Validate()
@@ -98,9 +120,20 @@ func (opts *WorkloadCreateOptions) Exec(ctx context.Context, c *cli.Config) erro
 		return err
 	}
 
+	if err := opts.checkAllowedGitHost(nil, workload); err != nil {
+		return err
+	}
+
+	if err := opts.PinImageDigest(ctx, workload); err != nil {
+		return err
+	}
+
 	if opts.DryRun {
-		cli.DryRunResource(ctx, workload, workload.GetGroupVersionKind())
-		return nil
+		return opts.DryRunWorkload(ctx, c, workload)
+	}
+
+	if opts.OutputFile != "" {
+		return opts.writeOutputFile(c, workload)
 	}
 
 	var okToCreate bool
@@ -113,6 +146,11 @@ func (opts *WorkloadCreateOptions) Exec(ctx context.Context, c *cli.Config) erro
 
 	shouldPrint := opts.Output == "" || (opts.Output != "" && !opts.Yes)
 
+	// the type is left to its default, make sure a supply chain picks the workload up
+	if shouldPrint && !cli.CommandFromContext(ctx).Flags().Changed(cli.StripDash(flags.TypeFlagName)) {
+		opts.checkSupplyChainMatch(ctx, c, workload)
+	}
+
 	if err := opts.PublishLocalSource(ctx, c, nil, workload, shouldPrint); err != nil {
 		return err
 	}
@@ -144,7 +182,7 @@ func (opts *WorkloadCreateOptions) Exec(ctx context.Context, c *cli.Config) erro
 		if opts.Wait || anyTail {
 			cli.PrintPrompt(shouldPrint, c.Infof, "Waiting for workload %q to become ready...\n", opts.Name)
 
-			workers = append(workers, getReadyConditionWorker(c, workload))
+			workers = append(workers, getReadyConditionWorker(c, workload, opts.WaitInterval, opts.FailOnUnknownReasons))
 
 			if anyTail {
 				workers = append(workers, getTailWorker(c, workload, opts.TailTimestamps))
@@ -178,6 +216,48 @@ func (opts *WorkloadCreateOptions) Exec(ctx context.Context, c *cli.Config) erro
 	return nil
 }
 
+// checkWorkloadNotExists fails when a workload with the same name already exists in the namespace
+func (opts *WorkloadCreateOptions) checkWorkloadNotExists(ctx context.Context, c *cli.Config, workload *cartov1alpha1.Workload) error {
+	existingWorkload := &cartov1alpha1.Workload{}
+
+	// with a server dry run the api server checks the namespace, that the user may not be allowed to
+	// read
+	if err := getWorkloadValidatingNamespace(ctx, c, opts.Retry, client.ObjectKey{Namespace: workload.Namespace, Name: workload.Name}, existingWorkload, opts.DryRunStrategy != serverDryRunStrategy); err != nil {
+		// return err, except when the workload is not found
+		if !apierrs.IsNotFound(err) || errors.Is(err, errNamespaceNotFound) {
+			return err
+		}
+	}
+
+	// check if the workload exists
+	if existingWorkload != nil {
+		if existingWorkload.Name == workload.Name && existingWorkload.Namespace == workload.Namespace {
+			c.Printf("%s workload %q already exists\n", printer.Serrorf("Error:"), fmt.Sprintf("%s/%s", workload.Namespace, workload.Name))
+			return cli.SilenceError(errors.New(""))
+		}
+	}
+	return nil
+}
+
+// writeOutputFile saves the workload to the --output-file path, formatted like --output and
+// defaulting to yaml, instead of creating it in the cluster
+func (opts *WorkloadCreateOptions) writeOutputFile(c *cli.Config, workload *cartov1alpha1.Workload) error {
+	format := printer.OutputFormat(opts.Output)
+	if format == "" {
+		format = printer.OutputFormat(printer.OutputFormatYaml)
+	}
+	export, err := opts.outputResource(workload, format, c.Scheme)
+	if err != nil {
+		c.Eprintf("%s %s\n", printer.Serrorf("Failed to output workload:"), err)
+		return cli.SilenceError(err)
+	}
+	if err := os.WriteFile(opts.OutputFile, []byte(export+"\n"), 0644); err != nil {
+		return fmt.Errorf("unable to write workload file %q: %w", opts.OutputFile, err)
+	}
+	c.Emoji(cli.FloppyDisk, "Saved workload %q to %s\n", workload.Name, opts.OutputFile)
+	return nil
+}
+
 func (opts *WorkloadCreateOptions) IsDryRun() bool {
 	return opts.DryRun
 }
@@ -202,6 +282,7 @@ Workload configuration options include:
 			fmt.Sprintf("%s workload create my-workload %s https://example.com/my-workload.git %s my-branch", c.Name, flags.GitRepoFlagName, flags.GitBranchFlagName),
 			fmt.Sprintf("%s workload create my-workload %s . %s registry.example/repository:tag", c.Name, flags.LocalPathFlagName, flags.SourceImageFlagName),
 			fmt.Sprintf("%s workload create %s workload.yaml", c.Name, flags.FilePathFlagName),
+			fmt.Sprintf("%s workload create my-workload %s registry.example/repository:tag %s workload.yaml", c.Name, flags.ImageFlagName, flags.OutputFileFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateE(ctx, opts),
 		RunE:    cli.ExecE(ctx, c, opts),
@@ -213,6 +294,8 @@ Workload configuration options include:
 
 	// Define common flags
 	opts.DefineFlags(ctx, c, cmd)
+	cmd.Flags().BoolVar(&opts.Bare, cli.StripDash(flags.BareFlagName), false, "with "+flags.DryRunFlagName+" or "+flags.OutputFileFlagName+", leave out the "+apis.WorkloadTypeLabelName+" label added by default when "+flags.TypeFlagName+" is not set, the status and the metadata populated by the cluster, for a minimal manifest")
+	cmd.Flags().StringVar(&opts.OutputFile, cli.StripDash(flags.OutputFileFlagName), "", "write the workload to the file `path`, formatted with "+flags.OutputFlagName+" (yaml by default), instead of creating it; the cluster is not contacted")
 
 	// Bind flags to environment variables
 	opts.DefineEnvVars(ctx, c, cmd)
@@ -31,10 +31,12 @@ import (
 	diecorev1 "dies.dev/apis/core/v1"
 	diemetav1 "dies.dev/apis/meta/v1"
 	"github.com/Netflix/go-expect"
+	"github.com/google/go-cmp/cmp"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/mock"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -92,6 +94,63 @@ func TestWorkloadCreateOptionsValidate(t *testing.T) {
 			},
 			ExpectFieldErrors: validation.ErrInvalidArrayValue("FOO", flags.BuildEnvFlagName, 0),
 		},
+		{
+			Name: "output patch",
+			Validatable: &commands.WorkloadCreateOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+					Output:    "patch",
+				},
+			},
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("patch", flags.OutputFlagName, "only supported by workload apply"),
+		},
+		{
+			Name: "output helm values",
+			Validatable: &commands.WorkloadCreateOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+					Output:    "helm-values",
+				},
+			},
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("helm-values", flags.OutputFlagName, "only supported by workload apply"),
+		},
+		{
+			Name: "output file with dry run",
+			Validatable: &commands.WorkloadCreateOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+					DryRun:    true,
+				},
+				OutputFile: "workload.yaml",
+			},
+			ExpectFieldErrors: validation.ErrMultipleOneOf(flags.OutputFileFlagName, flags.DryRunFlagName),
+		},
+		{
+			Name: "output file with wait",
+			Validatable: &commands.WorkloadCreateOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+					Wait:      true,
+				},
+				OutputFile: "workload.yaml",
+			},
+			ExpectFieldErrors: validation.ErrMultipleOneOf(flags.OutputFileFlagName, flags.WaitFlagName),
+		},
+		{
+			Name: "bare without dry run or output file",
+			Validatable: &commands.WorkloadCreateOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+					Bare:      true,
+				},
+			},
+			ExpectFieldErrors: validation.ErrMissingOneOfWithDetail("required by "+flags.BareFlagName, flags.DryRunFlagName, flags.OutputFileFlagName),
+		},
 		{
 			Name: "apply with multiple sources",
 			Validatable: &commands.WorkloadCreateOptions{
@@ -182,6 +241,168 @@ status:
   supplyChainRef: {}
 `,
 		},
+		{
+			Name:         "dry run annotated for overlay",
+			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.EnvFlagName, "FOO=bar", flags.BareFlagName, flags.AnnotateForOverlayFlagName, flags.DryRunFlagName, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			ExpectOutput: `
+#@ load("@ytt:overlay", "overlay")
+#@overlay/match by=overlay.subset({"kind":"Workload","metadata":{"name":"my-workload","namespace":"default"}})
+#@overlay/match-child-defaults missing_ok=True
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: my-workload
+  namespace: default
+spec:
+  env:
+  #@overlay/match by="name"
+  - name: FOO
+    value: bar
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://example.com/repo.git
+`,
+		},
+		{
+			Name: "dry run server without namespace read",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.DryRunFlagName + "=server", flags.YesFlagName},
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("get", "Namespace", clitesting.InduceFailureOpts{
+					Error: apierrors.NewNotFound(corev1.Resource("Namespace"), defaultNamespace),
+				}),
+			},
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  labels:
+    apps.tanzu.vmware.com/workload-type: web
+  name: my-workload
+  namespace: default
+spec:
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://example.com/repo.git
+status:
+  supplyChainRef: {}
+`,
+		},
+		{
+			Name: "dry run client without namespace read",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.DryRunFlagName + "=client", flags.YesFlagName},
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("get", "Namespace", clitesting.InduceFailureOpts{
+					Error: apierrors.NewNotFound(corev1.Resource("Namespace"), defaultNamespace),
+				}),
+			},
+			ShouldError: true,
+			ExpectOutput: `
+Error: namespace "default" not found, it may not exist or user does not have permissions to read it.
+`,
+		},
+		{
+			Name:        "invalid dry run strategy",
+			Args:        []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.DryRunFlagName + "=always"},
+			ShouldError: true,
+		},
+		func() clitesting.CommandTestCase {
+			outputFile := filepath.Join(t.TempDir(), "workload.yaml")
+			return clitesting.CommandTestCase{
+				Name: "output file without a cluster",
+				Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.OutputFileFlagName, outputFile},
+				ExpectOutput: fmt.Sprintf(`
+💾 Saved workload "my-workload" to %s
+`, outputFile),
+				Verify: func(t *testing.T, output string, err error) {
+					content, readErr := os.ReadFile(outputFile)
+					if readErr != nil {
+						t.Fatalf("unable to read output file: %v", readErr)
+					}
+					expected := `---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  labels:
+    apps.tanzu.vmware.com/workload-type: web
+  name: my-workload
+  namespace: default
+spec:
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://example.com/repo.git
+status:
+  supplyChainRef: {}
+`
+					if diff := cmp.Diff(expected, string(content)); diff != "" {
+						t.Errorf("output file (-expected, +actual) = %s", diff)
+					}
+				},
+			}
+		}(),
+		func() clitesting.CommandTestCase {
+			outputFile := filepath.Join(t.TempDir(), "workload.yaml")
+			return clitesting.CommandTestCase{
+				Name: "bare output file",
+				Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.OutputFileFlagName, outputFile, flags.BareFlagName},
+				ExpectOutput: fmt.Sprintf(`
+💾 Saved workload "my-workload" to %s
+`, outputFile),
+				Verify: func(t *testing.T, output string, err error) {
+					content, readErr := os.ReadFile(outputFile)
+					if readErr != nil {
+						t.Fatalf("unable to read output file: %v", readErr)
+					}
+					expected := `---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: my-workload
+  namespace: default
+spec:
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://example.com/repo.git
+`
+					if diff := cmp.Diff(expected, string(content)); diff != "" {
+						t.Errorf("output file (-expected, +actual) = %s", diff)
+					}
+				},
+			}
+		}(),
 		{
 			Name: "create - output yaml with wait",
 			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch,
@@ -564,7 +785,7 @@ Workload "my-workload" is ready
 
 				tailer := &logs.FakeTailer{}
 				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Minute, false).Return(nil).Once()
+				tailer.On("Tail", mock.Anything, "default", selector, logs.TailOptions{Containers: []string{}, Since: time.Minute, TailLines: -1, Follow: true}).Return(nil).Once()
 				ctx = logs.StashTailer(ctx, tailer)
 
 				return ctx, nil
@@ -651,7 +872,7 @@ Workload "my-workload" is ready
 
 				tailer := &logs.FakeTailer{}
 				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Minute, true).Return(nil).Once()
+				tailer.On("Tail", mock.Anything, "default", selector, logs.TailOptions{Containers: []string{}, Since: time.Minute, TailLines: -1, Timestamps: true, Follow: true}).Return(nil).Once()
 				ctx = logs.StashTailer(ctx, tailer)
 
 				return ctx, nil
@@ -1180,6 +1401,169 @@ To get status: "tanzu apps workload get my-workload"
 			Args:        []string{workloadName, flags.MavenArtifactFlagName, "spring-petclinic", flags.MavenVersionFlagName, "1.2.3", flags.YesFlagName},
 			ShouldError: true,
 		},
+		{
+			Name: "create - no supply chain selects the workload",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.YesFlagName},
+			GivenObjects: append(givenNamespaceDefault,
+				diecartov1alpha1.ClusterSupplyChainBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("source-to-url")
+					}).
+					SpecDie(func(d *diecartov1alpha1.SupplyChainSpecDie) {
+						d.Selector(map[string]string{apis.WorkloadTypeLabelName: "server"})
+					}),
+				diecartov1alpha1.ClusterSupplyChainBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("basic-image-to-url")
+					}).
+					SpecDie(func(d *diecartov1alpha1.SupplyChainSpecDie) {
+						d.SelectorMatchExpressions(metav1.LabelSelectorRequirement{
+							Key:      apis.WorkloadTypeLabelName,
+							Operator: metav1.LabelSelectorOpIn,
+							Values:   []string{"server", "worker"},
+						})
+						d.SelectorMatchFields(cartov1alpha1.FieldSelectorRequirement{
+							Key:      "spec.image",
+							Operator: "Exists",
+						})
+					}),
+			),
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+					},
+				},
+			},
+			ExpectOutput: `
+❗ WARNING: no supply chain selects workload "default/my-workload", use --type to pick one of the supply chains below
+  basic-image-to-url: apps.tanzu.vmware.com/workload-type in (server,worker), spec.image Exists
+  source-to-url: apps.tanzu.vmware.com/workload-type=server
+
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  image: ubuntu:bionic
+👍 Created workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name: "create - supply chain selects the workload",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.YesFlagName},
+			GivenObjects: append(givenNamespaceDefault,
+				diecartov1alpha1.ClusterSupplyChainBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("basic-image-to-url")
+					}).
+					SpecDie(func(d *diecartov1alpha1.SupplyChainSpecDie) {
+						d.Selector(map[string]string{apis.WorkloadTypeLabelName: "web"})
+						d.SelectorMatchFields(cartov1alpha1.FieldSelectorRequirement{
+							Key:      "spec.image",
+							Operator: "Exists",
+						})
+					}),
+			),
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+					},
+				},
+			},
+			ExpectOutput: `
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  image: ubuntu:bionic
+👍 Created workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
+		{
+			Name: "create - supply chains can't be listed",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.YesFlagName},
+			GivenObjects: append(givenNamespaceDefault,
+				diecartov1alpha1.ClusterSupplyChainBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("source-to-url")
+					}).
+					SpecDie(func(d *diecartov1alpha1.SupplyChainSpecDie) {
+						d.Selector(map[string]string{apis.WorkloadTypeLabelName: "server"})
+					}),
+			),
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("list", "ClusterSupplyChainList", clitesting.InduceFailureOpts{
+					Error: apierrors.NewForbidden(cartov1alpha1.Resource("clustersupplychains"), "", fmt.Errorf("")),
+				}),
+			},
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+					},
+				},
+			},
+			ExpectOutput: `
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: default
+      9 + |spec:
+     10 + |  image: ubuntu:bionic
+👍 Created workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload"
+
+`,
+		},
 		{
 			Name: "create with multiple param-yaml using valid json and yaml",
 			Args: []string{flags.FilePathFlagName, "testdata/param-yaml.yaml",
@@ -1326,6 +1710,66 @@ To get status: "tanzu apps workload get spring-petclinic"
 To see logs:   "tanzu apps workload tail spring-petclinic --timestamp --since 1h"
 To get status: "tanzu apps workload get spring-petclinic"
 
+`,
+		}, {
+			Name: "git source with namespace from env var",
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				os.Setenv("TANZU_APPS_NAMESPACE", "my-custom-namespace")
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				os.Unsetenv("TANZU_APPS_NAMESPACE")
+				return nil
+			},
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				diecorev1.NamespaceBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("my-custom-namespace")
+					}),
+			},
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-custom-namespace",
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+🔎 Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    apps.tanzu.vmware.com/workload-type: web
+      7 + |  name: my-workload
+      8 + |  namespace: my-custom-namespace
+      9 + |spec:
+     10 + |  source:
+     11 + |    git:
+     12 + |      ref:
+     13 + |        branch: main
+     14 + |      url: https://example.com/repo.git
+👍 Created workload "my-workload"
+
+To see logs:   "tanzu apps workload tail my-workload --namespace my-custom-namespace --timestamp --since 1h"
+To get status: "tanzu apps workload get my-workload --namespace my-custom-namespace"
+
 `,
 		}, {
 			Name: "git source with non-allowed env var",
@@ -1494,6 +1938,24 @@ To see logs:   "tanzu apps workload tail my-workload --timestamp --since 1h"
 To get status: "tanzu apps workload get my-workload"
 
 `,
+		}, {
+			Name: "git source with host not allowed through env var",
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				os.Setenv("TANZU_APPS_ALLOWED_HOSTS", "github.com,gitlab.com")
+				return ctx, nil
+			},
+			GivenObjects: givenNamespaceDefault,
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				os.Unsetenv("TANZU_APPS_ALLOWED_HOSTS")
+				return nil
+			},
+			Args:        []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName},
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := `unable to use git repository "https://example.com/repo.git": host "example.com" is not in the allowed hosts (github.com, gitlab.com)`; err == nil || err.Error() != expected {
+					t.Errorf("expected error %q, got %v", expected, err)
+				}
+			},
 		}, {
 			Name:         "git source with terminal interaction",
 			GivenObjects: givenNamespaceDefault,
@@ -1817,7 +2279,7 @@ To get status: "tanzu apps workload get %s"`, workloadName, workloadName, worklo
 
 				tailer := &logs.FakeTailer{}
 				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Minute, false).Return(nil).Once()
+				tailer.On("Tail", mock.Anything, "default", selector, logs.TailOptions{Containers: []string{}, Since: time.Minute, TailLines: -1, Follow: true}).Return(nil).Once()
 				ctx = logs.StashTailer(ctx, tailer)
 
 				return ctx, nil
@@ -1935,7 +2397,7 @@ To get status: "tanzu apps workload get %s"`, workloadName, workloadName, worklo
 
 				tailer := &logs.FakeTailer{}
 				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Minute, false).Return(nil).Once()
+				tailer.On("Tail", mock.Anything, "default", selector, logs.TailOptions{Containers: []string{}, Since: time.Minute, TailLines: -1, Follow: true}).Return(nil).Once()
 				ctx = logs.StashTailer(ctx, tailer)
 
 				return ctx, nil
@@ -45,9 +45,10 @@ type WorkloadDeleteOptions struct {
 
 	FilePath string
 
-	Wait        bool
-	WaitTimeout time.Duration
-	Yes         bool
+	Wait         bool
+	WaitTimeout  time.Duration
+	WaitInterval time.Duration
+	Yes          bool
 }
 
 var (
@@ -60,6 +61,8 @@ func (opts *WorkloadDeleteOptions) Validate(_ context.Context) validation.FieldE
 
 	if opts.Namespace == "" {
 		errs = errs.Also(validation.ErrMissingField(flags.NamespaceFlagName))
+	} else {
+		errs = errs.Also(validation.K8sName(opts.Namespace, flags.NamespaceFlagName))
 	}
 
 	if opts.All && len(opts.Names) != 0 {
@@ -74,6 +77,10 @@ func (opts *WorkloadDeleteOptions) Validate(_ context.Context) validation.FieldE
 		errs = errs.Also(validation.ErrMissingOneOf(flags.AllFlagName, cli.NamesArgumentName, flags.FilePathFlagName))
 	}
 
+	if opts.WaitInterval < 0 {
+		errs = errs.Also(validation.ErrInvalidValueWithDetail(opts.WaitInterval.String(), flags.WaitIntervalFlagName, "must be a positive duration"))
+	}
+
 	return errs
 }
 
@@ -147,7 +154,7 @@ func (opts *WorkloadDeleteOptions) Exec(ctx context.Context, c *cli.Config) erro
 			c.Infof("Waiting for workload %q to be deleted...\n", name)
 			workers := []wait.Worker{
 				func(ctx context.Context) error {
-					return wait.UntilDelete(ctx, c.Client, workload)
+					return wait.UntilDeleteEvery(ctx, c.GetClock(), c.Client, workload, opts.WaitInterval)
 				},
 			}
 			if err := wait.Race(ctx, opts.WaitTimeout, workers); err != nil {
@@ -214,6 +221,8 @@ registry.
 	cmd.Flags().BoolVar(&opts.Wait, cli.StripDash(flags.WaitFlagName), false, "waits for workload to be deleted")
 	cmd.Flags().DurationVar(&opts.WaitTimeout, cli.StripDash(flags.WaitTimeoutFlagName), 1*time.Minute, "timeout for workload to be deleted when waiting")
 	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.WaitTimeoutFlagName), completion.SuggestDurationUnits(ctx, completion.CommonDurationUnits))
+	cmd.Flags().DurationVar(&opts.WaitInterval, cli.StripDash(flags.WaitIntervalFlagName), wait.BackOffTime, "`duration` between checks of whether the workload is deleted when waiting")
+	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.WaitIntervalFlagName), completion.SuggestDurationUnits(ctx, completion.CommonDurationUnits))
 	cmd.Flags().BoolVarP(&opts.Yes, cli.StripDash(flags.YesFlagName), "y", false, "accept all prompts")
 	cmd.Flags().StringVarP(&opts.FilePath, cli.StripDash(flags.FilePathFlagName), "f", "", "`file path` containing the description of a single workload, other flags are layered on top of this resource. Use value \"-\" to read from stdin")
 
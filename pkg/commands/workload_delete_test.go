@@ -74,7 +74,7 @@ func TestWorkloadDeleteOptionsValidate(t *testing.T) {
 				Namespace: "default-",
 				Names:     []string{"my"},
 			},
-			ShouldValidate: true,
+			ExpectFieldErrors: validation.ErrInvalidValue("default-", flags.NamespaceFlagName),
 		},
 		{
 			Name: "all",
@@ -121,6 +121,16 @@ func TestWorkloadDeleteOptionsValidate(t *testing.T) {
 			},
 			ShouldValidate: true,
 		},
+		{
+			Name: "negative wait interval",
+			Validatable: &commands.WorkloadDeleteOptions{
+				Namespace:    "default",
+				Names:        []string{"my-workload"},
+				Wait:         true,
+				WaitInterval: -time.Second,
+			},
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("-1s", flags.WaitIntervalFlagName, "must be a positive duration"),
+		},
 	}
 
 	table.Run(t)
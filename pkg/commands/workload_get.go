@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
@@ -43,8 +44,26 @@ type WorkloadGetOptions struct {
 	Namespace string
 	Name      string
 
-	Export bool
-	Output string
+	Export            bool
+	Output            string
+	ShowManagedFields bool
+	Fields            []string
+	Status            bool
+	Age               bool
+	FullTimestamps    bool
+	History           bool
+	ResolveDigests    bool
+	Params            bool
+	NoTruncate        bool
+	Pods              bool
+	Summary           bool
+	Retry             cli.RetryOptions
+
+	// the registry flags are used to resolve the digests, see --resolve-digests
+	CACertPaths      []string
+	RegistryUsername string
+	RegistryPassword string
+	RegistryToken    string
 }
 
 var (
@@ -57,6 +76,8 @@ func (opts *WorkloadGetOptions) Validate(ctx context.Context) validation.FieldEr
 
 	if opts.Namespace == "" {
 		errs = errs.Also(validation.ErrMissingField(flags.NamespaceFlagName))
+	} else {
+		errs = errs.Also(validation.K8sName(opts.Namespace, flags.NamespaceFlagName))
 	}
 
 	if opts.Name == "" {
@@ -64,15 +85,134 @@ func (opts *WorkloadGetOptions) Validate(ctx context.Context) validation.FieldEr
 	}
 
 	if opts.Output != "" {
-		errs = errs.Also(validation.Enum(opts.Output, flags.OutputFlagName, []string{printer.OutputFormatJson, printer.OutputFormatYaml, printer.OutputFormatYml}))
+		errs = errs.Also(validateOutputWithCustomColumns(opts.Output))
+	}
+
+	if printer.IsCustomColumns(opts.Output) && (opts.Export || opts.ShowManagedFields) {
+		errs = errs.Also(validation.ErrInvalidValueWithDetail(opts.Output, flags.OutputFlagName, "custom-columns can't be used with "+flags.ExportFlagName+" or "+flags.ShowManagedFieldsFlagName))
+	}
+
+	if opts.ShowManagedFields {
+		if opts.Output == "" {
+			errs = errs.Also(validation.ErrMissingField(flags.OutputFlagName))
+		}
+		if opts.Export {
+			errs = errs.Also(validation.ErrMultipleSources(flags.ExportFlagName, flags.ShowManagedFieldsFlagName))
+		}
+	}
+
+	if len(opts.Fields) != 0 {
+		if opts.Output == "" {
+			errs = errs.Also(validation.ErrMissingField(flags.OutputFlagName))
+		}
+		if printer.IsCustomColumns(opts.Output) {
+			errs = errs.Also(validation.ErrInvalidValueWithDetail(opts.Output, flags.OutputFlagName, "custom-columns can't be used with "+flags.FieldsFlagName))
+		}
+		if opts.Export {
+			errs = errs.Also(validation.ErrMultipleSources(flags.ExportFlagName, flags.FieldsFlagName))
+		}
+		for _, path := range opts.Fields {
+			if !isValidFieldPath(path) {
+				errs = errs.Also(validation.ErrInvalidValueWithDetail(path, flags.FieldsFlagName, "expected a dotted path under apiVersion, kind, metadata, spec or status, like spec.source"))
+			}
+		}
+	}
+
+	if opts.Status {
+		if printer.IsCustomColumns(opts.Output) {
+			errs = errs.Also(validation.ErrInvalidValueWithDetail(opts.Output, flags.OutputFlagName, "custom-columns can't be used with "+flags.StatusFlagName))
+		}
+		if opts.Export {
+			errs = errs.Also(validation.ErrMultipleSources(flags.ExportFlagName, flags.StatusFlagName))
+		}
+		if opts.ShowManagedFields {
+			errs = errs.Also(validation.ErrMultipleSources(flags.ShowManagedFieldsFlagName, flags.StatusFlagName))
+		}
+		if len(opts.Fields) != 0 {
+			errs = errs.Also(validation.ErrMultipleSources(flags.FieldsFlagName, flags.StatusFlagName))
+		}
+	}
+
+	if opts.Params {
+		if opts.Output != "" {
+			errs = errs.Also(validation.ErrMultipleSources(flags.OutputFlagName, flags.ParamsFlagName))
+		}
+		if opts.Export {
+			errs = errs.Also(validation.ErrMultipleSources(flags.ExportFlagName, flags.ParamsFlagName))
+		}
+		if opts.Status {
+			errs = errs.Also(validation.ErrMultipleSources(flags.StatusFlagName, flags.ParamsFlagName))
+		}
+	}
+	if opts.NoTruncate && !opts.Params {
+		errs = errs.Also(validation.ErrMissingField(flags.ParamsFlagName))
+	}
+
+	if opts.ResolveDigests {
+		if opts.Output != "" {
+			errs = errs.Also(validation.ErrMultipleSources(flags.OutputFlagName, flags.ResolveDigestsFlagName))
+		}
+		if opts.Export {
+			errs = errs.Also(validation.ErrMultipleSources(flags.ExportFlagName, flags.ResolveDigestsFlagName))
+		}
+		if opts.Status {
+			errs = errs.Also(validation.ErrMultipleSources(flags.StatusFlagName, flags.ResolveDigestsFlagName))
+		}
+	}
+
+	if opts.Pods {
+		if opts.Output != "" {
+			errs = errs.Also(validation.ErrMultipleSources(flags.OutputFlagName, flags.PodsFlagName))
+		}
+		if opts.Export {
+			errs = errs.Also(validation.ErrMultipleSources(flags.ExportFlagName, flags.PodsFlagName))
+		}
+		if opts.Status {
+			errs = errs.Also(validation.ErrMultipleSources(flags.StatusFlagName, flags.PodsFlagName))
+		}
+	}
+
+	if (opts.RegistryPassword != "" || opts.RegistryUsername != "" || opts.RegistryToken != "" || len(opts.CACertPaths) != 0) && !opts.ResolveDigests {
+		errs = errs.Also(validation.ErrMissingField(flags.ResolveDigestsFlagName))
+	}
+
+	if opts.Summary {
+		if opts.Output != "" {
+			errs = errs.Also(validation.ErrMultipleSources(flags.OutputFlagName, flags.SummaryFlagName))
+		}
+		if opts.Export {
+			errs = errs.Also(validation.ErrMultipleSources(flags.ExportFlagName, flags.SummaryFlagName))
+		}
+		if opts.Status {
+			errs = errs.Also(validation.ErrMultipleSources(flags.StatusFlagName, flags.SummaryFlagName))
+		}
 	}
 
 	return errs
 }
 
+// isValidFieldPath returns true for dotted paths, like spec.source.git, that start at one of the
+// top level fields of a workload and have no empty segment
+func isValidFieldPath(path string) bool {
+	fields := strings.Split(path, ".")
+	switch fields[0] {
+	case "apiVersion", "kind", "metadata", "spec", "status":
+	default:
+		return false
+	}
+	for _, f := range fields {
+		if f == "" {
+			return false
+		}
+	}
+	return true
+}
+
 func (opts *WorkloadGetOptions) Exec(ctx context.Context, c *cli.Config) error {
 	workload := &cartov1alpha1.Workload{}
-	err := c.Get(ctx, client.ObjectKey{Namespace: opts.Namespace, Name: opts.Name}, workload)
+	err := cli.Retry(ctx, c.GetClock(), retryLogger(c), opts.Retry, func() error {
+		return c.Get(ctx, client.ObjectKey{Namespace: opts.Namespace, Name: opts.Name}, workload)
+	})
 	if err != nil {
 		if apierrs.IsNotFound(err) {
 			nsGet := &corev1.Namespace{}
@@ -104,8 +244,46 @@ func (opts *WorkloadGetOptions) Exec(ctx context.Context, c *cli.Config) error {
 		return nil
 	}
 
+	if printer.IsCustomColumns(opts.Output) {
+		columns, err := printer.ParseCustomColumns(opts.Output)
+		if err != nil {
+			return err
+		}
+		return printer.OutputCustomColumns(c.Stdout, []printer.Object{workload}, columns, false)
+	}
+
+	if opts.Status {
+		format := printer.OutputFormat(printer.OutputFormatYaml)
+		if opts.Output != "" {
+			format = printer.OutputFormat(opts.Output)
+		}
+		export, err := printer.OutputResourceStatus(workload, format)
+		if err != nil {
+			c.Eprintf("%s %s\n", printer.Serrorf("Failed to output workload status:"), err)
+			return cli.SilenceError(err)
+		}
+
+		c.Printf("%s\n", export)
+		return nil
+	}
+
+	if len(opts.Fields) != 0 {
+		export, err := printer.OutputResourceFields(workload, printer.OutputFormat(opts.Output), c.Scheme, opts.Fields)
+		if err != nil {
+			c.Eprintf("%s %s\n", printer.Serrorf("Failed to output workload:"), err)
+			return cli.SilenceError(err)
+		}
+
+		c.Printf("%s\n", export)
+		return nil
+	}
+
 	if opts.Output != "" {
-		export, err := printer.OutputResource(workload, printer.OutputFormat(opts.Output), c.Scheme)
+		outputResource := printer.OutputResource
+		if opts.ShowManagedFields {
+			outputResource = printer.OutputResourceWithManagedFields
+		}
+		export, err := outputResource(workload, printer.OutputFormat(opts.Output), c.Scheme)
 		if err != nil {
 			c.Eprintf("%s %s\n", printer.Serrorf("Failed to output workload:"), err)
 			return cli.SilenceError(err)
@@ -115,9 +293,19 @@ func (opts *WorkloadGetOptions) Exec(ctx context.Context, c *cli.Config) error {
 		return nil
 	}
 
+	timestamps := printer.TimestampOptions{Now: c.Now(), Full: opts.FullTimestamps}
+
+	// print a one row summary, like the workload list, before the details
+	if opts.Summary {
+		if err := printer.WorkloadSummaryPrinter(c.Stdout, workload, timestamps); err != nil {
+			return err
+		}
+		c.Printf("\n")
+	}
+
 	//print workload details
 	c.Emoji(cli.Antenna, cliprinter.Sboldf("Overview\n"))
-	if err := printer.WorkloadOverviewPrinter(c.Stdout, workload); err != nil {
+	if err := printer.WorkloadOverviewPrinter(c.Stdout, workload, opts.Age, timestamps); err != nil {
 		return err
 	}
 	c.Printf("\n")
@@ -126,14 +314,14 @@ func (opts *WorkloadGetOptions) Exec(ctx context.Context, c *cli.Config) error {
 		c.Emoji(cli.FloppyDisk, cliprinter.Sboldf("Source\n"))
 
 		if workload.Spec.Image != "" {
-			if err := printer.WorkloadSourceImagePrinter(c.Stdout, workload); err != nil {
+			if err := printer.WorkloadSourceImagePrinter(c.Stdout, workload, opts.resolveDigest(ctx, c, workload.Spec.Image)); err != nil {
 				return err
 			}
 		}
 
 		if workload.Spec.Source != nil {
 			if workload.Spec.Source.Image != "" {
-				if err := printer.WorkloadLocalSourceImagePrinter(c.Stdout, workload); err != nil {
+				if err := printer.WorkloadLocalSourceImagePrinter(c.Stdout, workload, opts.resolveDigest(ctx, c, workload.Spec.Source.Image)); err != nil {
 					return err
 				}
 			}
@@ -146,6 +334,16 @@ func (opts *WorkloadGetOptions) Exec(ctx context.Context, c *cli.Config) error {
 		c.Printf("\n")
 	}
 
+	if opts.Params {
+		c.Emoji(cli.Gear, cliprinter.Sboldf("Params\n"))
+		if len(workload.Spec.Params) == 0 {
+			c.Infof(printer.AddPaddingStart("No params found.\n"))
+		} else if err := printer.WorkloadParamsPrinter(c.Stdout, workload, opts.NoTruncate); err != nil {
+			return err
+		}
+		c.Printf("\n")
+	}
+
 	// Print workload supply chain
 	if workload.Status.SupplyChainRef == (cartov1alpha1.ObjectReference{}) && len(workload.Status.Conditions) == 0 {
 		c.Infof("Supply Chain reference not found.\n")
@@ -162,7 +360,7 @@ func (opts *WorkloadGetOptions) Exec(ctx context.Context, c *cli.Config) error {
 	if len(workload.Status.Resources) == 0 {
 		c.Infof(printer.AddPaddingStart("Supply Chain resources not found.\n"))
 	} else {
-		if err := printer.WorkloadResourcesPrinter(c.Stdout, workload); err != nil {
+		if err := printer.WorkloadResourcesPrinter(c.Stdout, workload, timestamps); err != nil {
 			return err
 		}
 	}
@@ -187,7 +385,7 @@ func (opts *WorkloadGetOptions) Exec(ctx context.Context, c *cli.Config) error {
 			c.Printf("\n")
 			if len(deliverable.Status.Resources) == 0 {
 				c.Infof(notFoundMsg)
-			} else if err := printer.DeliverableResourcesPrinter(c.Stdout, deliverable); err != nil {
+			} else if err := printer.DeliverableResourcesPrinter(c.Stdout, deliverable, timestamps); err != nil {
 				return err
 			}
 		}
@@ -211,6 +409,16 @@ func (opts *WorkloadGetOptions) Exec(ctx context.Context, c *cli.Config) error {
 		}
 	}
 
+	if opts.History {
+		c.Printf("\n")
+		c.Emoji(cli.Scroll, cliprinter.Sboldf("Condition History\n"))
+		if !hasConditions(workload) {
+			c.Infof(printer.AddPaddingStart("No conditions found.\n"))
+		} else if err := printer.WorkloadConditionHistoryPrinter(c.Stdout, workload, timestamps); err != nil {
+			return err
+		}
+	}
+
 	if len(workload.Spec.ServiceClaims) > 0 {
 		c.Printf("\n")
 		c.Emoji(cli.Repeat, cliprinter.Sboldf("Services\n"))
@@ -236,6 +444,24 @@ func (opts *WorkloadGetOptions) Exec(ctx context.Context, c *cli.Config) error {
 		}
 	}
 
+	if opts.Pods {
+		c.Printf("\n")
+		c.Emoji(cli.Canoe, cliprinter.Sboldf("Pod Readiness\n"))
+		pods := &corev1.PodList{}
+		if err := c.List(ctx, pods, client.InNamespace(workload.Namespace), client.MatchingLabels{cartov1alpha1.WorkloadLabelName: workload.Name}); err != nil {
+			c.Eerrorf(printer.AddPaddingStart("Failed to list pods:\n"))
+			c.Eprintf("%s\n", printer.AddPaddingStart("  "+err.Error()))
+		} else if len(pods.Items) == 0 {
+			c.Infof(printer.AddPaddingStart("No pods found for workload.\n"))
+		} else {
+			pods = pods.DeepCopy()
+			printer.SortByNamespaceAndName(pods.Items)
+			if err := printer.PodReadinessPrinter(c.Stdout, pods); err != nil {
+				return err
+			}
+		}
+	}
+
 	ksvcs := &knativeservingv1.ServiceList{}
 	_ = c.List(ctx, ksvcs, client.InNamespace(workload.Namespace), client.MatchingLabels{cartov1alpha1.WorkloadLabelName: workload.Name})
 	if len(ksvcs.Items) > 0 {
@@ -259,6 +485,34 @@ func (opts *WorkloadGetOptions) Exec(ctx context.Context, c *cli.Config) error {
 	return nil
 }
 
+// resolveDigest queries the registry for the digest the image reference points to, with
+// --resolve-digests. The registry errors, like missing credentials, are printed as a warning and no
+// digest is returned, so the rest of the workload is still shown.
+func (opts *WorkloadGetOptions) resolveDigest(ctx context.Context, c *cli.Config, image string) string {
+	if !opts.ResolveDigests {
+		return ""
+	}
+	ref, err := name.ParseReference(image, name.WeakValidation)
+	if err != nil {
+		c.Eprintf("%s unable to parse image %q: %v\n", cliprinter.Swarnf("Warning:"), image, err)
+		return ""
+	}
+	if digest, ok := ref.(name.Digest); ok {
+		return digest.DigestStr()
+	}
+	reg, err := source.NewRegistry(ctx, &source.RegistryOpts{CACertPaths: opts.CACertPaths, RegistryUsername: opts.RegistryUsername, RegistryPassword: opts.RegistryPassword, RegistryToken: opts.RegistryToken})
+	if err != nil {
+		c.Eprintf("%s unable to resolve digest for image %q: %v\n", cliprinter.Swarnf("Warning:"), image, err)
+		return ""
+	}
+	digest, err := reg.Digest(ref)
+	if err != nil {
+		c.Eprintf("%s unable to resolve digest for image %q: %v\n", cliprinter.Swarnf("Warning:"), image, err)
+		return ""
+	}
+	return digest.String()
+}
+
 func NewWorkloadGetCommand(ctx context.Context, c *cli.Config) *cobra.Command {
 	opts := &WorkloadGetOptions{}
 
@@ -268,6 +522,9 @@ func NewWorkloadGetCommand(ctx context.Context, c *cli.Config) *cobra.Command {
 		Long:  strings.TrimSpace(`Get details from a workload`),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s workload get my-workload", c.Name),
+			fmt.Sprintf("%s workload get my-workload %s %s", c.Name, flags.AgeFlagName, flags.FullTimestampsFlagName),
+			fmt.Sprintf("%s workload get my-workload %s", c.Name, flags.HistoryFlagName),
+			fmt.Sprintf("%s workload get my-workload %s yaml %s spec.source", c.Name, flags.OutputFlagName, flags.FieldsFlagName),
 		}, "\n"),
 		PreRunE:           cli.ValidateE(ctx, opts),
 		RunE:              cli.ExecE(ctx, c, opts),
@@ -280,7 +537,23 @@ func NewWorkloadGetCommand(ctx context.Context, c *cli.Config) *cobra.Command {
 
 	cli.NamespaceFlag(ctx, cmd, c, &opts.Namespace)
 	cmd.Flags().BoolVarP(&opts.Export, cli.StripDash(flags.ExportFlagName), "e", false, "export workload in yaml format")
-	cmd.Flags().StringVarP(&opts.Output, cli.StripDash(flags.OutputFlagName), "o", "", "output the Workload formatted. Supported formats: \"json\", \"yaml\", \"yml\"")
+	cmd.Flags().StringVarP(&opts.Output, cli.StripDash(flags.OutputFlagName), "o", "", "output the Workload formatted. Supported formats: \"json\", \"yaml\", \"yml\", \"custom-columns=<header>:<json-path>[,...]\"")
+	cmd.Flags().BoolVar(&opts.ShowManagedFields, cli.StripDash(flags.ShowManagedFieldsFlagName), false, "keep metadata.managedFields in the "+flags.OutputFlagName+" formatted workload")
+	cmd.Flags().StringSliceVar(&opts.Fields, cli.StripDash(flags.FieldsFlagName), []string{}, "only output the subtrees of the workload at the dotted `path`, like spec.source, with "+flags.OutputFlagName+" (can be specified multiple times)")
+	cmd.Flags().BoolVar(&opts.Status, cli.StripDash(flags.StatusFlagName), false, "only output the status of the workload, in yaml or the "+flags.OutputFlagName+" format")
+	cmd.Flags().BoolVar(&opts.Age, cli.StripDash(flags.AgeFlagName), false, "show how long ago the workload was created in the overview")
+	cmd.Flags().BoolVar(&opts.FullTimestamps, cli.StripDash(flags.FullTimestampsFlagName), false, "show absolute RFC3339 times instead of relative ages")
+	cmd.Flags().BoolVar(&opts.ResolveDigests, cli.StripDash(flags.ResolveDigestsFlagName), false, "query the registry for the digest of the workload image and source image, and show it in the source section")
+	cmd.Flags().StringArrayVar(&opts.CACertPaths, cli.StripDash(flags.RegistryCertFlagName), []string{}, "with "+flags.ResolveDigestsFlagName+", file path to CA certificate used to authenticate with registry, flag can be used multiple times")
+	cmd.Flags().StringVar(&opts.RegistryUsername, cli.StripDash(flags.RegistryUsernameFlagName), "", "with "+flags.ResolveDigestsFlagName+", username for authenticating with registry")
+	cmd.Flags().StringVar(&opts.RegistryPassword, cli.StripDash(flags.RegistryPasswordFlagName), "", "with "+flags.ResolveDigestsFlagName+", password for authenticating with registry")
+	cmd.Flags().StringVar(&opts.RegistryToken, cli.StripDash(flags.RegistryTokenFlagName), "", "with "+flags.ResolveDigestsFlagName+", token for authenticating with registry")
+	cmd.Flags().BoolVar(&opts.Params, cli.StripDash(flags.ParamsFlagName), false, "list the workload params in a name and value table")
+	cmd.Flags().BoolVar(&opts.NoTruncate, cli.StripDash(flags.NoTruncateFlagName), false, fmt.Sprintf("with %s, show the full param values instead of truncating them to %d characters", flags.ParamsFlagName, printer.MaxParamValueWidth))
+	cmd.Flags().BoolVar(&opts.Pods, cli.StripDash(flags.PodsFlagName), false, "list the pods of the workload with their phase, ready containers and restarts, and how many of them are ready")
+	cmd.Flags().BoolVar(&opts.Summary, cli.StripDash(flags.SummaryFlagName), false, "print a one row summary of the workload, like the workload list, before the details")
+	cmd.Flags().BoolVar(&opts.History, cli.StripDash(flags.HistoryFlagName), false, "list the workload and resource conditions ordered by their last transition time")
+	cli.RetryFlags(cmd, &opts.Retry)
 
 	return cmd
 }
@@ -294,6 +567,19 @@ func getWorkloadResourceByKind(workload *cartov1alpha1.Workload, kind string) *c
 	return nil
 }
 
+// hasConditions returns true when the workload or any of its resources reports a condition
+func hasConditions(workload *cartov1alpha1.Workload) bool {
+	if len(workload.Status.Conditions) != 0 {
+		return true
+	}
+	for _, r := range workload.Status.Resources {
+		if len(r.Conditions) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func areAllResourcesReady(resourcesConditions ...*metav1.Condition) bool {
 	for _, condition := range resourcesConditions {
 		if ready := condition == nil || (condition.Status == metav1.ConditionTrue || condition.Message == ""); !ready {
@@ -17,15 +17,26 @@ limitations under the License.
 package commands_test
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	neturl "net/url"
+	"strings"
 	"testing"
 	"time"
 
 	diecorev1 "dies.dev/apis/core/v1"
 	diemetav1 "dies.dev/apis/meta/v1"
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/apis"
@@ -73,7 +84,7 @@ func TestWorkloadGetOptionsValidate(t *testing.T) {
 				Namespace: "default-",
 				Name:      "my",
 			},
-			ShouldValidate: true,
+			ExpectFieldErrors: validation.ErrInvalidValue("default-", flags.NamespaceFlagName),
 		},
 		{
 			Name: "export",
@@ -102,6 +113,245 @@ func TestWorkloadGetOptionsValidate(t *testing.T) {
 			},
 			ExpectFieldErrors: validation.EnumInvalidValue("myFormat", flags.OutputFlagName, []string{"json", "yaml", "yml"}),
 		},
+		{
+			Name: "show managed fields with output",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace:         "default",
+				Name:              "my-workload",
+				Output:            "yaml",
+				ShowManagedFields: true,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "show managed fields without output",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace:         "default",
+				Name:              "my-workload",
+				ShowManagedFields: true,
+			},
+			ExpectFieldErrors: validation.ErrMissingField(flags.OutputFlagName),
+		},
+		{
+			Name: "show managed fields with export",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace:         "default",
+				Name:              "my-workload",
+				Export:            true,
+				Output:            "yaml",
+				ShowManagedFields: true,
+			},
+			ExpectFieldErrors: validation.ErrMultipleSources(flags.ExportFlagName, flags.ShowManagedFieldsFlagName),
+		},
+		{
+			Name: "custom columns output",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Output:    "custom-columns=NAME:.metadata.name",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid custom columns output",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Output:    "custom-columns=NAME",
+			},
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("custom-columns=NAME", flags.OutputFlagName, `column "NAME" must be in the form <header>:<json-path>`),
+		},
+		{
+			Name: "custom columns output with export",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Export:    true,
+				Output:    "custom-columns=NAME:.metadata.name",
+			},
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("custom-columns=NAME:.metadata.name", flags.OutputFlagName, "custom-columns can't be used with --export or --show-managed-fields"),
+		},
+		{
+			Name: "fields with output",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Output:    "yaml",
+				Fields:    []string{"spec.source", "metadata.labels"},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "fields without output",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Fields:    []string{"spec.source"},
+			},
+			ExpectFieldErrors: validation.ErrMissingField(flags.OutputFlagName),
+		},
+		{
+			Name: "fields with export",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Export:    true,
+				Output:    "yaml",
+				Fields:    []string{"spec.source"},
+			},
+			ExpectFieldErrors: validation.ErrMultipleSources(flags.ExportFlagName, flags.FieldsFlagName),
+		},
+		{
+			Name: "fields with custom columns output",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Output:    "custom-columns=NAME:.metadata.name",
+				Fields:    []string{"spec.source"},
+			},
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("custom-columns=NAME:.metadata.name", flags.OutputFlagName, "custom-columns can't be used with --fields"),
+		},
+		{
+			Name: "invalid fields",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Output:    "yaml",
+				Fields:    []string{"source.git", "spec..image"},
+			},
+			ExpectFieldErrors: validation.FieldErrors{}.Also(
+				validation.ErrInvalidValueWithDetail("source.git", flags.FieldsFlagName, "expected a dotted path under apiVersion, kind, metadata, spec or status, like spec.source"),
+				validation.ErrInvalidValueWithDetail("spec..image", flags.FieldsFlagName, "expected a dotted path under apiVersion, kind, metadata, spec or status, like spec.source"),
+			),
+		},
+		{
+			Name: "status",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Output:    "json",
+				Status:    true,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "status with export",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Export:    true,
+				Status:    true,
+			},
+			ExpectFieldErrors: validation.ErrMultipleSources(flags.ExportFlagName, flags.StatusFlagName),
+		},
+		{
+			Name: "status with fields",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Output:    "yaml",
+				Fields:    []string{"spec.source"},
+				Status:    true,
+			},
+			ExpectFieldErrors: validation.ErrMultipleSources(flags.FieldsFlagName, flags.StatusFlagName),
+		},
+		{
+			Name: "params with no truncate",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace:  "default",
+				Name:       "my-workload",
+				Params:     true,
+				NoTruncate: true,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "registry credentials without resolve digests",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace:        "default",
+				Name:             "my-workload",
+				RegistryUsername: "my-user",
+				RegistryPassword: "my-password",
+			},
+			ExpectFieldErrors: validation.ErrMissingField(flags.ResolveDigestsFlagName),
+		},
+		{
+			Name: "summary with output",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Output:    "yaml",
+				Summary:   true,
+			},
+			ExpectFieldErrors: validation.ErrMultipleSources(flags.OutputFlagName, flags.SummaryFlagName),
+		},
+		{
+			Name: "params with output",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Output:    "yaml",
+				Params:    true,
+			},
+			ExpectFieldErrors: validation.ErrMultipleSources(flags.OutputFlagName, flags.ParamsFlagName),
+		},
+		{
+			Name: "no truncate without params",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace:  "default",
+				Name:       "my-workload",
+				NoTruncate: true,
+			},
+			ExpectFieldErrors: validation.ErrMissingField(flags.ParamsFlagName),
+		},
+		{
+			Name: "pods",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Pods:      true,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "pods with output",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Output:    "yaml",
+				Pods:      true,
+			},
+			ExpectFieldErrors: validation.ErrMultipleSources(flags.OutputFlagName, flags.PodsFlagName),
+		},
+		{
+			Name: "resolve digests",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace:      "default",
+				Name:           "my-workload",
+				ResolveDigests: true,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "resolve digests with output",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace:      "default",
+				Name:           "my-workload",
+				Output:         "yaml",
+				ResolveDigests: true,
+			},
+			ExpectFieldErrors: validation.ErrMultipleSources(flags.OutputFlagName, flags.ResolveDigestsFlagName),
+		},
+		{
+			Name: "resolve digests with export",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace:      "default",
+				Name:           "my-workload",
+				Export:         true,
+				ResolveDigests: true,
+			},
+			ExpectFieldErrors: validation.ErrMultipleSources(flags.ExportFlagName, flags.ResolveDigestsFlagName),
+		},
 	}
 
 	table.Run(t)
@@ -116,8 +366,34 @@ func TestWorkloadGetCommand(t *testing.T) {
 	_ = cartov1alpha1.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 	_ = knativeservingv1.AddToScheme(scheme)
+	now := time.Date(2023, time.March, 10, 12, 0, 0, 0, time.UTC)
 	objTimeStamp := metav1.NewTime(time.Now().AddDate(-2, 0, 0))
 
+	regServer := httptest.NewServer(ggcrregistry.New())
+	defer regServer.Close()
+	regURL, err := neturl.Parse(regServer.URL)
+	utilruntime.Must(err)
+	registryHost := regURL.Host
+	imgDigest, err := empty.Image.Digest()
+	utilruntime.Must(err)
+	imgRef, err := name.ParseReference(fmt.Sprintf("%s/hello:v1", registryHost))
+	utilruntime.Must(err)
+	utilruntime.Must(remote.Write(imgRef, empty.Image))
+
+	// a registry that only serves the authenticated requests
+	authRegServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "my-user" || password != "my-password" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		regServer.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer authRegServer.Close()
+	authRegURL, err := neturl.Parse(authRegServer.URL)
+	utilruntime.Must(err)
+	authRegistryHost := authRegURL.Host
+
 	parent := diecartov1alpha1.WorkloadBlank.
 		MetadataDie(func(d *diemetav1.ObjectMetaDie) {
 			d.Name(workloadName)
@@ -203,6 +479,106 @@ No pods found for workload.
 
 To see logs: "tanzu apps workload tail my-workload --timestamp --since 1h"
 
+`,
+		}, {
+			Name: "no supply chain info with age",
+			Args: []string{workloadName, flags.AgeFlagName},
+			Now:  time.Date(2021, time.September, 13, 15, 0, 0, 0, time.UTC),
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.CreationTimestamp(metav1.Date(2021, time.September, 10, 15, 0, 0, 0, time.UTC))
+					}),
+			},
+			ExpectOutput: `
+📡 Overview
+   name:        my-workload
+   type:        <empty>
+   namespace:   default
+   age:         3d
+
+Supply Chain reference not found.
+
+   Supply Chain resources not found.
+
+🚚 Delivery
+
+   Delivery resources not found.
+
+💬 Messages
+   No messages found.
+
+No pods found for workload.
+
+To see logs: "tanzu apps workload tail my-workload --timestamp --since 1h"
+
+`,
+		}, {
+			Name: "no supply chain info with summary",
+			Args: []string{workloadName, flags.SummaryFlagName},
+			Now:  time.Date(2021, time.September, 13, 15, 0, 0, 0, time.UTC),
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.CreationTimestamp(metav1.Date(2021, time.September, 10, 15, 0, 0, 0, time.UTC))
+					}),
+			},
+			ExpectOutput: `
+NAME          TYPE      SOURCE    READY       AGE
+my-workload   <empty>   <empty>   <unknown>   3d
+
+📡 Overview
+   name:        my-workload
+   type:        <empty>
+   namespace:   default
+
+Supply Chain reference not found.
+
+   Supply Chain resources not found.
+
+🚚 Delivery
+
+   Delivery resources not found.
+
+💬 Messages
+   No messages found.
+
+No pods found for workload.
+
+To see logs: "tanzu apps workload tail my-workload --timestamp --since 1h"
+
+`,
+		}, {
+			Name: "no supply chain info with age and full timestamps",
+			Args: []string{workloadName, flags.AgeFlagName, flags.FullTimestampsFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.CreationTimestamp(metav1.Date(2021, time.September, 10, 15, 0, 0, 0, time.UTC))
+					}),
+			},
+			ExpectOutput: `
+📡 Overview
+   name:        my-workload
+   type:        <empty>
+   namespace:   default
+   created:     2021-09-10T15:00:00Z
+
+Supply Chain reference not found.
+
+   Supply Chain resources not found.
+
+🚚 Delivery
+
+   Delivery resources not found.
+
+💬 Messages
+   No messages found.
+
+No pods found for workload.
+
+To see logs: "tanzu apps workload tail my-workload --timestamp --since 1h"
+
 `,
 		}, {
 			Name: "no supply chain info in different namespace",
@@ -880,39 +1256,206 @@ To see logs: "tanzu apps workload tail my-workload --timestamp --since 1h"
 
 `,
 		}, {
-			Name: "show resources",
-			Args: []string{workloadName},
+			Name: "show params",
+			Now:  now,
+			Args: []string{workloadName, flags.ParamsFlagName},
 			GivenObjects: []client.Object{
 				parent.
 					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
-						d.Source(&cartov1alpha1.Source{
-							Git: &cartov1alpha1.GitSource{
-								URL: url,
-								Ref: cartov1alpha1.GitRef{
-									Branch: "main",
-									Tag:    "v1.0.0",
-									Commit: "abcdef",
-								},
-							},
-						})
-					}).
-					StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
-						d.ConditionsDie(
-							diecartov1alpha1.WorkloadConditionReadyBlank.
-								Status(metav1.ConditionFalse).Reason("OopsieDoodle").
-								Message("a hopefully informative message about what went wrong"),
-						).SupplyChainRef(cartov1alpha1.ObjectReference{
-							APIVersion: "supplychains.tanzu.vmware.com/v1alpha1",
-							Kind:       "SupplyChain",
-							Name:       "my-supply-chain",
-							Namespace:  defaultNamespace,
-						})
-						d.Resources(
-							diecartov1alpha1.RealizedResourceBlank.
-								Name("source-provider").
-								Outputs(cartov1alpha1.Output{
-									Name:    "revision",
-									Preview: "main/abcdef",
+						d.Image("docker.io/library/nginx:latest")
+						d.Params(
+							cartov1alpha1.Param{Name: "scanning", Value: apiextensionsv1.JSON{Raw: []byte(`"enabled"`)}},
+							cartov1alpha1.Param{Name: "server", Value: apiextensionsv1.JSON{Raw: []byte(`{"port": 8080, "management": {"port": 8181}}`)}},
+						)
+					}),
+			},
+			ExpectOutput: `
+📡 Overview
+   name:        my-workload
+   type:        <empty>
+   namespace:   default
+
+💾 Source
+   type:    image
+   image:   docker.io/library/nginx:latest
+
+⚙ Params
+   NAME       VALUE
+   scanning   enabled
+   server     {"management":{"port":8181},"port":8080}
+
+Supply Chain reference not found.
+
+   Supply Chain resources not found.
+
+🚚 Delivery
+
+   Delivery resources not found.
+
+💬 Messages
+   No messages found.
+
+No pods found for workload.
+
+To see logs: "tanzu apps workload tail my-workload --timestamp --since 1h"
+
+`,
+		}, {
+			Name: "show params without params",
+			Now:  now,
+			Args: []string{workloadName, flags.ParamsFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("docker.io/library/nginx:latest")
+					}),
+			},
+			ExpectOutput: `
+📡 Overview
+   name:        my-workload
+   type:        <empty>
+   namespace:   default
+
+💾 Source
+   type:    image
+   image:   docker.io/library/nginx:latest
+
+⚙ Params
+   No params found.
+
+Supply Chain reference not found.
+
+   Supply Chain resources not found.
+
+🚚 Delivery
+
+   Delivery resources not found.
+
+💬 Messages
+   No messages found.
+
+No pods found for workload.
+
+To see logs: "tanzu apps workload tail my-workload --timestamp --since 1h"
+
+`,
+		}, {
+			Name: "show source info - image with resolved digest",
+			Now:  now,
+			Args: []string{workloadName, flags.ResolveDigestsFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image(fmt.Sprintf("%s/hello:v1", registryHost))
+					}),
+			},
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := fmt.Sprintf("   digest:   %s\n", imgDigest); !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %q, got %q", expected, output)
+				}
+			},
+		}, {
+			Name: "show source info - source image with resolved digest",
+			Now:  now,
+			Args: []string{workloadName, flags.ResolveDigestsFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Source(&cartov1alpha1.Source{
+							Image: fmt.Sprintf("%s/hello:v1", registryHost),
+						})
+					}),
+			},
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := fmt.Sprintf("   digest:   %s\n", imgDigest); !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %q, got %q", expected, output)
+				}
+			},
+		}, {
+			Name: "show source info - image with digest resolved with the registry credentials",
+			Now:  now,
+			Args: []string{workloadName, flags.ResolveDigestsFlagName, flags.RegistryUsernameFlagName, "my-user", flags.RegistryPasswordFlagName, "my-password"},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image(fmt.Sprintf("%s/hello:v1", authRegistryHost))
+					}),
+			},
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := fmt.Sprintf("   digest:   %s\n", imgDigest); !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %q, got %q", expected, output)
+				}
+			},
+		}, {
+			Name: "show source info - image digest not resolved without the registry credentials",
+			Now:  now,
+			Args: []string{workloadName, flags.ResolveDigestsFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image(fmt.Sprintf("%s/hello:v1", authRegistryHost))
+					}),
+			},
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := fmt.Sprintf("Warning: unable to resolve digest for image %q", fmt.Sprintf("%s/hello:v1", authRegistryHost)); !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %q, got %q", expected, output)
+				}
+			},
+		}, {
+			Name: "show source info - image digest not resolved",
+			Now:  now,
+			Args: []string{workloadName, flags.ResolveDigestsFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image(fmt.Sprintf("%s/hello:missing", registryHost))
+					}),
+			},
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := fmt.Sprintf("Warning: unable to resolve digest for image %q", fmt.Sprintf("%s/hello:missing", registryHost)); !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %q, got %q", expected, output)
+				}
+				if strings.Contains(output, "digest:") {
+					t.Errorf("expected output to not contain a digest, got %q", output)
+				}
+				if expected := "💾 Source"; !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %q, got %q", expected, output)
+				}
+			},
+		}, {
+			Name: "show resources",
+			Args: []string{workloadName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Source(&cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: url,
+								Ref: cartov1alpha1.GitRef{
+									Branch: "main",
+									Tag:    "v1.0.0",
+									Commit: "abcdef",
+								},
+							},
+						})
+					}).
+					StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
+						d.ConditionsDie(
+							diecartov1alpha1.WorkloadConditionReadyBlank.
+								Status(metav1.ConditionFalse).Reason("OopsieDoodle").
+								Message("a hopefully informative message about what went wrong"),
+						).SupplyChainRef(cartov1alpha1.ObjectReference{
+							APIVersion: "supplychains.tanzu.vmware.com/v1alpha1",
+							Kind:       "SupplyChain",
+							Name:       "my-supply-chain",
+							Namespace:  defaultNamespace,
+						})
+						d.Resources(
+							diecartov1alpha1.RealizedResourceBlank.
+								Name("source-provider").
+								Outputs(cartov1alpha1.Output{
+									Name:    "revision",
+									Preview: "main/abcdef",
 								}).
 								ConditionsDie(
 									diecartov1alpha1.WorkloadConditionResourceReadyBlank.
@@ -980,6 +1523,95 @@ No pods found for workload.
 
 To see logs: "tanzu apps workload tail my-workload --timestamp --since 1h"
 
+`,
+		}, {
+			Name: "show condition history",
+			Args: []string{workloadName, flags.HistoryFlagName},
+			Now:  time.Date(2021, time.September, 10, 15, 30, 0, 0, time.UTC),
+			GivenObjects: []client.Object{
+				parent.
+					StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
+						d.ConditionsDie(
+							diecartov1alpha1.WorkloadConditionReadyBlank.
+								Status(metav1.ConditionFalse).Reason("OopsieDoodle").
+								Message("a hopefully informative message about what went wrong").
+								LastTransitionTime(metav1.Date(2021, time.September, 10, 15, 25, 0, 0, time.UTC)),
+						).SupplyChainRef(cartov1alpha1.ObjectReference{
+							APIVersion: "supplychains.tanzu.vmware.com/v1alpha1",
+							Kind:       "SupplyChain",
+							Name:       "my-supply-chain",
+							Namespace:  defaultNamespace,
+						})
+						d.Resources(
+							diecartov1alpha1.RealizedResourceBlank.
+								Name("source-provider").
+								ConditionsDie(
+									diecartov1alpha1.WorkloadConditionResourceReadyBlank.
+										Status(metav1.ConditionTrue).Reason("Ready").
+										LastTransitionTime(metav1.Date(2021, time.September, 10, 15, 0, 0, 0, time.UTC)),
+								).DieRelease(),
+						)
+					}),
+			},
+			ExpectOutput: `
+📡 Overview
+   name:        my-workload
+   type:        <empty>
+   namespace:   default
+
+📦 Supply Chain
+   name:   my-supply-chain
+
+   NAME              READY   HEALTHY   UPDATED   RESOURCE
+   source-provider   True              30m       not found
+
+🚚 Delivery
+
+   Delivery resources not found.
+
+💬 Messages
+   Workload [OopsieDoodle]:   a hopefully informative message about what went wrong
+
+📜 Condition History
+   UPDATED       RESOURCE          CONDITION   STATUS   REASON
+   30m           source-provider   Ready       True     Ready
+   5m (latest)   Workload          Ready       False    OopsieDoodle
+
+No pods found for workload.
+
+To see logs: "tanzu apps workload tail my-workload --timestamp --since 1h"
+
+`,
+		}, {
+			Name: "show condition history without conditions",
+			Args: []string{workloadName, flags.HistoryFlagName},
+			GivenObjects: []client.Object{
+				parent,
+			},
+			ExpectOutput: `
+📡 Overview
+   name:        my-workload
+   type:        <empty>
+   namespace:   default
+
+Supply Chain reference not found.
+
+   Supply Chain resources not found.
+
+🚚 Delivery
+
+   Delivery resources not found.
+
+💬 Messages
+   No messages found.
+
+📜 Condition History
+   No conditions found.
+
+No pods found for workload.
+
+To see logs: "tanzu apps workload tail my-workload --timestamp --since 1h"
+
 `,
 		}, {
 			Name: "show resources with overview type",
@@ -1241,6 +1873,138 @@ To see logs: "tanzu apps workload tail my-workload --timestamp --since 1h"
 
 To see logs: "tanzu apps workload tail my-workload --timestamp --since 1h"
 
+`,
+		}, {
+			Name: "show pod readiness",
+			Now:  now,
+			Args: []string{workloadName, flags.PodsFlagName},
+			GivenObjects: []client.Object{
+				parent,
+				pod2Die.
+					SpecDie(func(d *diecorev1.PodSpecDie) {
+						d.ContainerDie("workload", func(d *diecorev1.ContainerDie) {})
+					}).
+					StatusDie(func(d *diecorev1.PodStatusDie) {
+						d.Phase(corev1.PodPending)
+						d.ContainerStatusDie("workload", func(d *diecorev1.ContainerStatusDie) {
+							d.RestartCount(3)
+						})
+					}),
+				pod1Die.
+					SpecDie(func(d *diecorev1.PodSpecDie) {
+						d.ContainerDie("workload", func(d *diecorev1.ContainerDie) {})
+					}).
+					StatusDie(func(d *diecorev1.PodStatusDie) {
+						d.Phase(corev1.PodRunning)
+						d.Conditions(corev1.PodCondition{Type: corev1.PodReady, Status: corev1.ConditionTrue})
+						d.ContainerStatusDie("workload", func(d *diecorev1.ContainerStatusDie) {
+							d.Ready(true)
+							d.RestartCount(1)
+						})
+					}),
+				diecorev1.PodBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("pod-something-else")
+						d.Namespace(defaultNamespace)
+						d.AddLabel(cartov1alpha1.WorkloadLabelName, "diff-workload")
+					}),
+			},
+			ExpectOutput: `
+📡 Overview
+   name:        my-workload
+   type:        <empty>
+   namespace:   default
+
+Supply Chain reference not found.
+
+   Supply Chain resources not found.
+
+🚚 Delivery
+
+   Delivery resources not found.
+
+💬 Messages
+   No messages found.
+
+No pods found for workload.
+
+🛶 Pod Readiness
+   1/2 pods ready
+
+   NAME   PHASE     READY   RESTARTS
+   pod1   Running   1/1     1
+   pod2   Pending   0/1     3
+
+To see logs: "tanzu apps workload tail my-workload --timestamp --since 1h"
+
+`,
+		}, {
+			Name: "show pod readiness without pods",
+			Now:  now,
+			Args: []string{workloadName, flags.PodsFlagName},
+			GivenObjects: []client.Object{
+				parent,
+			},
+			ExpectOutput: `
+📡 Overview
+   name:        my-workload
+   type:        <empty>
+   namespace:   default
+
+Supply Chain reference not found.
+
+   Supply Chain resources not found.
+
+🚚 Delivery
+
+   Delivery resources not found.
+
+💬 Messages
+   No messages found.
+
+No pods found for workload.
+
+🛶 Pod Readiness
+   No pods found for workload.
+
+To see logs: "tanzu apps workload tail my-workload --timestamp --since 1h"
+
+`,
+		}, {
+			Name: "show pod readiness list error",
+			Now:  now,
+			Args: []string{workloadName, flags.PodsFlagName},
+			GivenObjects: []client.Object{
+				parent,
+			},
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("list", "PodList"),
+			},
+			ExpectOutput: `
+📡 Overview
+   name:        my-workload
+   type:        <empty>
+   namespace:   default
+
+Supply Chain reference not found.
+
+   Supply Chain resources not found.
+
+🚚 Delivery
+
+   Delivery resources not found.
+
+💬 Messages
+   No messages found.
+
+No pods found for workload.
+
+🛶 Pod Readiness
+   Failed to list pods:
+     inducing failure for list PodList
+
+To see logs: "tanzu apps workload tail my-workload --timestamp --since 1h"
+
 `,
 		}, {
 			Name: "show knative services",
@@ -1393,6 +2157,60 @@ Workload "default/my-workload" not found
 `,
 			ShouldError: true,
 		},
+		{
+			Name: "not found with json output",
+			Args: []string{workloadName, flags.OutputFlagName, "json"},
+			GivenObjects: []client.Object{
+				diecorev1.NamespaceBlank.MetadataDie(
+					func(d *diemetav1.ObjectMetaDie) {
+						d.Name("default")
+					},
+				),
+			},
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("get", "Workload", clitesting.InduceFailureOpts{
+					Error: apierrors.NewNotFound(cartov1alpha1.Resource("Workload"), workloadName),
+				}),
+			},
+			ExpectOutput: `
+Workload "default/my-workload" not found
+{"error":{"message":"Workload.carto.run \"my-workload\" not found","reason":"NotFound"}}
+`,
+			ShouldError: true,
+		},
+		func() clitesting.CommandTestCase {
+			gets := 0
+			return clitesting.CommandTestCase{
+				Name: "server timeout is retried",
+				Args: []string{workloadName, flags.RetryFlagName, "2", flags.RetryBackoffFlagName, "0s"},
+				WithReactors: []clitesting.ReactionFunc{
+					func(action clitesting.Action) (bool, runtime.Object, error) {
+						if !action.Matches("get", "Workload") {
+							return false, nil, nil
+						}
+						gets++
+						return true, nil, apierrors.NewServerTimeout(cartov1alpha1.Resource("Workload"), "get", 0)
+					},
+				},
+				ShouldError: true,
+				Verify: func(t *testing.T, output string, err error) {
+					if !apierrors.IsServerTimeout(err) {
+						t.Errorf("expected server timeout error, got %v", err)
+					}
+					if expected := 3; gets != expected {
+						t.Errorf("expected %d get requests, got %d", expected, gets)
+					}
+				},
+			}
+		}(),
+		{
+			Name:        "invalid flags with json error",
+			Args:        []string{workloadName, flags.OutputFlagName, "json", flags.ExportFlagName, flags.ShowManagedFieldsFlagName},
+			ShouldError: true,
+			ExpectOutput: `
+{"error":{"message":"[--export, --show-managed-fields]: Required value: expected exactly one, got multiple","reason":"Invalid"}}
+`,
+		},
 		{
 			Name: "namespace not found",
 			Args: []string{workloadName, flags.NamespaceFlagName, "foo"},
@@ -1583,6 +2401,146 @@ status:
     status: Unknown
     type: Ready
   supplyChainRef: {}
+`,
+		}, {
+			Name: "get workload output data in yaml format with managed fields",
+			Args: []string{workloadName, flags.OutputFlagName, "yaml", flags.ShowManagedFieldsFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.ManagedFields(metav1.ManagedFieldsEntry{
+							Manager:   "tanzu",
+							Operation: metav1.ManagedFieldsOperationApply,
+						})
+					}),
+			},
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: "1970-01-01T00:00:01Z"
+  managedFields:
+  - manager: tanzu
+    operation: Apply
+  name: my-workload
+  namespace: default
+  resourceVersion: "999"
+spec: {}
+status:
+  supplyChainRef: {}
+`,
+		}, {
+			Name: "get workload output data in yaml format strips managed fields",
+			Args: []string{workloadName, flags.OutputFlagName, "yaml"},
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.ManagedFields(metav1.ManagedFieldsEntry{
+							Manager:   "tanzu",
+							Operation: metav1.ManagedFieldsOperationApply,
+						})
+					}),
+			},
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: "1970-01-01T00:00:01Z"
+  name: my-workload
+  namespace: default
+  resourceVersion: "999"
+spec: {}
+status:
+  supplyChainRef: {}
+`,
+		}, {
+			Name: "get workload output selected fields",
+			Args: []string{workloadName, flags.OutputFlagName, "yaml", flags.FieldsFlagName, "spec.image", flags.FieldsFlagName, "metadata.labels"},
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.AddLabel(apis.AppPartOfLabelName, "my-app")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("docker.io/library/nginx:latest")
+					}),
+			},
+			ExpectOutput: `
+---
+metadata:
+  labels:
+    app.kubernetes.io/part-of: my-app
+spec:
+  image: docker.io/library/nginx:latest
+`,
+		}, {
+			Name: "get workload status",
+			Args: []string{workloadName, flags.StatusFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
+						d.ConditionsDie(
+							diecartov1alpha1.WorkloadConditionReadyBlank.
+								Status(metav1.ConditionTrue),
+						)
+					}),
+			},
+			ExpectOutput: `
+---
+conditions:
+- lastTransitionTime: null
+  message: ""
+  reason: ""
+  status: "True"
+  type: Ready
+supplyChainRef: {}
+`,
+		}, {
+			Name: "get workload status in json format",
+			Args: []string{workloadName, flags.StatusFlagName, flags.OutputFlagName, "json"},
+			GivenObjects: []client.Object{
+				parent.
+					StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
+						d.ConditionsDie(
+							diecartov1alpha1.WorkloadConditionReadyBlank.
+								Status(metav1.ConditionTrue),
+						)
+					}),
+			},
+			ExpectOutput: `
+{
+	"conditions": [
+		{
+			"lastTransitionTime": null,
+			"message": "",
+			"reason": "",
+			"status": "True",
+			"type": "Ready"
+		}
+	],
+	"supplyChainRef": {}
+}
+`,
+		}, {
+			Name: "get workload output custom columns",
+			Args: []string{workloadName, flags.OutputFlagName, "custom-columns=NAME:.metadata.name,APP:.metadata.labels['app.kubernetes.io/part-of'],READY:.status.conditions[?(@.type==\"Ready\")].status"},
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.AddLabel(apis.AppPartOfLabelName, "my-app")
+					}).
+					StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
+						d.ConditionsDie(
+							diecartov1alpha1.WorkloadConditionReadyBlank.
+								Status(metav1.ConditionTrue),
+						)
+					}),
+			},
+			ExpectOutput: `
+NAME          APP      READY
+my-workload   my-app   True
 `,
 		}, {
 			Name: "get workload output data in json format",
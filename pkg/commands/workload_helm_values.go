@@ -0,0 +1,126 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/printer"
+)
+
+// helmValuesOutputFormat renders the workload set through the file and the flags as a Helm values
+// snippet, without contacting the cluster, only supported by workload apply
+const helmValuesOutputFormat = "helm-values"
+
+// helmValues is the values snippet rendered by --output helm-values. The env vars, build env vars
+// and params are maps keyed by name, so values files layered by Helm override them one by one.
+type helmValues struct {
+	Workload helmWorkloadValues `json:"workload"`
+}
+
+type helmWorkloadValues struct {
+	Name               string                               `json:"name"`
+	Namespace          string                               `json:"namespace,omitempty"`
+	Labels             map[string]string                    `json:"labels,omitempty"`
+	Annotations        map[string]string                    `json:"annotations,omitempty"`
+	ServiceAccountName *string                              `json:"serviceAccountName,omitempty"`
+	Image              string                               `json:"image,omitempty"`
+	Source             *cartov1alpha1.Source                `json:"source,omitempty"`
+	Env                map[string]interface{}               `json:"env,omitempty"`
+	BuildEnv           map[string]interface{}               `json:"buildEnv,omitempty"`
+	Params             map[string]apiextensionsv1.JSON      `json:"params,omitempty"`
+	Resources          *corev1.ResourceRequirements         `json:"resources,omitempty"`
+	ServiceClaims      []cartov1alpha1.WorkloadServiceClaim `json:"serviceClaims,omitempty"`
+}
+
+// outputHelmValues prints the workload built from the file and the flags as Helm values. The
+// workload in the cluster is not read, the values only hold what this command sets.
+func (opts *WorkloadApplyOptions) outputHelmValues(ctx context.Context, c *cli.Config, fileWorkload *cartov1alpha1.Workload, validationDisabled bool) error {
+	workload := fileWorkload.DeepCopy()
+	workload.Name = opts.Name
+	workload.Namespace = opts.Namespace
+	opts.ApplyOptionsToWorkload(ctx, nil, workload)
+	if err := opts.setImageTag(workload); err != nil {
+		return err
+	}
+	if !validationDisabled {
+		if err := workload.Validate().ToAggregate(); err != nil {
+			// show command usage before error
+			cli.CommandFromContext(ctx).SilenceUsage = false
+			return err
+		}
+	}
+
+	if !opts.ShowSecrets {
+		workload = maskSecretValues(workload)
+	}
+	values, err := yaml.Marshal(workloadHelmValues(workload))
+	if err != nil {
+		c.Eprintf("%s %s\n", printer.Serrorf("Failed to output workload:"), err)
+		return cli.SilenceError(err)
+	}
+	c.Printf("%s", values)
+	return nil
+}
+
+// workloadHelmValues maps the workload to its Helm values. An env var with an inline value is set
+// to the value, an env var read from a secret or a config map is set to its valueFrom.
+func workloadHelmValues(workload *cartov1alpha1.Workload) helmValues {
+	values := helmWorkloadValues{
+		Name:               workload.Name,
+		Namespace:          workload.Namespace,
+		Labels:             workload.Labels,
+		Annotations:        workload.Annotations,
+		ServiceAccountName: workload.Spec.ServiceAccountName,
+		Image:              workload.Spec.Image,
+		Source:             workload.Spec.Source,
+		Env:                helmEnvValues(workload.Spec.Env),
+		Resources:          workload.Spec.Resources,
+		ServiceClaims:      workload.Spec.ServiceClaims,
+	}
+	if workload.Spec.Build != nil {
+		values.BuildEnv = helmEnvValues(workload.Spec.Build.Env)
+	}
+	if len(workload.Spec.Params) != 0 {
+		values.Params = make(map[string]apiextensionsv1.JSON, len(workload.Spec.Params))
+		for _, p := range workload.Spec.Params {
+			values.Params[p.Name] = p.Value
+		}
+	}
+	return helmValues{Workload: values}
+}
+
+func helmEnvValues(env []corev1.EnvVar) map[string]interface{} {
+	if len(env) == 0 {
+		return nil
+	}
+	values := make(map[string]interface{}, len(env))
+	for _, e := range env {
+		if e.ValueFrom != nil {
+			values[e.Name] = map[string]interface{}{"valueFrom": e.ValueFrom}
+			continue
+		}
+		values[e.Name] = e.Value
+	}
+	return values
+}
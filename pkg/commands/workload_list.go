@@ -20,7 +20,6 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
@@ -40,10 +39,12 @@ import (
 )
 
 type WorkloadListOptions struct {
-	Namespace     string
-	AllNamespaces bool
-	App           string
-	Output        string
+	Namespace      string
+	AllNamespaces  bool
+	App            string
+	Output         string
+	NoHeaders      bool
+	FullTimestamps bool
 }
 
 var (
@@ -60,18 +61,36 @@ func (opts *WorkloadListOptions) Validate(ctx context.Context) validation.FieldE
 	if opts.Namespace != "" && opts.AllNamespaces {
 		errs = errs.Also(validation.ErrMultipleOneOf(flags.NamespaceFlagName, flags.AllNamespacesFlagName))
 	}
+	if opts.Namespace != "" {
+		errs = errs.Also(validation.K8sName(opts.Namespace, flags.NamespaceFlagName))
+	}
 
 	if opts.App != "" {
 		errs = errs.Also(validation.K8sName(opts.App, flags.AppFlagName))
 	}
 
 	if opts.Output != "" {
-		errs = errs.Also(validation.Enum(opts.Output, flags.OutputFlagName, []string{printer.OutputFormatJson, printer.OutputFormatYaml, printer.OutputFormatYml}))
+		errs = errs.Also(validateOutputWithCustomColumns(opts.Output))
+	}
+
+	if opts.Output != "" && !printer.IsCustomColumns(opts.Output) && opts.NoHeaders {
+		errs = errs.Also(validation.ErrMultipleOneOf(flags.OutputFlagName, flags.NoHeadersFlagName))
 	}
 
 	return errs
 }
 
+// validateOutputWithCustomColumns accepts the structured output formats and a custom-columns spec
+func validateOutputWithCustomColumns(output string) validation.FieldErrors {
+	if printer.IsCustomColumns(output) {
+		if _, err := printer.ParseCustomColumns(output); err != nil {
+			return validation.ErrInvalidValueWithDetail(output, flags.OutputFlagName, err.Error())
+		}
+		return validation.FieldErrors{}
+	}
+	return validation.Enum(output, flags.OutputFlagName, []string{printer.OutputFormatJson, printer.OutputFormatYaml, printer.OutputFormatYml})
+}
+
 func (opts *WorkloadListOptions) Exec(ctx context.Context, c *cli.Config) error {
 	workloads := &cartov1alpha1.WorkloadList{}
 	labels := map[string]string{}
@@ -82,7 +101,7 @@ func (opts *WorkloadListOptions) Exec(ctx context.Context, c *cli.Config) error
 		return err
 	}
 
-	if opts.Output != "" {
+	if opts.Output != "" && !printer.IsCustomColumns(opts.Output) {
 		var list []printer.Object
 		for i := range workloads.Items {
 			list = append(list, &workloads.Items[i])
@@ -107,17 +126,31 @@ func (opts *WorkloadListOptions) Exec(ctx context.Context, c *cli.Config) error
 		return nil
 	}
 
+	workloads = workloads.DeepCopy()
+	printer.SortByNamespaceAndName(workloads.Items)
+
+	if printer.IsCustomColumns(opts.Output) {
+		columns, err := printer.ParseCustomColumns(opts.Output)
+		if err != nil {
+			return err
+		}
+		list := make([]printer.Object, 0, len(workloads.Items))
+		for i := range workloads.Items {
+			list = append(list, &workloads.Items[i])
+		}
+		return printer.OutputCustomColumns(c.Stdout, list, columns, opts.NoHeaders)
+	}
+
 	tablePrinter := table.NewTablePrinter(table.PrintOptions{
 		WithNamespace: opts.AllNamespaces,
+		NoHeaders:     opts.NoHeaders,
+		Now:           c.Now(),
 	}).With(func(h table.PrintHandler) {
 		columns := opts.printColumns()
 		h.TableHandler(columns, opts.printList)
 		h.TableHandler(columns, opts.print)
 	})
 
-	workloads = workloads.DeepCopy()
-	printer.SortByNamespaceAndName(workloads.Items)
-
 	return tablePrinter.PrintObj(workloads, c.Stdout)
 }
 
@@ -133,6 +166,8 @@ List workloads in a namespace or across all namespaces.
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s workload list", c.Name),
 			fmt.Sprintf("%s workload list %s", c.Name, flags.AllNamespacesFlagName),
+			fmt.Sprintf("%s workload list %s", c.Name, flags.NoHeadersFlagName),
+			fmt.Sprintf("%s workload list %s custom-columns=NAME:.metadata.name,READY:.status.conditions[?(@.type==\"Ready\")].status", c.Name, flags.OutputFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateE(ctx, opts),
 		RunE:    cli.ExecE(ctx, c, opts),
@@ -140,7 +175,9 @@ List workloads in a namespace or across all namespaces.
 
 	cli.AllNamespacesFlag(ctx, cmd, c, &opts.Namespace, &opts.AllNamespaces)
 	cmd.Flags().StringVar(&opts.App, cli.StripDash(flags.AppFlagName), "", "application `name` the workload is a part of")
-	cmd.Flags().StringVarP(&opts.Output, cli.StripDash(flags.OutputFlagName), "o", "", "output the Workloads formatted. Supported formats: \"json\", \"yaml\", \"yml\"")
+	cmd.Flags().StringVarP(&opts.Output, cli.StripDash(flags.OutputFlagName), "o", "", "output the Workloads formatted. Supported formats: \"json\", \"yaml\", \"yml\", \"custom-columns=<header>:<json-path>[,...]\"")
+	cmd.Flags().BoolVar(&opts.NoHeaders, cli.StripDash(flags.NoHeadersFlagName), false, "omit the column header row from the table")
+	cmd.Flags().BoolVar(&opts.FullTimestamps, cli.StripDash(flags.FullTimestampsFlagName), false, "show absolute RFC3339 times instead of relative ages")
 
 	return cmd
 }
@@ -157,8 +194,7 @@ func (opts *WorkloadListOptions) printList(workloads *cartov1alpha1.WorkloadList
 	return rows, nil
 }
 
-func (opts *WorkloadListOptions) print(workload *cartov1alpha1.Workload, _ table.PrintOptions) ([]metav1beta1.TableRow, error) {
-	now := time.Now()
+func (opts *WorkloadListOptions) print(workload *cartov1alpha1.Workload, printOpts table.PrintOptions) ([]metav1beta1.TableRow, error) {
 	row := metav1beta1.TableRow{
 		Object: runtime.RawExtension{Object: workload},
 	}
@@ -175,7 +211,7 @@ func (opts *WorkloadListOptions) print(workload *cartov1alpha1.Workload, _ table
 	}
 	row.Cells = append(row.Cells,
 		printer.ConditionStatus(printer.FindCondition(workload.Status.Conditions, cartov1alpha1.WorkloadConditionReady)),
-		printer.TimestampSince(workload.CreationTimestamp, now),
+		printer.Timestamp(workload.CreationTimestamp, printOpts.Now, opts.FullTimestamps),
 	)
 	return []metav1beta1.TableRow{row}, nil
 }
@@ -188,9 +224,13 @@ func (opts *WorkloadListOptions) printColumns() []metav1beta1.TableColumnDefinit
 	if opts.App == "" {
 		cols = append(cols, metav1beta1.TableColumnDefinition{Name: "App", Type: "string"})
 	}
+	age := metav1beta1.TableColumnDefinition{Name: "Age", Type: "string"}
+	if opts.FullTimestamps {
+		age.Name = "Created"
+	}
 	cols = append(cols,
 		metav1beta1.TableColumnDefinition{Name: "Ready", Type: "string"},
-		metav1beta1.TableColumnDefinition{Name: "Age", Type: "string"},
+		age,
 	)
 
 	return cols
@@ -60,6 +60,13 @@ func TestWorkloadListOptionsValidate(t *testing.T) {
 			},
 			ShouldValidate: true,
 		},
+		{
+			Name: "invalid namespace",
+			Validatable: &commands.WorkloadListOptions{
+				Namespace: "Default",
+			},
+			ExpectFieldErrors: validation.ErrInvalidValue("Default", flags.NamespaceFlagName),
+		},
 		{
 			Name: "invalid app",
 			Validatable: &commands.WorkloadListOptions{
@@ -84,6 +91,40 @@ func TestWorkloadListOptionsValidate(t *testing.T) {
 			},
 			ExpectFieldErrors: validation.EnumInvalidValue("myFormat", flags.OutputFlagName, []string{"json", "yaml", "yml"}),
 		},
+		{
+			Name: "no headers",
+			Validatable: &commands.WorkloadListOptions{
+				Namespace: "default",
+				NoHeaders: true,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "no headers with output format",
+			Validatable: &commands.WorkloadListOptions{
+				Namespace: "default",
+				Output:    "json",
+				NoHeaders: true,
+			},
+			ExpectFieldErrors: validation.ErrMultipleOneOf(flags.OutputFlagName, flags.NoHeadersFlagName),
+		},
+		{
+			Name: "custom columns output with no headers",
+			Validatable: &commands.WorkloadListOptions{
+				Namespace: "default",
+				Output:    "custom-columns=NAME:.metadata.name",
+				NoHeaders: true,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid custom columns output",
+			Validatable: &commands.WorkloadListOptions{
+				Namespace: "default",
+				Output:    "custom-columns=NAME:.metadata[",
+			},
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("custom-columns=NAME:.metadata[", flags.OutputFlagName, `column "NAME" has an invalid json path: unterminated array`),
+		},
 	}
 
 	table.Run(t)
@@ -140,6 +181,75 @@ No workloads found.
 			ExpectOutput: `
 NAME            TYPE      APP       READY       AGE
 test-workload   <empty>   <empty>   <unknown>   2y
+`,
+		},
+		{
+			Name: "lists an item without headers",
+			Args: []string{flags.NoHeadersFlagName},
+			GivenObjects: []client.Object{
+				parent,
+			},
+			ExpectOutput: `
+test-workload   <empty>   <empty>   <unknown>   2y
+`,
+		},
+		{
+			Name: "lists an item with a fixed time",
+			Args: []string{},
+			Now:  time.Date(2021, time.September, 10, 15, 45, 0, 0, time.UTC),
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.CreationTimestamp(metav1.Date(2021, time.September, 10, 15, 0, 0, 0, time.UTC))
+					}),
+			},
+			ExpectOutput: `
+NAME            TYPE      APP       READY       AGE
+test-workload   <empty>   <empty>   <unknown>   45m
+`,
+		},
+		{
+			Name: "lists an item with full timestamps",
+			Args: []string{flags.FullTimestampsFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.CreationTimestamp(metav1.Date(2021, time.September, 10, 15, 0, 0, 0, time.UTC))
+					}),
+			},
+			ExpectOutput: `
+NAME            TYPE      APP       READY       CREATED
+test-workload   <empty>   <empty>   <unknown>   2021-09-10T15:00:00Z
+`,
+		},
+		{
+			Name: "lists items with custom columns",
+			Args: []string{flags.AllNamespacesFlagName, flags.OutputFlagName, "custom-columns=NAMESPACE:.metadata.namespace,NAME:.metadata.name,TYPE:.metadata.labels['apps.tanzu.vmware.com/workload-type']"},
+			GivenObjects: []client.Object{
+				otherNamespaceDie,
+				parent,
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("test-other-workload")
+						d.Namespace(otherNamespace)
+						d.CreationTimestamp(objTimeStamp)
+						d.AddLabel(apis.WorkloadTypeLabelName, "web")
+					}),
+			},
+			ExpectOutput: `
+NAMESPACE         NAME                  TYPE
+default           test-workload         <none>
+other-namespace   test-other-workload   web
+`,
+		},
+		{
+			Name: "lists items with custom columns without headers",
+			Args: []string{flags.OutputFlagName, "custom-columns=NAME:.metadata.name", flags.NoHeadersFlagName},
+			GivenObjects: []client.Object{
+				parent,
+			},
+			ExpectOutput: `
+test-workload
 `,
 		},
 		{
@@ -165,53 +275,58 @@ test-workload   <empty>   <empty>   <unknown>   2y
 					}),
 			},
 			ExpectOutput: `
-[
-	{
-		"kind": "Workload",
-		"apiVersion": "carto.run/v1alpha1",
-		"metadata": {
-			"name": "another-workload",
-			"namespace": "default",
-			"resourceVersion": "999",
-			"creationTimestamp": "2021-09-10T15:00:00Z"
-		},
-		"spec": {},
-		"status": {
-			"supplyChainRef": {}
-		}
-	},
-	{
-		"kind": "Workload",
-		"apiVersion": "carto.run/v1alpha1",
-		"metadata": {
-			"name": "my-workload",
-			"namespace": "default",
-			"resourceVersion": "999",
-			"creationTimestamp": "2021-09-10T15:00:00Z",
-			"labels": {
-				"apps.tanzu.vmware.com/workload-type": "web"
+{
+	"apiVersion": "v1",
+	"items": [
+		{
+			"apiVersion": "carto.run/v1alpha1",
+			"kind": "Workload",
+			"metadata": {
+				"creationTimestamp": "2021-09-10T15:00:00Z",
+				"name": "another-workload",
+				"namespace": "default",
+				"resourceVersion": "999"
+			},
+			"spec": {},
+			"status": {
+				"supplyChainRef": {}
 			}
 		},
-		"spec": {},
-		"status": {
-			"supplyChainRef": {}
-		}
-	},
-	{
-		"kind": "Workload",
-		"apiVersion": "carto.run/v1alpha1",
-		"metadata": {
-			"name": "test-workload",
-			"namespace": "default",
-			"resourceVersion": "999",
-			"creationTimestamp": "2021-09-10T15:00:00Z"
+		{
+			"apiVersion": "carto.run/v1alpha1",
+			"kind": "Workload",
+			"metadata": {
+				"creationTimestamp": "2021-09-10T15:00:00Z",
+				"labels": {
+					"apps.tanzu.vmware.com/workload-type": "web"
+				},
+				"name": "my-workload",
+				"namespace": "default",
+				"resourceVersion": "999"
+			},
+			"spec": {},
+			"status": {
+				"supplyChainRef": {}
+			}
 		},
-		"spec": {},
-		"status": {
-			"supplyChainRef": {}
+		{
+			"apiVersion": "carto.run/v1alpha1",
+			"kind": "Workload",
+			"metadata": {
+				"creationTimestamp": "2021-09-10T15:00:00Z",
+				"name": "test-workload",
+				"namespace": "default",
+				"resourceVersion": "999"
+			},
+			"spec": {},
+			"status": {
+				"supplyChainRef": {}
+			}
 		}
-	}
-]
+	],
+	"kind": "List",
+	"metadata": {}
+}
 `,
 		},
 		{
@@ -238,6 +353,8 @@ test-workload   <empty>   <empty>   <unknown>   2y
 			},
 			ExpectOutput: `
 ---
+apiVersion: v1
+items:
 - apiVersion: carto.run/v1alpha1
   kind: Workload
   metadata:
@@ -270,6 +387,8 @@ test-workload   <empty>   <empty>   <unknown>   2y
   spec: {}
   status:
     supplyChainRef: {}
+kind: List
+metadata: {}
 `,
 		},
 		{
@@ -360,6 +479,25 @@ Error: namespace "foo" not found, it may not exist or user does not have permiss
 NAMESPACE         NAME                  TYPE      APP       READY       AGE
 default           test-workload         <empty>   <empty>   <unknown>   2y
 other-namespace   test-other-workload   web       <empty>   <unknown>   2y
+`,
+		},
+		{
+			Name: "all namespace without headers",
+			Args: []string{flags.AllNamespacesFlagName, flags.NoHeadersFlagName},
+			GivenObjects: []client.Object{
+				otherNamespaceDie,
+				parent,
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("test-other-workload")
+						d.Namespace(otherNamespace)
+						d.CreationTimestamp(objTimeStamp)
+						d.AddLabel(apis.WorkloadTypeLabelName, "web")
+					}),
+			},
+			ExpectOutput: `
+default           test-workload         <empty>   <empty>   <unknown>   2y
+other-namespace   test-other-workload   web       <empty>   <unknown>   2y
 `,
 		},
 		{
@@ -0,0 +1,108 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/printer"
+)
+
+// patchOutputFormat prints the change applied to the workload instead of the workload, only
+// supported by workload apply
+const patchOutputFormat = "patch"
+
+// outputWorkloadPatch prints the change applied to the workload, see workloadPatch
+func (opts *WorkloadOptions) outputWorkloadPatch(c *cli.Config, w io.Writer, current, workload *cartov1alpha1.Workload) error {
+	patch, err := opts.workloadPatch(current, workload, c.Scheme)
+	if err != nil {
+		c.Eprintf("%s %s\n", printer.Serrorf("Failed to output workload:"), err)
+		return cli.SilenceError(err)
+	}
+	fmt.Fprintf(w, "%s\n", patch)
+	return nil
+}
+
+// workloadPatch returns the JSON merge patch turning the current workload into the workload, for
+// the labels, the annotations and the spec. There is no patch for a workload to create, its create
+// body is returned instead. The secret like values are masked unless --show-secrets is set.
+func (opts *WorkloadOptions) workloadPatch(current, workload *cartov1alpha1.Workload, scheme *k8sruntime.Scheme) (string, error) {
+	shown := workload
+	if !opts.ShowSecrets {
+		shown = maskSecretValues(workload)
+	}
+	if current == nil {
+		return printer.ExportResource(shown, printer.OutputFormat(printer.OutputFormatJson), scheme)
+	}
+
+	original, err := json.Marshal(roundTripFields(current))
+	if err != nil {
+		return "", err
+	}
+	modified, err := json.Marshal(roundTripFields(workload))
+	if err != nil {
+		return "", err
+	}
+	b, err := jsonpatch.CreateMergePatch(original, modified)
+	if err != nil {
+		return "", err
+	}
+	patch := map[string]interface{}{}
+	if err := json.Unmarshal(b, &patch); err != nil {
+		return "", err
+	}
+	if !opts.ShowSecrets {
+		b, err := json.Marshal(roundTripFields(shown))
+		if err != nil {
+			return "", err
+		}
+		masked := map[string]interface{}{}
+		if err := json.Unmarshal(b, &masked); err != nil {
+			return "", err
+		}
+		maskPatch(patch, masked)
+	}
+	// the keys of the maps are sorted, like in the json output of the workload
+	b, err = json.MarshalIndent(patch, "", "\t")
+	return string(b), err
+}
+
+// maskPatch replaces the values set by the patch with the values of the masked workload. The
+// values of a merge patch are the values of the modified workload, lists included, and the masked
+// workload only differs by the secret like values.
+func maskPatch(patch, masked map[string]interface{}) {
+	for k, v := range patch {
+		if v == nil {
+			// a removed field
+			continue
+		}
+		nested, isMap := v.(map[string]interface{})
+		maskedNested, maskedIsMap := masked[k].(map[string]interface{})
+		if isMap && maskedIsMap {
+			maskPatch(nested, maskedNested)
+			continue
+		}
+		patch[k] = masked[k]
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/printer"
+)
+
+// removedFieldsReport is the document written to stderr with --output json, listing the fields
+// removed from the workload in the cluster
+type removedFieldsReport struct {
+	Removed []string `json:"removed"`
+}
+
+// workloadRemovedFields returns the paths of the fields the apply removes from the workload in the
+// cluster, either pruned or dropped by the replace update strategy. On top of the removed fields,
+// the env vars, build env vars, params and service claims removed from a list that is kept are
+// reported by name, like "spec.env[name=FOO]".
+func workloadRemovedFields(current, workload *cartov1alpha1.Workload) ([]string, error) {
+	if current == nil {
+		return []string{}, nil
+	}
+	removed, err := printer.ResourceRemovedFields(current, workload)
+	if err != nil {
+		return nil, err
+	}
+	envName := func(e corev1.EnvVar) string { return e.Name }
+	removed = append(removed, removedByName("spec.env", current.Spec.Env, workload.Spec.Env, envName)...)
+	if current.Spec.Build != nil && workload.Spec.Build != nil {
+		removed = append(removed, removedByName("spec.build.env", current.Spec.Build.Env, workload.Spec.Build.Env, envName)...)
+	}
+	removed = append(removed, removedByName("spec.params", current.Spec.Params, workload.Spec.Params, func(p cartov1alpha1.Param) string { return p.Name })...)
+	removed = append(removed, removedByName("spec.serviceClaims", current.Spec.ServiceClaims, workload.Spec.ServiceClaims, func(s cartov1alpha1.WorkloadServiceClaim) string { return s.Name })...)
+	return removed, nil
+}
+
+// removedByName returns the paths of the current items whose name is not in items. An empty items
+// list is already reported as a removed field.
+func removedByName[T any](path string, current, items []T, name func(T) string) []string {
+	removed := []string{}
+	if len(items) == 0 {
+		return removed
+	}
+	kept := make(map[string]bool, len(items))
+	for _, item := range items {
+		kept[name(item)] = true
+	}
+	for _, c := range current {
+		if !kept[name(c)] {
+			removed = append(removed, fmt.Sprintf("%s[name=%s]", path, name(c)))
+			// a duplicated name is only reported once
+			kept[name(c)] = true
+		}
+	}
+	return removed
+}
+
+// outputRemovedFields writes the removed fields as a json document to stderr, so the workload
+// written to stdout with --output json can still be piped as is. Nothing is written when no field
+// is removed.
+func outputRemovedFields(c *cli.Config, removed []string) error {
+	if len(removed) == 0 {
+		return nil
+	}
+	report, err := json.Marshal(removedFieldsReport{Removed: removed})
+	if err != nil {
+		return err
+	}
+	c.Eprintf("%s\n", report)
+	return nil
+}
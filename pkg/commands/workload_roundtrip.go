@@ -0,0 +1,68 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/printer"
+)
+
+// CheckWorkloadRoundTrip reads back the workload printed by the workload commands, like the
+// output of workload create --dry-run or --output yaml, the same way it is read when reapplied
+// with --file-path -. It returns an error with the differences when the workload read back is not
+// the original one, as the generated yaml or json would not reapply the same workload.
+func CheckWorkloadRoundTrip(ctx context.Context, c *cli.Config, original *cartov1alpha1.Workload, generated string) error {
+	readBackConfig := *c
+	readBackConfig.Stdin = strings.NewReader(generated)
+	opts := &WorkloadOptions{FilePath: "-", InputFormat: autoInputFormat}
+	readBack := &cartov1alpha1.Workload{}
+	if err := opts.LoadInputWorkload(ctx, &readBackConfig, readBack); err != nil {
+		return fmt.Errorf("unable to read back workload %q: %w", original.Name, err)
+	}
+
+	expected, actual := roundTripFields(original), roundTripFields(readBack)
+	if equality.Semantic.DeepEqual(expected, actual) {
+		return nil
+	}
+	diff, _, err := printer.ResourceUnifiedDiff(expected, actual, c.Scheme)
+	if err != nil {
+		return fmt.Errorf("workload %q is not read back the same: %w", original.Name, err)
+	}
+	return fmt.Errorf("workload %q is not read back the same:\n%s", original.Name, diff)
+}
+
+// roundTripFields returns the part of the workload that is reapplied, the metadata populated by
+// the cluster and the status are left out
+func roundTripFields(workload *cartov1alpha1.Workload) *cartov1alpha1.Workload {
+	return &cartov1alpha1.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   workload.Namespace,
+			Name:        workload.Name,
+			Labels:      workload.Labels,
+			Annotations: workload.Annotations,
+		},
+		Spec: *workload.Spec.DeepCopy(),
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	diecorev1 "dies.dev/apis/core/v1"
+	diemetav1 "dies.dev/apis/meta/v1"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/apis"
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+	clitesting "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/testing"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/commands"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/flags"
+)
+
+func TestCheckWorkloadRoundTrip(t *testing.T) {
+	defaultNamespace := "default"
+	workloadName := "my-workload"
+
+	scheme := runtime.NewScheme()
+	_ = cartov1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	givenNamespaceDefault := []client.Object{
+		diecorev1.NamespaceBlank.
+			MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+				d.Name(defaultNamespace)
+			}),
+	}
+	workload := func(spec cartov1alpha1.WorkloadSpec) *cartov1alpha1.Workload {
+		return &cartov1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: defaultNamespace,
+				Name:      workloadName,
+				Labels: map[string]string{
+					apis.WorkloadTypeLabelName: "web",
+				},
+			},
+			Spec: spec,
+		}
+	}
+	roundTrip := func(expected *cartov1alpha1.Workload) func(t *testing.T, output string, err error) {
+		return func(t *testing.T, output string, err error) {
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if err := commands.CheckWorkloadRoundTrip(context.Background(), cli.NewDefaultConfig("test", scheme), expected, output); err != nil {
+				t.Errorf("CheckWorkloadRoundTrip() = %v", err)
+			}
+		}
+	}
+
+	table := clitesting.CommandTestSuite{
+		{
+			Name:         "yaml",
+			Args:         []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.DryRunFlagName, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			Verify: roundTrip(workload(cartov1alpha1.WorkloadSpec{
+				Image: "ubuntu:bionic",
+			})),
+		},
+		{
+			Name:         "json",
+			Args:         []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.OutputFlagName, "json", flags.DryRunFlagName, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			Verify: roundTrip(workload(cartov1alpha1.WorkloadSpec{
+				Image: "ubuntu:bionic",
+			})),
+		},
+		{
+			Name:         "bare",
+			Args:         []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.BareFlagName, flags.DryRunFlagName, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			Verify: func() func(t *testing.T, output string, err error) {
+				// the type label is defaulted when the bare workload is applied
+				expected := workload(cartov1alpha1.WorkloadSpec{
+					Image: "ubuntu:bionic",
+				})
+				expected.Labels = nil
+				return roundTrip(expected)
+			}(),
+		},
+		{
+			Name:         "annotated for overlay",
+			Args:         []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.EnvFlagName, "FOO=bar", flags.AnnotateForOverlayFlagName, flags.DryRunFlagName, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			Verify: roundTrip(workload(cartov1alpha1.WorkloadSpec{
+				Image: "ubuntu:bionic",
+				Env: []corev1.EnvVar{
+					{Name: "FOO", Value: "bar"},
+				},
+			})),
+		},
+		{
+			Name: "multi-line env",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:bionic",
+				flags.EnvFlagName, "SCRIPT=#!/bin/sh\n\techo hello  \nexit 0",
+				flags.BuildEnvFlagName, "CERT=-----BEGIN CERTIFICATE-----\r\nMIIDdzCCAl+gAwIBAgIEAgAAuTANBgkqhkiG9w0BAQUFADBaMQswCQYDVQQGEwJJ\r\n-----END CERTIFICATE-----\r\n",
+				flags.DryRunFlagName, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			Verify: roundTrip(workload(cartov1alpha1.WorkloadSpec{
+				Image: "ubuntu:bionic",
+				Env: []corev1.EnvVar{
+					{Name: "SCRIPT", Value: "#!/bin/sh\n\techo hello  \nexit 0"},
+				},
+				Build: &cartov1alpha1.WorkloadBuild{
+					Env: []corev1.EnvVar{
+						{Name: "CERT", Value: "-----BEGIN CERTIFICATE-----\r\nMIIDdzCCAl+gAwIBAgIEAgAAuTANBgkqhkiG9w0BAQUFADBaMQswCQYDVQQGEwJJ\r\n-----END CERTIFICATE-----\r\n"},
+					},
+				},
+			})),
+		},
+		{
+			Name: "params and annotations",
+			Args: []string{workloadName, flags.ImageFlagName, "ubuntu:bionic",
+				flags.ParamYamlFlagName, `ports={"port": 8080, "name": "http"}`,
+				flags.AnnotationFlagName, "autoscaling.knative.dev/minScale=2",
+				flags.DryRunFlagName, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			Verify: roundTrip(workload(cartov1alpha1.WorkloadSpec{
+				Image: "ubuntu:bionic",
+				Params: []cartov1alpha1.Param{
+					{Name: "annotations", Value: apiextensionsv1.JSON{Raw: []byte(`{"autoscaling.knative.dev/minScale":"2"}`)}},
+					{Name: "ports", Value: apiextensionsv1.JSON{Raw: []byte(`{"name":"http","port":8080}`)}},
+				},
+			})),
+		},
+		{
+			Name:         "changed workload",
+			Args:         []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.DryRunFlagName, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			Verify: func(t *testing.T, output string, err error) {
+				expected := workload(cartov1alpha1.WorkloadSpec{
+					Image: "ubuntu:jammy",
+				})
+				err = commands.CheckWorkloadRoundTrip(context.Background(), cli.NewDefaultConfig("test", scheme), expected, output)
+				if err == nil {
+					t.Fatalf("expected CheckWorkloadRoundTrip() to error")
+				}
+				if !strings.Contains(err.Error(), "image: ubuntu:jammy") {
+					t.Errorf("expected the error to show the differences, got %v", err)
+				}
+			},
+		},
+		{
+			Name:         "not a workload",
+			Args:         []string{workloadName, flags.ImageFlagName, "ubuntu:bionic", flags.DryRunFlagName, flags.YesFlagName},
+			GivenObjects: givenNamespaceDefault,
+			Verify: func(t *testing.T, output string, err error) {
+				expected := workload(cartov1alpha1.WorkloadSpec{
+					Image: "ubuntu:bionic",
+				})
+				if err := commands.CheckWorkloadRoundTrip(context.Background(), cli.NewDefaultConfig("test", scheme), expected, "{"); err == nil {
+					t.Errorf("expected CheckWorkloadRoundTrip() to error")
+				}
+			},
+		},
+	}
+
+	table.Run(t, scheme, func(ctx context.Context, c *cli.Config) *cobra.Command {
+		return commands.NewWorkloadCreateCommand(ctx, c)
+	})
+}
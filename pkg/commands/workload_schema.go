@@ -0,0 +1,82 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/validation"
+)
+
+// workloadSchemas caches the schema of the Workload CRD of each cluster client, so the CRD is read
+// once for all the files of a recursive apply
+var workloadSchemas = struct {
+	sync.Mutex
+	byClient map[cli.Client]*apiextensionsv1.JSONSchemaProps
+}{byClient: map[cli.Client]*apiextensionsv1.JSONSchemaProps{}}
+
+// validateSchema validates the workload spec against the schema of the Workload CRD, see
+// --validate-schema. When the CRD can't be read, like without the permission to read CRDs, a
+// warning is printed and the workload is left for the cluster to validate.
+func (opts *WorkloadApplyOptions) validateSchema(ctx context.Context, c *cli.Config, workload *cartov1alpha1.Workload) validation.FieldErrors {
+	schema, err := workloadSchema(ctx, c)
+	var u map[string]interface{}
+	if err == nil {
+		u, err = k8sruntime.DefaultUnstructuredConverter.ToUnstructured(workload)
+	}
+	if err != nil {
+		opts.warn(c, SchemaUnavailableWarningID, fmt.Sprintf("unable to validate the workload against the Workload schema: %s", err))
+		return validation.FieldErrors{}
+	}
+	spec, ok := schema.Properties["spec"]
+	if !ok {
+		return validation.FieldErrors{}
+	}
+	return validation.Schema(u["spec"], &spec, k8sfield.NewPath("spec"))
+}
+
+// workloadSchema returns the schema of the Workload version of the CLI, read from the Workload CRD
+// of the cluster
+func workloadSchema(ctx context.Context, c *cli.Config) (*apiextensionsv1.JSONSchemaProps, error) {
+	workloadSchemas.Lock()
+	defer workloadSchemas.Unlock()
+	if schema, ok := workloadSchemas.byClient[c.Client]; ok {
+		return schema, nil
+	}
+
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	name := "workloads." + cartov1alpha1.SchemeGroupVersion.Group
+	if err := c.Get(ctx, client.ObjectKey{Name: name}, crd); err != nil {
+		return nil, err
+	}
+	for _, version := range crd.Spec.Versions {
+		if version.Name == cartov1alpha1.SchemeGroupVersion.Version && version.Schema != nil && version.Schema.OpenAPIV3Schema != nil {
+			workloadSchemas.byClient[c.Client] = version.Schema.OpenAPIV3Schema
+			return version.Schema.OpenAPIV3Schema, nil
+		}
+	}
+	return nil, fmt.Errorf("CustomResourceDefinition %q has no schema for version %q", name, cartov1alpha1.SchemeGroupVersion.Version)
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/apis"
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/flags"
+)
+
+// checkSupplyChainMatch warns when no supply chain of the cluster selects the workload, listing the
+// selectors of the supply chains so a type can be picked with --type. The check is skipped when the
+// supply chains can't be read, like without the permission to list them.
+func (opts *WorkloadOptions) checkSupplyChainMatch(ctx context.Context, c *cli.Config, workload *cartov1alpha1.Workload) {
+	if opts.isWarningSuppressed(NoSupplyChainWarningID) {
+		return
+	}
+	supplyChains := &cartov1alpha1.ClusterSupplyChainList{}
+	if err := c.List(ctx, supplyChains); err != nil || len(supplyChains.Items) == 0 {
+		return
+	}
+	for i := range supplyChains.Items {
+		if supplyChainSelects(&supplyChains.Items[i], workload) {
+			return
+		}
+	}
+
+	opts.warn(c, NoSupplyChainWarningID, fmt.Sprintf("no supply chain selects workload %q, use %s to pick one of the supply chains below", fmt.Sprintf("%s/%s", workload.Namespace, workload.Name), flags.TypeFlagName))
+	sort.Slice(supplyChains.Items, func(i, j int) bool {
+		return supplyChains.Items[i].Name < supplyChains.Items[j].Name
+	})
+	for _, supplyChain := range supplyChains.Items {
+		if selector := supplyChainSelector(&supplyChain); selector != "" {
+			c.Printf("  %s: %s\n", supplyChain.Name, selector)
+		}
+	}
+	c.Printf("\n")
+}
+
+// supplyChainSelects reports if the selectors of the supply chain all match the workload. A supply
+// chain without selectors selects no workload.
+func supplyChainSelects(supplyChain *cartov1alpha1.ClusterSupplyChain, workload *cartov1alpha1.Workload) bool {
+	spec := supplyChain.Spec
+	if len(spec.Selector) == 0 && len(spec.SelectorMatchExpressions) == 0 && len(spec.SelectorMatchFields) == 0 {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchLabels: spec.Selector, MatchExpressions: spec.SelectorMatchExpressions})
+	if err != nil || !selector.Matches(labels.Set(workload.Labels)) {
+		return false
+	}
+	if len(spec.SelectorMatchFields) == 0 {
+		return true
+	}
+	u, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(workload)
+	if err != nil {
+		return false
+	}
+	for _, field := range spec.SelectorMatchFields {
+		if !fieldSelectorMatches(field, u) {
+			return false
+		}
+	}
+	return true
+}
+
+func fieldSelectorMatches(field cartov1alpha1.FieldSelectorRequirement, u map[string]interface{}) bool {
+	path := jsonpath.New(field.Key).AllowMissingKeys(true)
+	if err := path.Parse(fmt.Sprintf("{.%s}", strings.TrimPrefix(field.Key, "."))); err != nil {
+		return false
+	}
+	results, err := path.FindResults(u)
+	if err != nil {
+		return false
+	}
+	var values []string
+	for _, result := range results {
+		for _, value := range result {
+			if value.IsValid() && !value.IsZero() {
+				values = append(values, fmt.Sprint(value.Interface()))
+			}
+		}
+	}
+	switch field.Operator {
+	case "Exists":
+		return len(values) != 0
+	case "DoesNotExist":
+		return len(values) == 0
+	case "In", "NotIn":
+		in := false
+		for _, value := range values {
+			for _, v := range field.Values {
+				in = in || value == v
+			}
+		}
+		return in == (field.Operator == "In")
+	}
+	return false
+}
+
+// supplyChainSelector formats the selectors of the supply chain, with the workload type first
+func supplyChainSelector(supplyChain *cartov1alpha1.ClusterSupplyChain) string {
+	spec := supplyChain.Spec
+	var requirements []string
+	if selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchLabels: spec.Selector, MatchExpressions: spec.SelectorMatchExpressions}); err == nil && !selector.Empty() {
+		reqs, _ := selector.Requirements()
+		for _, req := range reqs {
+			if req.Key() == apis.WorkloadTypeLabelName {
+				requirements = append([]string{req.String()}, requirements...)
+			} else {
+				requirements = append(requirements, req.String())
+			}
+		}
+	}
+	for _, field := range spec.SelectorMatchFields {
+		requirement := fmt.Sprintf("%s %s", field.Key, field.Operator)
+		if len(field.Values) != 0 {
+			requirement = fmt.Sprintf("%s (%s)", requirement, strings.Join(field.Values, ","))
+		}
+		requirements = append(requirements, requirement)
+	}
+	return strings.Join(requirements, ", ")
+}
@@ -18,6 +18,7 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -42,7 +43,13 @@ type WorkloadTailOptions struct {
 
 	Component  string
 	Since      time.Duration
+	TailLines  int64
 	Timestamps bool
+	Follow     bool
+	Output     string
+
+	Previous        bool
+	ContainerStates []string
 }
 
 var (
@@ -55,6 +62,8 @@ func (opts *WorkloadTailOptions) Validate(ctx context.Context) validation.FieldE
 
 	if opts.Namespace == "" {
 		errs = errs.Also(validation.ErrMissingField(flags.NamespaceFlagName))
+	} else {
+		errs = errs.Also(validation.K8sName(opts.Namespace, flags.NamespaceFlagName))
 	}
 
 	if opts.Name == "" {
@@ -67,7 +76,23 @@ func (opts *WorkloadTailOptions) Validate(ctx context.Context) validation.FieldE
 		errs = errs.Also(validation.ErrInvalidValue(opts.Since, flags.SinceFlagName))
 	}
 
+	// -1, the default, prints every line
+	if opts.TailLines < -1 {
+		errs = errs.Also(validation.ErrInvalidValueWithDetail(opts.TailLines, flags.TailFlagName, "must be a non-negative number of lines"))
+	}
+	if cmd := cli.CommandFromContext(ctx); opts.TailLines >= 0 && cmd != nil && cmd.Flags().Changed(cli.StripDash(flags.SinceFlagName)) {
+		errs = errs.Also(validation.ErrMultipleOneOf(flags.SinceFlagName, flags.TailFlagName))
+	}
+
 	errs = errs.Also(validation.K8sLabelValue(opts.Component, flags.ComponentFlagName))
+
+	if opts.Output != "" {
+		errs = errs.Also(validation.Enum(opts.Output, flags.OutputFlagName, []string{logs.OutputFormatJson}))
+	}
+
+	for _, state := range opts.ContainerStates {
+		errs = errs.Also(validation.Enum(state, flags.ContainerStateFlagName, logs.ContainerStates))
+	}
 	return errs
 }
 
@@ -91,7 +116,30 @@ func (opts *WorkloadTailOptions) Exec(ctx context.Context, c *cli.Config) error
 		panic(err)
 	}
 	containers := []string{}
-	return logs.Tail(ctx, c, opts.Namespace, selector, containers, opts.Since, opts.Timestamps)
+	since := opts.Since
+	if opts.TailLines >= 0 {
+		// the last lines are taken from the whole logs, not only the default --since window
+		since = 0
+	}
+	if cmd := cli.CommandFromContext(ctx); opts.Previous && (cmd == nil || !cmd.Flags().Changed(cli.StripDash(flags.SinceFlagName))) {
+		// a previous instance usually ended before the default --since window
+		since = 0
+	}
+	err = logs.Tail(ctx, c, opts.Namespace, selector, logs.TailOptions{
+		Containers:      containers,
+		Since:           since,
+		TailLines:       opts.TailLines,
+		Timestamps:      opts.Timestamps,
+		Follow:          opts.Follow,
+		Output:          opts.Output,
+		Previous:        opts.Previous,
+		ContainerStates: opts.ContainerStates,
+	})
+	if errors.Is(err, logs.ErrNoPreviousLogs) {
+		c.Errorf("No previous logs found for workload %q, none of the matching containers was restarted\n", fmt.Sprintf("%s/%s", opts.Namespace, opts.Name))
+		return cli.SilenceError(err)
+	}
+	return err
 }
 
 func NewWorkloadTailCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -103,11 +151,18 @@ func NewWorkloadTailCommand(ctx context.Context, c *cli.Config) *cobra.Command {
 		Long: strings.TrimSpace(`
 Stream logs for a workload until canceled. To cancel, press Ctl-c in
 the shell or stop the process. As new workload pods are started, the logs
-are displayed. To show historical logs use ` + flags.SinceFlagName + `.
+are displayed. To show historical logs use ` + flags.SinceFlagName + `, or ` + flags.TailFlagName + ` to
+show the last lines of each container. To print the current logs and exit use
+` + flags.FollowFlagName + `=false. To debug a crashing container use ` + flags.PreviousFlagName + ` to
+print the logs of its previous instance.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s workload tail my-workload", c.Name),
 			fmt.Sprintf("%s workload tail my-workload %s 1h", c.Name, flags.SinceFlagName),
+			fmt.Sprintf("%s workload tail my-workload %s 100", c.Name, flags.TailFlagName),
+			fmt.Sprintf("%s workload tail my-workload %s=false", c.Name, flags.FollowFlagName),
+			fmt.Sprintf("%s workload tail my-workload %s json", c.Name, flags.OutputFlagName),
+			fmt.Sprintf("%s workload tail my-workload %s %s terminated", c.Name, flags.PreviousFlagName, flags.ContainerStateFlagName),
 		}, "\n"),
 		PreRunE:           cli.ValidateE(ctx, opts),
 		RunE:              cli.ExecE(ctx, c, opts),
@@ -124,5 +179,16 @@ are displayed. To show historical logs use ` + flags.SinceFlagName + `.
 	cmd.Flags().BoolVarP(&opts.Timestamps, cli.StripDash(flags.TimestampFlagName), "t", false, "print timestamp for each log line")
 	cmd.Flags().DurationVar(&opts.Since, cli.StripDash(flags.SinceFlagName), time.Minute, "time `duration` to start reading logs from")
 	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.SinceFlagName), completion.SuggestDurationUnits(ctx, completion.CommonDurationUnits))
+	cmd.Flags().Int64Var(&opts.TailLines, cli.StripDash(flags.TailFlagName), -1, "`number` of the most recent log lines to show from each container, read from the whole logs (cannot be used with "+flags.SinceFlagName+", -1 shows every line)")
+	cmd.Flags().BoolVar(&opts.Follow, cli.StripDash(flags.FollowFlagName), true, "keep streaming new logs until canceled ("+flags.FollowFlagName+"=false to print the current logs and exit)")
+	cmd.Flags().StringVarP(&opts.Output, cli.StripDash(flags.OutputFlagName), "o", "", "print each log line as a JSON object with the pod, container, timestamp and message. Supported formats: \"json\"")
+	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.OutputFlagName), func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{logs.OutputFormatJson}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().BoolVar(&opts.Previous, cli.StripDash(flags.PreviousFlagName), false, "print the logs of the previous instance of each restarted container and exit, read from the whole logs unless "+flags.SinceFlagName+" is set")
+	cmd.Flags().StringSliceVar(&opts.ContainerStates, cli.StripDash(flags.ContainerStateFlagName), nil, "only include the containers in one of the `states`, comma separated (supported states: running, waiting, terminated, all), containers started while following are always included")
+	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.ContainerStateFlagName), func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return logs.ContainerStates, cobra.ShellCompDirectiveNoFileComp
+	})
 	return cmd
 }
@@ -76,6 +76,14 @@ func TestWorkloadTailOptionsValidate(t *testing.T) {
 			},
 			ShouldValidate: true,
 		},
+		{
+			Name: "invalid namespace",
+			Validatable: &commands.WorkloadTailOptions{
+				Namespace: "my_namespace",
+				Name:      "my-workload",
+			},
+			ExpectFieldErrors: validation.ErrInvalidValue("my_namespace", flags.NamespaceFlagName),
+		},
 		{
 			Name: "invalid since",
 			Validatable: &commands.WorkloadTailOptions{
@@ -85,6 +93,24 @@ func TestWorkloadTailOptionsValidate(t *testing.T) {
 			},
 			ExpectFieldErrors: validation.ErrInvalidValue(-1*time.Nanosecond, flags.SinceFlagName),
 		},
+		{
+			Name: "tail lines",
+			Validatable: &commands.WorkloadTailOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				TailLines: 100,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid tail lines",
+			Validatable: &commands.WorkloadTailOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				TailLines: -2,
+			},
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail(int64(-2), flags.TailFlagName, "must be a non-negative number of lines"),
+		},
 		{
 			Name: "component",
 			Validatable: &commands.WorkloadTailOptions{
@@ -103,6 +129,43 @@ func TestWorkloadTailOptionsValidate(t *testing.T) {
 			},
 			ExpectFieldErrors: validation.ErrInvalidValue("---", flags.ComponentFlagName),
 		},
+		{
+			Name: "json output",
+			Validatable: &commands.WorkloadTailOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Output:    "json",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid output",
+			Validatable: &commands.WorkloadTailOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Output:    "yaml",
+			},
+			ExpectFieldErrors: validation.EnumInvalidValue("yaml", flags.OutputFlagName, []string{"json"}),
+		},
+		{
+			Name: "container states",
+			Validatable: &commands.WorkloadTailOptions{
+				Namespace:       "default",
+				Name:            "my-workload",
+				Previous:        true,
+				ContainerStates: []string{"running", "terminated"},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid container state",
+			Validatable: &commands.WorkloadTailOptions{
+				Namespace:       "default",
+				Name:            "my-workload",
+				ContainerStates: []string{"running", "crashed"},
+			},
+			ExpectFieldErrors: validation.EnumInvalidValue("crashed", flags.ContainerStateFlagName, []string{"running", "waiting", "terminated", "all"}),
+		},
 	}
 	table.Run(t)
 }
@@ -160,7 +223,7 @@ Workload "default/test-workload" not found
 			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
 				tailer := &logs.FakeTailer{}
 				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Hour, false).Return(nil).Once()
+				tailer.On("Tail", mock.Anything, "default", selector, logs.TailOptions{Containers: []string{}, Since: time.Hour, TailLines: -1, Follow: true}).Return(nil).Once()
 				ctx = logs.StashTailer(ctx, tailer)
 				// simulate a user exit after 10ms
 				ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
@@ -185,7 +248,32 @@ Workload "default/test-workload" not found
 			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
 				tailer := &logs.FakeTailer{}
 				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Minute, false).Return(nil).Once()
+				tailer.On("Tail", mock.Anything, "default", selector, logs.TailOptions{Containers: []string{}, Since: time.Minute, TailLines: -1, Follow: true}).Return(nil).Once()
+				ctx = logs.StashTailer(ctx, tailer)
+				// simulate a user exit after 10ms
+				ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+				_ = cancel
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				tailer := logs.RetrieveTailer(ctx).(*logs.FakeTailer)
+				tailer.AssertExpectations(t)
+				return nil
+			},
+			GivenObjects: []client.Object{
+				parent,
+			},
+			ExpectOutput: `
+...tail output...
+`,
+		},
+		{
+			Name: "show the last lines of the workload logs",
+			Args: []string{flags.NamespaceFlagName, defaultNamespace, flags.TailFlagName, "100", workloadName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				tailer := &logs.FakeTailer{}
+				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
+				tailer.On("Tail", mock.Anything, "default", selector, logs.TailOptions{Containers: []string{}, TailLines: 100, Follow: true}).Return(nil).Once()
 				ctx = logs.StashTailer(ctx, tailer)
 				// simulate a user exit after 10ms
 				ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
@@ -204,13 +292,23 @@ Workload "default/test-workload" not found
 ...tail output...
 `,
 		},
+		{
+			Name:        "tail lines with since",
+			Args:        []string{flags.NamespaceFlagName, defaultNamespace, flags.TailFlagName, "100", flags.SinceFlagName, "1h", workloadName},
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := validation.ErrMultipleOneOf(flags.SinceFlagName, flags.TailFlagName).ToAggregate().Error(); err == nil || err.Error() != expected {
+					t.Errorf("expected error %q, got %v", expected, err)
+				}
+			},
+		},
 		{
 			Name: "show logs for workload with since time in seconds",
 			Args: []string{flags.NamespaceFlagName, defaultNamespace, flags.SinceFlagName, "1s", workloadName},
 			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
 				tailer := &logs.FakeTailer{}
 				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Second, false).Return(nil).Once()
+				tailer.On("Tail", mock.Anything, "default", selector, logs.TailOptions{Containers: []string{}, Since: time.Second, TailLines: -1, Follow: true}).Return(nil).Once()
 				ctx = logs.StashTailer(ctx, tailer)
 				// simulate a user exit after 10ms
 				ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
@@ -235,7 +333,7 @@ Workload "default/test-workload" not found
 			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
 				tailer := &logs.FakeTailer{}
 				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Second, false).Return(nil).Once()
+				tailer.On("Tail", mock.Anything, "default", selector, logs.TailOptions{Containers: []string{}, Since: time.Second, TailLines: -1, Follow: true}).Return(nil).Once()
 				color.NoColor = false
 				ctx = logs.StashTailer(ctx, tailer)
 				// simulate a user exit after 10ms
@@ -260,7 +358,7 @@ Workload "default/test-workload" not found
 			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
 				tailer := &logs.FakeTailer{}
 				selector, _ := labels.Parse(fmt.Sprintf("%s=%s,%s=%s", cartov1alpha1.WorkloadLabelName, workloadName, apis.ComponentLabelName, "build"))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Hour, false).Return(nil).Once()
+				tailer.On("Tail", mock.Anything, "default", selector, logs.TailOptions{Containers: []string{}, Since: time.Hour, TailLines: -1, Follow: true}).Return(nil).Once()
 				ctx = logs.StashTailer(ctx, tailer)
 				// simulate a user exit after 10ms
 				ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
@@ -285,7 +383,7 @@ Workload "default/test-workload" not found
 			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
 				tailer := &logs.FakeTailer{}
 				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Hour, false).Return(fmt.Errorf("tail error")).Once()
+				tailer.On("Tail", mock.Anything, "default", selector, logs.TailOptions{Containers: []string{}, Since: time.Hour, TailLines: -1, Follow: true}).Return(fmt.Errorf("tail error")).Once()
 				ctx = logs.StashTailer(ctx, tailer)
 				return ctx, nil
 			},
@@ -308,7 +406,7 @@ Workload "default/test-workload" not found
 			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
 				tailer := &logs.FakeTailer{}
 				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Hour, true).Return(nil).Once()
+				tailer.On("Tail", mock.Anything, "default", selector, logs.TailOptions{Containers: []string{}, Since: time.Hour, TailLines: -1, Timestamps: true, Follow: true}).Return(nil).Once()
 				ctx = logs.StashTailer(ctx, tailer)
 				// simulate a user exit after 10ms
 				ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
@@ -325,6 +423,128 @@ Workload "default/test-workload" not found
 			},
 			ExpectOutput: `
 ...tail output...
+`,
+		},
+		{
+			Name: "show logs for workload as json",
+			Args: []string{flags.NamespaceFlagName, defaultNamespace, flags.SinceFlagName, "1h", flags.OutputFlagName, "json", workloadName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				tailer := &logs.FakeTailer{}
+				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
+				tailer.On("Tail", mock.Anything, "default", selector, logs.TailOptions{Containers: []string{}, Since: time.Hour, TailLines: -1, Follow: true, Output: "json"}).Return(nil).Once()
+				ctx = logs.StashTailer(ctx, tailer)
+				// simulate a user exit after 10ms
+				ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+				_ = cancel
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				tailer := logs.RetrieveTailer(ctx).(*logs.FakeTailer)
+				tailer.AssertExpectations(t)
+				return nil
+			},
+			GivenObjects: []client.Object{
+				parent,
+			},
+			ExpectOutput: `
+...tail output...
+`,
+		},
+		{
+			Name: "print current logs for workload without following",
+			Args: []string{flags.NamespaceFlagName, defaultNamespace, flags.SinceFlagName, "1h", flags.TimestampFlagName, flags.ComponentFlagName, "build", flags.FollowFlagName + "=false", workloadName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				tailer := &logs.FakeTailer{}
+				selector, _ := labels.Parse(fmt.Sprintf("%s=%s,%s=%s", cartov1alpha1.WorkloadLabelName, workloadName, apis.ComponentLabelName, "build"))
+				tailer.On("Tail", mock.Anything, "default", selector, logs.TailOptions{Containers: []string{}, Since: time.Hour, TailLines: -1, Timestamps: true}).Return(nil).Once()
+				// no timeout, the tail must return on its own
+				ctx = logs.StashTailer(ctx, tailer)
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				tailer := logs.RetrieveTailer(ctx).(*logs.FakeTailer)
+				tailer.AssertExpectations(t)
+				return nil
+			},
+			GivenObjects: []client.Object{
+				parent,
+			},
+			ExpectOutput: `
+...tail output...
+`,
+		},
+		{
+			Name: "show previous logs for workload",
+			Args: []string{flags.NamespaceFlagName, defaultNamespace, flags.PreviousFlagName, flags.ContainerStateFlagName, "terminated,waiting", workloadName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				tailer := &logs.FakeTailer{}
+				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
+				tailer.On("Tail", mock.Anything, "default", selector, logs.TailOptions{Containers: []string{}, TailLines: -1, Follow: true, Previous: true, ContainerStates: []string{"terminated", "waiting"}}).Return(nil).Once()
+				ctx = logs.StashTailer(ctx, tailer)
+				// simulate a user exit after 10ms
+				ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+				_ = cancel
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				tailer := logs.RetrieveTailer(ctx).(*logs.FakeTailer)
+				tailer.AssertExpectations(t)
+				return nil
+			},
+			GivenObjects: []client.Object{
+				parent,
+			},
+			ExpectOutput: `
+...tail output...
+`,
+		},
+		{
+			Name: "show previous logs for workload since time",
+			Args: []string{flags.NamespaceFlagName, defaultNamespace, flags.PreviousFlagName, flags.SinceFlagName, "1h", workloadName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				tailer := &logs.FakeTailer{}
+				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
+				tailer.On("Tail", mock.Anything, "default", selector, logs.TailOptions{Containers: []string{}, Since: time.Hour, TailLines: -1, Follow: true, Previous: true}).Return(nil).Once()
+				ctx = logs.StashTailer(ctx, tailer)
+				// simulate a user exit after 10ms
+				ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+				_ = cancel
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				tailer := logs.RetrieveTailer(ctx).(*logs.FakeTailer)
+				tailer.AssertExpectations(t)
+				return nil
+			},
+			GivenObjects: []client.Object{
+				parent,
+			},
+			ExpectOutput: `
+...tail output...
+`,
+		},
+		{
+			Name: "no previous logs for workload",
+			Args: []string{flags.NamespaceFlagName, defaultNamespace, flags.PreviousFlagName, workloadName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				tailer := &logs.FakeTailer{}
+				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
+				tailer.On("Tail", mock.Anything, "default", selector, logs.TailOptions{Containers: []string{}, TailLines: -1, Follow: true, Previous: true}).Return(logs.ErrNoPreviousLogs).Once()
+				ctx = logs.StashTailer(ctx, tailer)
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				tailer := logs.RetrieveTailer(ctx).(*logs.FakeTailer)
+				tailer.AssertExpectations(t)
+				return nil
+			},
+			GivenObjects: []client.Object{
+				parent,
+			},
+			ShouldError: true,
+			ExpectOutput: `
+...tail output...
+No previous logs found for workload "default/test-workload", none of the matching containers was restarted
 `,
 		},
 	}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -32,7 +33,10 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/name"
 	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -43,6 +47,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/apis"
@@ -61,6 +66,18 @@ import (
 
 var localSource = filepath.Join("testdata", "local-source")
 
+func TestMain(m *testing.M) {
+	// keep the sources published by the tests out of the user push cache
+	cacheDir, err := os.MkdirTemp("", "apps-cli-cache")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("XDG_CACHE_HOME", cacheDir)
+	code := m.Run()
+	os.RemoveAll(cacheDir)
+	os.Exit(code)
+}
+
 func TestWorkloadCommand(t *testing.T) {
 	scheme := k8sruntime.NewScheme()
 	_ = cartov1alpha1.AddToScheme(scheme)
@@ -101,6 +118,61 @@ func TestWorkloadOptionsValidate(t *testing.T) {
 			},
 			ExpectFieldErrors: validation.ErrInvalidValue("", cli.NameArgumentName),
 		},
+		{
+			Name: "wait interval",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:    "default",
+				Name:         "my-resource",
+				Wait:         true,
+				WaitInterval: 2 * time.Second,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "negative wait interval",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:    "default",
+				Name:         "my-resource",
+				Wait:         true,
+				WaitInterval: -2 * time.Second,
+			},
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("-2s", flags.WaitIntervalFlagName, "must be a positive duration, or 0 to check the status on every change"),
+		},
+		{
+			Name: "fail on unknown reason with wait",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:            "default",
+				Name:                 "my-resource",
+				Wait:                 true,
+				FailOnUnknownReasons: []string{"MissingValueAtPath"},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "fail on unknown reason without wait",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:            "default",
+				Name:                 "my-resource",
+				FailOnUnknownReasons: []string{"MissingValueAtPath"},
+			},
+			ExpectFieldErrors: validation.ErrMissingOneOfWithDetail("required by "+flags.FailOnUnknownReasonFlagName, flags.WaitFlagName, flags.TailFlagName),
+		},
+		{
+			Name: "file from configmap",
+			Validatable: &commands.WorkloadOptions{
+				Namespace: "default",
+				FilePath:  "configmap://default/golden-workloads/workload.yaml",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "file from invalid configmap reference",
+			Validatable: &commands.WorkloadOptions{
+				Namespace: "default",
+				FilePath:  "configmap://default/golden-workloads",
+			},
+			ExpectFieldErrors: validation.ErrInvalidValue("default/golden-workloads", flags.FilePathFlagName),
+		},
 		{
 			Name: "valid env",
 			Validatable: &commands.WorkloadOptions{
@@ -173,6 +245,64 @@ func TestWorkloadOptionsValidate(t *testing.T) {
 			},
 			ExpectFieldErrors: validation.ErrInvalidArrayValue("FOO", flags.BuildEnvFlagName, 0),
 		},
+		{
+			Name: "build params",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:   "default",
+				Name:        "my-resource",
+				BuildParams: []string{"builder=full", "jvm-version=17", "native-image=true", "node-version-"},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "unknown build param",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:   "default",
+				Name:        "my-resource",
+				BuildParams: []string{"buildpack=paketo"},
+			},
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("buildpack=paketo", validation.CurrentField, "supported build params are builder, jvm-version, maven-build-arguments, native-image, node-version").ViaFieldIndex(flags.BuildParamFlagName, 0),
+		},
+		{
+			Name: "invalid build param value",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:   "default",
+				Name:        "my-resource",
+				BuildParams: []string{"builder=full", "jvm-version=latest"},
+			},
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("latest", validation.CurrentField, "must be a positive whole number").ViaFieldIndex(flags.BuildParamFlagName, 1),
+		},
+		{
+			Name: "build params yaml",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:       "default",
+				Name:            "my-resource",
+				BuildParamsYaml: []string{"builder=full", "jvm-version=17", "maven-build-arguments={\"goals\": [\"package\"]}", "native-image-"},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid build params yaml",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:       "default",
+				Name:            "my-resource",
+				BuildParamsYaml: []string{"jvm-version=[17]", "native-image=yes please", "node-version={"},
+			},
+			ExpectFieldErrors: validation.FieldErrors{}.Also(
+				validation.ErrInvalidValueWithDetail("[17]", validation.CurrentField, "must be a positive whole number").ViaFieldIndex(flags.BuildParamYamlFlagName, 0),
+				validation.ErrInvalidValueWithDetail("yes please", validation.CurrentField, "must be true or false").ViaFieldIndex(flags.BuildParamYamlFlagName, 1),
+				validation.ErrInvalidValueWithDetail("node-version={", validation.CurrentField, "error converting YAML to JSON: yaml: line 1: did not find expected node content").ViaFieldIndex(flags.BuildParamYamlFlagName, 2),
+			),
+		},
+		{
+			Name: "invalid build param",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:   "default",
+				Name:        "my-resource",
+				BuildParams: []string{"builder"},
+			},
+			ExpectFieldErrors: validation.ErrInvalidArrayValue("builder", flags.BuildParamFlagName, 0),
+		},
 		{
 			Name: "params",
 			Validatable: &commands.WorkloadOptions{
@@ -192,6 +322,190 @@ func TestWorkloadOptionsValidate(t *testing.T) {
 			ShouldValidate:    false,
 			ExpectFieldErrors: validation.ErrInvalidValue("bleep", flags.ParamFlagName+"[1]"),
 		},
+		{
+			Name: "valid typed params",
+			Validatable: &commands.WorkloadOptions{
+				Namespace: "default",
+				Name:      "my-resource",
+				Params:    []string{"version:string=1.10", "replicas:number=2"},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "unknown param type",
+			Validatable: &commands.WorkloadOptions{
+				Namespace: "default",
+				Name:      "my-resource",
+				Params:    []string{"version:int=1"},
+			},
+			ShouldValidate:    false,
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("version:int=1", flags.ParamFlagName+"[0]", "supported param types are string, number, bool, json"),
+		},
+		{
+			Name: "pack sub path",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:   "default",
+				Name:        "my-resource",
+				LocalPath:   localSource,
+				SourceImage: "repo.example/image:tag",
+				SubPath:     "subpath",
+				PackSubPath: true,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "pack sub path without local path",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:   "default",
+				Name:        "my-resource",
+				SubPath:     "subpath",
+				PackSubPath: true,
+			},
+			ShouldValidate:    false,
+			ExpectFieldErrors: validation.ErrMissingField(flags.LocalPathFlagName),
+		},
+		{
+			Name: "no cache without local path",
+			Validatable: &commands.WorkloadOptions{
+				Namespace: "default",
+				Name:      "my-resource",
+				Image:     "ubuntu:bionic",
+				NoCache:   true,
+			},
+			ShouldValidate:    false,
+			ExpectFieldErrors: validation.ErrMissingField(flags.LocalPathFlagName),
+		},
+		{
+			Name: "pack sub path not found",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:   "default",
+				Name:        "my-resource",
+				LocalPath:   localSource,
+				SourceImage: "repo.example/image:tag",
+				SubPath:     "missing",
+				PackSubPath: true,
+			},
+			ShouldValidate:    false,
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("missing", flags.SubPathFlagName, fmt.Sprintf("directory not found in %q", localSource)),
+		},
+		{
+			Name: "pack sub path outside local path",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:   "default",
+				Name:        "my-resource",
+				LocalPath:   localSource,
+				SourceImage: "repo.example/image:tag",
+				SubPath:     "../local-source-exclude-files",
+				PackSubPath: true,
+			},
+			ShouldValidate:    false,
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("../local-source-exclude-files", flags.SubPathFlagName, fmt.Sprintf("path must be relative to %q", localSource)),
+		},
+		{
+			Name: "invalid env from configmap name",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:     "default",
+				Name:          "my-resource",
+				EnvConfigMaps: []string{"app-config-"},
+			},
+			ShouldValidate:    false,
+			ExpectFieldErrors: validation.ErrInvalidArrayValue("app-config-", flags.EnvFromConfigMapFlagName, 0),
+		},
+		{
+			Name: "invalid diff format",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:  "default",
+				Name:       "my-resource",
+				DiffFormat: "side-by-side",
+			},
+			ShouldValidate:    false,
+			ExpectFieldErrors: validation.EnumInvalidValue("side-by-side", flags.DiffFormatFlagName, []string{"default", "unified"}),
+		},
+		{
+			Name: "word diff with unified diff format",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:  "default",
+				Name:       "my-resource",
+				DiffFormat: "unified",
+				WordDiff:   true,
+			},
+			ShouldValidate:    false,
+			ExpectFieldErrors: validation.ErrMultipleOneOf(flags.WordDiffFlagName, flags.DiffFormatFlagName),
+		},
+		{
+			Name: "collapse unchanged with unified diff format",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:         "default",
+				Name:              "my-resource",
+				DiffFormat:        "unified",
+				CollapseUnchanged: true,
+			},
+			ShouldValidate:    false,
+			ExpectFieldErrors: validation.ErrMultipleOneOf(flags.CollapseUnchangedFlagName, flags.DiffFormatFlagName),
+		},
+		{
+			Name: "annotate for overlay with dry run",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:          "default",
+				Name:               "my-resource",
+				DryRun:             true,
+				AnnotateForOverlay: true,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "annotate for overlay with yaml output",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:          "default",
+				Name:               "my-resource",
+				Output:             "yaml",
+				AnnotateForOverlay: true,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "annotate for overlay with json output",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:          "default",
+				Name:               "my-resource",
+				DryRun:             true,
+				Output:             "json",
+				AnnotateForOverlay: true,
+			},
+			ShouldValidate:    false,
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("json", flags.OutputFlagName, "only yaml can be annotated by "+flags.AnnotateForOverlayFlagName),
+		},
+		{
+			Name: "annotate for overlay with patch output",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:          "default",
+				Name:               "my-resource",
+				Output:             "patch",
+				AnnotateForOverlay: true,
+			},
+			ShouldValidate:    false,
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("patch", flags.OutputFlagName, "only yaml can be annotated by "+flags.AnnotateForOverlayFlagName),
+		},
+		{
+			Name: "annotate for overlay without output",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:          "default",
+				Name:               "my-resource",
+				AnnotateForOverlay: true,
+			},
+			ShouldValidate:    false,
+			ExpectFieldErrors: validation.ErrMissingOneOfWithDetail("required by "+flags.AnnotateForOverlayFlagName, flags.DryRunFlagName, flags.OutputFlagName),
+		},
+		{
+			Name: "negative max value width",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:     "default",
+				Name:          "my-resource",
+				MaxValueWidth: -1,
+			},
+			ShouldValidate:    false,
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("-1", flags.MaxValueWidthFlagName, "must be 0 or more"),
+		},
 		{
 			Name: "valid resources limits",
 			Validatable: &commands.WorkloadOptions{
@@ -309,6 +623,16 @@ func TestWorkloadOptionsValidate(t *testing.T) {
 			ShouldValidate:    false,
 			ExpectFieldErrors: validation.ErrInvalidValue("bleep", flags.LabelFlagName+"[1]"),
 		},
+		{
+			Name: "invalid label key syntax",
+			Validatable: &commands.WorkloadOptions{
+				Namespace: "default",
+				Name:      "my-resource",
+				Labels:    []string{"foo=bar", "Invalid Key=x"},
+			},
+			ShouldValidate:    false,
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("Invalid Key", flags.LabelFlagName+"[1]", strings.Join(k8svalidation.IsQualifiedName("Invalid Key"), "; ")),
+		},
 		{
 			Name: "remove label",
 			Validatable: &commands.WorkloadOptions{
@@ -407,6 +731,28 @@ func TestWorkloadOptionsValidate(t *testing.T) {
 			},
 			ShouldValidate: true,
 		},
+		{
+			Name: "git source with secret",
+			Validatable: &commands.WorkloadOptions{
+				Namespace: "default",
+				Name:      "my-resource",
+				GitRepo:   "https://example.com/repo.git",
+				GitBranch: "main",
+				GitSecret: "git-credentials",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid git secret",
+			Validatable: &commands.WorkloadOptions{
+				Namespace: "default",
+				Name:      "my-resource",
+				GitRepo:   "https://example.com/repo.git",
+				GitBranch: "main",
+				GitSecret: "Git_Credentials",
+			},
+			ExpectFieldErrors: validation.ErrInvalidValue("Git_Credentials", flags.GitSecretFlagName),
+		},
 		{
 			Name: "source image",
 			Validatable: &commands.WorkloadOptions{
@@ -557,6 +903,24 @@ func TestWorkloadOptionsValidate(t *testing.T) {
 			},
 			ExpectFieldErrors: validation.ErrMissingField(flags.LocalPathFlagName),
 		},
+		{
+			Name: "pre-pushed source image",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:   "default",
+				Name:        "my-resource",
+				SourceImage: "registry.example.com/source@sha256:978be33a7f0cbe89bf48fbb438846047a28e1298d6d10d0de2d64bdc102a9e69",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid source image",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:   "default",
+				Name:        "my-resource",
+				SourceImage: "registry.example.com/Source",
+			},
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("registry.example.com/Source", flags.SourceImageFlagName, "not a valid image reference: could not parse reference: registry.example.com/Source"),
+		},
 		{
 			Name: "ca cert with no source image",
 			Validatable: &commands.WorkloadOptions{
@@ -862,42 +1226,191 @@ status:
 }
 `,
 	}, {
-		name:        "not valid output",
-		args:        []string{flags.OutputFlagName, "myBadFormat"},
-		input:       &cartov1alpha1.Workload{},
+		name:        "not valid output",
+		args:        []string{flags.OutputFlagName, "myBadFormat"},
+		input:       &cartov1alpha1.Workload{},
+		shouldError: true,
+	}}
+
+	for _, test := range tests {
+		scheme := k8sruntime.NewScheme()
+		_ = cartov1alpha1.AddToScheme(scheme)
+		c := cli.NewDefaultConfig("test", scheme)
+		output := &bytes.Buffer{}
+		c.Stdout = output
+		c.Stderr = output
+
+		cmd := &cobra.Command{}
+		ctx := cli.WithCommand(context.Background(), cmd)
+
+		opts := &commands.WorkloadOptions{}
+		opts.DefineFlags(ctx, c, cmd)
+		cmd.ParseFlags(test.args)
+
+		actual := test.input.DeepCopy()
+		err := opts.OutputWorkload(c, actual)
+		if err != nil && !test.shouldError {
+			t.Errorf("OutputWorkload() errored %v", err)
+		}
+		if err == nil && test.shouldError {
+			t.Errorf("OutputWorkload() expected error")
+		}
+		if test.shouldError {
+			return
+		}
+
+		if diff := cmp.Diff(strings.TrimSpace(test.expected), strings.TrimSpace(output.String())); diff != "" {
+			t.Errorf("OutputWorkload() (-want, +got) = %s", diff)
+		}
+	}
+}
+
+func TestDryRunWorkload(t *testing.T) {
+	workload := &cartov1alpha1.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-workload",
+			Namespace: "default",
+			Labels: map[string]string{
+				apis.WorkloadTypeLabelName: "web",
+			},
+		},
+		Spec: cartov1alpha1.WorkloadSpec{
+			Image: "ubuntu:bionic",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{{
+		name: "defaults to yaml",
+		expected: `---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  labels:
+    apps.tanzu.vmware.com/workload-type: web
+  name: my-workload
+  namespace: default
+spec:
+  image: ubuntu:bionic
+status:
+  supplyChainRef: {}
+`,
+	}, {
+		name: "yaml output",
+		args: []string{flags.OutputFlagName, printer.OutputFormatYaml},
+	}, {
+		name: "json output",
+		args: []string{flags.OutputFlagName, printer.OutputFormatJson},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scheme := k8sruntime.NewScheme()
+			_ = cartov1alpha1.AddToScheme(scheme)
+			c := cli.NewDefaultConfig("test", scheme)
+			output := &bytes.Buffer{}
+			c.Stdout = output
+			c.Stderr = output
+
+			cmd := &cobra.Command{}
+			dryRunOutput := &bytes.Buffer{}
+			ctx := cli.WithStdout(cli.WithCommand(context.Background(), cmd), dryRunOutput)
+
+			opts := &commands.WorkloadOptions{}
+			opts.DefineFlags(ctx, c, cmd)
+			cmd.ParseFlags(test.args)
+
+			if err := opts.DryRunWorkload(ctx, c, workload.DeepCopy()); err != nil {
+				t.Fatalf("DryRunWorkload() errored %v", err)
+			}
+
+			expected := test.expected
+			if expected == "" {
+				// the dry run output must match the --output one byte for byte
+				if err := opts.OutputWorkload(c, workload.DeepCopy()); err != nil {
+					t.Fatalf("OutputWorkload() errored %v", err)
+				}
+				expected = output.String()
+			}
+			if diff := cmp.Diff(expected, dryRunOutput.String()); diff != "" {
+				t.Errorf("DryRunWorkload() (-want, +got) = %s", diff)
+			}
+		})
+	}
+}
+
+func TestWorkloadOptionsPinImageDigest(t *testing.T) {
+	regServer := httptest.NewServer(ggcrregistry.New())
+	defer regServer.Close()
+	u, err := url.Parse(regServer.URL)
+	utilruntime.Must(err)
+	registryHost := u.Host
+
+	img := empty.Image
+	digest, err := img.Digest()
+	utilruntime.Must(err)
+	ref, err := name.ParseReference(fmt.Sprintf("%s/hello:v1", registryHost))
+	utilruntime.Must(err)
+	utilruntime.Must(remote.Write(ref, img))
+
+	tests := []struct {
+		name        string
+		args        []string
+		image       string
+		expected    string
+		shouldError bool
+	}{{
+		name:     "tag is kept without flag",
+		image:    fmt.Sprintf("%s/hello:v1", registryHost),
+		expected: fmt.Sprintf("%s/hello:v1", registryHost),
+	}, {
+		name:     "pin tag to digest",
+		args:     []string{flags.PinImageFlagName},
+		image:    fmt.Sprintf("%s/hello:v1", registryHost),
+		expected: fmt.Sprintf("%s/hello@%s", registryHost, digest),
+	}, {
+		name:     "image already pinned",
+		args:     []string{flags.PinImageFlagName},
+		image:    fmt.Sprintf("%s/hello@%s", registryHost, digest),
+		expected: fmt.Sprintf("%s/hello@%s", registryHost, digest),
+	}, {
+		name:        "unknown tag",
+		args:        []string{flags.PinImageFlagName},
+		image:       fmt.Sprintf("%s/hello:missing", registryHost),
 		shouldError: true,
 	}}
 
 	for _, test := range tests {
-		scheme := k8sruntime.NewScheme()
-		_ = cartov1alpha1.AddToScheme(scheme)
-		c := cli.NewDefaultConfig("test", scheme)
-		output := &bytes.Buffer{}
-		c.Stdout = output
-		c.Stderr = output
-
-		cmd := &cobra.Command{}
-		ctx := cli.WithCommand(context.Background(), cmd)
-
-		opts := &commands.WorkloadOptions{}
-		opts.DefineFlags(ctx, c, cmd)
-		cmd.ParseFlags(test.args)
+		t.Run(test.name, func(t *testing.T) {
+			scheme := k8sruntime.NewScheme()
+			c := cli.NewDefaultConfig("test", scheme)
+			cmd := &cobra.Command{}
+			ctx := cli.WithCommand(context.Background(), cmd)
 
-		actual := test.input.DeepCopy()
-		err := opts.OutputWorkload(c, actual)
-		if err != nil && !test.shouldError {
-			t.Errorf("OutputWorkload() errored %v", err)
-		}
-		if err == nil && test.shouldError {
-			t.Errorf("OutputWorkload() expected error")
-		}
-		if test.shouldError {
-			return
-		}
+			opts := &commands.WorkloadOptions{}
+			opts.DefineFlags(ctx, c, cmd)
+			cmd.ParseFlags(test.args)
 
-		if diff := cmp.Diff(strings.TrimSpace(test.expected), strings.TrimSpace(output.String())); diff != "" {
-			t.Errorf("OutputWorkload() (-want, +got) = %s", diff)
-		}
+			workload := &cartov1alpha1.Workload{
+				Spec: cartov1alpha1.WorkloadSpec{
+					Image: test.image,
+				},
+			}
+			err := opts.PinImageDigest(ctx, workload)
+			if (err != nil) != test.shouldError {
+				t.Fatalf("PinImageDigest() errored %v, expected error %t", err, test.shouldError)
+			}
+			if test.shouldError {
+				return
+			}
+			if diff := cmp.Diff(test.expected, workload.Spec.Image); diff != "" {
+				t.Errorf("PinImageDigest() (-want, +got) = %s", diff)
+			}
+		})
 	}
 }
 
@@ -1107,6 +1620,58 @@ func TestWorkloadOptionsApplyOptionsToWorkload(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "update typed params",
+			args: []string{flags.ParamFlagName, "version:string=1.10", flags.ParamFlagName, "replicas:number=2", flags.ParamFlagName, "debug:bool=true", flags.ParamFlagName, `ports:json=[8080]`, flags.ParamFlagName, "removeme:string-"},
+			input: &cartov1alpha1.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: defaultNamespace,
+					Name:      workloadName,
+					Labels: map[string]string{
+						apis.WorkloadTypeLabelName: "web",
+					},
+				},
+				Spec: cartov1alpha1.WorkloadSpec{
+					Image: "ubuntu:bionic",
+					Params: []cartov1alpha1.Param{
+						{
+							Name:  "removeme",
+							Value: apiextensionsv1.JSON{Raw: []byte(`"bye"`)},
+						},
+					},
+				},
+			},
+			expected: &cartov1alpha1.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: defaultNamespace,
+					Name:      workloadName,
+					Labels: map[string]string{
+						apis.WorkloadTypeLabelName: "web",
+					},
+				},
+				Spec: cartov1alpha1.WorkloadSpec{
+					Image: "ubuntu:bionic",
+					Params: []cartov1alpha1.Param{
+						{
+							Name:  "version",
+							Value: apiextensionsv1.JSON{Raw: []byte(`"1.10"`)},
+						},
+						{
+							Name:  "replicas",
+							Value: apiextensionsv1.JSON{Raw: []byte(`2`)},
+						},
+						{
+							Name:  "debug",
+							Value: apiextensionsv1.JSON{Raw: []byte(`true`)},
+						},
+						{
+							Name:  "ports",
+							Value: apiextensionsv1.JSON{Raw: []byte(`[8080]`)},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "update params",
 			args: []string{flags.ParamFlagName, "foo=bar", flags.ParamFlagName, "removeme-"},
@@ -1695,6 +2260,97 @@ func TestWorkloadOptionsApplyOptionsToWorkload(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "add/update/remove build params",
+			args: []string{flags.BuildParamFlagName, "builder=full", flags.BuildParamFlagName, "jvm-version=17", flags.BuildParamFlagName, "native-image-"},
+			input: &cartov1alpha1.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: defaultNamespace,
+					Name:      workloadName,
+					Labels: map[string]string{
+						apis.WorkloadTypeLabelName: "web",
+					},
+				},
+				Spec: cartov1alpha1.WorkloadSpec{
+					Build: &cartov1alpha1.WorkloadBuild{
+						Env: []corev1.EnvVar{
+							{Name: "BP_JVM_VERSION", Value: "11"},
+							{Name: "BP_NATIVE_IMAGE", Value: "true"},
+						},
+					},
+					Image: "ubuntu:bionic",
+				},
+			},
+			expected: &cartov1alpha1.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: defaultNamespace,
+					Name:      workloadName,
+					Labels: map[string]string{
+						apis.WorkloadTypeLabelName: "web",
+					},
+				},
+				Spec: cartov1alpha1.WorkloadSpec{
+					Params: []cartov1alpha1.Param{
+						{
+							Name:  "clusterBuilder",
+							Value: apiextensionsv1.JSON{Raw: []byte(`"full"`)},
+						},
+					},
+					Build: &cartov1alpha1.WorkloadBuild{
+						Env: []corev1.EnvVar{
+							{Name: "BP_JVM_VERSION", Value: "17"},
+						},
+					},
+					Image: "ubuntu:bionic",
+				},
+			},
+		},
+		{
+			name: "add/update/remove build params yaml",
+			args: []string{flags.BuildParamYamlFlagName, "builder=full", flags.BuildParamYamlFlagName, "jvm-version=17", flags.BuildParamYamlFlagName, "maven-build-arguments={goals: [package], skipTests: true}", flags.BuildParamYamlFlagName, "native-image-"},
+			input: &cartov1alpha1.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: defaultNamespace,
+					Name:      workloadName,
+					Labels: map[string]string{
+						apis.WorkloadTypeLabelName: "web",
+					},
+				},
+				Spec: cartov1alpha1.WorkloadSpec{
+					Build: &cartov1alpha1.WorkloadBuild{
+						Env: []corev1.EnvVar{
+							{Name: "BP_JVM_VERSION", Value: "11"},
+							{Name: "BP_NATIVE_IMAGE", Value: "true"},
+						},
+					},
+					Image: "ubuntu:bionic",
+				},
+			},
+			expected: &cartov1alpha1.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: defaultNamespace,
+					Name:      workloadName,
+					Labels: map[string]string{
+						apis.WorkloadTypeLabelName: "web",
+					},
+				},
+				Spec: cartov1alpha1.WorkloadSpec{
+					Params: []cartov1alpha1.Param{
+						{
+							Name:  "clusterBuilder",
+							Value: apiextensionsv1.JSON{Raw: []byte(`"full"`)},
+						},
+					},
+					Build: &cartov1alpha1.WorkloadBuild{
+						Env: []corev1.EnvVar{
+							{Name: "BP_JVM_VERSION", Value: "17"},
+							{Name: "BP_MAVEN_BUILD_ARGUMENTS", Value: `{"goals":["package"],"skipTests":true}`},
+						},
+					},
+					Image: "ubuntu:bionic",
+				},
+			},
+		},
 		{
 			name: "workload with optional flags",
 			args: []string{flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.AppFlagName, appName, flags.TypeFlagName, typeName, flags.ParamFlagName, "foo=bar", flags.ParamFlagName, "bleep=bloop", flags.ParamFlagName, "bleep-", flags.EnvFlagName, "FOO=bar", flags.BuildEnvFlagName, "BAR=baz", flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db", flags.LimitCPUFlagName, "500m", flags.LimitMemoryFlagName, "1Gi", flags.LabelFlagName, "build.tanzu.vmware.com/supply-chain=custom", flags.YesFlagName},
@@ -3003,6 +3659,94 @@ Publishing source in ` + fmt.Sprintf("%q", helloJarFilePath) + ` to "` + registr
 	}
 }
 
+func TestWorkloadOptionsPublishLocalSourcePackSubPath(t *testing.T) {
+	reg, err := ggcrregistry.TLS("localhost")
+	utilruntime.Must(err)
+	defer reg.Close()
+	u, err := url.Parse(reg.URL)
+	utilruntime.Must(err)
+	sourceImage := fmt.Sprintf("%s/hello:source", u.Host)
+
+	publish := func(t *testing.T, args []string, subPath string) (*cartov1alpha1.Workload, error) {
+		scheme := k8sruntime.NewScheme()
+		c := cli.NewDefaultConfig("test", scheme)
+		c.Stdout = &bytes.Buffer{}
+		c.Stderr = &bytes.Buffer{}
+		c.Client = clitesting.NewFakeCliClient(clitesting.NewFakeClient(scheme))
+
+		cmd := &cobra.Command{}
+		ctx := cli.WithCommand(context.Background(), cmd)
+		ctx = source.StashContainerRemoteTransport(ctx, reg.Client().Transport)
+		ctx = logger.StashSourceImageLogger(ctx, logger.NewNoopLogger())
+		opts := &commands.WorkloadOptions{}
+		opts.LoadDefaults(c)
+		opts.DefineFlags(ctx, c, cmd)
+		cmd.ParseFlags(append(args, flags.SourceImageFlagName, sourceImage, flags.YesFlagName))
+
+		ctx = logger.StashProgressBarLogger(ctx, fake.NewNoopProgressBar())
+		workload := &cartov1alpha1.Workload{
+			Spec: cartov1alpha1.WorkloadSpec{
+				Source: &cartov1alpha1.Source{
+					Image:   sourceImage,
+					Subpath: subPath,
+				},
+			},
+		}
+		return workload, opts.PublishLocalSource(ctx, c, nil, workload, false)
+	}
+
+	// the packed sub path must be published exactly as if it was the local path
+	expected, err := publish(t, []string{flags.LocalPathFlagName, filepath.Join(localSource, "subpath")}, "")
+	if err != nil {
+		t.Fatalf("PublishLocalSource() errored %v", err)
+	}
+
+	workload, err := publish(t, []string{flags.LocalPathFlagName, localSource, flags.SubPathFlagName, "subpath", flags.PackSubPathFlagName}, "subpath")
+	if err != nil {
+		t.Fatalf("PublishLocalSource() errored %v", err)
+	}
+	if diff := cmp.Diff(expected.Spec.Source, workload.Spec.Source); diff != "" {
+		t.Errorf("PublishLocalSource() (-want, +got) = %s", diff)
+	}
+
+	// without the flag the whole local path is published and the sub path is kept
+	workload, err = publish(t, []string{flags.LocalPathFlagName, localSource, flags.SubPathFlagName, "subpath"}, "subpath")
+	if err != nil {
+		t.Fatalf("PublishLocalSource() errored %v", err)
+	}
+	if workload.Spec.Source.Subpath != "subpath" || workload.Spec.Source.Image == expected.Spec.Source.Image {
+		t.Errorf("PublishLocalSource() expected the whole local path to be published, got %+v", workload.Spec.Source)
+	}
+
+	if _, err := publish(t, []string{flags.LocalPathFlagName, localSource, flags.SubPathFlagName, "missing", flags.PackSubPathFlagName}, "missing"); err == nil {
+		t.Errorf("PublishLocalSource() expected error for a missing sub path")
+	}
+
+	// the ignore file of the local path applies to the packed sub path
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		".tanzuignore":                "subpath/build/\nhello.txt\n",
+		"hello.txt":                   "hello\n",
+		"subpath/hello.txt":           "hello\n",
+		"subpath/build/generated.txt": "generated\n",
+		"expected/hello.txt":          "hello\n",
+	} {
+		utilruntime.Must(os.MkdirAll(filepath.Dir(filepath.Join(root, name)), 0755))
+		utilruntime.Must(os.WriteFile(filepath.Join(root, name), []byte(content), 0644))
+	}
+	expected, err = publish(t, []string{flags.LocalPathFlagName, filepath.Join(root, "expected")}, "")
+	if err != nil {
+		t.Fatalf("PublishLocalSource() errored %v", err)
+	}
+	workload, err = publish(t, []string{flags.LocalPathFlagName, root, flags.SubPathFlagName, "subpath", flags.PackSubPathFlagName}, "subpath")
+	if err != nil {
+		t.Fatalf("PublishLocalSource() errored %v", err)
+	}
+	if diff := cmp.Diff(expected.Spec.Source, workload.Spec.Source); diff != "" {
+		t.Errorf("PublishLocalSource() (-want, +got) = %s", diff)
+	}
+}
+
 func TestWorkloadOptionsPublishLocalSourceProxy(t *testing.T) {
 	expectedImageDigest := "fedc574423e7aa2ecdd2ffb3381214e3c288db871ab9a3758f77489d6a777a1d"
 	if runtime.GOOS == "windows" {
@@ -3649,13 +4393,39 @@ Updated workload "my-workload"
 
 func TestLoadInputWorkload(t *testing.T) {
 	scheme := k8sruntime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
 	c := cli.NewDefaultConfig("test", scheme)
+	workloadConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "golden-workloads",
+		},
+		Data: map[string]string{
+			"workload.yaml": `
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: spring-petclinic
+spec:
+  image: ubuntu:bionic
+`,
+		},
+	}
+	workloadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(workloadConfigMap.Data["workload.yaml"]))
+	}))
+	defer workloadServer.Close()
+	workloadServerURL, _ := url.Parse(workloadServer.URL)
 
 	tests := []struct {
-		name        string
-		file        string
-		shouldError bool
-		stdin       io.Reader
+		name         string
+		file         string
+		allowedHosts []string
+		inputFormat  string
+		shouldError  bool
+		expectedErr  string
+		stdin        io.Reader
+		givenObjects []client.Object
 	}{
 		{
 			name:  "loads workload from file",
@@ -3697,12 +4467,51 @@ spec:
 `,
 			),
 		},
+		{
+			name:         "loads workload from allowed url host",
+			file:         workloadServer.URL + "/workload.yaml",
+			allowedHosts: []string{"example.com", workloadServerURL.Hostname()},
+			stdin:        c.Stdin,
+		},
+		{
+			name:         "error loading workload from not allowed url host",
+			file:         workloadServer.URL + "/workload.yaml",
+			allowedHosts: []string{"example.com"},
+			stdin:        c.Stdin,
+			shouldError:  true,
+		},
+		{
+			name:         "loads workload from configmap",
+			file:         "configmap://default/golden-workloads/workload.yaml",
+			stdin:        c.Stdin,
+			givenObjects: []client.Object{workloadConfigMap},
+		},
+		{
+			name:         "error loading missing configmap key",
+			file:         "configmap://default/golden-workloads/other.yaml",
+			stdin:        c.Stdin,
+			givenObjects: []client.Object{workloadConfigMap},
+			shouldError:  true,
+		},
+		{
+			name:        "error loading non-existent configmap",
+			file:        "configmap://default/golden-workloads/workload.yaml",
+			stdin:       c.Stdin,
+			shouldError: true,
+		},
 		{
 			name:        "error loading non-existent file",
 			file:        "testdata/workload1.yaml",
 			stdin:       c.Stdin,
 			shouldError: true,
 		},
+		{
+			name:        "error loading a directory",
+			file:        "testdata",
+			stdin:       c.Stdin,
+			shouldError: true,
+			expectedErr: `unable to load file "testdata": expected a file, got a directory; did you mean --local-path?`,
+		},
 		{
 			name:        "error loading non-accepted url file",
 			file:        "ftp://raw.githubusercontent.com/vmware-tanzu/apps-cli-plugin/main/pkg/commands/testdata/workload.yaml",
@@ -3746,19 +4555,61 @@ spec:
 			),
 			shouldError: true,
 		},
+		{
+			name:  "loads json workload from stdin",
+			file:  "-",
+			stdin: strings.NewReader(`  {"apiVersion": "carto.run/v1alpha1", "kind": "Workload", "metadata": {"name": "spring-petclinic"}, "spec": {"image": "ubuntu:bionic"}}`),
+		},
+		{
+			name:        "loads json workload from stdin with json input format",
+			file:        "-",
+			inputFormat: "json",
+			stdin:       strings.NewReader(`{"apiVersion": "carto.run/v1alpha1", "kind": "Workload", "metadata": {"name": "spring-petclinic"}, "spec": {"image": "ubuntu:bionic"}}`),
+		},
+		{
+			name:        "error loading yaml workload with json input format",
+			file:        "testdata/workload.yaml",
+			inputFormat: "json",
+			stdin:       c.Stdin,
+			shouldError: true,
+			expectedErr: `unable to load file "testdata/workload.yaml": expected JSON content (--input-format json), the content is YAML`,
+		},
+		{
+			name:        "error loading json workload with yaml input format",
+			file:        "-",
+			inputFormat: "yaml",
+			stdin:       strings.NewReader(`{"apiVersion": "carto.run/v1alpha1", "kind": "Workload", "metadata": {"name": "spring-petclinic"}, "spec": {"image": "ubuntu:bionic"}}`),
+			shouldError: true,
+			expectedErr: `unable to load file "-": expected YAML content (--input-format yaml), the content is JSON`,
+		},
+		{
+			name:        "error loading invalid json workload",
+			file:        "-",
+			stdin:       strings.NewReader(`{"apiVersion": "carto.run/v1alpha1", "kind": "Workload",}`),
+			shouldError: true,
+			expectedErr: `unable to load file "-": invalid JSON: invalid character '}' looking for beginning of object key string`,
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			opts := &commands.WorkloadOptions{
-				FilePath: test.file,
+				FilePath:     test.file,
+				AllowedHosts: test.allowedHosts,
+				InputFormat:  test.inputFormat,
 			}
 
-			err := opts.LoadInputWorkload(test.stdin, &cartov1alpha1.Workload{})
+			c.Stdin = test.stdin
+			c.Client = clitesting.NewFakeCliClient(clitesting.NewFakeClient(scheme, test.givenObjects...))
+
+			err := opts.LoadInputWorkload(context.Background(), c, &cartov1alpha1.Workload{})
 
 			if (err == nil) == test.shouldError {
 				t.Errorf("Load() shouldErr %t, got %v", test.shouldError, err)
 			} else if test.shouldError {
+				if test.expectedErr != "" && err.Error() != test.expectedErr {
+					t.Errorf("Load() expected error %q, got %q", test.expectedErr, err.Error())
+				}
 				return
 			}
 		})
@@ -0,0 +1,64 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package completion
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+)
+
+// SuggestServiceRefs completes the value of a service ref, like
+// database=services.apps.tanzu.vmware.com/v1alpha1:ClassClaim:my-db, with the apiVersion and
+// kind of the namespaced resources served by the cluster once the service ref name is typed.
+// Errors listing the resources result in no suggestions.
+func SuggestServiceRefs(ctx context.Context, c *cli.Config) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		suggestions := []string{}
+		name, ref, found := strings.Cut(toComplete, "=")
+		if !found || strings.Count(ref, ":") > 1 {
+			// the service ref name and the referenced service name are up to the user
+			return suggestions, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		dc, err := c.ToDiscoveryClient()
+		if err != nil {
+			return suggestions, cobra.ShellCompDirectiveNoFileComp
+		}
+		// discovery returns the resources of the groups that could be read along with the error
+		resources, _ := dc.ServerPreferredResources()
+		for _, list := range resources {
+			for _, r := range list.APIResources {
+				if !r.Namespaced || strings.Contains(r.Name, "/") {
+					// skip cluster scoped resources and subresources
+					continue
+				}
+				suggestion := fmt.Sprintf("%s=%s:%s:", name, list.GroupVersion, r.Kind)
+				if strings.HasPrefix(suggestion, toComplete) {
+					suggestions = append(suggestions, suggestion)
+				}
+			}
+		}
+		sort.Strings(suggestions)
+		return suggestions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package completion_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+	clitesting "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/testing"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/completion"
+)
+
+type failingDiscoveryClient struct {
+	*clitesting.FakeCachedDiscoveryClient
+}
+
+func (d *failingDiscoveryClient) ServerPreferredResources() ([]*metav1.APIResourceList, error) {
+	return nil, fmt.Errorf("discovery failed")
+}
+
+func TestSuggestServiceRefs(t *testing.T) {
+	scheme := runtime.NewScheme()
+
+	discovery := clitesting.NewFakeCachedDiscoveryClient()
+	discovery.PreferredResources = []*metav1.APIResourceList{{
+		GroupVersion: "services.apps.tanzu.vmware.com/v1alpha1",
+		APIResources: []metav1.APIResource{
+			{Name: "classclaims", Namespaced: true, Kind: "ClassClaim", Verbs: metav1.Verbs{"get"}},
+			{Name: "classclaims/status", Namespaced: true, Kind: "ClassClaim", Verbs: metav1.Verbs{"get"}},
+			{Name: "clusterinstanceclasses", Namespaced: false, Kind: "ClusterInstanceClass", Verbs: metav1.Verbs{"get"}},
+		},
+	}, {
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{
+			{Name: "secrets", Namespaced: true, Kind: "Secret", Verbs: metav1.Verbs{"get"}},
+		},
+	}}
+
+	tests := []struct {
+		name               string
+		discovery          *clitesting.FakeCachedDiscoveryClient
+		failing            bool
+		toComplete         string
+		sugestions         []string
+		shellCompDirective cobra.ShellCompDirective
+	}{{
+		name:               "service ref name",
+		discovery:          discovery,
+		toComplete:         "data",
+		sugestions:         []string{},
+		shellCompDirective: cobra.ShellCompDirectiveNoFileComp,
+	}, {
+		name:       "api versions and kinds",
+		discovery:  discovery,
+		toComplete: "database=",
+		sugestions: []string{
+			"database=services.apps.tanzu.vmware.com/v1alpha1:ClassClaim:",
+			"database=v1:Secret:",
+		},
+		shellCompDirective: cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace,
+	}, {
+		name:       "partial api version",
+		discovery:  discovery,
+		toComplete: "database=services",
+		sugestions: []string{
+			"database=services.apps.tanzu.vmware.com/v1alpha1:ClassClaim:",
+		},
+		shellCompDirective: cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace,
+	}, {
+		name:               "referenced service name",
+		discovery:          discovery,
+		toComplete:         "database=v1:Secret:my",
+		sugestions:         []string{},
+		shellCompDirective: cobra.ShellCompDirectiveNoFileComp,
+	}, {
+		name:               "discovery error",
+		discovery:          discovery,
+		failing:            true,
+		toComplete:         "database=",
+		sugestions:         []string{},
+		shellCompDirective: cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			c := cli.NewDefaultConfig("test", scheme)
+			client := clitesting.NewFakeClient(scheme)
+			if test.failing {
+				c.Client = clitesting.NewFakeCliClientWithDiscovery(client, &failingDiscoveryClient{test.discovery})
+			} else {
+				c.Client = clitesting.NewFakeCliClientWithDiscovery(client, test.discovery)
+			}
+			cmd := &cobra.Command{}
+
+			suggestions, directive := completion.SuggestServiceRefs(ctx, c)(cmd, []string{}, test.toComplete)
+			if diff := cmp.Diff(test.sugestions, suggestions); diff != "" {
+				t.Errorf("SuggestServiceRefs() sugestions (-want, +got) = %v", diff)
+			}
+			if want, got := test.shellCompDirective, directive; want != got {
+				t.Errorf("SuggestServiceRefs() ShellCompDirective: want %d, got %d", want, got)
+			}
+		})
+	}
+}
@@ -22,10 +22,13 @@ const TanzuAppsEnvVarPrefix = "TANZU_APPS"
 
 var (
 	EnvVarAllowedList = map[string]struct{}{
+		FlagToEnvVar(AllowedHostsFlagName):     {},
+		FlagToEnvVar(NamespaceFlagName):        {},
 		FlagToEnvVar(RegistryCertFlagName):     {},
 		FlagToEnvVar(RegistryPasswordFlagName): {},
 		FlagToEnvVar(RegistryTokenFlagName):    {},
 		FlagToEnvVar(RegistryUsernameFlagName): {},
+		FlagToEnvVar(SuppressWarningsFlagName): {},
 		FlagToEnvVar(TypeFlagName):             {},
 	}
 )
@@ -21,58 +21,128 @@ import (
 )
 
 const (
-	AllFlagName              = "--all"
-	AllNamespacesFlagName    = cli.AllNamespacesFlagName
-	AnnotationFlagName       = "--annotation"
-	AppFlagName              = "--app"
-	BuildEnvFlagName         = "--build-env"
-	ComponentFlagName        = "--component"
-	ConfigFlagName           = "--config"
-	ContextFlagName          = cli.ContextFlagName
-	DebugFlagName            = "--debug"
-	DryRunFlagName           = "--dry-run"
-	EnvFlagName              = "--env"
-	ExportFlagName           = "--export"
-	FilePathFlagName         = "--file"
-	GitBranchFlagName        = "--git-branch"
-	GitCommitFlagName        = "--git-commit"
-	GitFlagWildcard          = "--git-*"
-	GitRepoFlagName          = "--git-repo"
-	GitTagFlagName           = "--git-tag"
-	ImageFlagName            = "--image"
-	KubeConfigFlagName       = cli.KubeConfigFlagName
-	LabelFlagName            = "--label"
-	LimitCPUFlagName         = "--limit-cpu"
-	LimitMemoryFlagName      = "--limit-memory"
-	LiveUpdateFlagName       = "--live-update"
-	LocalPathFlagName        = "--local-path"
-	MavenArtifactFlagName    = "--maven-artifact"
-	MavenGroupFlagName       = "--maven-group"
-	MavenTypeFlagName        = "--maven-type"
-	MavenVersionFlagName     = "--maven-version"
-	NamespaceFlagName        = cli.NamespaceFlagName
-	NoColorFlagName          = cli.NoColorFlagName
-	OutputFlagName           = "--output"
-	ParamFlagName            = "--param"
-	ParamYamlFlagName        = "--param-yaml"
-	RegistryCertFlagName     = "--registry-ca-cert"
-	RegistryPasswordFlagName = "--registry-password"
-	RegistryTokenFlagName    = "--registry-token"
-	RegistryUsernameFlagName = "--registry-username"
-	RequestCPUFlagName       = "--request-cpu"
-	RequestMemoryFlagName    = "--request-memory"
-	ServiceAccountFlagName   = "--service-account"
-	ServiceRefFlagName       = "--service-ref"
-	SinceFlagName            = "--since"
-	SourceImageFlagName      = "--source-image"
-	SubPathFlagName          = "--sub-path"
-	TailFlagName             = "--tail"
-	TimestampFlagName        = "--timestamp"
-	TailTimestampFlagName    = "--tail-timestamp"
-	TypeFlagName             = "--type"
-	UpdateStrategyFlagName   = "--update-strategy"
-	VerboseLevelFlagName     = "--verbose"
-	WaitFlagName             = "--wait"
-	WaitTimeoutFlagName      = "--wait-timeout"
-	YesFlagName              = "--yes"
+	AgeFlagName                 = "--age"
+	AllFlagName                 = "--all"
+	AllowedHostsFlagName        = "--allowed-hosts"
+	AlwaysShowFlagName          = "--always-show"
+	AllNamespacesFlagName       = cli.AllNamespacesFlagName
+	AnnotateForOverlayFlagName  = "--annotate-for-overlay"
+	AnnotationFlagName          = "--annotation"
+	AppFlagName                 = "--app"
+	BareFlagName                = "--bare"
+	BuildEnvFlagName            = "--build-env"
+	BuildParamFlagName          = "--build-param"
+	BuildParamYamlFlagName      = "--build-param-yaml"
+	CollapseUnchangedFlagName   = "--collapse-unchanged"
+	ComponentFlagName           = "--component"
+	ConcurrencyFlagName         = "--concurrency"
+	ConfigFlagName              = "--config"
+	ContainerStateFlagName      = "--container-state"
+	ContextFlagName             = cli.ContextFlagName
+	ContextDirFlagName          = "--context-dir"
+	DebugFlagName               = "--debug"
+	DiffFlagName                = "--diff"
+	DiffFileFlagName            = "--diff-file"
+	DiffFormatFlagName          = "--diff-format"
+	DryRunFlagName              = "--dry-run"
+	EnvFlagName                 = "--env"
+	EnvFromConfigMapFlagName    = "--env-from-configmap"
+	EventsFlagName              = "--events"
+	ExitCodeFlagName            = "--exit-code"
+	ExpandEnvFlagName           = "--expand-env"
+	ExportFlagName              = "--export"
+	FailOnUnknownReasonFlagName = "--fail-on-unknown-reason"
+	FieldsFlagName              = "--fields"
+	FilePathFlagName            = "--file"
+	FollowFlagName              = "--follow"
+	ForceReplaceSourceFlagName  = "--force-replace-source"
+	FullTimestampsFlagName      = "--full-timestamps"
+	GitBranchFlagName           = "--git-branch"
+	GitCommitFlagName           = "--git-commit"
+	GitFlagWildcard             = "--git-*"
+	GitRefExclusiveFlagName     = "--git-ref-exclusive"
+	GitSecretFlagName           = "--git-secret"
+	GitRepoFlagName             = "--git-repo"
+	GitTagFlagName              = "--git-tag"
+	GuaranteedFlagName          = "--guaranteed"
+	HistoryFlagName             = "--history"
+	IgnoreNotFoundFlagName      = "--ignore-not-found"
+	ImageFlagName               = "--image"
+	InputFormatFlagName         = "--input-format"
+	KubeConfigFlagName          = cli.KubeConfigFlagName
+	LabelFlagName               = "--label"
+	LimitCPUFlagName            = "--limit-cpu"
+	LimitMemoryFlagName         = "--limit-memory"
+	LiveUpdateFlagName          = "--live-update"
+	LocalPathFlagName           = "--local-path"
+	MavenArtifactFlagName       = "--maven-artifact"
+	MavenGroupFlagName          = "--maven-group"
+	MavenTypeFlagName           = "--maven-type"
+	MavenVersionFlagName        = "--maven-version"
+	MaxValueWidthFlagName       = "--max-value-width"
+	MessageFlagName             = "--message"
+	MessageModeFlagName         = "--message-mode"
+	NamespaceFlagName           = cli.NamespaceFlagName
+	NoCacheFlagName             = "--no-cache"
+	NoColorFlagName             = cli.NoColorFlagName
+	NoHeadersFlagName           = "--no-headers"
+	NoProgressFlagName          = "--no-progress"
+	NoSACheckFlagName           = "--no-sa-check"
+	NoTruncateFlagName          = "--no-truncate"
+	OutputFlagName              = cli.OutputFlagName
+	OutputFileFlagName          = "--output-file"
+	PackSubPathFlagName         = "--pack-subpath"
+	ParamFlagName               = "--param"
+	ParamsFlagName              = "--params"
+	ParamYamlFlagName           = "--param-yaml"
+	PinImageFlagName            = "--pin-image"
+	PodsFlagName                = "--pods"
+	PreviousFlagName            = "--previous"
+	PrintFlagsFlagName          = "--print-flags"
+	ProfileFlagName             = "--profile"
+	PruneAllowlistFlagName      = "--prune-allowlist"
+	PruneBuildEnvFlagName       = "--prune-build-env"
+	PruneEnvFlagName            = "--prune-env"
+	PruneParamsFlagName         = "--prune-params"
+	QuietFlagName               = "--quiet"
+	RecursiveFlagName           = "--recursive"
+	RegistryCertFlagName        = "--registry-ca-cert"
+	RegistryPasswordFlagName    = "--registry-password"
+	RegistryTokenFlagName       = "--registry-token"
+	RegistryUsernameFlagName    = "--registry-username"
+	RequestCPUFlagName          = "--request-cpu"
+	RequestMemoryFlagName       = "--request-memory"
+	ResolveDigestsFlagName      = "--resolve-digests"
+	RetryBackoffFlagName        = cli.RetryBackoffFlagName
+	RetryFlagName               = cli.RetryFlagName
+	RollbackOnErrorFlagName     = "--rollback-on-error"
+	SaveConfigFlagName          = "--save-config"
+	ServiceAccountFlagName      = "--service-account"
+	ServiceRefFlagName          = "--service-ref"
+	SetImageTagFlagName         = "--set-image-tag"
+	ShowManagedFieldsFlagName   = "--show-managed-fields"
+	ShowSecretsFlagName         = "--show-secrets"
+	SinceFlagName               = "--since"
+	SourceImageFlagName         = "--source-image"
+	StatusFlagName              = "--status"
+	SubPathFlagName             = "--sub-path"
+	SummaryFlagName             = "--summary"
+	SuppressWarningsFlagName    = "--suppress-warnings"
+	TailFlagName                = "--tail"
+	TimeoutActionFlagName       = "--timeout-action"
+	TimestampFlagName           = "--timestamp"
+	TailTimestampFlagName       = "--tail-timestamp"
+	TypeFlagName                = "--type"
+	UpdateOnlyFlagName          = "--update-only"
+	UpdateStrategyFlagName      = "--update-strategy"
+	ValidateFlagName            = "--validate"
+	ValidateSchemaFlagName      = "--validate-schema"
+	VerboseLevelFlagName        = "--verbose"
+	WaitFlagName                = "--wait"
+	WaitIntervalFlagName        = "--wait-interval"
+	WaitTimeoutFlagName         = "--wait-timeout"
+	WarningsAsErrorsFlagName    = "--warnings-as-errors"
+	WordDiffFlagName            = "--word-diff"
+	YamlFlowParamsFlagName      = "--yaml-flow-params"
+	YesFlagName                 = "--yes"
 )
@@ -21,11 +21,24 @@ import (
 )
 
 type Object = printer.Object
+type DiffOptions = printer.DiffOptions
+type YamlTransform = printer.YamlTransform
 
+var DiffStat = printer.DiffStat
 var ExportResource = printer.ExportResource
 var OutputResource = printer.OutputResource
+var OutputResourceWithManagedFields = printer.OutputResourceWithManagedFields
+var OutputResourceFields = printer.OutputResourceFields
+var OutputResourceStatus = printer.OutputResourceStatus
 var FindCondition = printer.FindCondition
+var IsCustomColumns = printer.IsCustomColumns
+var OutputCustomColumns = printer.OutputCustomColumns
+var ParseCustomColumns = printer.ParseCustomColumns
 var ResourceDiff = printer.ResourceDiff
+var ResourceDiffWithOptions = printer.ResourceDiffWithOptions
+var ResourceRemovedFields = printer.ResourceRemovedFields
+var ResourceUnifiedDiff = printer.ResourceUnifiedDiff
+var ResourceWordDiff = printer.ResourceWordDiff
 var ResourceStatus = printer.ResourceStatus
 var Serrorf = printer.Serrorf
 var SortByNamespaceAndName = printer.SortByNamespaceAndName
@@ -35,3 +48,4 @@ type OutputFormat = printer.OutputFormat
 var OutputFormatJson = printer.OutputFormatJson
 var OutputFormatYaml = printer.OutputFormatYaml
 var OutputFormatYml = printer.OutputFormatYml
+var OutputFormatCustomColumns = printer.OutputFormatCustomColumns
@@ -0,0 +1,57 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"io"
+
+	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/printer/table"
+)
+
+// ApplyResult is what was done to the workload of a file applied with workload apply --recursive
+type ApplyResult struct {
+	File     string
+	Workload string
+	// Result is created, updated, unchanged or failed
+	Result string
+}
+
+// ApplySummaryPrinter prints the results of applying many workload files as a FILE / WORKLOAD /
+// RESULT table, in the order the files were applied
+func ApplySummaryPrinter(w io.Writer, results []ApplyResult) error {
+	summary := &metav1beta1.Table{
+		ColumnDefinitions: []metav1beta1.TableColumnDefinition{
+			{Name: "File", Type: "string"},
+			{Name: "Workload", Type: "string"},
+			{Name: "Result", Type: "string"},
+		},
+		Rows: make([]metav1beta1.TableRow, 0, len(results)),
+	}
+	for _, result := range results {
+		workload := result.Workload
+		if workload == "" {
+			workload = "<unknown>"
+		}
+		summary.Rows = append(summary.Rows, metav1beta1.TableRow{
+			Cells: []interface{}{result.File, workload, result.Result},
+		})
+	}
+	tablePrinter := table.NewTablePrinter(table.PrintOptions{PaddingStart: paddingStart})
+	return tablePrinter.PrintObj(summary, w)
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/printer"
+)
+
+func TestApplySummaryPrinter(t *testing.T) {
+	results := []printer.ApplyResult{
+		{File: "workloads/api.yaml", Workload: "api", Result: "created"},
+		{File: "workloads/web/invalid.yaml", Result: "failed"},
+		{File: "workloads/web/web.yml", Workload: "web", Result: "unchanged"},
+	}
+	expected := `
+   FILE                         WORKLOAD    RESULT
+   workloads/api.yaml           api         created
+   workloads/web/invalid.yaml   <unknown>   failed
+   workloads/web/web.yml        web         unchanged
+`
+
+	output := &bytes.Buffer{}
+	if err := printer.ApplySummaryPrinter(output, results); err != nil {
+		t.Fatalf("ApplySummaryPrinter() unexpected error = %v", err)
+	}
+	if diff := cmp.Diff(strings.TrimPrefix(expected, "\n"), output.String()); diff != "" {
+		t.Errorf("ApplySummaryPrinter() (-expected, +actual) = %s", diff)
+	}
+}
@@ -18,8 +18,12 @@ package printer
 
 import (
 	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/printer"
 )
 
 const (
@@ -35,3 +39,20 @@ var (
 func AddPaddingStart(text string) string {
 	return strings.Repeat(" ", paddingStart) + text
 }
+
+// TimestampOptions controls how creation and transition times are shown, so every command formats
+// ages the same way
+type TimestampOptions struct {
+	// Now is the time ages are relative to, defaults to the current time
+	Now time.Time
+	// Full shows absolute RFC3339 times instead of ages
+	Full bool
+}
+
+func (o TimestampOptions) Format(timestamp metav1.Time) string {
+	now := o.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	return printer.Timestamp(timestamp, now, o.Full)
+}
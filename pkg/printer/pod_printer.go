@@ -17,6 +17,11 @@ limitations under the License.
 package printer
 
 import (
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
@@ -27,3 +32,69 @@ func PodTablePrinter(c *cli.Config, tableResult runtime.Object) error {
 	tablePrinter := table.NewTablePrinter(table.PrintOptions{PaddingStart: paddingStart})
 	return tablePrinter.PrintObj(tableResult, c.Stdout)
 }
+
+// PodReadinessPrinter prints how many of the pods are ready, followed by the phase, the ready
+// containers and the restarts of each pod
+func PodReadinessPrinter(w io.Writer, podList *corev1.PodList) error {
+	ready := 0
+	for i := range podList.Items {
+		if isPodReady(&podList.Items[i]) {
+			ready++
+		}
+	}
+	if _, err := fmt.Fprint(w, AddPaddingStart(fmt.Sprintf("%d/%d pods ready\n\n", ready, len(podList.Items)))); err != nil {
+		return err
+	}
+
+	printPodRow := func(pod *corev1.Pod, _ table.PrintOptions) ([]metav1beta1.TableRow, error) {
+		readyContainers, restarts := 0, int32(0)
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Ready {
+				readyContainers++
+			}
+			restarts += status.RestartCount
+		}
+		row := metav1beta1.TableRow{
+			Object: runtime.RawExtension{Object: pod},
+		}
+		row.Cells = append(row.Cells,
+			pod.Name,
+			string(pod.Status.Phase),
+			fmt.Sprintf("%d/%d", readyContainers, len(pod.Spec.Containers)),
+			restarts,
+		)
+		return []metav1beta1.TableRow{row}, nil
+	}
+	printPodList := func(podList *corev1.PodList, printOpts table.PrintOptions) ([]metav1beta1.TableRow, error) {
+		rows := make([]metav1beta1.TableRow, 0, len(podList.Items))
+		for i := range podList.Items {
+			r, err := printPodRow(&podList.Items[i], printOpts)
+			if err != nil {
+				return nil, err
+			}
+			rows = append(rows, r...)
+		}
+		return rows, nil
+	}
+	tablePrinter := table.NewTablePrinter(table.PrintOptions{PaddingStart: paddingStart}).With(func(h table.PrintHandler) {
+		columns := []metav1beta1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "Phase", Type: "string"},
+			{Name: "Ready", Type: "string"},
+			{Name: "Restarts", Type: "integer"},
+		}
+		h.TableHandler(columns, printPodList)
+		h.TableHandler(columns, printPodRow)
+	})
+	return tablePrinter.PrintObj(podList, w)
+}
+
+// isPodReady returns true when the pod reports a true Ready condition
+func isPodReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -104,3 +104,71 @@ func TestPodTablePrinter(t *testing.T) {
 		})
 	}
 }
+
+func TestPodReadinessPrinter(t *testing.T) {
+	defaultNamespace := "default"
+
+	tests := []struct {
+		name           string
+		pods           []corev1.Pod
+		expectedOutput string
+	}{{
+		name: "ready and not ready pods",
+		pods: []corev1.Pod{
+			diecorev1.PodBlank.
+				MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+					d.Name("pod1")
+					d.Namespace(defaultNamespace)
+				}).
+				SpecDie(func(d *diecorev1.PodSpecDie) {
+					d.ContainerDie("workload", func(d *diecorev1.ContainerDie) {})
+					d.ContainerDie("sidecar", func(d *diecorev1.ContainerDie) {})
+				}).
+				StatusDie(func(d *diecorev1.PodStatusDie) {
+					d.Phase(corev1.PodRunning)
+					d.Conditions(corev1.PodCondition{Type: corev1.PodReady, Status: corev1.ConditionTrue})
+					d.ContainerStatusDie("workload", func(d *diecorev1.ContainerStatusDie) {
+						d.Ready(true)
+						d.RestartCount(2)
+					})
+					d.ContainerStatusDie("sidecar", func(d *diecorev1.ContainerStatusDie) {
+						d.Ready(true)
+						d.RestartCount(1)
+					})
+				}).
+				DieRelease(),
+			diecorev1.PodBlank.
+				MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+					d.Name("pod2")
+					d.Namespace(defaultNamespace)
+				}).
+				SpecDie(func(d *diecorev1.PodSpecDie) {
+					d.ContainerDie("workload", func(d *diecorev1.ContainerDie) {})
+				}).
+				StatusDie(func(d *diecorev1.PodStatusDie) {
+					d.Phase(corev1.PodRunning)
+					d.Conditions(corev1.PodCondition{Type: corev1.PodReady, Status: corev1.ConditionFalse})
+				}).
+				DieRelease(),
+		},
+		expectedOutput: `
+   1/2 pods ready
+
+   NAME   PHASE     READY   RESTARTS
+   pod1   Running   2/2     3
+   pod2   Running   0/1     0
+`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			if err := printer.PodReadinessPrinter(output, &corev1.PodList{Items: test.pods}); err != nil {
+				t.Errorf("PodReadinessPrinter() expected no error, got %v", err)
+			}
+			if diff := cmp.Diff(strings.TrimPrefix(test.expectedOutput, "\n"), output.String()); diff != "" {
+				t.Errorf("Unexpected output (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
@@ -28,7 +28,7 @@ import (
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/printer/table"
 )
 
-func DeliverableResourcesPrinter(w io.Writer, deliverable *cartov1alpha1.Deliverable) error {
+func DeliverableResourcesPrinter(w io.Writer, deliverable *cartov1alpha1.Deliverable, timestamps TimestampOptions) error {
 	printResourceInfoRow := func(resource *cartov1alpha1.RealizedResource, _ table.PrintOptions) ([]metav1beta1.TableRow, error) {
 		var healthy string
 		healthyCond := printer.FindCondition(resource.Conditions, cartov1alpha1.ConditionResourceHealthy)
@@ -36,7 +36,7 @@ func DeliverableResourcesPrinter(w io.Writer, deliverable *cartov1alpha1.Deliver
 			healthy = printer.ColorConditionStatus(string(healthyCond.Status))
 		}
 
-		ready, elapsedTransitionTime := findConditionReady(resource.Conditions, cartov1alpha1.ConditionResourceReady)
+		ready, elapsedTransitionTime := findConditionReady(resource.Conditions, cartov1alpha1.ConditionResourceReady, timestamps)
 		row := metav1beta1.TableRow{
 			Cells: []interface{}{
 				resource.Name,
@@ -358,7 +358,7 @@ func TestDeliverableResourcesPrinter(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			output := &bytes.Buffer{}
-			if err := printer.DeliverableResourcesPrinter(output, test.testDeliverable); err != nil {
+			if err := printer.DeliverableResourcesPrinter(output, test.testDeliverable, printer.TimestampOptions{}); err != nil {
 				t.Errorf("DeliverableSourcePrinter() expected no error, got %v", err)
 			}
 			outputString := output.String()
@@ -0,0 +1,104 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+var _ YamlTransform = FlowStyleParams
+
+// FlowStyleParams renders the structured values of the workload params in a yaml document in flow
+// style, like {a: 1, b: 2}, so large params take a single line. Scalar values and the other fields
+// are left as they are.
+func FlowStyleParams(doc string) (string, error) {
+	root := &yamlv3.Node{}
+	if err := yamlv3.Unmarshal([]byte(doc), root); err != nil {
+		return "", err
+	}
+	values := paramValueNodes(root)
+	if len(values) == 0 {
+		return doc, nil
+	}
+
+	lines := strings.Split(doc, "\n")
+	// the last values are replaced first, so the line numbers of the previous ones stay valid
+	for i := len(values) - 1; i >= 0; i-- {
+		key, value := values[i][0], values[i][1]
+		value.Style = yamlv3.FlowStyle
+		b, err := yamlv3.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		start, indent := key.Line-1, key.Column-1
+		end := start + 1
+		for end < len(lines) && inBlock(lines[end], indent, value.Kind == yamlv3.SequenceNode) {
+			end++
+		}
+		flow := fmt.Sprintf("%s%s: %s", lines[start][:indent], key.Value, strings.TrimSpace(string(b)))
+		lines = append(lines[:start], append([]string{flow}, lines[end:]...)...)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// paramValueNodes returns the key and value nodes of the spec.params[].value mappings and
+// sequences, in the order they appear in the document
+func paramValueNodes(root *yamlv3.Node) [][2]*yamlv3.Node {
+	values := [][2]*yamlv3.Node{}
+	if root.Kind != yamlv3.DocumentNode || len(root.Content) == 0 {
+		return values
+	}
+	_, spec := mappingEntry(root.Content[0], "spec")
+	_, params := mappingEntry(spec, "params")
+	if params == nil || params.Kind != yamlv3.SequenceNode {
+		return values
+	}
+	for _, param := range params.Content {
+		key, value := mappingEntry(param, "value")
+		if value != nil && (value.Kind == yamlv3.MappingNode || value.Kind == yamlv3.SequenceNode) && len(value.Content) != 0 {
+			values = append(values, [2]*yamlv3.Node{key, value})
+		}
+	}
+	return values
+}
+
+func mappingEntry(node *yamlv3.Node, name string) (*yamlv3.Node, *yamlv3.Node) {
+	if node == nil || node.Kind != yamlv3.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == name {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// inBlock returns true when the line is part of the block value of a key at the indent. The
+// items of a sequence value are at the same indent as the key.
+func inBlock(line string, indent int, sequence bool) bool {
+	content := strings.TrimLeft(line, " ")
+	if content == "" {
+		// only block scalars have empty lines
+		return true
+	}
+	lineIndent := len(line) - len(content)
+	return lineIndent > indent || (sequence && lineIndent == indent && strings.HasPrefix(content, "-"))
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/printer"
+)
+
+func TestFlowStyleParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		expected string
+	}{{
+		name: "structured params",
+		doc: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: my-workload
+spec:
+  params:
+  - name: ports
+    value:
+    - 8080
+    - 8181
+  - name: server
+    value:
+      management:
+        port: 8181
+      port: 8080
+  - name: scalar
+    value: my-value
+  source:
+    git:
+      url: https://example.com/repo.git`,
+		expected: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: my-workload
+spec:
+  params:
+  - name: ports
+    value: [8080, 8181]
+  - name: server
+    value: {management: {port: 8181}, port: 8080}
+  - name: scalar
+    value: my-value
+  source:
+    git:
+      url: https://example.com/repo.git`,
+	}, {
+		name: "multi line string in a param",
+		doc: `
+---
+spec:
+  params:
+  - name: config
+    value:
+      script: |-
+        echo hello
+
+        echo world
+      shell: bash`,
+		expected: `
+---
+spec:
+  params:
+  - name: config
+    value: {script: "echo hello\n\necho world", shell: bash}`,
+	}, {
+		name: "no params",
+		doc: `
+---
+spec:
+  image: ubuntu:bionic`,
+		expected: `
+---
+spec:
+  image: ubuntu:bionic`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := printer.FlowStyleParams(strings.TrimPrefix(test.doc, "\n"))
+			if err != nil {
+				t.Fatalf("FlowStyleParams() errored %v", err)
+			}
+			if diff := cmp.Diff(strings.TrimPrefix(test.expected, "\n"), got); diff != "" {
+				t.Errorf("FlowStyleParams() (-want, +got) = %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+var _ YamlTransform = MultilineEnvValues
+
+// MultilineEnvValues renders each line of the multi-line env and build env values of the
+// workload in a yaml document on its own line. Values that yaml can't keep in a block scalar, like
+// the ones with carriage returns or trailing spaces, are otherwise rendered as a single quoted
+// string folded at an arbitrary width. They are rendered as a double quoted string with an escaped
+// line break after each of their lines instead, which is read back as the same value.
+func MultilineEnvValues(doc string) (string, error) {
+	root := &yamlv3.Node{}
+	if err := yamlv3.Unmarshal([]byte(doc), root); err != nil {
+		return "", err
+	}
+	values := multilineEnvValueNodes(root)
+	if len(values) == 0 {
+		return doc, nil
+	}
+
+	lines := strings.Split(doc, "\n")
+	// the last values are replaced first, so the line numbers of the previous ones stay valid
+	for i := len(values) - 1; i >= 0; i-- {
+		key, value := values[i][0], values[i][1]
+		start, indent := key.Line-1, key.Column-1
+		end := start + 1
+		// quoted strings never have empty lines, unlike the end of the document
+		for end < len(lines) && lines[end] != "" && inBlock(lines[end], indent, false) {
+			end++
+		}
+		rendered := strings.Split(fmt.Sprintf("%s%s: %s", lines[start][:indent], key.Value, quoteLines(value.Value, indent+2)), "\n")
+		lines = append(lines[:start], append(rendered, lines[end:]...)...)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// multilineEnvValueNodes returns the key and value nodes of the spec.env[].value and
+// spec.build.env[].value quoted strings with line breaks, in the order they appear in the document
+func multilineEnvValueNodes(root *yamlv3.Node) [][2]*yamlv3.Node {
+	values := [][2]*yamlv3.Node{}
+	if root.Kind != yamlv3.DocumentNode || len(root.Content) == 0 {
+		return values
+	}
+	_, spec := mappingEntry(root.Content[0], "spec")
+	_, build := mappingEntry(spec, "build")
+	_, buildEnv := mappingEntry(build, "env")
+	_, env := mappingEntry(spec, "env")
+	for _, list := range []*yamlv3.Node{env, buildEnv} {
+		if list == nil || list.Kind != yamlv3.SequenceNode {
+			continue
+		}
+		for _, item := range list.Content {
+			key, value := mappingEntry(item, "value")
+			if value != nil && value.Kind == yamlv3.ScalarNode && value.Style&yamlv3.DoubleQuotedStyle != 0 && strings.Contains(strings.TrimSuffix(value.Value, "\n"), "\n") {
+				values = append(values, [2]*yamlv3.Node{key, value})
+			}
+		}
+	}
+	// the build env comes before the env in the document
+	sort.Slice(values, func(i, j int) bool {
+		return values[i][0].Line < values[j][0].Line
+	})
+	return values
+}
+
+// quoteLines returns s as a double quoted string with an escaped line break after each of its
+// lines. The escaped line breaks and the indent of the following lines are not part of the value.
+func quoteLines(s string, indent int) string {
+	segments := strings.SplitAfter(s, "\n")
+	if segments[len(segments)-1] == "" {
+		segments = segments[:len(segments)-1]
+	}
+	quoted := make([]string, len(segments))
+	for i, segment := range segments {
+		q := strconv.Quote(segment)
+		q = q[1 : len(q)-1]
+		if i > 0 && strings.HasPrefix(q, " ") {
+			// the leading spaces of a continuation line are dropped unless escaped
+			q = `\x20` + q[1:]
+		}
+		quoted[i] = q
+	}
+	return `"` + strings.Join(quoted, "\\\n"+strings.Repeat(" ", indent)) + `"`
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/printer"
+)
+
+func TestMultilineEnvValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		expected string
+	}{{
+		name: "quoted multi-line values",
+		doc: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: my-workload
+spec:
+  build:
+    env:
+    - name: SCRIPT
+      value: "#!/bin/sh\n\techo hello  \nexit 0\n"
+  env:
+  - name: CERT
+    value: "-----BEGIN CERTIFICATE-----\r\nMIIDdzCCAl+gAwIBAgIEAgAAuTANBgkqhkiG9w0BAQUFADBaMQswCQYDVQQGEwJJ\r\n-----END
+      CERTIFICATE-----\r\n"
+  - name: INDENTED
+    value: "trailing \n  indented"
+`,
+		expected: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: my-workload
+spec:
+  build:
+    env:
+    - name: SCRIPT
+      value: "#!/bin/sh\n\
+        \techo hello  \n\
+        exit 0\n"
+  env:
+  - name: CERT
+    value: "-----BEGIN CERTIFICATE-----\r\n\
+      MIIDdzCCAl+gAwIBAgIEAgAAuTANBgkqhkiG9w0BAQUFADBaMQswCQYDVQQGEwJJ\r\n\
+      -----END CERTIFICATE-----\r\n"
+  - name: INDENTED
+    value: "trailing \n\
+      \x20 indented"
+`,
+	}, {
+		name: "block and single line values",
+		doc: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: my-workload
+spec:
+  env:
+  - name: BLOCK
+    value: |
+      line 1
+      line 2
+  - name: NEWLINE
+    value: "trailing \n"
+  - name: PLAIN
+    value: value
+`,
+		expected: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: my-workload
+spec:
+  env:
+  - name: BLOCK
+    value: |
+      line 1
+      line 2
+  - name: NEWLINE
+    value: "trailing \n"
+  - name: PLAIN
+    value: value
+`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := printer.MultilineEnvValues(strings.TrimPrefix(test.doc, "\n"))
+			if err != nil {
+				t.Fatalf("MultilineEnvValues() errored %v", err)
+			}
+			if diff := cmp.Diff(strings.TrimPrefix(test.expected, "\n"), got); diff != "" {
+				t.Errorf("MultilineEnvValues() (-want, +got) = %s", diff)
+			}
+		})
+	}
+}
+
+func TestMultilineEnvValues_RoundTrip(t *testing.T) {
+	scheme := runtime.NewScheme()
+	cartov1alpha1.AddToScheme(scheme)
+
+	env := []corev1.EnvVar{
+		{Name: "CERT", Value: "-----BEGIN CERTIFICATE-----\r\nMIIDdzCCAl+gAwIBAgIEAgAAuTANBgkqhkiG9w0BAQUFADBaMQswCQYDVQQGEwJJ\r\n-----END CERTIFICATE-----\r\n"},
+		{Name: "SCRIPT", Value: "#!/bin/sh\n\techo \"hello\" \\\n  world  \nexit 0"},
+		{Name: "SPACES", Value: "\n   \n  a \n"},
+		{Name: "UNICODE", Value: "héllo  \nwörld\u0007\n"},
+		{Name: "BLOCK", Value: "line 1\nline 2\n"},
+	}
+	workload := &cartov1alpha1.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-workload",
+		},
+		Spec: cartov1alpha1.WorkloadSpec{
+			Env: env,
+			Build: &cartov1alpha1.WorkloadBuild{
+				Env: env,
+			},
+			Image: "ubuntu:bionic",
+		},
+	}
+
+	export, err := printer.ExportResource(workload, printer.OutputFormat(printer.OutputFormatYaml), scheme)
+	if err != nil {
+		t.Fatalf("ExportResource() unexpected error = %v", err)
+	}
+	got, err := printer.MultilineEnvValues(export)
+	if err != nil {
+		t.Fatalf("MultilineEnvValues() errored %v", err)
+	}
+	if got == export {
+		t.Errorf("MultilineEnvValues() expected the quoted values to be split in lines:\n%s", got)
+	}
+
+	actual := &cartov1alpha1.Workload{}
+	if err := yaml.Unmarshal([]byte(got), actual); err != nil {
+		t.Fatalf("unable to read the rendered workload: %v\n%s", err, got)
+	}
+	if diff := cmp.Diff(env, actual.Spec.Env); diff != "" {
+		t.Errorf("env (-expected, +actual) = %s", diff)
+	}
+	if diff := cmp.Diff(env, actual.Spec.Build.Env); diff != "" {
+		t.Errorf("build env (-expected, +actual) = %s", diff)
+	}
+}
@@ -27,7 +27,9 @@ import (
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/printer/table"
 )
 
-func WorkloadOverviewPrinter(w io.Writer, workload *cartov1alpha1.Workload) error {
+// WorkloadOverviewPrinter prints the workload name, type and namespace, and how long ago it was
+// created when withAge is set
+func WorkloadOverviewPrinter(w io.Writer, workload *cartov1alpha1.Workload, withAge bool, timestamps TimestampOptions) error {
 	printWorkloadOverview := func(workload *cartov1alpha1.Workload, printOpts table.PrintOptions) ([]metav1beta1.TableRow, error) {
 		labels := workload.Labels
 		if labels == nil {
@@ -53,6 +55,18 @@ func WorkloadOverviewPrinter(w io.Writer, workload *cartov1alpha1.Workload) erro
 		}
 
 		rows := []metav1beta1.TableRow{nameRow, typeRow, namespaceRow}
+		if created := workload.GetCreationTimestamp(); withAge && !created.IsZero() {
+			label := "age:"
+			if timestamps.Full {
+				label = "created:"
+			}
+			rows = append(rows, metav1beta1.TableRow{
+				Cells: []interface{}{
+					label,
+					timestamps.Format(created),
+				},
+			})
+		}
 
 		return rows, nil
 	}
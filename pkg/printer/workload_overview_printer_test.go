@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,9 +35,12 @@ func TestWorkloadOverviewPrinter(t *testing.T) {
 	workloadName := "my-workload"
 	labels := make(map[string]string)
 	labels[apis.WorkloadTypeLabelName] = "web"
+	now := time.Date(2023, time.March, 10, 12, 0, 0, 0, time.UTC)
 	tests := []struct {
 		name           string
 		testWorkload   *cartov1alpha1.Workload
+		withAge        bool
+		timestamps     printer.TimestampOptions
 		expectedOutput string
 	}{{
 		name: "type label not present",
@@ -87,13 +91,65 @@ func TestWorkloadOverviewPrinter(t *testing.T) {
    name:        my-workload
    type:        web
    namespace:   my-namespace
+`,
+	}, {
+		name: "with creation timestamp",
+		testWorkload: &cartov1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              workloadName,
+				Namespace:         defaultNamespace,
+				Labels:            labels,
+				CreationTimestamp: metav1.NewTime(now.AddDate(0, 0, -3)),
+			},
+		},
+		withAge:    true,
+		timestamps: printer.TimestampOptions{Now: now},
+		expectedOutput: `
+   name:        my-workload
+   type:        web
+   namespace:   default
+   age:         3d
+`,
+	}, {
+		name: "with creation timestamp without age",
+		testWorkload: &cartov1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              workloadName,
+				Namespace:         defaultNamespace,
+				Labels:            labels,
+				CreationTimestamp: metav1.NewTime(now.AddDate(0, 0, -3)),
+			},
+		},
+		timestamps: printer.TimestampOptions{Now: now},
+		expectedOutput: `
+   name:        my-workload
+   type:        web
+   namespace:   default
+`,
+	}, {
+		name: "with creation timestamp and full timestamps",
+		testWorkload: &cartov1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              workloadName,
+				Namespace:         defaultNamespace,
+				Labels:            labels,
+				CreationTimestamp: metav1.NewTime(now.AddDate(0, 0, -3)),
+			},
+		},
+		withAge:    true,
+		timestamps: printer.TimestampOptions{Now: now, Full: true},
+		expectedOutput: `
+   name:        my-workload
+   type:        web
+   namespace:   default
+   created:     2023-03-07T12:00:00Z
 `,
 	}}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			output := &bytes.Buffer{}
-			if err := printer.WorkloadOverviewPrinter(output, test.testWorkload); err != nil {
+			if err := printer.WorkloadOverviewPrinter(output, test.testWorkload, test.withAge, test.timestamps); err != nil {
 				t.Errorf("WorkloadOverviewPrinter() expected no error, got %v", err)
 			}
 			outputString := output.String()
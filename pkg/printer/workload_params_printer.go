@@ -0,0 +1,90 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/printer/table"
+)
+
+// MaxParamValueWidth is the number of characters of a param value shown in the params table before
+// it is truncated
+const MaxParamValueWidth = 60
+
+// WorkloadParamsPrinter prints the workload params as a NAME / VALUE table. String values are shown
+// as is and the other values as compact JSON, on a single line. Values longer than
+// MaxParamValueWidth are truncated unless noTruncate is set.
+func WorkloadParamsPrinter(w io.Writer, workload *cartov1alpha1.Workload, noTruncate bool) error {
+	printParams := func(workload *cartov1alpha1.Workload, _ table.PrintOptions) ([]metav1beta1.TableRow, error) {
+		rows := make([]metav1beta1.TableRow, 0, len(workload.Spec.Params))
+		for i := range workload.Spec.Params {
+			param := &workload.Spec.Params[i]
+			value := paramValue(param)
+			if !noTruncate {
+				value = truncate(value, MaxParamValueWidth)
+			}
+			rows = append(rows, metav1beta1.TableRow{
+				Cells: []interface{}{
+					param.Name,
+					value,
+				},
+			})
+		}
+		return rows, nil
+	}
+
+	tablePrinter := table.NewTablePrinter(table.PrintOptions{PaddingStart: paddingStart}).With(func(h table.PrintHandler) {
+		columns := []metav1beta1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "Value", Type: "string"},
+		}
+		h.TableHandler(columns, printParams)
+	})
+
+	return tablePrinter.PrintObj(workload, w)
+}
+
+// paramValue renders the JSON value of the param on a single line, strings without their quotes
+func paramValue(param *cartov1alpha1.Param) string {
+	var value interface{}
+	if err := json.Unmarshal(param.Value.Raw, &value); err != nil {
+		return string(param.Value.Raw)
+	}
+	if s, ok := value.(string); ok {
+		return strings.ReplaceAll(s, "\n", `\n`)
+	}
+	// maps are marshaled with their keys sorted
+	b, err := json.Marshal(value)
+	if err != nil {
+		return string(param.Value.Raw)
+	}
+	return string(b)
+}
+
+func truncate(s string, width int) string {
+	r := []rune(s)
+	if len(r) <= width {
+		return s
+	}
+	return string(r[:width-3]) + "..."
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/printer"
+)
+
+func TestWorkloadParamsPrinter(t *testing.T) {
+	params := []cartov1alpha1.Param{{
+		Name:  "scanning",
+		Value: apiextensionsv1.JSON{Raw: []byte(`"enabled"`)},
+	}, {
+		Name:  "ports",
+		Value: apiextensionsv1.JSON{Raw: []byte(`[8080, 8181]`)},
+	}, {
+		Name:  "server",
+		Value: apiextensionsv1.JSON{Raw: []byte(`{"port": 8080, "management": {"port": 8181}}`)},
+	}, {
+		Name:  "script",
+		Value: apiextensionsv1.JSON{Raw: []byte(`"echo hello\necho world"`)},
+	}, {
+		Name:  "annotations",
+		Value: apiextensionsv1.JSON{Raw: []byte(`{"autoscaling.knative.dev/minScale": "1", "autoscaling.knative.dev/maxScale": "10"}`)},
+	}}
+
+	tests := []struct {
+		name           string
+		noTruncate     bool
+		expectedOutput string
+	}{{
+		name: "truncated values",
+		expectedOutput: `
+   NAME          VALUE
+   scanning      enabled
+   ports         [8080,8181]
+   server        {"management":{"port":8181},"port":8080}
+   script        echo hello\necho world
+   annotations   {"autoscaling.knative.dev/maxScale":"10","autoscaling.kna...
+`,
+	}, {
+		name:       "full values",
+		noTruncate: true,
+		expectedOutput: `
+   NAME          VALUE
+   scanning      enabled
+   ports         [8080,8181]
+   server        {"management":{"port":8181},"port":8080}
+   script        echo hello\necho world
+   annotations   {"autoscaling.knative.dev/maxScale":"10","autoscaling.knative.dev/minScale":"1"}
+`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			workload := &cartov1alpha1.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-workload",
+					Namespace: "default",
+				},
+				Spec: cartov1alpha1.WorkloadSpec{
+					Params: params,
+				},
+			}
+			output := &bytes.Buffer{}
+			if err := printer.WorkloadParamsPrinter(output, workload, test.noTruncate); err != nil {
+				t.Errorf("WorkloadParamsPrinter() errored %v", err)
+			}
+			if diff := cmp.Diff(strings.TrimPrefix(test.expectedOutput, "\n"), output.String()); diff != "" {
+				t.Errorf("Unexpected output (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
@@ -27,7 +27,9 @@ import (
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/printer/table"
 )
 
-func WorkloadSourceImagePrinter(w io.Writer, workload *cartov1alpha1.Workload) error {
+// WorkloadSourceImagePrinter prints the pre-built image of the workload, and the digest it was
+// resolved to when the digest is not empty
+func WorkloadSourceImagePrinter(w io.Writer, workload *cartov1alpha1.Workload, digest string) error {
 	printImageInfo := func(workload *cartov1alpha1.Workload, printOpts table.PrintOptions) ([]metav1beta1.TableRow, error) {
 		sourceRow := metav1beta1.TableRow{
 			Cells: []interface{}{
@@ -44,6 +46,7 @@ func WorkloadSourceImagePrinter(w io.Writer, workload *cartov1alpha1.Workload) e
 		}
 
 		rows := []metav1beta1.TableRow{sourceRow, imageRow}
+		rows = appendDigestRow(rows, digest)
 		return rows, nil
 	}
 
@@ -54,7 +57,9 @@ func WorkloadSourceImagePrinter(w io.Writer, workload *cartov1alpha1.Workload) e
 	return tablePrinter.PrintObj(workload, w)
 }
 
-func WorkloadLocalSourceImagePrinter(w io.Writer, workload *cartov1alpha1.Workload) error {
+// WorkloadLocalSourceImagePrinter prints the source image of the workload, and the digest it was
+// resolved to when the digest is not empty
+func WorkloadLocalSourceImagePrinter(w io.Writer, workload *cartov1alpha1.Workload, digest string) error {
 	printLocalSourceInfo := func(workload *cartov1alpha1.Workload, printOpts table.PrintOptions) ([]metav1beta1.TableRow, error) {
 		sourceRow := metav1beta1.TableRow{
 			Cells: []interface{}{
@@ -82,6 +87,7 @@ func WorkloadLocalSourceImagePrinter(w io.Writer, workload *cartov1alpha1.Worklo
 			},
 		}
 		rows = append(rows, imageRow)
+		rows = appendDigestRow(rows, digest)
 
 		return rows, nil
 	}
@@ -170,6 +176,18 @@ func WorkloadSourceGitPrinter(w io.Writer, workload *cartov1alpha1.Workload) err
 	return tablePrinter.PrintObj(workload, w)
 }
 
+func appendDigestRow(rows []metav1beta1.TableRow, digest string) []metav1beta1.TableRow {
+	if digest == "" {
+		return rows
+	}
+	return append(rows, metav1beta1.TableRow{
+		Cells: []interface{}{
+			"digest:",
+			digest,
+		},
+	})
+}
+
 func getRevision(workload *cartov1alpha1.Workload) string {
 	if !reflect.DeepEqual(workload.Status, (cartov1alpha1.WorkloadStatus{})) {
 		for _, r := range workload.Status.Resources {
@@ -35,6 +35,7 @@ func TestWorkloadSourceImagePrinter(t *testing.T) {
 	tests := []struct {
 		name           string
 		testWorkload   *cartov1alpha1.Workload
+		digest         string
 		expectedOutput string
 	}{{
 		name: "built from image",
@@ -50,13 +51,30 @@ func TestWorkloadSourceImagePrinter(t *testing.T) {
 		expectedOutput: `
    type:    image
    image:   my-image
+`,
+	}, {
+		name: "built from image with resolved digest",
+		testWorkload: &cartov1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      workloadName,
+				Namespace: defaultNamespace,
+			},
+			Spec: cartov1alpha1.WorkloadSpec{
+				Image: "my-image:v1",
+			},
+		},
+		digest: "sha256:0123456789abcdef",
+		expectedOutput: `
+   type:     image
+   image:    my-image:v1
+   digest:   sha256:0123456789abcdef
 `,
 	}}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			output := &bytes.Buffer{}
-			if err := printer.WorkloadSourceImagePrinter(output, test.testWorkload); err != nil {
+			if err := printer.WorkloadSourceImagePrinter(output, test.testWorkload, test.digest); err != nil {
 				t.Errorf("WorkloadSourcePrinter() expected no error, got %v", err)
 			}
 			outputString := output.String()
@@ -74,6 +92,7 @@ func TestWorkloadLocalSourceImagePrinter(t *testing.T) {
 	tests := []struct {
 		name           string
 		testWorkload   *cartov1alpha1.Workload
+		digest         string
 		expectedOutput string
 	}{{
 		name: "built from image",
@@ -91,13 +110,32 @@ func TestWorkloadLocalSourceImagePrinter(t *testing.T) {
 		expectedOutput: `
    type:    source image
    image:   my-image
+`,
+	}, {
+		name: "built from image with resolved digest",
+		testWorkload: &cartov1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      workloadName,
+				Namespace: defaultNamespace,
+			},
+			Spec: cartov1alpha1.WorkloadSpec{
+				Source: &cartov1alpha1.Source{
+					Image: "my-image:latest",
+				},
+			},
+		},
+		digest: "sha256:0123456789abcdef",
+		expectedOutput: `
+   type:     source image
+   image:    my-image:latest
+   digest:   sha256:0123456789abcdef
 `,
 	}}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			output := &bytes.Buffer{}
-			if err := printer.WorkloadLocalSourceImagePrinter(output, test.testWorkload); err != nil {
+			if err := printer.WorkloadLocalSourceImagePrinter(output, test.testWorkload, test.digest); err != nil {
 				t.Errorf("WorkloadSourcePrinter() expected no error, got %v", err)
 			}
 			outputString := output.String()
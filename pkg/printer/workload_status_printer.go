@@ -19,8 +19,8 @@ package printer
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
-	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
@@ -30,7 +30,7 @@ import (
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/printer/table"
 )
 
-func WorkloadResourcesPrinter(w io.Writer, workload *cartov1alpha1.Workload) error {
+func WorkloadResourcesPrinter(w io.Writer, workload *cartov1alpha1.Workload, timestamps TimestampOptions) error {
 	printResourceInfoRow := func(resource *cartov1alpha1.RealizedResource, _ table.PrintOptions) ([]metav1beta1.TableRow, error) {
 		var healthy string
 		healthyCond := printer.FindCondition(resource.Conditions, cartov1alpha1.ConditionResourceHealthy)
@@ -38,7 +38,7 @@ func WorkloadResourcesPrinter(w io.Writer, workload *cartov1alpha1.Workload) err
 			healthy = printer.ColorConditionStatus(string(healthyCond.Status))
 		}
 
-		ready, elapsedTransitionTime := findConditionReady(resource.Conditions, cartov1alpha1.ConditionResourceReady)
+		ready, elapsedTransitionTime := findConditionReady(resource.Conditions, cartov1alpha1.ConditionResourceReady, timestamps)
 		row := metav1beta1.TableRow{
 			Cells: []interface{}{
 				resource.Name,
@@ -150,7 +150,66 @@ func WorkloadIssuesPrinter(w io.Writer, workload *cartov1alpha1.Workload) error
 	return tablePrinter.PrintObj(workload, w)
 }
 
-func findConditionReady(conditions []metav1.Condition, strReadyCondition string) (string, string) {
+// WorkloadConditionHistoryPrinter lists the conditions of the workload and of its resources ordered
+// by their last transition time, oldest first. The most recent transitions are marked as latest.
+func WorkloadConditionHistoryPrinter(w io.Writer, workload *cartov1alpha1.Workload, timestamps TimestampOptions) error {
+	type historyEntry struct {
+		resource  string
+		condition metav1.Condition
+	}
+
+	printHistory := func(workload *cartov1alpha1.Workload, _ table.PrintOptions) ([]metav1beta1.TableRow, error) {
+		entries := []historyEntry{}
+		for _, cond := range workload.Status.Conditions {
+			entries = append(entries, historyEntry{resource: cartov1alpha1.WorkloadKind, condition: cond})
+		}
+		for _, r := range workload.Status.Resources {
+			for _, cond := range r.Conditions {
+				entries = append(entries, historyEntry{resource: r.Name, condition: cond})
+			}
+		}
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].condition.LastTransitionTime.Before(&entries[j].condition.LastTransitionTime)
+		})
+
+		var latest metav1.Time
+		if len(entries) != 0 {
+			latest = entries[len(entries)-1].condition.LastTransitionTime
+		}
+		rows := make([]metav1beta1.TableRow, 0, len(entries))
+		for _, e := range entries {
+			updated := timestamps.Format(e.condition.LastTransitionTime)
+			if !latest.IsZero() && e.condition.LastTransitionTime.Equal(&latest) {
+				updated = fmt.Sprintf("%s %s", updated, printer.Sinfof("(latest)"))
+			}
+			rows = append(rows, metav1beta1.TableRow{
+				Cells: []interface{}{
+					updated,
+					e.resource,
+					e.condition.Type,
+					printer.ColorConditionStatus(string(e.condition.Status)),
+					e.condition.Reason,
+				},
+			})
+		}
+		return rows, nil
+	}
+
+	tablePrinter := table.NewTablePrinter(table.PrintOptions{PaddingStart: paddingStart}).With(func(h table.PrintHandler) {
+		columns := []metav1beta1.TableColumnDefinition{
+			{Name: "Updated", Type: "string"},
+			{Name: "Resource", Type: "string"},
+			{Name: "Condition", Type: "string"},
+			{Name: "Status", Type: "string"},
+			{Name: "Reason", Type: "string"},
+		}
+		h.TableHandler(columns, printHistory)
+	})
+
+	return tablePrinter.PrintObj(workload, w)
+}
+
+func findConditionReady(conditions []metav1.Condition, strReadyCondition string, timestamps TimestampOptions) (string, string) {
 	var ready string
 	var elapsedTransitionTime string
 
@@ -158,7 +217,7 @@ func findConditionReady(conditions []metav1.Condition, strReadyCondition string)
 
 	if conditionReady != nil {
 		ready = string(conditionReady.Status)
-		elapsedTransitionTime = printer.TimestampSince(conditionReady.LastTransitionTime, time.Now())
+		elapsedTransitionTime = timestamps.Format(conditionReady.LastTransitionTime)
 	}
 
 	return ready, elapsedTransitionTime
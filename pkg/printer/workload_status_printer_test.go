@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
@@ -32,10 +33,12 @@ import (
 func TestWorkloadResourcesPrinter(t *testing.T) {
 	defaultNamespace := "default"
 	workloadName := "my-workload"
+	now := time.Date(2023, time.March, 10, 12, 0, 0, 0, time.UTC)
 
 	tests := []struct {
 		name           string
 		testWorkload   *cartov1alpha1.Workload
+		timestamps     printer.TimestampOptions
 		expectedOutput string
 	}{{
 		name: "various resources",
@@ -408,13 +411,59 @@ func TestWorkloadResourcesPrinter(t *testing.T) {
    NAME              READY   HEALTHY   UPDATED   RESOURCE
    source-provider                               not found
    deliverable                                   not found
+`,
+	}, {
+		name: "updated times",
+		testWorkload: &cartov1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      workloadName,
+				Namespace: defaultNamespace,
+			},
+			Status: cartov1alpha1.WorkloadStatus{
+				Resources: []cartov1alpha1.RealizedResource{{
+					Name: "source-provider",
+					Conditions: []metav1.Condition{{
+						Type:               cartov1alpha1.ConditionResourceReady,
+						Status:             metav1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(now.Add(-45 * time.Minute)),
+					}},
+				}},
+			},
+		},
+		timestamps: printer.TimestampOptions{Now: now},
+		expectedOutput: `
+   NAME              READY   HEALTHY   UPDATED   RESOURCE
+   source-provider   True              45m       not found
+`,
+	}, {
+		name: "updated times with full timestamps",
+		testWorkload: &cartov1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      workloadName,
+				Namespace: defaultNamespace,
+			},
+			Status: cartov1alpha1.WorkloadStatus{
+				Resources: []cartov1alpha1.RealizedResource{{
+					Name: "source-provider",
+					Conditions: []metav1.Condition{{
+						Type:               cartov1alpha1.ConditionResourceReady,
+						Status:             metav1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(now.Add(-45 * time.Minute)),
+					}},
+				}},
+			},
+		},
+		timestamps: printer.TimestampOptions{Now: now, Full: true},
+		expectedOutput: `
+   NAME              READY   HEALTHY   UPDATED                RESOURCE
+   source-provider   True              2023-03-10T11:15:00Z   not found
 `,
 	}}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			output := &bytes.Buffer{}
-			if err := printer.WorkloadResourcesPrinter(output, test.testWorkload); err != nil {
+			if err := printer.WorkloadResourcesPrinter(output, test.testWorkload, test.timestamps); err != nil {
 				t.Errorf("WorkloadSourcePrinter() expected no error, got %v", err)
 			}
 			outputString := output.String()
@@ -425,6 +474,78 @@ func TestWorkloadResourcesPrinter(t *testing.T) {
 	}
 }
 
+func TestWorkloadConditionHistoryPrinter(t *testing.T) {
+	now := time.Date(2023, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		testWorkload   *cartov1alpha1.Workload
+		timestamps     printer.TimestampOptions
+		expectedOutput string
+	}{{
+		name:         "no conditions",
+		testWorkload: &cartov1alpha1.Workload{},
+		expectedOutput: `
+   UPDATED   RESOURCE   CONDITION   STATUS   REASON
+`,
+	}, {
+		name: "ordered by transition time",
+		testWorkload: &cartov1alpha1.Workload{
+			Status: cartov1alpha1.WorkloadStatus{
+				Conditions: []metav1.Condition{{
+					Type:               cartov1alpha1.WorkloadConditionReady,
+					Status:             metav1.ConditionFalse,
+					Reason:             "MissingValueAtPath",
+					LastTransitionTime: metav1.NewTime(now.Add(-2 * time.Minute)),
+				}},
+				Resources: []cartov1alpha1.RealizedResource{{
+					Name: "source-provider",
+					Conditions: []metav1.Condition{{
+						Type:               cartov1alpha1.ConditionResourceReady,
+						Status:             metav1.ConditionTrue,
+						Reason:             "Ready",
+						LastTransitionTime: metav1.NewTime(now.Add(-10 * time.Minute)),
+					}},
+				}, {
+					Name: "image-builder",
+					Conditions: []metav1.Condition{{
+						Type:               cartov1alpha1.ConditionResourceReady,
+						Status:             metav1.ConditionFalse,
+						Reason:             "MissingValueAtPath",
+						LastTransitionTime: metav1.NewTime(now.Add(-2 * time.Minute)),
+					}, {
+						Type:               cartov1alpha1.ConditionResourceSubmitted,
+						Status:             metav1.ConditionTrue,
+						Reason:             "ResourceSubmissionComplete",
+						LastTransitionTime: metav1.NewTime(now.Add(-5 * time.Minute)),
+					}},
+				}},
+			},
+		},
+		timestamps: printer.TimestampOptions{Now: now},
+		expectedOutput: `
+   UPDATED       RESOURCE          CONDITION           STATUS   REASON
+   10m           source-provider   Ready               True     Ready
+   5m            image-builder     ResourceSubmitted   True     ResourceSubmissionComplete
+   2m (latest)   Workload          Ready               False    MissingValueAtPath
+   2m (latest)   image-builder     Ready               False    MissingValueAtPath
+`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			if err := printer.WorkloadConditionHistoryPrinter(output, test.testWorkload, test.timestamps); err != nil {
+				t.Errorf("WorkloadConditionHistoryPrinter() expected no error, got %v", err)
+			}
+			outputString := output.String()
+			if diff := cmp.Diff(strings.TrimPrefix(test.expectedOutput, "\n"), outputString); diff != "" {
+				t.Errorf("Unexpected output (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
+
 func TestWorkloadSupplyChainInfoPrinter(t *testing.T) {
 	defaultNamespace := "default"
 	workloadName := "my-workload"
@@ -0,0 +1,97 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"io"
+
+	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/apis"
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/printer"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/printer/table"
+)
+
+// WorkloadSummaryPrinter prints the workload as a single row table with the columns of the
+// workload list, and its source
+func WorkloadSummaryPrinter(w io.Writer, workload *cartov1alpha1.Workload, timestamps TimestampOptions) error {
+	age := metav1beta1.TableColumnDefinition{Name: "Age", Type: "string"}
+	if timestamps.Full {
+		age.Name = "Created"
+	}
+	columns := []metav1beta1.TableColumnDefinition{
+		{Name: "Name", Type: "string"},
+		{Name: "Type", Type: "string"},
+		{Name: "Source", Type: "string"},
+		{Name: "Ready", Type: "string"},
+		age,
+	}
+	printWorkloadSummary := func(workload *cartov1alpha1.Workload, printOpts table.PrintOptions) ([]metav1beta1.TableRow, error) {
+		labels := workload.Labels
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		row := metav1beta1.TableRow{
+			Cells: []interface{}{
+				workload.Name,
+				printer.EmptyString(labels[apis.WorkloadTypeLabelName]),
+				printer.EmptyString(workloadSourceSummary(workload)),
+				printer.ConditionStatus(printer.FindCondition(workload.Status.Conditions, cartov1alpha1.WorkloadConditionReady)),
+				timestamps.Format(workload.CreationTimestamp),
+			},
+		}
+		return []metav1beta1.TableRow{row}, nil
+	}
+	tablePrinter := table.NewTablePrinter(table.PrintOptions{}).With(func(h table.PrintHandler) {
+		h.TableHandler(columns, printWorkloadSummary)
+	})
+
+	return tablePrinter.PrintObj(workload, w)
+}
+
+// workloadSourceSummary describes the workload source in a single value: the pre-built image, the
+// maven artifact, the git url with its commit, tag or branch or the source image
+func workloadSourceSummary(workload *cartov1alpha1.Workload) string {
+	if workload.Spec.Image != "" {
+		return workload.Spec.Image
+	}
+	if maven := workload.Spec.GetMavenSource(); maven != nil {
+		return fmt.Sprintf("%s:%s:%s", maven.GroupId, maven.ArtifactId, maven.Version)
+	}
+	source := workload.Spec.Source
+	switch {
+	case source == nil:
+		return ""
+	case source.Git != nil:
+		ref := source.Git.Ref.Branch
+		if source.Git.Ref.Tag != "" {
+			ref = source.Git.Ref.Tag
+		}
+		if source.Git.Ref.Commit != "" {
+			ref = source.Git.Ref.Commit
+		}
+		if ref == "" {
+			return source.Git.URL
+		}
+		return fmt.Sprintf("%s@%s", source.Git.URL, ref)
+	case source.Image != "":
+		return source.Image
+	}
+	return ""
+}
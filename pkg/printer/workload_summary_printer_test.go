@@ -0,0 +1,168 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/apis"
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/printer"
+)
+
+func TestWorkloadSummaryPrinter(t *testing.T) {
+	now := time.Date(2023, time.March, 10, 12, 0, 0, 0, time.UTC)
+	created := metav1.NewTime(now.Add(-3 * 24 * time.Hour))
+	objectMeta := metav1.ObjectMeta{
+		Name:              "my-workload",
+		Namespace:         "default",
+		CreationTimestamp: created,
+		Labels: map[string]string{
+			apis.WorkloadTypeLabelName: "web",
+		},
+	}
+	ready := cartov1alpha1.WorkloadStatus{
+		Conditions: []metav1.Condition{{
+			Type:   cartov1alpha1.WorkloadConditionReady,
+			Status: metav1.ConditionTrue,
+		}},
+	}
+	tests := []struct {
+		name           string
+		testWorkload   *cartov1alpha1.Workload
+		timestamps     printer.TimestampOptions
+		expectedOutput string
+	}{{
+		name: "pre-built image",
+		testWorkload: &cartov1alpha1.Workload{
+			ObjectMeta: objectMeta,
+			Spec: cartov1alpha1.WorkloadSpec{
+				Image: "my-registry/my-image:v1.0.0",
+			},
+			Status: ready,
+		},
+		timestamps: printer.TimestampOptions{Now: now},
+		expectedOutput: `
+NAME          TYPE   SOURCE                        READY   AGE
+my-workload   web    my-registry/my-image:v1.0.0   Ready   3d
+`,
+	}, {
+		name: "git source with branch",
+		testWorkload: &cartov1alpha1.Workload{
+			ObjectMeta: objectMeta,
+			Spec: cartov1alpha1.WorkloadSpec{
+				Source: &cartov1alpha1.Source{
+					Git: &cartov1alpha1.GitSource{
+						URL: "https://example.com/repo.git",
+						Ref: cartov1alpha1.GitRef{Branch: "main"},
+					},
+				},
+			},
+		},
+		timestamps: printer.TimestampOptions{Now: now},
+		expectedOutput: `
+NAME          TYPE   SOURCE                              READY       AGE
+my-workload   web    https://example.com/repo.git@main   <unknown>   3d
+`,
+	}, {
+		name: "git source with commit",
+		testWorkload: &cartov1alpha1.Workload{
+			ObjectMeta: objectMeta,
+			Spec: cartov1alpha1.WorkloadSpec{
+				Source: &cartov1alpha1.Source{
+					Git: &cartov1alpha1.GitSource{
+						URL: "https://example.com/repo.git",
+						Ref: cartov1alpha1.GitRef{Branch: "main", Commit: "abcdef"},
+					},
+				},
+			},
+			Status: ready,
+		},
+		timestamps: printer.TimestampOptions{Now: now},
+		expectedOutput: `
+NAME          TYPE   SOURCE                                READY   AGE
+my-workload   web    https://example.com/repo.git@abcdef   Ready   3d
+`,
+	}, {
+		name: "source image",
+		testWorkload: &cartov1alpha1.Workload{
+			ObjectMeta: objectMeta,
+			Spec: cartov1alpha1.WorkloadSpec{
+				Source: &cartov1alpha1.Source{
+					Image: "my-registry/my-source:latest",
+				},
+			},
+			Status: ready,
+		},
+		timestamps: printer.TimestampOptions{Now: now},
+		expectedOutput: `
+NAME          TYPE   SOURCE                         READY   AGE
+my-workload   web    my-registry/my-source:latest   Ready   3d
+`,
+	}, {
+		name: "maven source",
+		testWorkload: &cartov1alpha1.Workload{
+			ObjectMeta: objectMeta,
+			Spec: cartov1alpha1.WorkloadSpec{
+				Params: []cartov1alpha1.Param{{
+					Name:  "maven",
+					Value: apiextensionsv1.JSON{Raw: []byte(`{"artifactId":"hello-world","groupId":"carto.run","version":"1.0.0"}`)},
+				}},
+			},
+			Status: ready,
+		},
+		timestamps: printer.TimestampOptions{Now: now},
+		expectedOutput: `
+NAME          TYPE   SOURCE                        READY   AGE
+my-workload   web    carto.run:hello-world:1.0.0   Ready   3d
+`,
+	}, {
+		name: "no source and full timestamps",
+		testWorkload: &cartov1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "my-workload",
+				Namespace:         "default",
+				CreationTimestamp: created,
+			},
+		},
+		timestamps: printer.TimestampOptions{Now: now, Full: true},
+		expectedOutput: `
+NAME          TYPE      SOURCE    READY       CREATED
+my-workload   <empty>   <empty>   <unknown>   2023-03-07T12:00:00Z
+`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			if err := printer.WorkloadSummaryPrinter(output, test.testWorkload, test.timestamps); err != nil {
+				t.Errorf("WorkloadSummaryPrinter() expected no error, got %v", err)
+			}
+			outputString := output.String()
+			if diff := cmp.Diff(strings.TrimPrefix(test.expectedOutput, "\n"), outputString); diff != "" {
+				t.Errorf("Unexpected output (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
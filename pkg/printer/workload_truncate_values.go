@@ -0,0 +1,99 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// TruncateLongValues returns a transform that cuts the scalar values of a yaml document longer
+// than max bytes, like a large inline certificate or a binary value, and marks them with the number
+// of bytes left out. Only the rendering is affected, the values are applied in full. A max of 0
+// keeps the values as they are.
+func TruncateLongValues(max int) YamlTransform {
+	return func(doc string) (string, error) {
+		if max <= 0 {
+			return doc, nil
+		}
+		root := &yamlv3.Node{}
+		if err := yamlv3.Unmarshal([]byte(doc), root); err != nil {
+			return "", err
+		}
+		values := longValueNodes(root, max)
+		if len(values) == 0 {
+			return doc, nil
+		}
+
+		lines := strings.Split(doc, "\n")
+		// the last values are replaced first, so the line numbers of the previous ones stay valid
+		for i := len(values) - 1; i >= 0; i-- {
+			key, value := values[i][0], values[i][1]
+			start, indent := key.Line-1, key.Column-1
+			end := start + 1
+			for end < len(lines) && inBlock(lines[end], indent, false) {
+				end++
+			}
+			// a literal block may hold empty lines, but the ones it ends with are left in place as
+			// they may as well be the end of the document
+			for end > start+1 && lines[end-1] == "" {
+				end--
+			}
+			rendered := strings.Split(fmt.Sprintf("%s%s: %s", lines[start][:indent], key.Value, quoteLines(truncateValue(value.Value, max), indent+2)), "\n")
+			lines = append(lines[:start], append(rendered, lines[end:]...)...)
+		}
+		return strings.Join(lines, "\n"), nil
+	}
+}
+
+// longValueNodes returns the key and value nodes of the mapping entries with a scalar value longer
+// than max bytes, in the order they appear in the document
+func longValueNodes(root *yamlv3.Node, max int) [][2]*yamlv3.Node {
+	values := [][2]*yamlv3.Node{}
+	var walk func(node *yamlv3.Node)
+	walk = func(node *yamlv3.Node) {
+		if node.Kind == yamlv3.MappingNode {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if value := node.Content[i+1]; value.Kind == yamlv3.ScalarNode && len(value.Value) > max {
+					values = append(values, [2]*yamlv3.Node{node.Content[i], value})
+				}
+			}
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(root)
+	sort.Slice(values, func(i, j int) bool {
+		return values[i][0].Line < values[j][0].Line
+	})
+	return values
+}
+
+// truncateValue keeps the first max bytes of the value, without splitting a character, followed
+// by the number of bytes left out
+func truncateValue(value string, max int) string {
+	cut := max
+	for cut > 0 && !utf8.RuneStart(value[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s...(truncated %d bytes)", value[:cut], len(value)-cut)
+}
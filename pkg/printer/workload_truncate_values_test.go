@@ -0,0 +1,141 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/printer"
+)
+
+func TestTruncateLongValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		max      int
+		doc      string
+		expected string
+	}{{
+		name: "long values",
+		max:  20,
+		doc: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  annotations:
+    note: a very long annotation value that is folded by
+      the yaml encoder
+  name: my-workload
+spec:
+  env:
+  - name: CERT
+    value: |
+      -----BEGIN CERTIFICATE-----
+      MIIDdzCCAl+gAwIBAgIEAgAAuTANBgkqhkiG9w0BAQUFADBaMQswCQYDVQQGEwJJ
+  - name: UNICODE
+    value: hello, wonderful skål
+  params:
+  - name: short
+    value: short
+`,
+		expected: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  annotations:
+    note: "a very long annotati...(truncated 43 bytes)"
+  name: my-workload
+spec:
+  env:
+  - name: CERT
+    value: "-----BEGIN CERTIFICA...(truncated 73 bytes)"
+  - name: UNICODE
+    value: "hello, wonderful sk...(truncated 3 bytes)"
+  params:
+  - name: short
+    value: short
+`,
+	}, {
+		name: "literal block with empty lines",
+		max:  20,
+		doc: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: my-workload
+spec:
+  env:
+  - name: BLOCK
+    value: |-
+      first line of the block
+
+      second line
+      third line
+  image: ubuntu:bionic
+`,
+		expected: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: my-workload
+spec:
+  env:
+  - name: BLOCK
+    value: "first line of the bl...(truncated 27 bytes)"
+  image: ubuntu:bionic
+`,
+	}, {
+		name: "disabled",
+		max:  0,
+		doc: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: my-workload
+spec:
+  image: ubuntu:bionic
+`,
+		expected: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: my-workload
+spec:
+  image: ubuntu:bionic
+`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := printer.TruncateLongValues(test.max)(strings.TrimPrefix(test.doc, "\n"))
+			if err != nil {
+				t.Fatalf("TruncateLongValues() errored %v", err)
+			}
+			if diff := cmp.Diff(strings.TrimPrefix(test.expected, "\n"), got); diff != "" {
+				t.Errorf("TruncateLongValues() (-want, +got) = %s", diff)
+			}
+		})
+	}
+}
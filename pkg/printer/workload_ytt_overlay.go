@@ -0,0 +1,129 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+var _ YamlTransform = YttOverlayAnnotations
+
+// YttOverlayAnnotations annotates a resource in a yaml document for it to be used as a Carvel ytt
+// overlay. The document matches the resource of the same kind, name and namespace, and the
+// missing keys are added. The sequence items with a name, like the env vars, the params and the
+// service claims, match the item of the same name, the other sequences and the param values are
+// replaced as a whole. It needs ytt v0.38 or later for the replaced keys to be added when missing.
+func YttOverlayAnnotations(doc string) (string, error) {
+	root := &yamlv3.Node{}
+	if err := yamlv3.Unmarshal([]byte(doc), root); err != nil {
+		return "", err
+	}
+	if root.Kind != yamlv3.DocumentNode || len(root.Content) == 0 || root.Content[0].Kind != yamlv3.MappingNode {
+		return doc, nil
+	}
+	resource := root.Content[0]
+
+	subset := map[string]interface{}{}
+	if _, kind := mappingEntry(resource, "kind"); kind != nil {
+		subset["kind"] = kind.Value
+	}
+	metadata := map[string]string{}
+	_, meta := mappingEntry(resource, "metadata")
+	for _, field := range []string{"name", "namespace"} {
+		if _, value := mappingEntry(meta, field); value != nil && value.Value != "" {
+			metadata[field] = value.Value
+		}
+	}
+	if len(metadata) != 0 {
+		subset["metadata"] = metadata
+	}
+	b, err := json.Marshal(subset)
+	if err != nil {
+		return "", err
+	}
+
+	lines := strings.Split(doc, "\n")
+	annotations := []overlayAnnotation{}
+	overlayAnnotations(lines, resource, false, &annotations)
+	// the last annotations are inserted first, so the line numbers of the previous ones stay valid
+	sort.SliceStable(annotations, func(i, j int) bool {
+		return annotations[i].line > annotations[j].line
+	})
+	for _, a := range annotations {
+		annotation := fmt.Sprintf("%s#@%s", strings.Repeat(" ", a.indent), a.text)
+		lines = append(lines[:a.line], append([]string{annotation}, lines[a.line:]...)...)
+	}
+
+	header := []string{
+		`#@ load("@ytt:overlay", "overlay")`,
+		fmt.Sprintf("#@overlay/match by=overlay.subset(%s)", b),
+		"#@overlay/match-child-defaults missing_ok=True",
+	}
+	if len(lines) == 0 || lines[0] != "---" {
+		header = append(header, "---")
+	}
+	return strings.Join(append(header, lines...), "\n"), nil
+}
+
+type overlayAnnotation struct {
+	// line is the index of the line the annotation is inserted before
+	line   int
+	indent int
+	text   string
+}
+
+// overlayAnnotations collects the annotations of the sequences in the mapping and in its nested
+// mappings. The structured value of a named item, like a param value, is replaced as a whole.
+func overlayAnnotations(lines []string, mapping *yamlv3.Node, namedItem bool, annotations *[]overlayAnnotation) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		replace := overlayAnnotation{line: key.Line - 1, indent: key.Column - 1, text: "overlay/replace or_add=True"}
+		switch {
+		case value.Kind == yamlv3.ScalarNode || value.Kind == yamlv3.AliasNode:
+		case namedItem && key.Value == "value":
+			*annotations = append(*annotations, replace)
+		case value.Kind == yamlv3.MappingNode:
+			overlayAnnotations(lines, value, false, annotations)
+		case !namedItems(value):
+			*annotations = append(*annotations, replace)
+		default:
+			for _, item := range value.Content {
+				line := item.Line - 1
+				*annotations = append(*annotations, overlayAnnotation{line: line, indent: strings.Index(lines[line], "-"), text: `overlay/match by="name"`})
+				overlayAnnotations(lines, item, true, annotations)
+			}
+		}
+	}
+}
+
+// namedItems returns true when the items of the sequence are mappings with a name
+func namedItems(sequence *yamlv3.Node) bool {
+	if len(sequence.Content) == 0 {
+		return false
+	}
+	for _, item := range sequence.Content {
+		if _, name := mappingEntry(item, "name"); name == nil || name.Kind != yamlv3.ScalarNode {
+			return false
+		}
+	}
+	return true
+}
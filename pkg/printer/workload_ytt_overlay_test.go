@@ -0,0 +1,160 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/printer"
+)
+
+func TestYttOverlayAnnotations(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		expected string
+	}{{
+		name: "workload",
+		doc: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  labels:
+    apps.tanzu.vmware.com/workload-type: web
+  name: my-workload
+  namespace: default
+spec:
+  build:
+    env:
+    - name: BP_JVM_VERSION
+      value: "17"
+  env:
+  - name: FOO
+    value: bar
+  params:
+  - name: ports
+    value:
+    - name: http
+      port: 8080
+  - name: debug
+    value: "true"
+  serviceClaims:
+  - name: database
+    ref:
+      apiVersion: services.apps.tanzu.vmware.com/v1alpha1
+      kind: ResourceClaim
+      name: db
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://example.com/repo.git
+status:
+  conditions:
+  - type: Ready
+    status: "True"
+`,
+		expected: `
+#@ load("@ytt:overlay", "overlay")
+#@overlay/match by=overlay.subset({"kind":"Workload","metadata":{"name":"my-workload","namespace":"default"}})
+#@overlay/match-child-defaults missing_ok=True
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  labels:
+    apps.tanzu.vmware.com/workload-type: web
+  name: my-workload
+  namespace: default
+spec:
+  build:
+    env:
+    #@overlay/match by="name"
+    - name: BP_JVM_VERSION
+      value: "17"
+  env:
+  #@overlay/match by="name"
+  - name: FOO
+    value: bar
+  params:
+  #@overlay/match by="name"
+  - name: ports
+    #@overlay/replace or_add=True
+    value:
+    - name: http
+      port: 8080
+  #@overlay/match by="name"
+  - name: debug
+    value: "true"
+  serviceClaims:
+  #@overlay/match by="name"
+  - name: database
+    ref:
+      apiVersion: services.apps.tanzu.vmware.com/v1alpha1
+      kind: ResourceClaim
+      name: db
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://example.com/repo.git
+status:
+  #@overlay/replace or_add=True
+  conditions:
+  - type: Ready
+    status: "True"
+`,
+	}, {
+		name: "without namespace nor document start",
+		doc: `
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: my-workload
+spec:
+  image: ubuntu:bionic
+`,
+		expected: `
+#@ load("@ytt:overlay", "overlay")
+#@overlay/match by=overlay.subset({"kind":"Workload","metadata":{"name":"my-workload"}})
+#@overlay/match-child-defaults missing_ok=True
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: my-workload
+spec:
+  image: ubuntu:bionic
+`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := printer.YttOverlayAnnotations(strings.TrimPrefix(test.doc, "\n"))
+			if err != nil {
+				t.Fatalf("YttOverlayAnnotations() errored %v", err)
+			}
+			if diff := cmp.Diff(strings.TrimPrefix(test.expected, "\n"), got); diff != "" {
+				t.Errorf("YttOverlayAnnotations() (-want, +got) = %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	regname "github.com/google/go-containerregistry/pkg/name"
+	regv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/vmware-tanzu/carvel-imgpkg/pkg/imgpkg/plainimage"
+)
+
+// ImagesReaderWriter pushes images and resolves the digest of the ones in the registry
+type ImagesReaderWriter interface {
+	plainimage.ImagesWriter
+	Digest(regname.Reference) (regv1.Hash, error)
+}
+
+// PushCache remembers the content last pushed to each source image, in a file at Path, so a push
+// of the same content can be skipped
+type PushCache struct {
+	Path string
+}
+
+type pushCacheEntry struct {
+	// Content is the digest of the pushed files, see ContentDigest
+	Content string `json:"content"`
+	// Image is the pushed image, with its digest
+	Image string `json:"image"`
+}
+
+// DefaultPushCachePath returns the path of the push cache in the user cache directory
+func DefaultPushCachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tanzu", "apps", "source-images.json"), nil
+}
+
+func (p *PushCache) load() map[string]pushCacheEntry {
+	entries := map[string]pushCacheEntry{}
+	b, err := os.ReadFile(p.Path)
+	if err != nil {
+		return entries
+	}
+	// a corrupted cache is the same as an empty one
+	_ = json.Unmarshal(b, &entries)
+	return entries
+}
+
+// Lookup returns the image pushed last for the source image, when its content has the digest
+func (p *PushCache) Lookup(image, content string) (string, bool) {
+	entry, ok := p.load()[image]
+	if !ok || entry.Content != content {
+		return "", false
+	}
+	return entry.Image, true
+}
+
+// Store records the image pushed for the source image and the digest of its content. Only the last
+// push of each source image is kept.
+func (p *PushCache) Store(image, content, pushed string) error {
+	entries := p.load()
+	entries[image] = pushCacheEntry{Content: content, Image: pushed}
+	b, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p.Path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(p.Path, b, 0644)
+}
+
+// ContentDigest returns a digest of the files imgpkg packages from the dir: their relative path,
+// permissions and content. Like imgpkg, the excluded files are relative paths within the dir.
+func ContentDigest(dir string, excludedFiles []string) (string, error) {
+	excluded := map[string]bool{}
+	for _, f := range excludedFiles {
+		excluded[f] = true
+	}
+	h := sha256.New()
+	// the walk is in lexical order, so the digest doesn't depend on the order files were written
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if excluded[rel] {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			fmt.Fprintf(h, "dir %s\n", filepath.ToSlash(rel))
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "file %s %o %d\n", filepath.ToSlash(rel), info.Mode()&0700, info.Size())
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(h, f)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("sha256:%x", h.Sum(nil)), nil
+}
+
+// ImgpkgPushWithCache pushes the dir like ImgpkgPush, unless the cache has the same content pushed
+// last to the image and the registry still has that image. It returns the pushed image and true
+// when the push was skipped. The cache is best effort, failing to read or update it never fails
+// the push.
+func ImgpkgPushWithCache(ctx context.Context, dir string, excludedFiles []string, reg ImagesReaderWriter, image string, cache *PushCache) (string, bool, error) {
+	if cache == nil {
+		digestedImage, err := ImgpkgPush(ctx, dir, excludedFiles, reg, image)
+		return digestedImage, false, err
+	}
+	content, err := ContentDigest(dir, excludedFiles)
+	if err != nil {
+		digestedImage, err := ImgpkgPush(ctx, dir, excludedFiles, reg, image)
+		return digestedImage, false, err
+	}
+	if pushed, ok := cache.Lookup(image, content); ok && imageExists(reg, pushed) {
+		return pushed, true, nil
+	}
+	digestedImage, err := ImgpkgPush(ctx, dir, excludedFiles, reg, image)
+	if err != nil {
+		return "", false, err
+	}
+	_ = cache.Store(image, content, digestedImage)
+	return digestedImage, false, nil
+}
+
+// imageExists returns true when the registry has the image with the digest
+func imageExists(reg ImagesReaderWriter, image string) bool {
+	ref, err := regname.NewDigest(image, regname.WeakValidation)
+	if err != nil {
+		return false
+	}
+	_, err = reg.Digest(ref)
+	return err == nil
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2023 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	regname "github.com/google/go-containerregistry/pkg/name"
+	regv1 "github.com/google/go-containerregistry/pkg/v1"
+	regremote "github.com/google/go-containerregistry/pkg/v1/remote"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/logger"
+)
+
+// fakeRegistry keeps the digests of the images written to it
+type fakeRegistry struct {
+	images map[string]bool
+	writes int
+}
+
+func (r *fakeRegistry) WriteImage(ref regname.Reference, img regv1.Image, _ chan regv1.Update) error {
+	digest, err := img.Digest()
+	if err != nil {
+		return err
+	}
+	r.images[digest.String()] = true
+	r.writes++
+	return nil
+}
+
+func (r *fakeRegistry) WriteTag(ref regname.Tag, taggable regremote.Taggable) error {
+	return nil
+}
+
+func (r *fakeRegistry) Digest(ref regname.Reference) (regv1.Hash, error) {
+	if digest, ok := ref.(regname.Digest); ok && r.images[digest.DigestStr()] {
+		return regv1.NewHash(digest.DigestStr())
+	}
+	return regv1.Hash{}, fmt.Errorf("image %q not found", ref.Name())
+}
+
+func writeSource(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("unable to write %s: %v", name, err)
+		}
+	}
+}
+
+func TestContentDigest(t *testing.T) {
+	dir := t.TempDir()
+	writeSource(t, dir, map[string]string{"main.go": "package main", "README.md": "hello"})
+	digest, err := ContentDigest(dir, nil)
+	if err != nil {
+		t.Fatalf("ContentDigest() errored %v", err)
+	}
+
+	if got, _ := ContentDigest(dir, nil); got != digest {
+		t.Errorf("ContentDigest() expected the same digest for the same content, got %q and %q", digest, got)
+	}
+	if got, _ := ContentDigest(dir, []string{"README.md"}); got == digest {
+		t.Errorf("ContentDigest() expected a different digest without the excluded file")
+	}
+
+	writeSource(t, dir, map[string]string{"README.md": "hello world"})
+	if got, _ := ContentDigest(dir, nil); got == digest {
+		t.Errorf("ContentDigest() expected a different digest for a changed file")
+	}
+	writeSource(t, dir, map[string]string{"README.md": "hello"})
+	if got, _ := ContentDigest(dir, nil); got != digest {
+		t.Errorf("ContentDigest() expected the same digest once the file is restored, got %q and %q", digest, got)
+	}
+}
+
+func TestImgpkgPushWithCache(t *testing.T) {
+	ctx := logger.StashSourceImageLogger(context.Background(), logger.NewNoopLogger())
+	image := "registry.example.com/hello:source"
+
+	tests := []struct {
+		name           string
+		noCache        bool
+		change         bool
+		deleted        bool
+		expectedWrites int
+		expectSkipped  bool
+	}{{
+		name:           "unchanged source",
+		expectedWrites: 1,
+		expectSkipped:  true,
+	}, {
+		name:           "changed source",
+		change:         true,
+		expectedWrites: 2,
+	}, {
+		name:           "image deleted from the registry",
+		deleted:        true,
+		expectedWrites: 2,
+	}, {
+		name:           "no cache",
+		noCache:        true,
+		expectedWrites: 2,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeSource(t, dir, map[string]string{"main.go": "package main"})
+			reg := &fakeRegistry{images: map[string]bool{}}
+			var cache *PushCache
+			if !test.noCache {
+				cache = &PushCache{Path: filepath.Join(t.TempDir(), "source-images.json")}
+			}
+
+			first, skipped, err := ImgpkgPushWithCache(ctx, dir, nil, reg, image, cache)
+			if err != nil {
+				t.Fatalf("ImgpkgPushWithCache() errored %v", err)
+			}
+			if skipped {
+				t.Errorf("ImgpkgPushWithCache() expected the first push not to be skipped")
+			}
+
+			if test.change {
+				writeSource(t, dir, map[string]string{"main.go": "package main\n"})
+			}
+			if test.deleted {
+				reg.images = map[string]bool{}
+			}
+			second, skipped, err := ImgpkgPushWithCache(ctx, dir, nil, reg, image, cache)
+			if err != nil {
+				t.Fatalf("ImgpkgPushWithCache() errored %v", err)
+			}
+			if skipped != test.expectSkipped {
+				t.Errorf("ImgpkgPushWithCache() expected skipped %t, got %t", test.expectSkipped, skipped)
+			}
+			if reg.writes != test.expectedWrites {
+				t.Errorf("ImgpkgPushWithCache() expected %d writes, got %d", test.expectedWrites, reg.writes)
+			}
+			if !test.change && first != second {
+				t.Errorf("ImgpkgPushWithCache() expected the same image %q, got %q", first, second)
+			}
+		})
+	}
+}